@@ -162,13 +162,13 @@ var (
 		"Set the port where the Prometheus metrics are exposed. [1024 - 65535]")
 
 	enableServiceInsight = flag.Bool("enable-service-insight", false,
-		`Enable service insight for external load balancers. Requires -nginx-plus`)
+		`Enable service insight for external load balancers. With NGINX, only the upstreams with active health checks are reported`)
 
 	serviceInsightTLSSecretName = flag.String("service-insight-tls-secret", "",
 		`A Secret with a TLS certificate and key for TLS termination of the service insight.`)
 
 	serviceInsightListenPort = flag.Int("service-insight-listen-port", 9114,
-		"Set the port where the Service Insight stats are exposed. Requires -nginx-plus. [1024 - 65535]")
+		"Set the port where the Service Insight stats are exposed. [1024 - 65535]")
 
 	enableCustomResources = flag.Bool("enable-custom-resources", true,
 		"Enable custom resources")
@@ -256,11 +256,6 @@ func initValidate(ctx context.Context) {
 		*enableLatencyMetrics = false
 	}
 
-	if *enableServiceInsight && !*nginxPlus {
-		nl.Warn(l, "enable-service-insight flag support is for NGINX Plus, service insight endpoint will not be exposed")
-		*enableServiceInsight = false
-	}
-
 	if *enableDynamicWeightChangesReload && !*nginxPlus {
 		nl.Warn(l, "weight-changes-dynamic-reload flag support is for NGINX Plus, Dynamic Weight Changes will not be enabled")
 		*enableDynamicWeightChangesReload = false
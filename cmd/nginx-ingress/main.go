@@ -39,6 +39,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	pkg_runtime "k8s.io/apimachinery/pkg/runtime"
 	util_version "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/client-go/dynamic"
@@ -278,8 +279,13 @@ func main() {
 		cr_validation.IsDirectiveAutoadjustEnabled(*enableDirectiveAutoadjust),
 	)
 
+	var healthProber *healthcheck.Prober
+	if !*nginxPlus {
+		healthProber = healthcheck.NewProber(ctx)
+	}
+
 	if *enableServiceInsight {
-		createHealthProbeEndpoint(kubeClient, plusClient, cnf)
+		createHealthProbeEndpoint(kubeClient, plusClient, healthProber, cnf)
 	}
 
 	lbcInput := k8s.NewLoadBalancerControllerInput{
@@ -303,6 +309,8 @@ func main() {
 		IngressLink:                  *ingressLink,
 		ControllerNamespace:          controllerNamespace,
 		Pod:                          pod,
+		Zone:                         getControllerZone(ctx, kubeClient, pod),
+		HealthProber:                 healthProber,
 		ReportIngressStatus:          *reportIngressStatus,
 		IsLeaderElectionEnabled:      *leaderElectionEnabled,
 		LeaderElectionLockName:       *leaderElectionLockName,
@@ -446,6 +454,22 @@ func validateKubernetesVersionInfo(ctx context.Context, kubeClient kubernetes.In
 	return nil
 }
 
+// getControllerZone returns the topology zone of the node the Ingress Controller pod runs on.
+func getControllerZone(ctx context.Context, kubeClient kubernetes.Interface, pod *api_v1.Pod) string {
+	l := nl.LoggerFromContext(ctx)
+	if pod.Spec.NodeName == "" {
+		return ""
+	}
+	nodes, err := kubeClient.CoreV1().Nodes().List(ctx, meta_v1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", pod.Spec.NodeName).String(),
+	})
+	if err != nil || len(nodes.Items) == 0 {
+		nl.Warnf(l, "Failed to get node %s of the pod, topology-aware routing will not be available: %v", pod.Spec.NodeName, err)
+		return ""
+	}
+	return nodes.Items[0].Labels[api_v1.LabelTopologyZone]
+}
+
 // mustValidateIngressClass calls internally os.Exit
 // and terminates the program if the ingress class is not valid.
 func mustValidateIngressClass(ctx context.Context, kubeClient kubernetes.Interface) {
@@ -936,10 +960,12 @@ func createPlusAndLatencyCollectors(
 			variableLabelNames := nginxCollector.NewVariableLabelNames(upstreamServerVariableLabels, serverZoneVariableLabels, upstreamServerPeerVariableLabelNames,
 				streamUpstreamServerVariableLabels, streamServerZoneVariableLabels, streamUpstreamServerPeerVariableLabelNames, nil)
 			plusCollector = nginxCollector.NewNginxPlusCollector(plusClient, "nginx_ingress_nginxplus", variableLabelNames, constLabels, l)
+			registerWebSocketCollector(ctx, registry, getSocketClient("/var/lib/nginx/nginx-plus-api.sock"), "http://nginx-plus-api/websocket_connections", constLabels)
 			go metrics.RunPrometheusListenerForNginxPlus(ctx, *prometheusMetricsListenPort, plusCollector, registry, prometheusSecret)
 		} else {
 			httpClient := getSocketClient("/var/lib/nginx/nginx-status.sock")
 			client := metrics.NewNginxMetricsClient(httpClient)
+			registerWebSocketCollector(ctx, registry, httpClient, "http://config-status/websocket_connections", constLabels)
 			go metrics.RunPrometheusListenerForNginx(ctx, *prometheusMetricsListenPort, client, registry, constLabels, prometheusSecret)
 		}
 		if *enableLatencyMetrics {
@@ -955,7 +981,15 @@ func createPlusAndLatencyCollectors(
 	return plusCollector, syslogListener, lc
 }
 
-func createHealthProbeEndpoint(kubeClient *kubernetes.Clientset, plusClient *client.NginxClient, cnf *configs.Configurator) {
+// registerWebSocketCollector registers the collector of the active upgraded connections of the upstreams with WebSocket.
+func registerWebSocketCollector(ctx context.Context, registry *prometheus.Registry, httpClient *http.Client, endpoint string, constLabels map[string]string) {
+	wc := collectors.NewWebSocketMetricsCollector(ctx, httpClient, endpoint, constLabels)
+	if err := wc.Register(registry); err != nil {
+		nl.Errorf(nl.LoggerFromContext(ctx), "Error registering WebSocket Prometheus metrics: %v", err)
+	}
+}
+
+func createHealthProbeEndpoint(kubeClient *kubernetes.Clientset, plusClient *client.NginxClient, healthProber *healthcheck.Prober, cnf *configs.Configurator) {
 	l := nl.LoggerFromContext(cnf.CfgParams.Context)
 	if !*enableServiceInsight {
 		return
@@ -969,7 +1003,7 @@ func createHealthProbeEndpoint(kubeClient *kubernetes.Clientset, plusClient *cli
 			nl.Fatalf(l, "Error trying to get the service insight TLS secret %v: %v", *serviceInsightTLSSecretName, err)
 		}
 	}
-	go healthcheck.RunHealthCheck(*serviceInsightListenPort, plusClient, cnf, serviceInsightSecret)
+	go healthcheck.RunHealthCheck(*serviceInsightListenPort, plusClient, healthProber, cnf, serviceInsightSecret)
 }
 
 // mustProcessGlobalConfiguration calls internally os.Exit
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	nl "github.com/nginx/kubernetes-ingress/internal/logger"
 	"github.com/nginx/kubernetes-ingress/internal/validation"
@@ -42,6 +43,7 @@ var masterDenylist = map[string]bool{
 	"nginx.org/grpc-services":                 true,
 	"nginx.org/websocket-services":            true,
 	"nginx.com/sticky-cookie-services":        true,
+	"nginx.org/session-affinity-services":     true,
 	"nginx.com/health-checks":                 true,
 	"nginx.com/health-checks-mandatory":       true,
 	"nginx.com/health-checks-mandatory-queue": true,
@@ -94,6 +96,10 @@ var minionInheritanceList = map[string]bool{
 	"nginx.org/limit-req-log-level":      true,
 	"nginx.org/limit-req-reject-code":    true,
 	"nginx.org/limit-req-scale":          true,
+	"nginx.org/otel-trace":               true,
+	"nginx.org/otel-trace-sampling":      true,
+	"nginx.org/otel-span-name":           true,
+	"nginx.org/otel-span-attributes":     true,
 }
 
 var validPathRegex = map[string]bool{
@@ -135,10 +141,9 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 		if err != nil {
 			nl.Error(l, err)
 		}
+		// with NGINX, the health checks are performed by the Ingress Controller
 		if isPlus {
 			cfgParams.HealthCheckEnabled = healthCheckEnabled
-		} else {
-			nl.Warn(l, "Annotation 'nginx.com/health-checks' requires NGINX Plus")
 		}
 	}
 
@@ -474,6 +479,10 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 		nl.Error(l, err)
 	}
 
+	for _, err := range parseOtelAnnotations(ingEx.Ingress.Annotations, &cfgParams, ingEx.Ingress) {
+		nl.Error(l, err)
+	}
+
 	return cfgParams
 }
 
@@ -551,6 +560,38 @@ func parseRateLimitAnnotations(annotations map[string]string, cfgParams *ConfigP
 	return errors
 }
 
+// parseOtelAnnotations parses OpenTelemetry-tracing-related annotations and places them into CfgParams. Occurring errors are collected and returned, but do not abort parsing.
+func parseOtelAnnotations(annotations map[string]string, cfgParams *ConfigParams, context apiObject) []error {
+	errors := make([]error, 0)
+	if otelTrace, exists, err := GetMapKeyAsBool(annotations, "nginx.org/otel-trace", context); exists {
+		if err != nil {
+			errors = append(errors, err)
+		} else {
+			cfgParams.OtelTrace = &otelTrace
+		}
+	}
+	if otelTraceSampling, exists, err := GetMapKeyAsInt(annotations, "nginx.org/otel-trace-sampling", context); exists {
+		if err != nil {
+			errors = append(errors, err)
+		} else if otelTraceSampling < 1 || otelTraceSampling > 100 {
+			errors = append(errors, fmt.Errorf("ingress %s/%s: invalid value for nginx.org/otel-trace-sampling: got %d: must be between 1 and 100", context.GetNamespace(), context.GetName(), otelTraceSampling))
+		} else {
+			cfgParams.OtelTraceSampling = otelTraceSampling
+		}
+	}
+	if otelSpanName, exists := annotations["nginx.org/otel-span-name"]; exists {
+		cfgParams.OtelSpanName = strings.TrimSpace(otelSpanName)
+	}
+	if otelSpanAttributes, exists := GetMapKeyAsStringSlice(annotations, "nginx.org/otel-span-attributes", context, ","); exists {
+		if attributes, err := ParseSpanAttributes(otelSpanAttributes); err != nil {
+			errors = append(errors, fmt.Errorf("ingress %s/%s: invalid value for nginx.org/otel-span-attributes: %w", context.GetNamespace(), context.GetName(), err))
+		} else {
+			cfgParams.OtelSpanAttributes = attributes
+		}
+	}
+	return errors
+}
+
 func getWebsocketServices(ingEx *IngressEx) map[string]bool {
 	if value, exists := ingEx.Ingress.Annotations["nginx.org/websocket-services"]; exists {
 		return ParseServiceList(value)
@@ -584,16 +625,31 @@ func getGrpcServices(ingEx *IngressEx) map[string]bool {
 	return nil
 }
 
+// getSessionPersistenceServices returns the services with a sticky cookie or session affinity.
+// A sticky cookie takes precedence over session affinity for the same service.
 func getSessionPersistenceServices(ctx context.Context, ingEx *IngressEx) map[string]string {
 	l := nl.LoggerFromContext(ctx)
+	var services map[string]string
+	if value, exists := ingEx.Ingress.Annotations["nginx.org/session-affinity-services"]; exists {
+		saServices, err := ParseSessionAffinityServiceList(value)
+		if err != nil {
+			nl.Error(l, err)
+		}
+		services = saServices
+	}
 	if value, exists := ingEx.Ingress.Annotations["nginx.com/sticky-cookie-services"]; exists {
-		services, err := ParseStickyServiceList(value)
+		spServices, err := ParseStickyServiceList(value)
 		if err != nil {
 			nl.Error(l, err)
 		}
-		return services
+		if services == nil {
+			return spServices
+		}
+		for name, cookie := range spServices {
+			services[name] = cookie
+		}
 	}
-	return nil
+	return services
 }
 
 func filterMasterAnnotations(annotations map[string]string) []string {
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
 	networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -217,6 +218,45 @@ func TestParseRateLimitAnnotations(t *testing.T) {
 	}
 }
 
+func TestParseOtelAnnotations(t *testing.T) {
+	ctx := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "context",
+		},
+	}
+
+	cfgParams := NewDefaultConfigParams(context.Background(), false)
+	if errors := parseOtelAnnotations(map[string]string{
+		"nginx.org/otel-trace":           "true",
+		"nginx.org/otel-trace-sampling":  "25",
+		"nginx.org/otel-span-name":       "cafe $request_method",
+		"nginx.org/otel-span-attributes": "team: cafe, http.user_agent: $http_user_agent",
+	}, cfgParams, ctx); len(errors) > 0 {
+		t.Errorf("Errors when parsing valid otel annotations: %v", errors)
+	}
+	expectedAttributes := []version2.SpanAttribute{
+		{Name: "team", Value: "cafe"},
+		{Name: "http.user_agent", Value: "$http_user_agent"},
+	}
+	if cfgParams.OtelTrace == nil || !*cfgParams.OtelTrace || cfgParams.OtelTraceSampling != 25 ||
+		cfgParams.OtelSpanName != "cafe $request_method" || !reflect.DeepEqual(cfgParams.OtelSpanAttributes, expectedAttributes) {
+		t.Errorf("parseOtelAnnotations() returned unexpected config params: %+v", cfgParams)
+	}
+
+	if errors := parseOtelAnnotations(map[string]string{
+		"nginx.org/otel-trace-sampling": "101",
+	}, NewDefaultConfigParams(context.Background(), false), ctx); len(errors) == 0 {
+		t.Error("No Errors when parsing invalid sampling")
+	}
+
+	if errors := parseOtelAnnotations(map[string]string{
+		"nginx.org/otel-span-attributes": "team",
+	}, NewDefaultConfigParams(context.Background(), false), ctx); len(errors) == 0 {
+		t.Error("No Errors when parsing invalid span attributes")
+	}
+}
+
 func BenchmarkParseRewrites(b *testing.B) {
 	serviceName := "coffee-svc"
 	serviceNamePart := "serviceName=" + serviceName
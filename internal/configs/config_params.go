@@ -2,6 +2,7 @@ package configs
 
 import (
 	"context"
+	"time"
 
 	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
 	"github.com/nginx/kubernetes-ingress/internal/nginx"
@@ -14,6 +15,7 @@ type ConfigParams struct {
 	ClientMaxBodySize                      string
 	DefaultServerAccessLogOff              bool
 	DefaultServerReturn                    string
+	EndpointDrainPeriod                    time.Duration
 	FailTimeout                            string
 	HealthCheckEnabled                     bool
 	HealthCheckMandatory                   bool
@@ -23,6 +25,7 @@ type ConfigParams struct {
 	HSTSIncludeSubdomains                  bool
 	HSTSMaxAge                             int64
 	HTTP2                                  bool
+	HTTP3                                  bool
 	Keepalive                              int
 	LBMethod                               string
 	LocationSnippets                       []string
@@ -40,6 +43,10 @@ type ConfigParams struct {
 	MainOtelExporterHeaderName             string
 	MainOtelExporterHeaderValue            string
 	MainOtelServiceName                    string
+	OtelTrace                              *bool
+	OtelTraceSampling                      int
+	OtelSpanName                           string
+	OtelSpanAttributes                     []version2.SpanAttribute
 	MainServerNamesHashBucketSize          string
 	MainServerNamesHashMaxSize             string
 	MainStreamLogFormat                    []string
@@ -81,6 +88,9 @@ type ConfigParams struct {
 	ProxyReadTimeout                       string
 	ProxySendTimeout                       string
 	RedirectToHTTPS                        bool
+	RequestID                              bool
+	RequestIDHeader                        string
+	RequestIDTrustIncoming                 bool
 	ResolverAddresses                      []string
 	ResolverIPV6                           bool
 	ResolverTimeout                        string
@@ -89,6 +99,8 @@ type ConfigParams struct {
 	ServerTokens                           string
 	SlowStart                              string
 	SSLRedirect                            bool
+	TopologyAwareRouting                   bool
+	TopologyAwareRoutingMinEndpoints       int
 	UpstreamZoneSize                       string
 	UseClusterIP                           bool
 	VariablesHashBucketSize                uint64
@@ -222,40 +234,42 @@ func NewDefaultConfigParams(ctx context.Context, isPlus bool) *ConfigParams {
 	}
 
 	return &ConfigParams{
-		Context:                       ctx,
-		DefaultServerReturn:           "404",
-		ServerTokens:                  "on",
-		ProxyConnectTimeout:           "60s",
-		ProxyReadTimeout:              "60s",
-		ProxySendTimeout:              "60s",
-		ClientMaxBodySize:             "1m",
-		SSLRedirect:                   true,
-		MainAccessLog:                 "/dev/stdout main",
-		MainServerNamesHashBucketSize: "256",
-		MainServerNamesHashMaxSize:    "1024",
-		MainMapHashBucketSize:         "256",
-		MainMapHashMaxSize:            "2048",
-		ProxyBuffering:                true,
-		MainWorkerProcesses:           "auto",
-		MainWorkerConnections:         "1024",
-		HSTSMaxAge:                    2592000,
-		Ports:                         []int{80},
-		SSLPorts:                      []int{443},
-		MaxFails:                      1,
-		MaxConns:                      0,
-		UpstreamZoneSize:              upstreamZoneSize,
-		FailTimeout:                   "10s",
-		LBMethod:                      "random two least_conn",
-		MainErrorLogLevel:             "notice",
-		ResolverIPV6:                  true,
-		MainKeepaliveTimeout:          "75s",
-		MainKeepaliveRequests:         1000,
-		VariablesHashBucketSize:       256,
-		VariablesHashMaxSize:          1024,
-		LimitReqKey:                   "${binary_remote_addr}",
-		LimitReqZoneSize:              "10m",
-		LimitReqLogLevel:              "error",
-		LimitReqRejectCode:            429,
+		Context:                          ctx,
+		DefaultServerReturn:              "404",
+		ServerTokens:                     "on",
+		ProxyConnectTimeout:              "60s",
+		ProxyReadTimeout:                 "60s",
+		ProxySendTimeout:                 "60s",
+		ClientMaxBodySize:                "1m",
+		SSLRedirect:                      true,
+		MainAccessLog:                    "/dev/stdout main",
+		MainServerNamesHashBucketSize:    "256",
+		MainServerNamesHashMaxSize:       "1024",
+		MainMapHashBucketSize:            "256",
+		MainMapHashMaxSize:               "2048",
+		ProxyBuffering:                   true,
+		MainWorkerProcesses:              "auto",
+		MainWorkerConnections:            "1024",
+		HSTSMaxAge:                       2592000,
+		RequestIDHeader:                  "X-Request-ID",
+		TopologyAwareRoutingMinEndpoints: 1,
+		Ports:                            []int{80},
+		SSLPorts:                         []int{443},
+		MaxFails:                         1,
+		MaxConns:                         0,
+		UpstreamZoneSize:                 upstreamZoneSize,
+		FailTimeout:                      "10s",
+		LBMethod:                         "random two least_conn",
+		MainErrorLogLevel:                "notice",
+		ResolverIPV6:                     true,
+		MainKeepaliveTimeout:             "75s",
+		MainKeepaliveRequests:            1000,
+		VariablesHashBucketSize:          256,
+		VariablesHashMaxSize:             1024,
+		LimitReqKey:                      "${binary_remote_addr}",
+		LimitReqZoneSize:                 "10m",
+		LimitReqLogLevel:                 "error",
+		LimitReqRejectCode:               429,
 	}
 }
 
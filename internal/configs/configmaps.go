@@ -129,6 +129,16 @@ func ParseConfigMap(ctx context.Context, cfgm *v1.ConfigMap, nginxPlus bool, has
 		}
 	}
 
+	if HTTP3, exists, err := GetMapKeyAsBool(cfgm.Data, "http3", cfgm); exists {
+		if err != nil {
+			nl.Error(l, err)
+			eventLog.Event(cfgm, v1.EventTypeWarning, nl.EventReasonInvalidValue, err.Error())
+			configOk = false
+		} else {
+			cfgParams.HTTP3 = HTTP3
+		}
+	}
+
 	if redirectToHTTPS, exists, err := GetMapKeyAsBool(cfgm.Data, "redirect-to-https", cfgm); exists {
 		if err != nil {
 			nl.Error(l, err)
@@ -209,6 +219,38 @@ func ParseConfigMap(ctx context.Context, cfgm *v1.ConfigMap, nginxPlus bool, has
 		}
 	}
 
+	if requestID, exists, err := GetMapKeyAsBool(cfgm.Data, "request-id", cfgm); exists {
+		if err != nil {
+			nl.Error(l, err)
+			eventLog.Event(cfgm, v1.EventTypeWarning, nl.EventReasonInvalidValue, err.Error())
+			configOk = false
+		} else {
+			cfgParams.RequestID = requestID
+		}
+	}
+
+	if requestIDHeader, exists := cfgm.Data["request-id-header"]; exists {
+		requestIDHeader = strings.TrimSpace(requestIDHeader)
+		if errorMessages := k8s_validation.IsHTTPHeaderName(requestIDHeader); len(errorMessages) > 0 {
+			errorText := fmt.Sprintf("ConfigMap %s/%s: invalid value for 'request-id-header': %q, %v, ignoring", cfgm.GetNamespace(), cfgm.GetName(), requestIDHeader, errorMessages)
+			nl.Error(l, errorText)
+			eventLog.Event(cfgm, v1.EventTypeWarning, nl.EventReasonInvalidValue, errorText)
+			configOk = false
+		} else {
+			cfgParams.RequestIDHeader = requestIDHeader
+		}
+	}
+
+	if requestIDTrustIncoming, exists, err := GetMapKeyAsBool(cfgm.Data, "request-id-trust-incoming", cfgm); exists {
+		if err != nil {
+			nl.Error(l, err)
+			eventLog.Event(cfgm, v1.EventTypeWarning, nl.EventReasonInvalidValue, err.Error())
+			configOk = false
+		} else {
+			cfgParams.RequestIDTrustIncoming = requestIDTrustIncoming
+		}
+	}
+
 	if realIPHeader, exists := cfgm.Data["real-ip-header"]; exists {
 		if hasTLSPassthrough {
 			errorText := fmt.Sprintf("ConfigMap %s/%s: 'real-ip-header' is ignored because 'real_ip_header' is automatically set to 'proxy_protocol' when TLS passthrough is enabled, ignoring", cfgm.GetNamespace(), cfgm.GetName())
@@ -467,6 +509,41 @@ func ParseConfigMap(ctx context.Context, cfgm *v1.ConfigMap, nginxPlus bool, has
 		cfgParams.FailTimeout = failTimeout
 	}
 
+	if topologyAwareRouting, exists, err := GetMapKeyAsBool(cfgm.Data, "topology-aware-routing", cfgm); exists {
+		if err != nil {
+			nl.Error(l, err)
+			eventLog.Event(cfgm, v1.EventTypeWarning, nl.EventReasonInvalidValue, err.Error())
+			configOk = false
+		} else {
+			cfgParams.TopologyAwareRouting = topologyAwareRouting
+		}
+	}
+
+	if minEndpoints, exists, err := GetMapKeyAsInt(cfgm.Data, "topology-aware-routing-min-endpoints", cfgm); exists {
+		if err == nil && minEndpoints < 1 {
+			err = fmt.Errorf("ConfigMap %s/%s: invalid value for 'topology-aware-routing-min-endpoints': %d, must be positive, ignoring", cfgm.GetNamespace(), cfgm.GetName(), minEndpoints)
+		}
+		if err != nil {
+			nl.Error(l, err)
+			eventLog.Event(cfgm, v1.EventTypeWarning, nl.EventReasonInvalidValue, err.Error())
+			configOk = false
+		} else {
+			cfgParams.TopologyAwareRoutingMinEndpoints = minEndpoints
+		}
+	}
+
+	if endpointDrainPeriod, exists := cfgm.Data["endpoint-drain-period"]; exists {
+		drainPeriod, err := time.ParseDuration(strings.TrimSpace(endpointDrainPeriod))
+		if err != nil || drainPeriod < 0 {
+			errorText := fmt.Sprintf("ConfigMap %s/%s: invalid value for 'endpoint-drain-period': %q, must be a non-negative duration, ignoring", cfgm.GetNamespace(), cfgm.GetName(), endpointDrainPeriod)
+			nl.Error(l, errorText)
+			eventLog.Event(cfgm, v1.EventTypeWarning, nl.EventReasonInvalidValue, errorText)
+			configOk = false
+		} else {
+			cfgParams.EndpointDrainPeriod = drainPeriod
+		}
+	}
+
 	if mainTemplate, exists := cfgm.Data["main-template"]; exists {
 		cfgParams.MainTemplate = &mainTemplate
 	} else {
@@ -1047,6 +1124,14 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		ResolverValid:     config.ZoneSync.ResolverValid,
 	}
 
+	var requestID *version1.RequestID
+	if config.RequestID {
+		requestID = &version1.RequestID{
+			HeaderVariable: getHeaderVariableName(config.RequestIDHeader),
+			TrustIncoming:  config.RequestIDTrustIncoming,
+		}
+	}
+
 	nginxCfg := &version1.MainConfig{
 		AccessLog:                          config.MainAccessLog,
 		DefaultServerAccessLogOff:          config.DefaultServerAccessLogOff,
@@ -1058,6 +1143,7 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		HealthStatus:                       staticCfgParams.HealthStatus,
 		HealthStatusURI:                    staticCfgParams.HealthStatusURI,
 		HTTP2:                              config.HTTP2,
+		HTTP3:                              config.HTTP3,
 		HTTPSnippets:                       config.MainHTTPSnippets,
 		KeepaliveRequests:                  config.MainKeepaliveRequests,
 		KeepaliveTimeout:                   config.MainKeepaliveTimeout,
@@ -1080,6 +1166,7 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		ResolverTimeout:                    config.ResolverTimeout,
 		ResolverValid:                      config.ResolverValid,
 		RealIPHeader:                       config.RealIPHeader,
+		RequestID:                          requestID,
 		RealIPRecursive:                    config.RealIPRecursive,
 		SetRealIPFrom:                      config.SetRealIPFrom,
 		ServerNamesHashBucketSize:          config.MainServerNamesHashBucketSize,
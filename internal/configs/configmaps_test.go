@@ -5,6 +5,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/nginx/kubernetes-ingress/internal/configs/commonhelpers"
 
@@ -215,6 +216,49 @@ func TestParseConfigMapWithoutTLSPassthroughProxyProtocol(t *testing.T) {
 	}
 }
 
+func TestParseConfigMapHTTP3(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		http3 string
+		want  bool
+		msg   string
+	}{
+		{
+			http3: "true",
+			want:  true,
+			msg:   "http3 enabled",
+		},
+		{
+			http3: "false",
+			want:  false,
+			msg:   "http3 disabled",
+		},
+		{
+			http3: "invalid",
+			want:  false,
+			msg:   "invalid http3 value, ignored",
+		},
+	}
+	nginxPlus := false
+	hasAppProtect := false
+	hasAppProtectDos := false
+	hasTLSPassthrough := false
+	directiveAutoadjustEnabled := false
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			cm := &v1.ConfigMap{
+				Data: map[string]string{
+					"http3": test.http3,
+				},
+			}
+			result, _ := ParseConfigMap(context.Background(), cm, nginxPlus, hasAppProtect, hasAppProtectDos, hasTLSPassthrough, directiveAutoadjustEnabled, makeEventLogger())
+			if result.HTTP3 != test.want {
+				t.Errorf("want %v, got %v", test.want, result.HTTP3)
+			}
+		})
+	}
+}
+
 func TestParseConfigMapAccessLog(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -296,6 +340,219 @@ func TestParseConfigMapAccessLogDefault(t *testing.T) {
 	}
 }
 
+func TestParseConfigMapRequestID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		data              map[string]string
+		wantEnable        bool
+		wantHeader        string
+		wantTrustIncoming bool
+		msg               string
+	}{
+		{
+			data:       map[string]string{},
+			wantHeader: "X-Request-ID",
+			msg:        "default",
+		},
+		{
+			data: map[string]string{
+				"request-id": "true",
+			},
+			wantEnable: true,
+			wantHeader: "X-Request-ID",
+			msg:        "enabled with default header",
+		},
+		{
+			data: map[string]string{
+				"request-id":                "true",
+				"request-id-header":         "X-Correlation-ID",
+				"request-id-trust-incoming": "true",
+			},
+			wantEnable:        true,
+			wantHeader:        "X-Correlation-ID",
+			wantTrustIncoming: true,
+			msg:               "enabled with custom header and trusted incoming request ID",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			cm := &v1.ConfigMap{
+				Data: test.data,
+			}
+			result, configOk := ParseConfigMap(context.Background(), cm, false, false, false, false, false, makeEventLogger())
+			if !configOk {
+				t.Error("want configOk true, got false")
+			}
+			if result.RequestID != test.wantEnable {
+				t.Errorf("want RequestID %t, got %t", test.wantEnable, result.RequestID)
+			}
+			if result.RequestIDHeader != test.wantHeader {
+				t.Errorf("want RequestIDHeader %q, got %q", test.wantHeader, result.RequestIDHeader)
+			}
+			if result.RequestIDTrustIncoming != test.wantTrustIncoming {
+				t.Errorf("want RequestIDTrustIncoming %t, got %t", test.wantTrustIncoming, result.RequestIDTrustIncoming)
+			}
+		})
+	}
+}
+
+func TestParseConfigMapRequestIDInvalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		data map[string]string
+		msg  string
+	}{
+		{
+			data: map[string]string{
+				"request-id": "yes",
+			},
+			msg: "invalid request-id",
+		},
+		{
+			data: map[string]string{
+				"request-id-header": "X Request ID",
+			},
+			msg: "invalid request-id-header",
+		},
+		{
+			data: map[string]string{
+				"request-id-trust-incoming": "always",
+			},
+			msg: "invalid request-id-trust-incoming",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			cm := &v1.ConfigMap{
+				Data: test.data,
+			}
+			result, configOk := ParseConfigMap(context.Background(), cm, false, false, false, false, false, makeEventLogger())
+			if configOk {
+				t.Error("want configOk false, got true")
+			}
+			if result.RequestID || result.RequestIDTrustIncoming || result.RequestIDHeader != "X-Request-ID" {
+				t.Errorf("want default request ID settings, got %t, %q, %t", result.RequestID, result.RequestIDHeader, result.RequestIDTrustIncoming)
+			}
+		})
+	}
+}
+
+func TestParseConfigMapEndpointDrainPeriod(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		data         map[string]string
+		want         time.Duration
+		wantConfigOk bool
+		msg          string
+	}{
+		{
+			data:         map[string]string{},
+			want:         0,
+			wantConfigOk: true,
+			msg:          "default",
+		},
+		{
+			data: map[string]string{
+				"endpoint-drain-period": "30s",
+			},
+			want:         30 * time.Second,
+			wantConfigOk: true,
+			msg:          "valid drain period",
+		},
+		{
+			data: map[string]string{
+				"endpoint-drain-period": "30",
+			},
+			want:         0,
+			wantConfigOk: false,
+			msg:          "drain period without unit",
+		},
+		{
+			data: map[string]string{
+				"endpoint-drain-period": "-1m",
+			},
+			want:         0,
+			wantConfigOk: false,
+			msg:          "negative drain period",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			cm := &v1.ConfigMap{
+				Data: test.data,
+			}
+			result, configOk := ParseConfigMap(context.Background(), cm, false, false, false, false, false, makeEventLogger())
+			if configOk != test.wantConfigOk {
+				t.Errorf("want configOk %t, got %t", test.wantConfigOk, configOk)
+			}
+			if result.EndpointDrainPeriod != test.want {
+				t.Errorf("want EndpointDrainPeriod %v, got %v", test.want, result.EndpointDrainPeriod)
+			}
+		})
+	}
+}
+
+func TestParseConfigMapTopologyAwareRouting(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		data             map[string]string
+		wantEnable       bool
+		wantMinEndpoints int
+		wantConfigOk     bool
+		msg              string
+	}{
+		{
+			data:             map[string]string{},
+			wantMinEndpoints: 1,
+			wantConfigOk:     true,
+			msg:              "default",
+		},
+		{
+			data: map[string]string{
+				"topology-aware-routing":               "true",
+				"topology-aware-routing-min-endpoints": "2",
+			},
+			wantEnable:       true,
+			wantMinEndpoints: 2,
+			wantConfigOk:     true,
+			msg:              "enabled with minimum endpoints",
+		},
+		{
+			data: map[string]string{
+				"topology-aware-routing": "zone",
+			},
+			wantMinEndpoints: 1,
+			wantConfigOk:     false,
+			msg:              "invalid topology-aware-routing",
+		},
+		{
+			data: map[string]string{
+				"topology-aware-routing-min-endpoints": "0",
+			},
+			wantMinEndpoints: 1,
+			wantConfigOk:     false,
+			msg:              "invalid topology-aware-routing-min-endpoints",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			cm := &v1.ConfigMap{
+				Data: test.data,
+			}
+			result, configOk := ParseConfigMap(context.Background(), cm, false, false, false, false, false, makeEventLogger())
+			if configOk != test.wantConfigOk {
+				t.Errorf("want configOk %t, got %t", test.wantConfigOk, configOk)
+			}
+			if result.TopologyAwareRouting != test.wantEnable {
+				t.Errorf("want TopologyAwareRouting %t, got %t", test.wantEnable, result.TopologyAwareRouting)
+			}
+			if result.TopologyAwareRoutingMinEndpoints != test.wantMinEndpoints {
+				t.Errorf("want TopologyAwareRoutingMinEndpoints %d, got %d", test.wantMinEndpoints, result.TopologyAwareRoutingMinEndpoints)
+			}
+		})
+	}
+}
+
 func TestParseMGMTConfigMapError(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
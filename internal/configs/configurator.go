@@ -91,11 +91,13 @@ type ExtendedResources struct {
 	TransportServerExes []*TransportServerEx
 }
 
-// WeightUpdate holds the information about the weight updates for weight changes without reloading.
+// WeightUpdate holds the information about a keyval update applied without reloading, such as weight changes or maintenance mode toggles.
 type WeightUpdate struct {
 	Zone  string
 	Key   string
 	Value string
+	// Pairs, when not nil, replace all the key-value pairs of the zone. Key and Value are ignored.
+	Pairs map[string]string
 }
 
 type tlsPassthroughPair struct {
@@ -374,6 +376,22 @@ func (cnf *Configurator) StreamUpstreamsForName(name string) []string {
 	return nil
 }
 
+// GetTransportServerUpstreamStatuses returns the health of the upstreams of the TransportServers from the NGINX Plus
+// stream upstream stats. The statuses are keyed by the namespace and the name of the TransportServer.
+func (cnf *Configurator) GetTransportServerUpstreamStatuses(transportServers []*conf_v1.TransportServer) (map[string][]conf_v1.TransportServerUpstreamStatus, error) {
+	streamUpstreams, err := cnf.nginxManager.GetStreamUpstreamsInPlus()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string][]conf_v1.TransportServerUpstreamStatus)
+	for _, ts := range transportServers {
+		statuses[ts.Namespace+"/"+ts.Name] = generateTransportServerUpstreamStatuses(ts, *streamUpstreams)
+	}
+
+	return statuses, nil
+}
+
 // transportServerForActionName takes an action name and returns
 // Transport Server obj associated with that name.
 func (cnf *Configurator) transportServerForActionName(name string) *conf_v1.TransportServer {
@@ -383,6 +401,11 @@ func (cnf *Configurator) transportServerForActionName(name string) *conf_v1.Tran
 		if tsEx.TransportServer.Spec.Action.Pass == name {
 			return tsEx.TransportServer
 		}
+		for _, s := range tsEx.TransportServer.Spec.Action.Splits {
+			if s.Pass == name {
+				return tsEx.TransportServer
+			}
+		}
 	}
 	return nil
 }
@@ -611,9 +634,7 @@ func (cnf *Configurator) AddOrUpdateVirtualServer(virtualServerEx *VirtualServer
 		return warnings, fmt.Errorf("error reloading NGINX for VirtualServer %v/%v: %w", virtualServerEx.VirtualServer.Namespace, virtualServerEx.VirtualServer.Name, err)
 	}
 
-	for _, weightUpdate := range weightUpdates {
-		cnf.nginxManager.UpsertSplitClientsKeyVal(weightUpdate.Zone, weightUpdate.Key, weightUpdate.Value)
-	}
+	cnf.applyWeightUpdates(weightUpdates)
 
 	return warnings, nil
 }
@@ -657,6 +678,15 @@ func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServer
 			weightUpdates = append(weightUpdates, WeightUpdate{Zone: splitClient.ZoneName, Key: splitClient.Key, Value: value})
 		}
 	}
+
+	if cnf.isPlus && vsCfg.Server.Maintenance != nil {
+		weightUpdates = append(weightUpdates, NewMaintenanceKeyValUpdate(virtualServerEx.VirtualServer))
+	}
+
+	if cnf.isPlus && virtualServerEx.VirtualServer.Spec.RedirectMap != nil {
+		update, _ := NewRedirectMapKeyValUpdate(virtualServerEx)
+		weightUpdates = append(weightUpdates, update)
+	}
 	return changed, warnings, weightUpdates, nil
 }
 
@@ -678,10 +708,39 @@ func (cnf *Configurator) AddOrUpdateVirtualServers(virtualServerExes []*VirtualS
 		return allWarnings, fmt.Errorf("error when reloading NGINX when updating Policy: %w", err)
 	}
 
-	for _, weightUpdate := range allWeightUpdates {
-		cnf.nginxManager.UpsertSplitClientsKeyVal(weightUpdate.Zone, weightUpdate.Key, weightUpdate.Value)
+	cnf.applyWeightUpdates(allWeightUpdates)
+
+	return allWarnings, nil
+}
+
+// AddOrUpdateResourcesThatUsePolicy updates VirtualServers and TransportServers that use a Policy.
+func (cnf *Configurator) AddOrUpdateResourcesThatUsePolicy(virtualServerExes []*VirtualServerEx, transportServerExes []*TransportServerEx) (Warnings, error) {
+	allWarnings := newWarnings()
+	allWeightUpdates := []WeightUpdate{}
+
+	for _, vsEx := range virtualServerExes {
+		_, warnings, weightUpdates, err := cnf.addOrUpdateVirtualServer(vsEx)
+		if err != nil {
+			return allWarnings, err
+		}
+		allWarnings.Add(warnings)
+		allWeightUpdates = append(allWeightUpdates, weightUpdates...)
+	}
+
+	for _, tsEx := range transportServerExes {
+		_, warnings, err := cnf.addOrUpdateTransportServer(tsEx)
+		if err != nil {
+			return allWarnings, err
+		}
+		allWarnings.Add(warnings)
+	}
+
+	if err := cnf.Reload(nginx.ReloadForOtherUpdate); err != nil {
+		return allWarnings, fmt.Errorf("error when reloading NGINX when updating Policy: %w", err)
 	}
 
+	cnf.applyWeightUpdates(allWeightUpdates)
+
 	return allWarnings, nil
 }
 
@@ -1189,8 +1248,10 @@ func (cnf *Configurator) updatePlusEndpointsForVirtualServer(virtualServerEx *Vi
 		serverCfg := createUpstreamServersConfigForPlus(upstream)
 
 		endpoints := createEndpointsFromUpstream(upstream)
+		drainingEndpoints := createDrainingEndpointsFromUpstream(upstream)
+		otherZoneEndpoints := createOtherZoneEndpointsFromUpstream(upstream)
 
-		err := cnf.updateServersInPlus(upstream.Name, endpoints, serverCfg)
+		err := cnf.updateServersInPlus(upstream.Name, endpoints, drainingEndpoints, otherZoneEndpoints, serverCfg)
 		if err != nil {
 			return fmt.Errorf("couldn't update the endpoints for %v: %w", upstream.Name, err)
 		}
@@ -1216,6 +1277,10 @@ func (cnf *Configurator) UpdateEndpointsForTransportServers(transportServerExes
 				nl.Warnf(l, "Couldn't update the endpoints via the API: %v; reloading configuration instead", err)
 				reloadPlus = true
 			}
+			// the pass of a port range listener is a split_clients map of the endpoints, which the API doesn't update
+			if tsEx.ListenerEndPort > 0 {
+				reloadPlus = true
+			}
 		}
 	}
 
@@ -1260,13 +1325,15 @@ func (cnf *Configurator) updatePlusEndpoints(ingEx *IngressEx) error {
 	}
 
 	if ingEx.Ingress.Spec.DefaultBackend != nil {
-		endps, exists := ingEx.Endpoints[ingEx.Ingress.Spec.DefaultBackend.Service.Name+GetBackendPortAsString(ingEx.Ingress.Spec.DefaultBackend.Service.Port)]
+		endpointsKey := ingEx.Ingress.Spec.DefaultBackend.Service.Name + GetBackendPortAsString(ingEx.Ingress.Spec.DefaultBackend.Service.Port)
+		endps, exists := ingEx.Endpoints[endpointsKey]
 		if exists {
 			if _, isExternalName := ingEx.ExternalNameSvcs[ingEx.Ingress.Spec.DefaultBackend.Service.Name]; isExternalName {
 				nl.Debugf(l, "Service %s is Type ExternalName, skipping NGINX Plus endpoints update via API", ingEx.Ingress.Spec.DefaultBackend.Service.Name)
 			} else {
 				name := getNameForUpstream(ingEx.Ingress, emptyHost, ingEx.Ingress.Spec.DefaultBackend)
-				err := cnf.updateServersInPlus(name, endps, cfg)
+				endps, otherZoneEndps := generateZoneAwareEndpoints(endps, ingEx.OtherZoneEndpoints[endpointsKey], ingCfg.LBMethod)
+				err := cnf.updateServersInPlus(name, endps, ingEx.DrainingEndpoints[endpointsKey], otherZoneEndps, cfg)
 				if err != nil {
 					return fmt.Errorf("couldn't update the endpoints for %v: %w", name, err)
 				}
@@ -1281,7 +1348,8 @@ func (cnf *Configurator) updatePlusEndpoints(ingEx *IngressEx) error {
 
 		for _, path := range rule.HTTP.Paths {
 			path := path // address gosec G601
-			endps, exists := ingEx.Endpoints[path.Backend.Service.Name+GetBackendPortAsString(path.Backend.Service.Port)]
+			endpointsKey := path.Backend.Service.Name + GetBackendPortAsString(path.Backend.Service.Port)
+			endps, exists := ingEx.Endpoints[endpointsKey]
 			if exists {
 				if _, isExternalName := ingEx.ExternalNameSvcs[path.Backend.Service.Name]; isExternalName {
 					nl.Debugf(l, "Service %s is Type ExternalName, skipping NGINX Plus endpoints update via API", path.Backend.Service.Name)
@@ -1289,7 +1357,8 @@ func (cnf *Configurator) updatePlusEndpoints(ingEx *IngressEx) error {
 				}
 
 				name := getNameForUpstream(ingEx.Ingress, rule.Host, &path.Backend)
-				err := cnf.updateServersInPlus(name, endps, cfg)
+				endps, otherZoneEndps := generateZoneAwareEndpoints(endps, ingEx.OtherZoneEndpoints[endpointsKey], ingCfg.LBMethod)
+				err := cnf.updateServersInPlus(name, endps, ingEx.DrainingEndpoints[endpointsKey], otherZoneEndps, cfg)
 				if err != nil {
 					return fmt.Errorf("couldn't update the endpoints for %v: %w", name, err)
 				}
@@ -1319,12 +1388,12 @@ func (cnf *Configurator) Reload(isEndpointsUpdate bool) error {
 	return cnf.nginxManager.Reload(isEndpointsUpdate)
 }
 
-func (cnf *Configurator) updateServersInPlus(upstream string, servers []string, config nginx.ServerConfig) error {
+func (cnf *Configurator) updateServersInPlus(upstream string, servers []string, drainingServers []string, backupServers []string, config nginx.ServerConfig) error {
 	if !cnf.isReloadsEnabled {
 		return nil
 	}
 
-	return cnf.nginxManager.UpdateServersInPlus(upstream, servers, config)
+	return cnf.nginxManager.UpdateServersInPlus(upstream, servers, drainingServers, backupServers, config)
 }
 
 func (cnf *Configurator) updateStreamServersInPlus(upstream string, servers []string) error {
@@ -1433,9 +1502,7 @@ func (cnf *Configurator) UpdateConfig(resources ExtendedResources) (Warnings, er
 		return allWarnings, fmt.Errorf("error when updating config from ConfigMap: %w", err)
 	}
 
-	for _, weightUpdate := range allWeightUpdates {
-		cnf.nginxManager.UpsertSplitClientsKeyVal(weightUpdate.Zone, weightUpdate.Key, weightUpdate.Value)
-	}
+	cnf.applyWeightUpdates(allWeightUpdates)
 
 	return allWarnings, nil
 }
@@ -1474,9 +1541,7 @@ func (cnf *Configurator) UpdateVirtualServers(updatedVSExes []*VirtualServerEx,
 		errList = append(errList, fmt.Errorf("error when updating VirtualServer: %w", err))
 	}
 
-	for _, weightUpdate := range allWeightUpdates {
-		cnf.nginxManager.UpsertSplitClientsKeyVal(weightUpdate.Zone, weightUpdate.Key, weightUpdate.Value)
-	}
+	cnf.applyWeightUpdates(allWeightUpdates)
 
 	return errList
 }
@@ -1905,9 +1970,7 @@ func (cnf *Configurator) addOrUpdateIngressesAndVirtualServers(ingExes []*Ingres
 		allWarnings.Add(warnings)
 	}
 
-	for _, weightUpdate := range allWeightUpdates {
-		cnf.nginxManager.UpsertSplitClientsKeyVal(weightUpdate.Zone, weightUpdate.Key, weightUpdate.Value)
-	}
+	cnf.applyWeightUpdates(allWeightUpdates)
 
 	return allWarnings, nil
 }
@@ -2037,6 +2100,34 @@ func (cnf *Configurator) DynamicSSLReloadEnabled() bool {
 	return cnf.isDynamicSSLReloadEnabled
 }
 
+// applyWeightUpdates applies the keyval updates after NGINX is reloaded.
+func (cnf *Configurator) applyWeightUpdates(weightUpdates []WeightUpdate) {
+	for _, weightUpdate := range weightUpdates {
+		if weightUpdate.Pairs != nil {
+			cnf.nginxManager.ReplaceKeyValPairs(weightUpdate.Zone, weightUpdate.Pairs)
+			continue
+		}
+		cnf.nginxManager.UpsertSplitClientsKeyVal(weightUpdate.Zone, weightUpdate.Key, weightUpdate.Value)
+	}
+}
+
+// UpdateVirtualServerRedirectMaps updates the bulk redirects of VirtualServers in the keyval zones without reloading NGINX Plus.
+func (cnf *Configurator) UpdateVirtualServerRedirectMaps(virtualServerExes []*VirtualServerEx) Warnings {
+	allWarnings := newWarnings()
+
+	for _, vsEx := range virtualServerExes {
+		cnf.virtualServers[getFileNameForVirtualServer(vsEx.VirtualServer)] = vsEx
+
+		update, problems := NewRedirectMapKeyValUpdate(vsEx)
+		for _, problem := range problems {
+			allWarnings.AddWarning(vsEx.VirtualServer, problem)
+		}
+		cnf.applyWeightUpdates([]WeightUpdate{update})
+	}
+
+	return allWarnings
+}
+
 // UpsertSplitClientsKeyVal upserts a key-value pair in a keyzal zone for weight changes without reloads.
 func (cnf *Configurator) UpsertSplitClientsKeyVal(zoneName, key, value string) {
 	cnf.nginxManager.UpsertSplitClientsKeyVal(zoneName, key, value)
@@ -1638,6 +1638,35 @@ func TestStreamUpstreamsForName_ReturnsStreamUpstreamsNamesOnValidServiceName(t
 	}
 }
 
+func TestStreamUpstreamsForName_ReturnsStreamUpstreamsNamesOnSplitServiceName(t *testing.T) {
+	t.Parallel()
+
+	tsEx := *validTransportServerExWithUpstreams
+	tsEx.TransportServer = validTransportServerExWithUpstreams.TransportServer.DeepCopy()
+	tsEx.TransportServer.Spec.Upstreams = append(tsEx.TransportServer.Spec.Upstreams, conf_v1.TransportServerUpstream{
+		Name:    "secure-app-v2",
+		Service: "secure-app-v2",
+		Port:    8443,
+	})
+	tsEx.TransportServer.Spec.Action = &conf_v1.TransportServerAction{
+		Splits: []conf_v1.TransportServerSplit{
+			{Weight: 90, Pass: "secure-app"},
+			{Weight: 10, Pass: "secure-app-v2"},
+		},
+	}
+
+	tcnf := createTestConfigurator(t)
+	tcnf.transportServers = map[string]*TransportServerEx{
+		"ts": &tsEx,
+	}
+
+	want := []string{"ts_default_secure-app_secure-app", "ts_default_secure-app_secure-app-v2"}
+	got := tcnf.StreamUpstreamsForName("secure-app-v2")
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
 func TestGetIngressAnnotations(t *testing.T) {
 	t.Parallel()
 
@@ -1830,6 +1859,61 @@ func TestAddOrUpdateTransportServer(t *testing.T) {
 	}
 }
 
+// reloadCountingManager is a fake NGINX manager that counts the reloads.
+type reloadCountingManager struct {
+	*nginx.FakeManager
+	reloads int
+}
+
+func (m *reloadCountingManager) Reload(_ bool) error {
+	m.reloads++
+	return nil
+}
+
+func TestUpdateEndpointsForTransportServersReloadsPlusForPortRange(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		listenerEndPort int
+		expectedReloads int
+		msg             string
+	}{
+		{
+			listenerEndPort: 0,
+			expectedReloads: 0,
+			msg:             "endpoints are updated via the API",
+		},
+		{
+			listenerEndPort: 30100,
+			expectedReloads: 1,
+			msg:             "port range endpoints are rendered in the config",
+		},
+	}
+
+	for _, test := range tests {
+		cnf := createTestConfigurator(t)
+		cnf.isPlus = true
+		manager := &reloadCountingManager{FakeManager: nginx.NewFakeManager("/etc/nginx")}
+		cnf.nginxManager = manager
+
+		tsEx := createTransportServerExWithHostNoTLSPassthrough()
+		tsEx.TransportServer.Spec.Host = ""
+		tsEx.TransportServer.Spec.TLS = nil
+		tsEx.ListenerPort = 30000
+		tsEx.ListenerEndPort = test.listenerEndPort
+		tsEx.Endpoints = map[string][]string{
+			"default/echo-app:7000": {"10.0.0.2:7000"},
+		}
+
+		err := cnf.UpdateEndpointsForTransportServers([]*TransportServerEx{&tsEx})
+		if err != nil {
+			t.Errorf("UpdateEndpointsForTransportServers() returned unexpected error %v for the case of %s", err, test.msg)
+		}
+		if manager.reloads != test.expectedReloads {
+			t.Errorf("UpdateEndpointsForTransportServers() reloaded NGINX %d times but expected %d for the case of %s", manager.reloads, test.expectedReloads, test.msg)
+		}
+	}
+}
+
 var (
 	invalidVirtualServerEx = &VirtualServerEx{
 		VirtualServer: &conf_v1.VirtualServer{},
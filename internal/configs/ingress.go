@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/nginx/kubernetes-ingress/internal/configs/version1"
+	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
 )
 
 const emptyHost = ""
@@ -35,18 +36,21 @@ type AppProtectLog struct {
 
 // IngressEx holds an Ingress along with the resources that are referenced in this Ingress.
 type IngressEx struct {
-	Ingress          *networking.Ingress
-	Endpoints        map[string][]string
-	HealthChecks     map[string]*api_v1.Probe
-	ExternalNameSvcs map[string]bool
-	PodsByIP         map[string]PodInfo
-	ValidHosts       map[string]bool
-	ValidMinionPaths map[string]bool
-	AppProtectPolicy *unstructured.Unstructured
-	AppProtectLogs   []AppProtectLog
-	DosEx            *DosEx
-	SecretRefs       map[string]*secrets.SecretReference
-	ZoneSync         bool
+	Ingress            *networking.Ingress
+	Endpoints          map[string][]string
+	DrainingEndpoints  map[string][]string
+	OtherZoneEndpoints map[string][]string
+	AppProtocols       map[string]string
+	HealthChecks       map[string]*api_v1.Probe
+	ExternalNameSvcs   map[string]bool
+	PodsByIP           map[string]PodInfo
+	ValidHosts         map[string]bool
+	ValidMinionPaths   map[string]bool
+	AppProtectPolicy   *unstructured.Unstructured
+	AppProtectLogs     []AppProtectLog
+	DosEx              *DosEx
+	SecretRefs         map[string]*secrets.SecretReference
+	ZoneSync           bool
 }
 
 // DosEx holds a DosProtectedResource and the dos policy and log confs it references.
@@ -115,11 +119,17 @@ func generateNginxCfg(p NginxCfgParams) (version1.IngressNginxConfig, Warnings)
 		grpcServices = make(map[string]bool)
 	}
 
+	wsServices, sslServices, grpcServices, appProtocolWarnings := applyAppProtocols(p.ingEx, cfgParams.HTTP2, wsServices, sslServices, grpcServices)
+
+	allWarnings := newWarnings()
+	allWarnings.Add(appProtocolWarnings)
+
 	if p.ingEx.Ingress.Spec.DefaultBackend != nil {
 		name := getNameForUpstream(p.ingEx.Ingress, emptyHost, p.ingEx.Ingress.Spec.DefaultBackend)
-		upstream := createUpstream(p.ingEx, name, p.ingEx.Ingress.Spec.DefaultBackend, spServices[p.ingEx.Ingress.Spec.DefaultBackend.Service.Name], &cfgParams,
+		upstream, warnings := createUpstream(p.ingEx, name, p.ingEx.Ingress.Spec.DefaultBackend, spServices[p.ingEx.Ingress.Spec.DefaultBackend.Service.Name], &cfgParams,
 			p.isPlus, p.isResolverConfigured, p.staticParams.EnableLatencyMetrics)
 		upstreams[name] = upstream
+		allWarnings.Add(warnings)
 
 		if cfgParams.HealthCheckEnabled {
 			if hc, exists := p.ingEx.HealthChecks[p.ingEx.Ingress.Spec.DefaultBackend.Service.Name+GetBackendPortAsString(p.ingEx.Ingress.Spec.DefaultBackend.Service.Port)]; exists {
@@ -128,10 +138,15 @@ func generateNginxCfg(p NginxCfgParams) (version1.IngressNginxConfig, Warnings)
 		}
 	}
 
-	allWarnings := newWarnings()
-
 	var servers []version1.Server
 	var limitReqZones []version1.LimitReqZone
+	var otelSamplers []version1.OtelSampler
+
+	tracing, otelSampler, warnings := generateIngressTracing(p.ingEx.Ingress, &cfgParams)
+	allWarnings.Add(warnings)
+	if otelSampler != nil {
+		otelSamplers = append(otelSamplers, *otelSampler)
+	}
 
 	for _, rule := range p.ingEx.Ingress.Spec.Rules {
 		// skipping invalid hosts
@@ -150,6 +165,11 @@ func generateNginxCfg(p NginxCfgParams) (version1.IngressNginxConfig, Warnings)
 
 		statusZone := rule.Host
 
+		var requestIDHeader string
+		if cfgParams.RequestID {
+			requestIDHeader = cfgParams.RequestIDHeader
+		}
+
 		server := version1.Server{
 			Name:                  serverName,
 			ServerTokens:          cfgParams.ServerTokens,
@@ -161,6 +181,7 @@ func generateNginxCfg(p NginxCfgParams) (version1.IngressNginxConfig, Warnings)
 			HSTSMaxAge:            cfgParams.HSTSMaxAge,
 			HSTSIncludeSubdomains: cfgParams.HSTSIncludeSubdomains,
 			HSTSBehindProxy:       cfgParams.HSTSBehindProxy,
+			RequestIDHeader:       requestIDHeader,
 			StatusZone:            statusZone,
 			RealIPHeader:          cfgParams.RealIPHeader,
 			SetRealIPFrom:         cfgParams.SetRealIPFrom,
@@ -246,14 +267,16 @@ func generateNginxCfg(p NginxCfgParams) (version1.IngressNginxConfig, Warnings)
 			}
 
 			if _, exists := upstreams[upsName]; !exists {
-				upstream := createUpstream(p.ingEx, upsName, &path.Backend, spServices[path.Backend.Service.Name], &cfgParams, p.isPlus, p.isResolverConfigured, p.staticParams.EnableLatencyMetrics)
+				upstream, warnings := createUpstream(p.ingEx, upsName, &path.Backend, spServices[path.Backend.Service.Name], &cfgParams, p.isPlus, p.isResolverConfigured, p.staticParams.EnableLatencyMetrics)
 				upstreams[upsName] = upstream
+				allWarnings.Add(warnings)
 			}
 
 			ssl := isSSLEnabled(sslServices[path.Backend.Service.Name], cfgParams, p.staticParams)
 			proxySSLName := generateProxySSLName(path.Backend.Service.Name, p.ingEx.Ingress.Namespace)
 			loc := createLocation(pathOrDefault(path.Path), upstreams[upsName], &cfgParams, wsServices[path.Backend.Service.Name], rewrites[path.Backend.Service.Name],
 				ssl, grpcServices[path.Backend.Service.Name], proxySSLName, path.PathType, path.Backend.Service.Name)
+			loc.Tracing = tracing
 
 			if p.isMinion && cfgParams.JWTKey != "" {
 				jwtAuth, redirectLoc, warnings := generateJWTConfig(p.ingEx.Ingress, p.ingEx.SecretRefs, &cfgParams, getNameForRedirectLocation(p.ingEx.Ingress))
@@ -319,6 +342,7 @@ func generateNginxCfg(p NginxCfgParams) (version1.IngressNginxConfig, Warnings)
 
 			loc := createLocation(pathOrDefault("/"), upstreams[upsName], &cfgParams, wsServices[p.ingEx.Ingress.Spec.DefaultBackend.Service.Name], rewrites[p.ingEx.Ingress.Spec.DefaultBackend.Service.Name],
 				ssl, grpcServices[p.ingEx.Ingress.Spec.DefaultBackend.Service.Name], proxySSLName, &pathtype, p.ingEx.Ingress.Spec.DefaultBackend.Service.Name)
+			loc.Tracing = tracing
 			locations = append(locations, loc)
 
 			if cfgParams.HealthCheckEnabled {
@@ -357,9 +381,54 @@ func generateNginxCfg(p NginxCfgParams) (version1.IngressNginxConfig, Warnings)
 		DynamicSSLReloadEnabled: p.staticParams.DynamicSSLReload,
 		StaticSSLPath:           p.staticParams.StaticSSLPath,
 		LimitReqZones:           limitReqZones,
+		OtelSamplers:            otelSamplers,
 	}, allWarnings
 }
 
+// generateIngressTracing generates the OpenTelemetry tracing of the locations of an Ingress and the variable
+// that enables tracing for a percentage of requests.
+func generateIngressTracing(owner *networking.Ingress, cfgParams *ConfigParams) (*version2.Tracing, *version1.OtelSampler, Warnings) {
+	warnings := newWarnings()
+
+	if cfgParams.OtelTrace == nil && cfgParams.OtelTraceSampling == 0 && cfgParams.OtelSpanName == "" && len(cfgParams.OtelSpanAttributes) == 0 {
+		return nil, nil, warnings
+	}
+
+	if !cfgParams.MainOtelLoadModule {
+		warnings.AddWarningf(owner, "Tracing cannot be configured. Tracing requires the otel-exporter-endpoint ConfigMap key")
+		return nil, nil, warnings
+	}
+
+	if cfgParams.OtelTrace != nil && !*cfgParams.OtelTrace {
+		return &version2.Tracing{Trace: "off"}, nil, warnings
+	}
+
+	tracing := &version2.Tracing{
+		SpanName:       cfgParams.OtelSpanName,
+		SpanAttributes: cfgParams.OtelSpanAttributes,
+	}
+	if cfgParams.OtelTrace != nil || cfgParams.OtelTraceSampling == 100 {
+		tracing.Trace = "on"
+	}
+
+	var sampler *version1.OtelSampler
+	if cfgParams.OtelTraceSampling > 0 && cfgParams.OtelTraceSampling < 100 {
+		sampler = &version1.OtelSampler{
+			Variable:   getNameForOtelSampler(owner, cfgParams.OtelTraceSampling),
+			Percentage: cfgParams.OtelTraceSampling,
+		}
+		tracing.Trace = sampler.Variable
+	}
+
+	return tracing, sampler, warnings
+}
+
+var variableNameReplacer = strings.NewReplacer("-", "_", ".", "_")
+
+func getNameForOtelSampler(ing *networking.Ingress, percentage int) string {
+	return variableNameReplacer.Replace(fmt.Sprintf("$ing_%s_%s_otel_sample_%d", ing.Namespace, ing.Name, percentage))
+}
+
 func generateJWTConfig(owner runtime.Object, secretRefs map[string]*secrets.SecretReference, cfgParams *ConfigParams,
 	redirectLocationName string,
 ) (*version1.JWTAuth, *version1.JWTRedirectLocation, Warnings) {
@@ -523,9 +592,12 @@ func upstreamRequiresQueue(name string, ingEx *IngressEx, cfg *ConfigParams) (n
 	return 0, 0
 }
 
+// createUpstream creates an upstream for the backend.
+// With NGINX, the sticky cookie of the backend service configures session affinity.
 func createUpstream(ingEx *IngressEx, name string, backend *networking.IngressBackend, stickyCookie string, cfg *ConfigParams,
 	isPlus bool, isResolverConfigured bool, isLatencyMetricsEnabled bool,
-) version1.Upstream {
+) (version1.Upstream, Warnings) {
+	warnings := newWarnings()
 	var ups version1.Upstream
 	labels := version1.UpstreamLabels{
 		Service:           backend.Service.Name,
@@ -544,7 +616,37 @@ func createUpstream(ingEx *IngressEx, name string, backend *networking.IngressBa
 		}
 	}
 
-	endps, exists := ingEx.Endpoints[backend.Service.Name+GetBackendPortAsString(backend.Service.Port)]
+	lbMethod := cfg.LBMethod
+	if !isPlus && stickyCookie != "" {
+		if sc, err := parseSessionAffinityCookie(stickyCookie); err != nil {
+			nl.Error(l, err)
+		} else {
+			lbMethod = generateSessionAffinityLBMethod(name)
+			ups.SessionAffinity = &version1.SessionAffinity{
+				Maps:           generateSessionAffinityMaps(name, sc),
+				CookieVariable: generateSessionAffinityVariable(name, "_cookie"),
+			}
+		}
+	}
+
+	endpointsKey := backend.Service.Name + GetBackendPortAsString(backend.Service.Port)
+	endps, exists := ingEx.Endpoints[endpointsKey]
+	drainingEndps := ingEx.DrainingEndpoints[endpointsKey]
+	otherZoneEndps := ingEx.OtherZoneEndpoints[endpointsKey]
+	if len(otherZoneEndps) > 0 && isIncompatibleLBMethodForBackup(lbMethod) {
+		warnings.AddWarningf(ingEx.Ingress, "Endpoints of service %s in other zones will not be used as backup servers because lb method '%s' is incompatible with backup servers", backend.Service.Name, lbMethod)
+	}
+	endps, otherZoneEndps = generateZoneAwareEndpoints(endps, otherZoneEndps, lbMethod)
+	if !isPlus && len(drainingEndps) > 0 {
+		if len(endps) == 0 {
+			// NGINX does not allow an upstream with only backup servers,
+			// so draining endpoints are used as regular servers while they are the only ones left.
+			endps, drainingEndps = drainingEndps, nil
+		} else if isIncompatibleLBMethodForBackup(lbMethod) {
+			nl.Warnf(l, "Terminating endpoints of service %s will not be drained because lb method '%s' is incompatible with backup servers", backend.Service.Name, lbMethod)
+			drainingEndps = nil
+		}
+	}
 	if exists {
 		var upsServers []version1.UpstreamServer
 		// Always false for NGINX OSS
@@ -564,6 +666,26 @@ func createUpstream(ingEx *IngressEx, name string, backend *networking.IngressBa
 				Resolve:     isExternalNameSvc,
 			})
 		}
+		for _, endp := range otherZoneEndps {
+			upsServers = append(upsServers, version1.UpstreamServer{
+				Address:     endp,
+				MaxFails:    cfg.MaxFails,
+				MaxConns:    cfg.MaxConns,
+				FailTimeout: cfg.FailTimeout,
+				SlowStart:   cfg.SlowStart,
+				Backup:      true,
+			})
+		}
+		for _, endp := range drainingEndps {
+			upsServers = append(upsServers, version1.UpstreamServer{
+				Address:     endp,
+				MaxFails:    cfg.MaxFails,
+				MaxConns:    cfg.MaxConns,
+				FailTimeout: cfg.FailTimeout,
+				SlowStart:   cfg.SlowStart,
+				Drain:       true,
+			})
+		}
 		if len(upsServers) > 0 {
 			sort.Slice(upsServers, func(i, j int) bool {
 				return upsServers[i].Address < upsServers[j].Address
@@ -572,9 +694,120 @@ func createUpstream(ingEx *IngressEx, name string, backend *networking.IngressBa
 		}
 	}
 
-	ups.LBMethod = cfg.LBMethod
+	ups.LBMethod = lbMethod
 	ups.UpstreamZoneSize = cfg.UpstreamZoneSize
-	return ups
+	return ups, warnings
+}
+
+// generateZoneAwareEndpoints returns the endpoints and the endpoints in other zones for topology-aware routing.
+// The endpoints in other zones are dropped if the lb method does not allow backup servers. In that case, the endpoints
+// in other zones are used only when the zone has fewer ready endpoints than the configured minimum,
+// because then they are already part of the endpoints.
+func generateZoneAwareEndpoints(endpoints []string, otherZoneEndpoints []string, lbMethod string) ([]string, []string) {
+	if isIncompatibleLBMethodForBackup(lbMethod) {
+		return endpoints, nil
+	}
+	return endpoints, otherZoneEndpoints
+}
+
+// appProtocol describes the upstream protocol that the appProtocol of a Service port stands for.
+type appProtocol struct {
+	grpc      bool
+	websocket bool
+	tls       bool
+	cleartext bool
+}
+
+// parseAppProtocol returns the upstream protocol for the appProtocol of a Service port
+// and whether the appProtocol is supported.
+func parseAppProtocol(value string) (appProtocol, bool) {
+	switch strings.ToLower(value) {
+	case "http":
+		return appProtocol{cleartext: true}, true
+	case "https":
+		return appProtocol{tls: true}, true
+	case "grpc":
+		return appProtocol{grpc: true}, true
+	case "kubernetes.io/h2c":
+		return appProtocol{grpc: true, cleartext: true}, true
+	case "kubernetes.io/ws":
+		return appProtocol{websocket: true, cleartext: true}, true
+	case "kubernetes.io/wss":
+		return appProtocol{websocket: true, tls: true}, true
+	}
+	return appProtocol{}, false
+}
+
+// applyAppProtocols adds the services of the Ingress to the websocket, SSL and gRPC services according to the
+// appProtocol of their Service ports. The nginx.org/websocket-services, nginx.org/ssl-services and
+// nginx.org/grpc-services annotations take precedence over the appProtocol, and conflicts are returned as warnings.
+func applyAppProtocols(ingEx *IngressEx, http2 bool, wsServices, sslServices, grpcServices map[string]bool) (map[string]bool, map[string]bool, map[string]bool, Warnings) {
+	warnings := newWarnings()
+	if len(ingEx.AppProtocols) == 0 {
+		return wsServices, sslServices, grpcServices, warnings
+	}
+
+	var backends []*networking.IngressBackend
+	if ingEx.Ingress.Spec.DefaultBackend != nil {
+		backends = append(backends, ingEx.Ingress.Spec.DefaultBackend)
+	}
+	for _, rule := range ingEx.Ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			backends = append(backends, &rule.HTTP.Paths[i].Backend)
+		}
+	}
+
+	ws := copyServices(wsServices)
+	ssl := copyServices(sslServices)
+	grpc := copyServices(grpcServices)
+
+	for _, backend := range backends {
+		if backend.Service == nil {
+			continue
+		}
+		name := backend.Service.Name
+		value, exists := ingEx.AppProtocols[name+GetBackendPortAsString(backend.Service.Port)]
+		if !exists {
+			continue
+		}
+		protocol, ok := parseAppProtocol(value)
+		if !ok {
+			continue
+		}
+
+		if grpcServices[name] && !protocol.grpc {
+			warnings.AddWarningf(ingEx.Ingress, "service %s is listed in nginx.org/grpc-services, but its appProtocol is %s, using gRPC", name, value)
+		} else if protocol.grpc && !grpcServices[name] {
+			if http2 {
+				grpc[name] = true
+			} else {
+				warnings.AddWarningf(ingEx.Ingress, "appProtocol %s of service %s requires HTTP2, ignoring", value, name)
+			}
+		}
+
+		if sslServices[name] && protocol.cleartext {
+			warnings.AddWarningf(ingEx.Ingress, "service %s is listed in nginx.org/ssl-services, but its appProtocol is %s, using TLS", name, value)
+		} else if protocol.tls {
+			ssl[name] = true
+		}
+
+		if protocol.websocket {
+			ws[name] = true
+		}
+	}
+
+	return ws, ssl, grpc, warnings
+}
+
+func copyServices(services map[string]bool) map[string]bool {
+	result := make(map[string]bool, len(services))
+	for name, enabled := range services {
+		result[name] = enabled
+	}
+	return result
 }
 
 func createHealthCheck(hc *api_v1.Probe, upstreamName string, cfg *ConfigParams) version1.HealthCheck {
@@ -641,6 +874,7 @@ func generateNginxCfgForMergeableIngresses(p NginxCfgParams) (version1.IngressNg
 	var upstreams []version1.Upstream
 	healthChecks := make(map[string]version1.HealthCheck)
 	var limitReqZones []version1.LimitReqZone
+	var otelSamplers []version1.OtelSampler
 	var keepalive string
 
 	// replace master with a deepcopy because we will modify it
@@ -739,6 +973,7 @@ func generateNginxCfgForMergeableIngresses(p NginxCfgParams) (version1.IngressNg
 
 		upstreams = append(upstreams, nginxCfg.Upstreams...)
 		limitReqZones = append(limitReqZones, nginxCfg.LimitReqZones...)
+		otelSamplers = append(otelSamplers, nginxCfg.OtelSamplers...)
 	}
 
 	masterServer.HealthChecks = healthChecks
@@ -753,6 +988,7 @@ func generateNginxCfgForMergeableIngresses(p NginxCfgParams) (version1.IngressNg
 		DynamicSSLReloadEnabled: p.staticParams.DynamicSSLReload,
 		StaticSSLPath:           p.staticParams.StaticSSLPath,
 		LimitReqZones:           limitReqZones,
+		OtelSamplers:            otelSamplers,
 	}, warnings
 }
 
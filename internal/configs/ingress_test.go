@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/nginx/kubernetes-ingress/internal/configs/version1"
+	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
 	"github.com/nginx/kubernetes-ingress/internal/k8s/secrets"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
@@ -2548,6 +2549,208 @@ func TestGenerateNginxCfgForMergeableIngressesForAppProtectDos(t *testing.T) {
 	}
 }
 
+func TestGenerateNginxCfgWithTracing(t *testing.T) {
+	t.Parallel()
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/otel-trace-sampling"] = "25"
+	cafeIngressEx.Ingress.Annotations["nginx.org/otel-span-name"] = "cafe $request_method"
+	cafeIngressEx.Ingress.Annotations["nginx.org/otel-span-attributes"] = "team: cafe"
+	configParams := NewDefaultConfigParams(context.Background(), false)
+	configParams.MainOtelLoadModule = true
+
+	result, warnings := generateNginxCfg(NginxCfgParams{
+		staticParams:  &StaticConfigParams{},
+		ingEx:         &cafeIngressEx,
+		BaseCfgParams: configParams,
+	})
+
+	expectedSamplers := []version1.OtelSampler{
+		{Variable: "$ing_default_cafe_ingress_otel_sample_25", Percentage: 25},
+	}
+	if diff := cmp.Diff(expectedSamplers, result.OtelSamplers); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected otel samplers (-want +got):\n%s", diff)
+	}
+
+	expectedTracing := &version2.Tracing{
+		Trace:    "$ing_default_cafe_ingress_otel_sample_25",
+		SpanName: "cafe $request_method",
+		SpanAttributes: []version2.SpanAttribute{
+			{Name: "team", Value: "cafe"},
+		},
+	}
+	for _, server := range result.Servers {
+		for _, loc := range server.Locations {
+			if diff := cmp.Diff(expectedTracing, loc.Tracing); diff != "" {
+				t.Errorf("generateNginxCfg() returned unexpected tracing for location %s (-want +got):\n%s", loc.Path, diff)
+			}
+		}
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("generateNginxCfg() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func TestGenerateNginxCfgWithTracingWithoutOtelModule(t *testing.T) {
+	t.Parallel()
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/otel-trace"] = "true"
+	configParams := NewDefaultConfigParams(context.Background(), false)
+
+	result, warnings := generateNginxCfg(NginxCfgParams{
+		staticParams:  &StaticConfigParams{},
+		ingEx:         &cafeIngressEx,
+		BaseCfgParams: configParams,
+	})
+
+	for _, server := range result.Servers {
+		for _, loc := range server.Locations {
+			if loc.Tracing != nil {
+				t.Errorf("generateNginxCfg() returned tracing %v for location %s but expected nil", loc.Tracing, loc.Path)
+			}
+		}
+	}
+
+	if len(warnings[cafeIngressEx.Ingress]) != 1 {
+		t.Errorf("generateNginxCfg() returned %d warnings but expected 1", len(warnings[cafeIngressEx.Ingress]))
+	}
+}
+
+func TestGenerateNginxCfgWithAppProtocols(t *testing.T) {
+	t.Parallel()
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.AppProtocols = map[string]string{
+		"coffee-svc80": "kubernetes.io/h2c",
+		"tea-svc80":    "kubernetes.io/wss",
+	}
+	configParams := NewDefaultConfigParams(context.Background(), false)
+	configParams.HTTP2 = true
+
+	result, _ := generateNginxCfg(NginxCfgParams{
+		staticParams:  &StaticConfigParams{},
+		ingEx:         &cafeIngressEx,
+		BaseCfgParams: configParams,
+	})
+
+	expected := map[string][3]bool{
+		"/coffee": {false, false, true},
+		"/tea":    {true, true, false},
+	}
+	for _, loc := range result.Servers[0].Locations {
+		got := [3]bool{loc.Websocket, loc.SSL, loc.GRPC}
+		if got != expected[loc.Path] {
+			t.Errorf("generateNginxCfg() returned websocket, SSL and gRPC %v for location %s, expected %v", got, loc.Path, expected[loc.Path])
+		}
+	}
+}
+
+func TestGenerateNginxCfgWithSessionAffinity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		isPlus           bool
+		wantLBMethod     string
+		wantStickyCookie string
+		wantAffinity     bool
+	}{
+		{
+			wantLBMethod: "hash $default_cafe_ingress_cafe_example_com_tea_svc_80_session_affinity consistent",
+			wantAffinity: true,
+		},
+		{
+			isPlus:           true,
+			wantLBMethod:     "random two least_conn",
+			wantStickyCookie: "srv_id expires=1h path=/tea",
+		},
+	}
+
+	for _, test := range tests {
+		cafeIngressEx := createCafeIngressEx()
+		cafeIngressEx.Ingress.Annotations["nginx.org/session-affinity-services"] = "serviceName=tea-svc srv_id expires=1h path=/tea"
+		configParams := NewDefaultConfigParams(context.Background(), test.isPlus)
+
+		result, _ := generateNginxCfg(NginxCfgParams{
+			staticParams:  &StaticConfigParams{},
+			ingEx:         &cafeIngressEx,
+			BaseCfgParams: configParams,
+			isPlus:        test.isPlus,
+		})
+
+		for _, ups := range result.Upstreams {
+			if ups.Name != "default-cafe-ingress-cafe.example.com-tea-svc-80" {
+				if ups.SessionAffinity != nil || ups.StickyCookie != "" {
+					t.Errorf("generateNginxCfg() returned session persistence for upstream %s without session affinity (isPlus %v)", ups.Name, test.isPlus)
+				}
+				continue
+			}
+			if ups.LBMethod != test.wantLBMethod {
+				t.Errorf("generateNginxCfg() returned lb method %q, expected %q (isPlus %v)", ups.LBMethod, test.wantLBMethod, test.isPlus)
+			}
+			if ups.StickyCookie != test.wantStickyCookie {
+				t.Errorf("generateNginxCfg() returned sticky cookie %q, expected %q (isPlus %v)", ups.StickyCookie, test.wantStickyCookie, test.isPlus)
+			}
+			if (ups.SessionAffinity != nil) != test.wantAffinity {
+				t.Errorf("generateNginxCfg() returned session affinity %+v, expected session affinity %v (isPlus %v)", ups.SessionAffinity, test.wantAffinity, test.isPlus)
+			}
+		}
+	}
+}
+
+func TestApplyAppProtocols(t *testing.T) {
+	t.Parallel()
+	ingEx := createCafeIngressEx()
+	ingEx.AppProtocols = map[string]string{
+		"coffee-svc80": "http",
+		"tea-svc80":    "grpc",
+	}
+
+	tests := []struct {
+		http2        bool
+		sslServices  map[string]bool
+		grpcServices map[string]bool
+		wantSSL      map[string]bool
+		wantGRPC     map[string]bool
+		wantWarnings int
+		msg          string
+	}{
+		{
+			http2:    true,
+			wantSSL:  map[string]bool{},
+			wantGRPC: map[string]bool{"tea-svc": true},
+			msg:      "gRPC from appProtocol",
+		},
+		{
+			http2:        false,
+			wantSSL:      map[string]bool{},
+			wantGRPC:     map[string]bool{},
+			wantWarnings: 1,
+			msg:          "gRPC from appProtocol requires HTTP2",
+		},
+		{
+			http2:        true,
+			sslServices:  map[string]bool{"coffee-svc": true},
+			grpcServices: map[string]bool{"coffee-svc": true},
+			wantSSL:      map[string]bool{"coffee-svc": true},
+			wantGRPC:     map[string]bool{"coffee-svc": true, "tea-svc": true},
+			wantWarnings: 2,
+			msg:          "annotations take precedence over appProtocol",
+		},
+	}
+
+	for _, test := range tests {
+		_, ssl, grpc, warnings := applyAppProtocols(&ingEx, test.http2, nil, test.sslServices, test.grpcServices)
+		if !reflect.DeepEqual(ssl, test.wantSSL) {
+			t.Errorf("applyAppProtocols() returned SSL services %v but expected %v for the case of %s", ssl, test.wantSSL, test.msg)
+		}
+		if !reflect.DeepEqual(grpc, test.wantGRPC) {
+			t.Errorf("applyAppProtocols() returned gRPC services %v but expected %v for the case of %s", grpc, test.wantGRPC, test.msg)
+		}
+		if len(warnings[ingEx.Ingress]) != test.wantWarnings {
+			t.Errorf("applyAppProtocols() returned warnings %v but expected %d for the case of %s", warnings, test.wantWarnings, test.msg)
+		}
+	}
+}
+
 func TestGetBackendPortAsString(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -2576,6 +2779,82 @@ func TestGetBackendPortAsString(t *testing.T) {
 	}
 }
 
+func TestGenerateNginxCfgWithOtherZoneEndpoints(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		lbMethod     string
+		wantWarnings int
+		msg          string
+	}{
+		{
+			lbMethod:     "least_conn",
+			wantWarnings: 0,
+			msg:          "lb method compatible with backup servers",
+		},
+		{
+			lbMethod:     "random two least_conn",
+			wantWarnings: 1,
+			msg:          "lb method incompatible with backup servers",
+		},
+	}
+
+	for _, test := range tests {
+		cafeIngressEx := createCafeIngressEx()
+		cafeIngressEx.OtherZoneEndpoints = map[string][]string{
+			"coffee-svc80": {"10.0.1.1:80"},
+		}
+		configParams := NewDefaultConfigParams(context.Background(), false)
+		configParams.LBMethod = test.lbMethod
+
+		_, warnings := generateNginxCfg(NginxCfgParams{
+			staticParams:  &StaticConfigParams{},
+			ingEx:         &cafeIngressEx,
+			BaseCfgParams: configParams,
+		})
+		if len(warnings[cafeIngressEx.Ingress]) != test.wantWarnings {
+			t.Errorf("generateNginxCfg() returned warnings %v, expected %d for the case of %s", warnings, test.wantWarnings, test.msg)
+		}
+	}
+}
+
+func TestGenerateZoneAwareEndpoints(t *testing.T) {
+	t.Parallel()
+	endpoints := []string{"10.0.0.1:80"}
+	otherZoneEndpoints := []string{"10.0.1.1:80"}
+
+	tests := []struct {
+		lbMethod               string
+		expectedOtherZoneEndps []string
+	}{
+		{
+			lbMethod:               "least_conn",
+			expectedOtherZoneEndps: otherZoneEndpoints,
+		},
+		{
+			lbMethod:               "random two least_conn",
+			expectedOtherZoneEndps: nil,
+		},
+		{
+			lbMethod:               "hash $request_uri consistent",
+			expectedOtherZoneEndps: nil,
+		},
+		{
+			lbMethod:               "ip_hash",
+			expectedOtherZoneEndps: nil,
+		},
+	}
+
+	for _, test := range tests {
+		endps, otherZoneEndps := generateZoneAwareEndpoints(endpoints, otherZoneEndpoints, test.lbMethod)
+		if !reflect.DeepEqual(endps, endpoints) {
+			t.Errorf("generateZoneAwareEndpoints() returned endpoints %v but expected %v for lb method %q", endps, endpoints, test.lbMethod)
+		}
+		if !reflect.DeepEqual(otherZoneEndps, test.expectedOtherZoneEndps) {
+			t.Errorf("generateZoneAwareEndpoints() returned other zone endpoints %v but expected %v for lb method %q", otherZoneEndps, test.expectedOtherZoneEndps, test.lbMethod)
+		}
+	}
+}
+
 func TestScaleRatelimit(t *testing.T) {
 	tests := []struct {
 		input    string
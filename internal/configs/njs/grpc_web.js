@@ -0,0 +1,144 @@
+const grpcWebContentTypes = ['application/grpc-web', 'application/grpc-web+proto'];
+const grpcWebTextContentTypes = ['application/grpc-web-text', 'application/grpc-web-text+proto'];
+const grpcWebAllowHeaders = 'content-type, x-grpc-web, x-user-agent, grpc-timeout';
+const grpcWebExposeHeaders = 'grpc-status, grpc-message';
+const grpcWebTrailerFlag = 0x80;
+
+function requestContentType(r) {
+    return (r.headersIn['Content-Type'] || '').split(';')[0].trim().toLowerCase();
+}
+
+function isGrpcWeb(r) {
+    return grpcWebContentTypes.includes(requestContentType(r));
+}
+
+function isGrpcWebText(r) {
+    return grpcWebTextContentTypes.includes(requestContentType(r));
+}
+
+// contentType returns the content type of the request passed to the gRPC upstream.
+function contentType(r) {
+    if (isGrpcWeb(r) || isGrpcWebText(r)) {
+        return 'application/grpc';
+    }
+    return r.headersIn['Content-Type'] || '';
+}
+
+// contentLength returns the content length of the request passed to the gRPC upstream.
+// The subrequest of a gRPC-Web text request carries the decoded body, so the length of the encoded body is dropped.
+function contentLength(r) {
+    if (r.parent) {
+        return '';
+    }
+    return r.headersIn['Content-Length'] || '';
+}
+
+// text returns '1' for a gRPC-Web text request that is passed to the internal location that decodes it.
+function text(r) {
+    return !r.parent && isGrpcWebText(r) ? '1' : '';
+}
+
+function setAllowOrigin(r) {
+    const origin = r.variables.grpc_web_allow_origin || '*';
+    r.headersOut['Access-Control-Allow-Origin'] = origin;
+    if (origin !== '*') {
+        r.headersOut['Vary'] = 'Origin';
+    }
+}
+
+function headerFilter(r) {
+    if (r.method === 'OPTIONS') {
+        setAllowOrigin(r);
+        r.headersOut['Access-Control-Allow-Methods'] = 'POST, OPTIONS';
+        r.headersOut['Access-Control-Allow-Headers'] = r.headersIn['Access-Control-Request-Headers'] || grpcWebAllowHeaders;
+        r.headersOut['Access-Control-Max-Age'] = '86400';
+        return;
+    }
+
+    if (!isGrpcWeb(r)) {
+        return;
+    }
+
+    // the trailers are appended to the body
+    delete r.headersOut['Content-Length'];
+    r.headersOut['Content-Type'] = requestContentType(r);
+    r.headersOut['Access-Control-Expose-Headers'] = grpcWebExposeHeaders;
+    setAllowOrigin(r);
+}
+
+// trailerFrame encodes the gRPC trailers of the upstream response as a gRPC-Web trailer frame.
+// A trailers-only response carries grpc-status in the headers and has no trailer frame.
+function trailerFrame(r) {
+    const status = r.variables.upstream_trailer_grpc_status;
+    if (!status) {
+        return null;
+    }
+
+    let trailers = 'grpc-status:' + status + '\r\n';
+    const message = r.variables.upstream_trailer_grpc_message;
+    if (message) {
+        trailers += 'grpc-message:' + message + '\r\n';
+    }
+
+    const payload = Buffer.from(trailers);
+    const header = Buffer.alloc(5);
+    header[0] = grpcWebTrailerFlag;
+    header.writeUInt32BE(payload.length, 1);
+
+    return Buffer.concat([header, payload]);
+}
+
+// decodeBase64 decodes the body of a gRPC-Web text request.
+// The body can be a concatenation of base64 strings, each with its own padding.
+function decodeBase64(text) {
+    const chunks = text.replace(/\s/g, '').match(/[^=]+=*/g) || [];
+    return Buffer.concat(chunks.map((chunk) => Buffer.from(chunk, 'base64')));
+}
+
+// textContent translates a gRPC-Web text request. The decoded request is passed to the gRPC upstream in a subrequest
+// to the original URI, and the response, followed by the trailer frame, is encoded in base64.
+async function textContent(r) {
+    const body = decodeBase64(r.requestText || '');
+    const reply = await r.subrequest(r.variables.request_uri, { method: 'POST', body: body });
+
+    if (reply.status !== 200) {
+        r.return(reply.status);
+        return;
+    }
+
+    r.headersOut['Content-Type'] = requestContentType(r);
+    r.headersOut['Access-Control-Expose-Headers'] = grpcWebExposeHeaders;
+    setAllowOrigin(r);
+    // a trailers-only response carries grpc-status in the headers
+    for (const name of ['grpc-status', 'grpc-message']) {
+        if (reply.headersOut[name] !== undefined) {
+            r.headersOut[name] = reply.headersOut[name];
+        }
+    }
+
+    let response = reply.responseBuffer ? reply.responseBuffer.toString('base64') : '';
+    const frame = trailerFrame(reply);
+    if (frame) {
+        response += frame.toString('base64');
+    }
+
+    r.return(200, response);
+}
+
+function bodyFilter(r, data, flags) {
+    if (!flags.last || !isGrpcWeb(r)) {
+        r.sendBuffer(data, flags);
+        return;
+    }
+
+    const frame = trailerFrame(r);
+    if (!frame) {
+        r.sendBuffer(data, flags);
+        return;
+    }
+
+    r.sendBuffer(data, { last: false });
+    r.sendBuffer(frame, { last: true });
+}
+
+export default { contentType, contentLength, text, headerFilter, bodyFilter, textContent };
@@ -0,0 +1,41 @@
+// The active upgraded connections are counted per upstream in the websocket shared dictionary.
+const connectionsZone = 'websocket';
+
+function headerFilter(r) {
+    if (r.status !== 101) {
+        return;
+    }
+
+    ngx.shared[connectionsZone].incr(r.variables.websocket_upstream, 1, 0);
+    r.variables.websocket_upgraded = '1';
+}
+
+// closed is evaluated in the access log when the upgraded connection is closed.
+// It always returns an empty value, so that nothing is logged.
+function closed(r) {
+    if (r.variables.websocket_upgraded !== '1') {
+        return '';
+    }
+    r.variables.websocket_upgraded = '';
+
+    const upstream = r.variables.websocket_upstream;
+    if (ngx.shared[connectionsZone].incr(upstream, -1, 0) <= 0) {
+        ngx.shared[connectionsZone].delete(upstream);
+    }
+
+    return '';
+}
+
+// connections returns the number of active upgraded connections of each upstream as a JSON object.
+function connections(r) {
+    const zone = ngx.shared[connectionsZone];
+    const result = {};
+    for (const upstream of zone.keys()) {
+        result[upstream] = zone.get(upstream);
+    }
+
+    r.headersOut['Content-Type'] = 'application/json';
+    r.return(200, JSON.stringify(result));
+}
+
+export default { headerFilter, closed, connections };
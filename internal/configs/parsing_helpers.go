@@ -6,8 +6,10 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -211,6 +213,39 @@ func ParseTime(s string) (string, error) {
 	return fmt.Sprintf("%s%s%s%s%s%s%s%s", years, months, weeks, days, hours, mins, secs, millis), nil
 }
 
+// timeUnits are the durations of the units of the time string groups of timeRegexp.
+var timeUnits = []time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+	time.Millisecond,
+}
+
+// ParseDuration converts a valid time string into a time.Duration.
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "" || strings.TrimSpace(s) == "" || !timeRegexp.MatchString(s) {
+		return 0, errors.New("invalid time string")
+	}
+	units := timeRegexp.FindStringSubmatch(s)
+	var duration time.Duration
+	for i, unit := range timeUnits {
+		value := strings.TrimRight(units[i+1], "yMwdhms")
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time string: %w", err)
+		}
+		duration += time.Duration(n) * unit
+	}
+	return duration, nil
+}
+
 // OffsetFmt http://nginx.org/en/docs/syntax.html
 const OffsetFmt = `\d+[kKmMgG]?`
 
@@ -310,6 +345,24 @@ func parseProxySetHeaders(proxySetHeaders []string) []version2.Header {
 	return headers
 }
 
+// ParseSpanAttributes parses a list of span attributes in the format name:value.
+func ParseSpanAttributes(spanAttributes []string) ([]version2.SpanAttribute, error) {
+	var attributes []version2.SpanAttribute
+	for _, attribute := range spanAttributes {
+		parts := strings.SplitN(attribute, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid span attribute format: %q", attribute)
+		}
+		name := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+		if name == "" || value == "" {
+			return nil, fmt.Errorf("span attribute must have a name and a value: %q", attribute)
+		}
+		attributes = append(attributes, version2.SpanAttribute{Name: name, Value: value})
+	}
+	return attributes, nil
+}
+
 // ParsePortList ensures that the string is a comma-separated list of port numbers
 func ParsePortList(s string) ([]int, error) {
 	var ports []int
@@ -371,6 +424,64 @@ func ParseStickyServiceList(s string) (map[string]string, error) {
 	return services, nil
 }
 
+// ParseSessionAffinityServiceList ensures that the string is a semicolon-separated list of services with session affinity.
+// The syntax is the same as for the list of sticky services.
+func ParseSessionAffinityServiceList(s string) (map[string]string, error) {
+	services, err := ParseStickyServiceList(s)
+	if err != nil {
+		return nil, err
+	}
+	for _, cookie := range services {
+		if _, err := parseSessionAffinityCookie(cookie); err != nil {
+			return nil, err
+		}
+	}
+	return services, nil
+}
+
+// parseSessionAffinityCookie parses the parameters of a sticky cookie, e.g. "srv_id expires=1h path=/".
+func parseSessionAffinityCookie(s string) (*conf_v1.SessionCookie, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, errors.New("session affinity cookie name is required")
+	}
+	if !sessionAffinityCookieNameRegex.MatchString(fields[0]) {
+		return nil, fmt.Errorf("invalid session affinity cookie name %q: must consist of alphanumeric characters or '_'", fields[0])
+	}
+
+	sc := &conf_v1.SessionCookie{Enable: true, Name: fields[0]}
+	for _, param := range fields[1:] {
+		name, value, _ := strings.Cut(param, "=")
+		switch name {
+		case "expires":
+			if value != "max" {
+				if _, err := ParseDuration(value); err != nil {
+					return nil, fmt.Errorf("invalid session affinity cookie expires %q", value)
+				}
+			}
+			sc.Expires = value
+		case "domain":
+			sc.Domain = value
+		case "path":
+			sc.Path = value
+		case "httponly":
+			sc.HTTPOnly = true
+		case "secure":
+			sc.Secure = true
+		case "samesite":
+			switch strings.ToLower(value) {
+			case "strict", "lax", "none":
+				sc.SameSite = value
+			default:
+				return nil, fmt.Errorf("invalid session affinity cookie samesite %q: must be one of strict, lax or none", value)
+			}
+		default:
+			return nil, fmt.Errorf("invalid session affinity cookie parameter %q", param)
+		}
+	}
+	return sc, nil
+}
+
 func parseStickyService(service string) (serviceName string, stickyCookie string, err error) {
 	parts := strings.SplitN(service, " ", 2)
 
@@ -420,6 +531,8 @@ var (
 	threshExR         = regexp.MustCompile(`low=([1-9]|[1-9][0-9]|100) high=([1-9]|[1-9][0-9]|100)\b`)
 	pathRegexp        = regexp.MustCompile("^" + `/[^\s{};$]*` + "$")
 	stickyCookieRegex = regexp.MustCompile("^" + `([^"$\\]|\\[^$])*` + "$")
+
+	sessionAffinityCookieNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
 )
 
 // VerifyAppProtectThresholds ensures that threshold values are set correctly
@@ -3,6 +3,7 @@ package configs
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
@@ -86,6 +87,40 @@ func TestParseStickyServicesLists_FailsOnBogusInputString(t *testing.T) {
 	}
 }
 
+func TestParseSessionAffinityServiceList(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseSessionAffinityServiceList("serviceName=tea-svc srv_id expires=1h path=/tea;serviceName=coffee-svc coffee_id expires=max httponly secure samesite=lax")
+	if err != nil {
+		t.Fatalf("ParseSessionAffinityServiceList() returned unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"tea-svc":    "srv_id expires=1h path=/tea",
+		"coffee-svc": "coffee_id expires=max httponly secure samesite=lax",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("ParseSessionAffinityServiceList() returned %v, want %v", got, want)
+	}
+}
+
+func TestParseSessionAffinityServiceList_FailsOnBogusInputString(t *testing.T) {
+	t.Parallel()
+
+	invalidInputs := []string{
+		"",
+		"serviceName=tea-svc srv-id",
+		"serviceName=tea-svc srv_id expires=never",
+		"serviceName=tea-svc srv_id samesite=always",
+		"serviceName=tea-svc srv_id route=/tea",
+	}
+	for _, s := range invalidInputs {
+		_, err := ParseSessionAffinityServiceList(s)
+		if err == nil {
+			t.Errorf("want err on invalid input %q, got nil", s)
+		}
+	}
+}
+
 func TestParseRewritesList_FailsOnBogusInputString(t *testing.T) {
 	t.Parallel()
 
@@ -514,6 +549,36 @@ func TestParseLBMethodForPlus(t *testing.T) {
 	}
 }
 
+func TestParseDuration(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"1h30m 5 100ms", time.Hour + 30*time.Minute + 5*time.Second + 100*time.Millisecond},
+		{"10ms", 10 * time.Millisecond},
+		{"1", time.Second},
+		{"5m 30s", 5*time.Minute + 30*time.Second},
+		{"2d", 48 * time.Hour},
+		{"1w", 7 * 24 * time.Hour},
+	}
+	for _, test := range tests {
+		result, err := ParseDuration(test.input)
+		if err != nil {
+			t.Fatalf("ParseDuration(%q) returned an error for valid input", test.input)
+		}
+		if result != test.expected {
+			t.Errorf("ParseDuration(%q) returned %v expected %v", test.input, result, test.expected)
+		}
+	}
+
+	for _, input := range []string{"5s 5s", "-5s", "", "1L"} {
+		if _, err := ParseDuration(input); err == nil {
+			t.Errorf("ParseDuration(%q) didn't return error for invalid input", input)
+		}
+	}
+}
+
 func TestParseTime(t *testing.T) {
 	t.Parallel()
 	testsWithValidInput := []struct {
@@ -2,6 +2,9 @@ package configs
 
 import (
 	"fmt"
+	"net"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -10,6 +13,7 @@ import (
 	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
 	"github.com/nginx/kubernetes-ingress/internal/k8s/secrets"
 	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginx/nginx-plus-go-client/v3/client"
 )
 
 const nginxNonExistingUnixSocket = "unix:/var/lib/nginx/non-existing-unix-socket.sock"
@@ -17,12 +21,14 @@ const nginxNonExistingUnixSocket = "unix:/var/lib/nginx/non-existing-unix-socket
 // TransportServerEx holds a TransportServer along with the resources referenced by it.
 type TransportServerEx struct {
 	ListenerPort     int
+	ListenerEndPort  int
 	TransportServer  *conf_v1.TransportServer
 	Endpoints        map[string][]string
 	PodsByIP         map[string]string
 	ExternalNameSvcs map[string]bool
 	DisableIPV6      bool
 	SecretRefs       map[string]*secrets.SecretReference
+	Policies         map[string]*conf_v1.Policy
 	IPv4             string
 	IPv6             string
 }
@@ -43,6 +49,43 @@ func newUpstreamNamerForTransportServer(transportServer *conf_v1.TransportServer
 	}
 }
 
+// generateTransportServerUpstreamStatuses summarizes the health of the servers of the upstreams of the TransportServer
+// from the NGINX Plus stream upstreams. The upstreams that NGINX Plus doesn't report are ignored.
+func generateTransportServerUpstreamStatuses(transportServer *conf_v1.TransportServer, streamUpstreams client.StreamUpstreams) []conf_v1.TransportServerUpstreamStatus {
+	var statuses []conf_v1.TransportServerUpstreamStatus
+	upstreamNamer := newUpstreamNamerForTransportServer(transportServer)
+
+	for _, u := range transportServer.Spec.Upstreams {
+		streamUpstream, exists := streamUpstreams[upstreamNamer.GetNameForUpstream(u.Name)]
+		if !exists {
+			continue
+		}
+
+		status := conf_v1.TransportServerUpstreamStatus{
+			Name:    u.Name,
+			Servers: len(streamUpstream.Peers),
+		}
+		for _, p := range streamUpstream.Peers {
+			if strings.ToLower(p.State) == "up" {
+				status.Up++
+			}
+		}
+
+		switch status.Up {
+		case 0:
+			status.Health = conf_v1.UpstreamHealthUnhealthy
+		case status.Servers:
+			status.Health = conf_v1.UpstreamHealthHealthy
+		default:
+			status.Health = conf_v1.UpstreamHealthDegraded
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
 type transportServerConfigParams struct {
 	transportServerEx      *TransportServerEx
 	listenerPort           int
@@ -61,15 +104,44 @@ func generateTransportServerConfig(p transportServerConfigParams) (*version2.Tra
 	upstreams, w := generateStreamUpstreams(p.transportServerEx, upstreamNamer, p.isPlus, p.isResolverConfigured)
 	warnings.Add(w)
 
-	healthCheck, match := generateTransportServerHealthCheck(p.transportServerEx.TransportServer.Spec.Action.Pass,
-		upstreamNamer.GetNameForUpstream(p.transportServerEx.TransportServer.Spec.Action.Pass),
-		p.transportServerEx.TransportServer.Spec.Upstreams)
+	isPortRange := p.transportServerEx.ListenerEndPort > 0
+
+	var healthCheck *version2.StreamHealthCheck
+	var match *version2.Match
+	if len(p.transportServerEx.TransportServer.Spec.Routes) == 0 && !isPortRange {
+		healthCheck, match = generateTransportServerHealthCheck(p.transportServerEx.TransportServer.Spec.Action.Pass,
+			upstreamNamer.GetNameForUpstream(p.transportServerEx.TransportServer.Spec.Action.Pass),
+			p.transportServerEx.TransportServer.Spec.Upstreams)
+	}
+
+	variablePrefix := strings.ReplaceAll(fmt.Sprintf("$ts_%s_%s", p.transportServerEx.TransportServer.Namespace, p.transportServerEx.TransportServer.Name), "-", "_")
+
+	var observability observabilityCfg
+	var proxyPass string
+	if isPortRange {
+		proxyPass, w = generateTransportServerPortRangeProxyPass(p.transportServerEx, variablePrefix, &observability)
+	} else {
+		proxyPass, w = generateTransportServerProxyPass(p.transportServerEx.TransportServer, upstreamNamer, variablePrefix, &observability)
+	}
+	warnings.Add(w)
 
 	sslConfig, w := generateSSLConfig(p.transportServerEx.TransportServer, p.transportServerEx.TransportServer.Spec.TLS, p.transportServerEx.TransportServer.Namespace, p.transportServerEx.SecretRefs)
 	warnings.Add(w)
 
+	warnings.Add(generateStreamSSLCertificates(p.transportServerEx.TransportServer, p.transportServerEx.TransportServer.Spec.TLS, sslConfig, p.transportServerEx.SecretRefs, variablePrefix))
+	generateStreamSSLALPN(p.transportServerEx.TransportServer, sslConfig)
+
+	isClientVerificationValid, w := generateStreamSSLClientVerification(p.transportServerEx.TransportServer, p.transportServerEx.TransportServer.Spec.TLS, sslConfig, p.transportServerEx.SecretRefs)
+	warnings.Add(w)
+
+	policiesCfg, w := generateTransportServerPolicies(p.transportServerEx)
+	warnings.Add(w)
+
+	proxySSL, proxyProtocol, isProxySSLValid, w := generateStreamProxyConnection(p.transportServerEx.TransportServer, p.transportServerEx.SecretRefs)
+	warnings.Add(w)
+
 	var proxyRequests, proxyResponses *int
-	var connectTimeout, nextUpstreamTimeout string
+	var connectTimeout, nextUpstreamTimeout, downloadRate, uploadRate string
 	var nextUpstream bool
 	var nextUpstreamTries int
 	if p.transportServerEx.TransportServer.Spec.UpstreamParameters != nil {
@@ -83,6 +155,14 @@ func generateTransportServerConfig(p transportServerConfigParams) (*version2.Tra
 		}
 
 		connectTimeout = p.transportServerEx.TransportServer.Spec.UpstreamParameters.ConnectTimeout
+		downloadRate = p.transportServerEx.TransportServer.Spec.UpstreamParameters.DownloadRate
+		uploadRate = p.transportServerEx.TransportServer.Spec.UpstreamParameters.UploadRate
+
+		if maxConns := generateIntFromPointer(p.transportServerEx.TransportServer.Spec.UpstreamParameters.MaxConnections, 0); maxConns > 0 {
+			zone, limitConn := generateStreamMaxConnections(p.transportServerEx.TransportServer, maxConns)
+			policiesCfg.LimitConnZones = append(policiesCfg.LimitConnZones, zone)
+			policiesCfg.LimitConns = append(policiesCfg.LimitConns, limitConn)
+		}
 	}
 
 	var proxyTimeout string
@@ -103,17 +183,22 @@ func generateTransportServerConfig(p transportServerConfigParams) (*version2.Tra
 	serverName := generateServerName(host, isTLSPassthrough)
 	isUDP := p.transportServerEx.TransportServer.Spec.Listener.Protocol == "UDP"
 
+	accessLog := generateAccessLog(p.transportServerEx.TransportServer.Spec.AccessLog, variablePrefix, defaultAccessLogDestination, defaultStreamAccessLogFormat, streamAccessLogSampleSource, &observability)
+
 	tsConfig := &version2.TransportServerConfig{
 		Server: version2.StreamServer{
 			ServerName:               serverName,
 			TLSPassthrough:           isTLSPassthrough,
 			UnixSocket:               generateUnixSocket(p.transportServerEx),
 			Port:                     p.listenerPort,
+			EndPort:                  p.transportServerEx.ListenerEndPort,
 			UDP:                      isUDP,
 			StatusZone:               statusZone,
 			ProxyRequests:            proxyRequests,
 			ProxyResponses:           proxyResponses,
-			ProxyPass:                upstreamNamer.GetNameForUpstream(p.transportServerEx.TransportServer.Spec.Action.Pass),
+			ProxyPass:                proxyPass,
+			ProxyProtocol:            proxyProtocol,
+			ProxySSL:                 proxySSL,
 			Name:                     p.transportServerEx.TransportServer.Name,
 			Namespace:                p.transportServerEx.TransportServer.Namespace,
 			ProxyConnectTimeout:      generateTimeWithDefault(connectTimeout, "60s"),
@@ -121,14 +206,25 @@ func generateTransportServerConfig(p transportServerConfigParams) (*version2.Tra
 			ProxyNextUpstream:        nextUpstream,
 			ProxyNextUpstreamTimeout: generateTimeWithDefault(nextUpstreamTimeout, "0s"),
 			ProxyNextUpstreamTries:   nextUpstreamTries,
+			ProxyDownloadRate:        downloadRate,
+			ProxyUploadRate:          uploadRate,
 			HealthCheck:              healthCheck,
 			ServerSnippets:           serverSnippets,
 			DisableIPV6:              p.transportServerEx.DisableIPV6,
 			SSL:                      sslConfig,
 			IPv4:                     p.transportServerEx.IPv4,
 			IPv6:                     p.transportServerEx.IPv6,
+			AccessLog:                accessLog,
+			Allow:                    policiesCfg.Allow,
+			Deny:                     policiesCfg.Deny,
+			DenyAll:                  policiesCfg.DenyAll || !isClientVerificationValid || !isProxySSLValid,
+			LimitConns:               policiesCfg.LimitConns,
+			LimitConnOptions:         policiesCfg.LimitConnOptions,
 		},
 		Match:                   match,
+		LimitConnZones:          policiesCfg.LimitConnZones,
+		Maps:                    observability.Maps,
+		SplitClients:            observability.SplitClients,
 		Upstreams:               upstreams,
 		StreamSnippets:          streamSnippets,
 		DynamicSSLReloadEnabled: p.isDynamicReloadEnabled,
@@ -137,6 +233,98 @@ func generateTransportServerConfig(p transportServerConfigParams) (*version2.Tra
 	return tsConfig, warnings
 }
 
+// generateStreamMaxConnections generates the limit on the total number of simultaneous connections of a TransportServer.
+// The key of the zone is the same for all connections of the server, so all of them are counted together.
+func generateStreamMaxConnections(ts *conf_v1.TransportServer, maxConns int) (version2.StreamLimitConnZone, version2.StreamLimitConn) {
+	zoneName := rfc1123ToSnake(fmt.Sprintf("ts_max_conns_%v_%v", ts.Namespace, ts.Name))
+
+	zone := version2.StreamLimitConnZone{
+		ZoneName: zoneName,
+		Key:      "$protocol",
+		ZoneSize: "64k",
+	}
+	limitConn := version2.StreamLimitConn{
+		ZoneName:    zoneName,
+		Connections: maxConns,
+	}
+
+	return zone, limitConn
+}
+
+// transportServerPoliciesCfg holds the configuration generated from the policies of a TransportServer.
+type transportServerPoliciesCfg struct {
+	Allow            []string
+	Deny             []string
+	DenyAll          bool
+	LimitConnZones   []version2.StreamLimitConnZone
+	LimitConns       []version2.StreamLimitConn
+	LimitConnOptions version2.StreamLimitConnOptions
+}
+
+// generateTransportServerPolicies generates the configuration for the policies referenced by a TransportServer.
+// If a policy is missing or invalid, all connections to the TransportServer are rejected.
+func generateTransportServerPolicies(transportServerEx *TransportServerEx) (transportServerPoliciesCfg, Warnings) {
+	warnings := newWarnings()
+	ts := transportServerEx.TransportServer
+	var cfg transportServerPoliciesCfg
+
+	for _, p := range ts.Spec.Policies {
+		polNamespace := p.Namespace
+		if polNamespace == "" {
+			polNamespace = ts.Namespace
+		}
+
+		key := fmt.Sprintf("%s/%s", polNamespace, p.Name)
+
+		pol, exists := transportServerEx.Policies[key]
+		if !exists {
+			warnings.AddWarningf(ts, "Policy %s is missing or invalid", key)
+			return transportServerPoliciesCfg{DenyAll: true}, warnings
+		}
+
+		switch {
+		case pol.Spec.AccessControl != nil:
+			cfg.Allow = append(cfg.Allow, pol.Spec.AccessControl.Allow...)
+			cfg.Deny = append(cfg.Deny, pol.Spec.AccessControl.Deny...)
+			if len(cfg.Allow) > 0 && len(cfg.Deny) > 0 {
+				warnings.AddWarning(ts, "AccessControl policy (or policies) with deny rules is overridden by policy (or policies) with allow rules")
+			}
+		case pol.Spec.ConnectionLimit != nil:
+			connectionLimit := pol.Spec.ConnectionLimit
+			zoneName := rfc1123ToSnake(fmt.Sprintf("pol_cl_%v_%v_%v_%v", pol.Namespace, pol.Name, ts.Namespace, ts.Name))
+
+			cfg.LimitConnZones = append(cfg.LimitConnZones, version2.StreamLimitConnZone{
+				ZoneName: zoneName,
+				Key:      connectionLimit.Key,
+				ZoneSize: connectionLimit.ZoneSize,
+			})
+			cfg.LimitConns = append(cfg.LimitConns, version2.StreamLimitConn{
+				ZoneName:    zoneName,
+				Connections: connectionLimit.Connections,
+			})
+
+			options := version2.StreamLimitConnOptions{
+				DryRun:   generateBool(connectionLimit.DryRun, false),
+				LogLevel: generateString(connectionLimit.LogLevel, "error"),
+			}
+			if len(cfg.LimitConns) == 1 {
+				cfg.LimitConnOptions = options
+			} else {
+				if options.DryRun != cfg.LimitConnOptions.DryRun {
+					warnings.AddWarningf(ts, "ConnectionLimit policy %s with limit connection option dryRun='%v' is overridden to dryRun='%v' by the first policy reference", key, options.DryRun, cfg.LimitConnOptions.DryRun)
+				}
+				if options.LogLevel != cfg.LimitConnOptions.LogLevel {
+					warnings.AddWarningf(ts, "ConnectionLimit policy %s with limit connection option logLevel='%v' is overridden to logLevel='%v' by the first policy reference", key, options.LogLevel, cfg.LimitConnOptions.LogLevel)
+				}
+			}
+		default:
+			warnings.AddWarningf(ts, "Policy %s is not supported in TransportServer and will be ignored", key)
+		}
+	}
+
+	return cfg, warnings
+}
+
 func generateUnixSocket(transportServerEx *TransportServerEx) string {
 	if transportServerEx.TransportServer.Spec.Listener.Name == conf_v1.TLSPassthroughListenerName {
 		return fmt.Sprintf("unix:/var/lib/nginx/passthrough-%s_%s.sock", transportServerEx.TransportServer.Namespace, transportServerEx.TransportServer.Name)
@@ -177,6 +365,300 @@ func generateSSLConfig(ts *conf_v1.TransportServer, tls *conf_v1.TransportServer
 	return &ssl, warnings
 }
 
+// generateTransportServerPortRangeProxyPass generates the value of proxy_pass for a TransportServer with a listener with a port range.
+// The connections are passed to the same port they were accepted on. Because the servers of an upstream block require a port,
+// the endpoint of the upstream is chosen by a split_clients on the client address, so that all connections of a client,
+// such as the control and data connections of FTP, reach the same endpoint.
+func generateTransportServerPortRangeProxyPass(transportServerEx *TransportServerEx, variablePrefix string, cfg *observabilityCfg) (string, Warnings) {
+	warnings := newWarnings()
+	ts := transportServerEx.TransportServer
+
+	if len(ts.Spec.Action.Splits) > 0 || len(ts.Spec.Routes) > 0 {
+		warnings.AddWarning(ts, "Splits and routes are not supported for listeners with a port range. The connections will be rejected.")
+		return nginxNonExistingUnixSocket, warnings
+	}
+
+	var hosts []string
+	for _, u := range ts.Spec.Upstreams {
+		if u.HealthCheck != nil && u.HealthCheck.Enabled {
+			warnings.AddWarningf(ts, "Health checks are not supported for listeners with a port range. The health check of the upstream %s will be ignored.", u.Name)
+		}
+		if u.Name != ts.Spec.Action.Pass {
+			continue
+		}
+
+		endpointsKey := GenerateEndpointsKey(ts.Namespace, u.Service, nil, uint16(u.Port)) //nolint:gosec
+		for _, e := range transportServerEx.Endpoints[endpointsKey] {
+			host, _, err := net.SplitHostPort(e)
+			if err != nil {
+				continue
+			}
+			if strings.Contains(host, ":") {
+				host = "[" + host + "]"
+			}
+			hosts = append(hosts, host)
+		}
+	}
+
+	sort.Strings(hosts)
+	hosts = slices.Compact(hosts)
+
+	switch len(hosts) {
+	case 0:
+		return nginxNonExistingUnixSocket, warnings
+	case 1:
+		return hosts[0] + ":$server_port", warnings
+	}
+
+	splitClient := version2.SplitClient{
+		Source:   "$remote_addr",
+		Variable: variablePrefix + "_endpoint",
+	}
+	// the share of each endpoint in hundredths of a percent, as split_clients supports two decimal places
+	share := 10000 / len(hosts)
+	for i, h := range hosts {
+		weight := "*"
+		if i < len(hosts)-1 {
+			weight = fmt.Sprintf("%d.%02d%%", share/100, share%100)
+		}
+		splitClient.Distributions = append(splitClient.Distributions, version2.Distribution{
+			Weight: weight,
+			Value:  h,
+		})
+	}
+	cfg.addSplitClient(splitClient)
+
+	return splitClient.Variable + ":$server_port", warnings
+}
+
+// generateStreamSSLCertificates configures the certificates chosen by the server name requested by the client.
+// The certificates with invalid secrets are ignored, so that the default certificate is used for their server names.
+func generateStreamSSLCertificates(ts *conf_v1.TransportServer, tls *conf_v1.TransportServerTLS, ssl *version2.StreamSSL, secretRefs map[string]*secrets.SecretReference, variablePrefix string) Warnings {
+	warnings := newWarnings()
+	if tls == nil || len(tls.Certificates) == 0 || !ssl.Enabled {
+		return warnings
+	}
+
+	for _, c := range tls.Certificates {
+		secretRef := secretRefs[fmt.Sprintf("%s/%s", ts.Namespace, c.Secret)]
+		var secretType api_v1.SecretType
+		if secretRef.Secret != nil {
+			secretType = secretRef.Secret.Type
+		}
+		if secretType != "" && secretType != api_v1.SecretTypeTLS {
+			warnings.AddWarningf(ts, "TLS secret %s of the server name %s is of a wrong type '%s', must be '%s'. The default certificate will be used.", c.Secret, c.ServerName, secretType, api_v1.SecretTypeTLS)
+			continue
+		} else if secretRef.Error != nil {
+			warnings.AddWarningf(ts, "TLS secret %s of the server name %s is invalid: %v. The default certificate will be used.", c.Secret, c.ServerName, secretRef.Error)
+			continue
+		}
+		ssl.Certificates = append(ssl.Certificates, version2.StreamSSLCertificate{
+			ServerName:  c.ServerName,
+			Certificate: secretRef.Path,
+		})
+	}
+
+	if len(ssl.Certificates) > 0 {
+		ssl.CertificateVariable = variablePrefix + "_certificate"
+	}
+
+	return warnings
+}
+
+// generateStreamSSLALPN configures the ALPN protocols that the server accepts, so that the routes can match the ALPN
+// protocol requested by the client. The protocols are listed in the order of the routes.
+func generateStreamSSLALPN(ts *conf_v1.TransportServer, ssl *version2.StreamSSL) {
+	if !ssl.Enabled {
+		return
+	}
+
+	for _, r := range ts.Spec.Routes {
+		if r.ALPN != "" && !slices.Contains(ssl.ALPN, r.ALPN) {
+			ssl.ALPN = append(ssl.ALPN, r.ALPN)
+		}
+	}
+}
+
+// generateStreamSSLClientVerification configures the verification of client certificates for a TransportServer.
+// It returns false if the verification cannot be configured, in which case all connections must be rejected.
+func generateStreamSSLClientVerification(ts *conf_v1.TransportServer, tls *conf_v1.TransportServerTLS, ssl *version2.StreamSSL, secretRefs map[string]*secrets.SecretReference) (bool, Warnings) {
+	warnings := newWarnings()
+	if tls == nil || tls.ClientCertSecret == "" {
+		return true, warnings
+	}
+
+	if !ssl.Enabled {
+		warnings.AddWarning(ts, "Client certificate verification requires SSL termination. All connections will be rejected.")
+		return false, warnings
+	}
+
+	secretKey := fmt.Sprintf("%s/%s", ts.Namespace, tls.ClientCertSecret)
+	secretRef := secretRefs[secretKey]
+	var secretType api_v1.SecretType
+	if secretRef.Secret != nil {
+		secretType = secretRef.Secret.Type
+	}
+	if secretType != "" && secretType != secrets.SecretTypeCA {
+		warnings.AddWarningf(ts, "Client certificate secret %s is of a wrong type '%s', must be '%s'. All connections will be rejected.", tls.ClientCertSecret, secretType, secrets.SecretTypeCA)
+		return false, warnings
+	} else if secretRef.Error != nil {
+		warnings.AddWarningf(ts, "Client certificate secret %s is invalid: %v. All connections will be rejected.", tls.ClientCertSecret, secretRef.Error)
+		return false, warnings
+	}
+
+	caFields := strings.Fields(secretRef.Path)
+	_, hasCrlKey := secretRef.Secret.Data[CACrlKey]
+
+	ssl.ClientCert = caFields[0]
+	if tls.CrlFileName != "" {
+		if hasCrlKey {
+			warnings.AddWarningf(ts, "Both ca.crl in the Secret and tls.crlFileName fields cannot be used. ca.crl in %s will be ignored and %s will be applied", secretKey, tls.CrlFileName)
+		}
+		ssl.ClientCrl = fmt.Sprintf("%s/%s", DefaultSecretPath, tls.CrlFileName)
+	} else if hasCrlKey {
+		ssl.ClientCrl = caFields[1]
+	}
+	ssl.VerifyClient = generateString(tls.VerifyClient, "on")
+	ssl.VerifyDepth = generateIntFromPointer(tls.VerifyDepth, 1)
+
+	return true, warnings
+}
+
+// generateTransportServerProxyPass generates the value of proxy_pass for a TransportServer.
+// For splits, it adds the split_clients that chooses an upstream by the client address.
+// For routes, it adds the map that chooses an upstream by the server name and the ALPN protocol requested by the client.
+func generateTransportServerProxyPass(ts *conf_v1.TransportServer, upstreamNamer *upstreamNamer, variablePrefix string, cfg *observabilityCfg) (string, Warnings) {
+	warnings := newWarnings()
+
+	proxyPass := upstreamNamer.GetNameForUpstream(ts.Spec.Action.Pass)
+	if len(ts.Spec.Action.Splits) > 0 {
+		splitClient := version2.SplitClient{
+			Source:   "$remote_addr",
+			Variable: variablePrefix + "_split",
+		}
+		for _, s := range ts.Spec.Action.Splits {
+			if s.Weight == 0 {
+				continue
+			}
+			splitClient.Distributions = append(splitClient.Distributions, version2.Distribution{
+				Weight: fmt.Sprintf("%d%%", s.Weight),
+				Value:  upstreamNamer.GetNameForUpstream(s.Pass),
+			})
+		}
+		cfg.addSplitClient(splitClient)
+		proxyPass = splitClient.Variable
+	}
+
+	if len(ts.Spec.Routes) > 0 {
+		routes := version2.Map{
+			Source:   "$ssl_server_name:$ssl_alpn_protocol",
+			Variable: variablePrefix + "_route",
+		}
+		for _, r := range ts.Spec.Routes {
+			var value string
+			switch {
+			case r.ServerName != "" && r.ALPN != "":
+				value = fmt.Sprintf(`"%s:%s"`, r.ServerName, r.ALPN)
+			case r.ServerName != "":
+				value = fmt.Sprintf(`"~^%s:"`, regexp.QuoteMeta(r.ServerName))
+			default:
+				value = fmt.Sprintf(`"~:%s$"`, regexp.QuoteMeta(r.ALPN))
+			}
+			routes.Parameters = append(routes.Parameters, version2.Parameter{
+				Value:  value,
+				Result: upstreamNamer.GetNameForUpstream(r.Pass),
+			})
+		}
+		routes.Parameters = append(routes.Parameters, version2.Parameter{
+			Value:  "default",
+			Result: proxyPass,
+		})
+		cfg.addMap(routes)
+		proxyPass = routes.Variable
+	}
+
+	if len(ts.Spec.Action.Splits) > 0 || len(ts.Spec.Routes) > 0 {
+		for _, u := range ts.Spec.Upstreams {
+			if u.HealthCheck != nil && u.HealthCheck.Enabled {
+				warnings.AddWarningf(ts, "Health checks are not supported for TransportServers with splits or routes. The health check of the upstream %s will be ignored.", u.Name)
+			}
+		}
+	}
+
+	return proxyPass, warnings
+}
+
+// generateStreamProxyConnection configures the TLS and PROXY protocol settings of the connections to the upstream
+// the TransportServer passes connections to. It returns the TLS configuration, whether PROXY protocol is enabled and
+// false if TLS cannot be configured, in which case all connections must be rejected.
+func generateStreamProxyConnection(ts *conf_v1.TransportServer, secretRefs map[string]*secrets.SecretReference) (*version2.StreamProxySSL, bool, bool, Warnings) {
+	warnings := newWarnings()
+
+	// The upstreams of the splits share the same settings, which is ensured by the validation.
+	pass := ts.Spec.Action.Pass
+	if len(ts.Spec.Action.Splits) > 0 {
+		pass = ts.Spec.Action.Splits[0].Pass
+	}
+
+	var upstream *conf_v1.TransportServerUpstream
+	for i := range ts.Spec.Upstreams {
+		if ts.Spec.Upstreams[i].Name == pass {
+			upstream = &ts.Spec.Upstreams[i]
+			break
+		}
+	}
+	if upstream == nil {
+		return nil, false, true, warnings
+	}
+
+	tls := upstream.TLS
+	if tls == nil || !tls.Enable {
+		return nil, upstream.ProxyProtocol, true, warnings
+	}
+
+	ssl := &version2.StreamProxySSL{
+		VerifyServer: tls.VerifyServer,
+		VerifyDepth:  generateIntFromPointer(tls.VerifyDepth, 1),
+		ServerName:   tls.ServerName,
+		SSLName:      tls.SSLName,
+	}
+
+	if tls.TLSSecret != "" {
+		secretRef := secretRefs[fmt.Sprintf("%s/%s", ts.Namespace, tls.TLSSecret)]
+		var secretType api_v1.SecretType
+		if secretRef.Secret != nil {
+			secretType = secretRef.Secret.Type
+		}
+		if secretType != "" && secretType != api_v1.SecretTypeTLS {
+			warnings.AddWarningf(ts, "Upstream %s TLS secret %s is of a wrong type '%s', must be '%s'. All connections will be rejected.", upstream.Name, tls.TLSSecret, secretType, api_v1.SecretTypeTLS)
+			return nil, upstream.ProxyProtocol, false, warnings
+		} else if secretRef.Error != nil {
+			warnings.AddWarningf(ts, "Upstream %s TLS secret %s is invalid: %v. All connections will be rejected.", upstream.Name, tls.TLSSecret, secretRef.Error)
+			return nil, upstream.ProxyProtocol, false, warnings
+		}
+		ssl.Certificate = secretRef.Path
+		ssl.CertificateKey = secretRef.Path
+	}
+
+	if tls.TrustedCertSecret != "" {
+		secretRef := secretRefs[fmt.Sprintf("%s/%s", ts.Namespace, tls.TrustedCertSecret)]
+		var secretType api_v1.SecretType
+		if secretRef.Secret != nil {
+			secretType = secretRef.Secret.Type
+		}
+		if secretType != "" && secretType != secrets.SecretTypeCA {
+			warnings.AddWarningf(ts, "Upstream %s trusted certificate secret %s is of a wrong type '%s', must be '%s'. All connections will be rejected.", upstream.Name, tls.TrustedCertSecret, secretType, secrets.SecretTypeCA)
+			return nil, upstream.ProxyProtocol, false, warnings
+		} else if secretRef.Error != nil {
+			warnings.AddWarningf(ts, "Upstream %s trusted certificate secret %s is invalid: %v. All connections will be rejected.", upstream.Name, tls.TrustedCertSecret, secretRef.Error)
+			return nil, upstream.ProxyProtocol, false, warnings
+		}
+		ssl.TrustedCert = strings.Fields(secretRef.Path)[0]
+	}
+
+	return ssl, upstream.ProxyProtocol, true, warnings
+}
+
 func generateStreamUpstreams(transportServerEx *TransportServerEx, upstreamNamer *upstreamNamer, isPlus bool, isResolverConfigured bool) ([]version2.StreamUpstream, Warnings) {
 	warnings := newWarnings()
 	var upstreams []version2.StreamUpstream
@@ -248,6 +730,9 @@ func generateTransportServerHealthCheck(upstreamName string, generatedUpstreamNa
 				hc.Passes = u.HealthCheck.Passes
 			}
 
+			hc.Mandatory = u.HealthCheck.Mandatory
+			hc.Persistent = u.HealthCheck.Persistent
+
 			if u.HealthCheck.Match != nil {
 				name := "match_" + generatedUpstreamName
 				match = generateHealthCheckMatch(u.HealthCheck.Match, name)
@@ -272,11 +757,40 @@ func generateTransportServerHealthCheckWithDefaults() *version2.StreamHealthChec
 	}
 }
 
+// healthCheckProfiles defines the data to send and the response to expect for the built-in health check probes.
+var healthCheckProfiles = map[string]conf_v1.TransportServerMatch{
+	// A query for the NS records of the root zone. The response must have the ID of the query and the QR bit set.
+	"dns": {
+		SendHex: "4e47010000010000000000000000020001",
+		Expect:  `~^\x4e\x47[\x80-\xff]`,
+	},
+	// A TLS 1.2 ClientHello with the common ECDHE and RSA cipher suites. The response must be a handshake record.
+	"tls": {
+		SendHex: "160301006b010000670303000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f000014c02fc030c02bc02ccca8cca9009c009d002f00350100002a000a00080006001d00170018000b00020100000d00140012040305030603080408050806040105010601",
+		Expect:  `~^\x16\x03`,
+	},
+	"redis": {
+		Send:   `PING\r\n`,
+		Expect: `~^\+PONG`,
+	},
+	// An SSLRequest packet. The server responds with S or N depending on whether it supports SSL.
+	"postgresql": {
+		SendHex: "0000000804d2162f",
+		Expect:  "~^[SN]",
+	},
+}
+
 func generateHealthCheckMatch(match *conf_v1.TransportServerMatch, name string) *version2.Match {
+	if profile, exists := healthCheckProfiles[match.Profile]; exists {
+		match = &profile
+	}
+
 	var modifier string
 	var expect string
 
-	if strings.HasPrefix(match.Expect, "~*") {
+	if match.ExpectHex != "" {
+		expect = generateHexLiterals(match.ExpectHex)
+	} else if strings.HasPrefix(match.Expect, "~*") {
 		modifier = "~*"
 		expect = strings.TrimPrefix(match.Expect, "~*")
 	} else if strings.HasPrefix(match.Expect, "~") {
@@ -286,14 +800,29 @@ func generateHealthCheckMatch(match *conf_v1.TransportServerMatch, name string)
 		expect = match.Expect
 	}
 
+	send := match.Send
+	if match.SendHex != "" {
+		send = generateHexLiterals(match.SendHex)
+	}
+
 	return &version2.Match{
 		Name:                name,
-		Send:                match.Send,
+		Send:                send,
 		ExpectRegexModifier: modifier,
 		Expect:              expect,
 	}
 }
 
+// generateHexLiterals converts a hex-encoded string into the hex literals of NGINX, for example 4e47 into \x4e\x47.
+func generateHexLiterals(s string) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(s); i += 2 {
+		b.WriteString(`\x`)
+		b.WriteString(s[i : i+2])
+	}
+	return b.String()
+}
+
 func generateStreamUpstream(upstream conf_v1.TransportServerUpstream, upstreamNamer *upstreamNamer, endpoints, backupEndpoints []string, isPlus bool) version2.StreamUpstream {
 	var upsServers []version2.StreamUpstreamServer
 
@@ -339,11 +868,39 @@ func generateStreamUpstream(upstream conf_v1.TransportServerUpstream, upstreamNa
 	return version2.StreamUpstream{
 		Name:                name,
 		Servers:             upsServers,
-		LoadBalancingMethod: generateLoadBalancingMethod(upstream.LoadBalancingMethod),
+		LoadBalancingMethod: generateStreamLoadBalancingMethod(upstream),
 		BackupServers:       upsBackups,
 	}
 }
 
+func generateStreamLoadBalancingMethod(upstream conf_v1.TransportServerUpstream) string {
+	lb := upstream.LoadBalancing
+	if lb == nil {
+		return generateLoadBalancingMethod(upstream.LoadBalancingMethod)
+	}
+
+	switch lb.Method {
+	case "hash":
+		method := "hash " + generateString(lb.Key, "$remote_addr")
+		if lb.Consistent {
+			method += " consistent"
+		}
+		return method
+	case "random_two":
+		if lb.LeastTime != "" {
+			return "random two least_time=" + lb.LeastTime
+		}
+		return "random two least_conn"
+	case "least_time":
+		method := "least_time " + generateString(lb.LeastTime, "connect")
+		if lb.Inflight {
+			method += " inflight"
+		}
+		return method
+	}
+	return generateLoadBalancingMethod(lb.Method)
+}
+
 func generateLoadBalancingMethod(method string) string {
 	if method == "" {
 		// By default, if unspecified, Nginx uses the 'round_robin' load balancing method.
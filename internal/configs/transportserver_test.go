@@ -9,6 +9,7 @@ import (
 	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
 	"github.com/nginx/kubernetes-ingress/internal/k8s/secrets"
 	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginx/nginx-plus-go-client/v3/client"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -353,6 +354,96 @@ func TestGenerateTransportServerConfigForTCP(t *testing.T) {
 	}
 }
 
+func TestGenerateTransportServerConfigForTCPWithAccessLog(t *testing.T) {
+	t.Parallel()
+	transportServerEx := TransportServerEx{
+		TransportServer: &conf_v1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "tcp-server",
+				Namespace: "default",
+			},
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
+					Name:     "tcp-listener",
+					Protocol: "TCP",
+				},
+				Upstreams: []conf_v1.TransportServerUpstream{
+					{
+						Name:    "tcp-app",
+						Service: "tcp-app-svc",
+						Port:    5001,
+					},
+				},
+				Action: &conf_v1.TransportServerAction{
+					Pass: "tcp-app",
+				},
+				AccessLog: &conf_v1.AccessLog{
+					Destination: "syslog:server=logs.example.com:514",
+					Sampling:    50,
+					Condition:   "5xx",
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tcp-app-svc:5001": {
+				"10.0.0.20:5001",
+			},
+		},
+	}
+
+	expectedAccessLog := &version2.AccessLog{
+		Destination: "syslog:server=logs.example.com:514",
+		Format:      "stream-main",
+		Condition:   "$ts_default_tcp_server_access_log_5xx_sample_50",
+	}
+	expectedMaps := []version2.Map{
+		{
+			Source:   "$status",
+			Variable: "$ts_default_tcp_server_access_log_5xx",
+			Parameters: []version2.Parameter{
+				{Value: "~^5", Result: "1"},
+				{Value: "default", Result: "0"},
+			},
+		},
+		{
+			Source:   `"${ts_default_tcp_server_access_log_5xx}${ts_default_tcp_server_access_log_sample_50}"`,
+			Variable: "$ts_default_tcp_server_access_log_5xx_sample_50",
+			Parameters: []version2.Parameter{
+				{Value: `"11"`, Result: "1"},
+				{Value: "default", Result: "0"},
+			},
+		},
+	}
+	expectedSplitClients := []version2.SplitClient{
+		{
+			Source:   `"${msec}${connection}"`,
+			Variable: "$ts_default_tcp_server_access_log_sample_50",
+			Distributions: []version2.Distribution{
+				{Weight: "50%", Value: "1"},
+				{Weight: "*", Value: "0"},
+			},
+		},
+	}
+
+	result, warnings := generateTransportServerConfig(transportServerConfigParams{
+		transportServerEx: &transportServerEx,
+		listenerPort:      2020,
+		isPlus:            true,
+	})
+	if len(warnings) != 0 {
+		t.Errorf("want no warnings, got %v", warnings)
+	}
+	if diff := cmp.Diff(expectedAccessLog, result.Server.AccessLog); diff != "" {
+		t.Errorf("generateTransportServerConfig() access log mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedMaps, result.Maps); diff != "" {
+		t.Errorf("generateTransportServerConfig() maps mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedSplitClients, result.SplitClients); diff != "" {
+		t.Errorf("generateTransportServerConfig() split clients mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestGenerateTransportServerConfigForTCPMaxConnections(t *testing.T) {
 	t.Parallel()
 	transportServerEx := TransportServerEx{
@@ -456,6 +547,146 @@ func TestGenerateTransportServerConfigForTCPMaxConnections(t *testing.T) {
 	}
 }
 
+func TestGenerateTransportServerConfigForTCPRateAndConnectionLimits(t *testing.T) {
+	t.Parallel()
+	transportServerEx := TransportServerEx{
+		TransportServer: &conf_v1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "tcp-server",
+				Namespace: "default",
+			},
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
+					Name:     "tcp-listener",
+					Protocol: "TCP",
+				},
+				Upstreams: []conf_v1.TransportServerUpstream{
+					{
+						Name:     "tcp-app",
+						Service:  "tcp-app-svc",
+						Port:     5001,
+						MaxConns: intPointer(3),
+					},
+					{
+						Name:    "tcp-app-bulk",
+						Service: "tcp-app-bulk-svc",
+						Port:    5001,
+					},
+				},
+				UpstreamParameters: &conf_v1.UpstreamParameters{
+					DownloadRate:   "1m",
+					UploadRate:     "512k",
+					MaxConnections: intPointer(100),
+				},
+				Action: &conf_v1.TransportServerAction{
+					Pass: "tcp-app",
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tcp-app-svc:5001": {
+				"10.0.0.20:5001",
+			},
+			"default/tcp-app-bulk-svc:5001": {
+				"10.0.0.30:5001",
+			},
+		},
+		DisableIPV6: false,
+	}
+
+	listenerPort := 2020
+
+	expected := &version2.TransportServerConfig{
+		Upstreams: []version2.StreamUpstream{
+			{
+				Name: "ts_default_tcp-server_tcp-app",
+				Servers: []version2.StreamUpstreamServer{
+					{
+						Address:        "10.0.0.20:5001",
+						MaxFails:       1,
+						FailTimeout:    "10s",
+						MaxConnections: 3,
+					},
+				},
+				UpstreamLabels: version2.UpstreamLabels{
+					ResourceName:      "tcp-server",
+					ResourceType:      "transportserver",
+					ResourceNamespace: "default",
+					Service:           "tcp-app-svc",
+				},
+				LoadBalancingMethod: "random two least_conn",
+			},
+			{
+				Name: "ts_default_tcp-server_tcp-app-bulk",
+				Servers: []version2.StreamUpstreamServer{
+					{
+						Address:        "10.0.0.30:5001",
+						MaxFails:       1,
+						FailTimeout:    "10s",
+						MaxConnections: 0,
+					},
+				},
+				UpstreamLabels: version2.UpstreamLabels{
+					ResourceName:      "tcp-server",
+					ResourceType:      "transportserver",
+					ResourceNamespace: "default",
+					Service:           "tcp-app-bulk-svc",
+				},
+				LoadBalancingMethod: "random two least_conn",
+			},
+		},
+		Server: version2.StreamServer{
+			Port:                     2020,
+			UDP:                      false,
+			StatusZone:               "tcp-listener",
+			ProxyPass:                "ts_default_tcp-server_tcp-app",
+			Name:                     "tcp-server",
+			Namespace:                "default",
+			ProxyConnectTimeout:      "60s",
+			ProxyNextUpstream:        false,
+			ProxyNextUpstreamTries:   0,
+			ProxyNextUpstreamTimeout: "0s",
+			ProxyTimeout:             "10m",
+			ProxyDownloadRate:        "1m",
+			ProxyUploadRate:          "512k",
+			HealthCheck:              nil,
+			DisableIPV6:              false,
+			ServerSnippets:           []string{},
+			SSL:                      &version2.StreamSSL{},
+			LimitConns: []version2.StreamLimitConn{
+				{
+					ZoneName:    "ts_max_conns_default_tcp_server",
+					Connections: 100,
+				},
+			},
+		},
+		LimitConnZones: []version2.StreamLimitConnZone{
+			{
+				ZoneName: "ts_max_conns_default_tcp_server",
+				Key:      "$protocol",
+				ZoneSize: "64k",
+			},
+		},
+		StreamSnippets: []string{},
+		StaticSSLPath:  "/etc/nginx/secret",
+	}
+
+	result, warnings := generateTransportServerConfig(transportServerConfigParams{
+		transportServerEx:      &transportServerEx,
+		listenerPort:           listenerPort,
+		isPlus:                 true,
+		isResolverConfigured:   false,
+		isDynamicReloadEnabled: false,
+		staticSSLPath:          "/etc/nginx/secret",
+	})
+	if len(warnings) != 0 {
+		t.Errorf("want no warnings, got %v", warnings)
+	}
+	if !cmp.Equal(expected, result) {
+		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", cmp.Diff(expected, result))
+	}
+}
+
 func TestGenerateTransportServerConfigForTLSPassthrough(t *testing.T) {
 	t.Parallel()
 	transportServerEx := TransportServerEx{
@@ -1713,6 +1944,31 @@ func TestGenerateTransportServerHealthChecks(t *testing.T) {
 			},
 			msg: "health check with match",
 		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{
+					Name: "dns-tcp",
+					Port: 90,
+					HealthCheck: &conf_v1.TransportServerHealthCheck{
+						Enabled:    true,
+						Mandatory:  true,
+						Persistent: true,
+					},
+				},
+			},
+			expectedHC: &version2.StreamHealthCheck{
+				Enabled:    true,
+				Timeout:    "5s",
+				Jitter:     "0s",
+				Interval:   "5s",
+				Passes:     1,
+				Fails:      1,
+				Mandatory:  true,
+				Persistent: true,
+			},
+			expectedMatch: nil,
+			msg:           "mandatory and persistent health check",
+		},
 	}
 
 	for _, test := range tests {
@@ -1785,6 +2041,55 @@ func TestGenerateHealthCheckMatch(t *testing.T) {
 			},
 			msg: "match with all fields and case insensitive regexp",
 		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				SendHex:   "0000000804d2162f",
+				ExpectHex: "4E",
+			},
+			expected: &version2.Match{
+				Name:                "match",
+				Send:                `\x00\x00\x00\x08\x04\xd2\x16\x2f`,
+				ExpectRegexModifier: "",
+				Expect:              `\x4E`,
+			},
+			msg: "match with hex-encoded data",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Profile: "postgresql",
+			},
+			expected: &version2.Match{
+				Name:                "match",
+				Send:                `\x00\x00\x00\x08\x04\xd2\x16\x2f`,
+				ExpectRegexModifier: "~",
+				Expect:              "^[SN]",
+			},
+			msg: "match with postgresql profile",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Profile: "redis",
+			},
+			expected: &version2.Match{
+				Name:                "match",
+				Send:                `PING\r\n`,
+				ExpectRegexModifier: "~",
+				Expect:              `^\+PONG`,
+			},
+			msg: "match with redis profile",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Profile: "dns",
+			},
+			expected: &version2.Match{
+				Name:                "match",
+				Send:                `\x4e\x47\x01\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00\x01`,
+				ExpectRegexModifier: "~",
+				Expect:              `^\x4e\x47[\x80-\xff]`,
+			},
+			msg: "match with dns profile",
+		},
 	}
 	name := "match"
 
@@ -1901,3 +2206,909 @@ func TestGenerateTsSSLConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateTransportServerPolicies(t *testing.T) {
+	t.Parallel()
+	dryRun := true
+
+	tests := []struct {
+		policyRefs       []conf_v1.PolicyReference
+		policies         map[string]*conf_v1.Policy
+		expected         transportServerPoliciesCfg
+		expectedWarnings int
+		msg              string
+	}{
+		{
+			policyRefs: nil,
+			policies:   nil,
+			expected:   transportServerPoliciesCfg{},
+			msg:        "no policies",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{Name: "allow-policy"},
+				{Name: "connection-limit", Namespace: "policies"},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/allow-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{Name: "allow-policy", Namespace: "default"},
+					Spec: conf_v1.PolicySpec{
+						AccessControl: &conf_v1.AccessControl{
+							Allow: []string{"10.0.0.0/8"},
+						},
+					},
+				},
+				"policies/connection-limit": {
+					ObjectMeta: meta_v1.ObjectMeta{Name: "connection-limit", Namespace: "policies"},
+					Spec: conf_v1.PolicySpec{
+						ConnectionLimit: &conf_v1.ConnectionLimit{
+							Key:         "${binary_remote_addr}",
+							Connections: 10,
+							ZoneSize:    "10M",
+							DryRun:      &dryRun,
+						},
+					},
+				},
+			},
+			expected: transportServerPoliciesCfg{
+				Allow: []string{"10.0.0.0/8"},
+				LimitConnZones: []version2.StreamLimitConnZone{
+					{
+						ZoneName: "pol_cl_policies_connection_limit_default_tcp_server",
+						Key:      "${binary_remote_addr}",
+						ZoneSize: "10M",
+					},
+				},
+				LimitConns: []version2.StreamLimitConn{
+					{
+						ZoneName:    "pol_cl_policies_connection_limit_default_tcp_server",
+						Connections: 10,
+					},
+				},
+				LimitConnOptions: version2.StreamLimitConnOptions{
+					DryRun:   true,
+					LogLevel: "error",
+				},
+			},
+			msg: "access control and connection limit policies",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{Name: "allow-policy"},
+				{Name: "deny-policy"},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/allow-policy": {
+					Spec: conf_v1.PolicySpec{
+						AccessControl: &conf_v1.AccessControl{
+							Allow: []string{"10.0.0.0/8"},
+						},
+					},
+				},
+				"default/deny-policy": {
+					Spec: conf_v1.PolicySpec{
+						AccessControl: &conf_v1.AccessControl{
+							Deny: []string{"127.0.0.1"},
+						},
+					},
+				},
+			},
+			expected: transportServerPoliciesCfg{
+				Allow: []string{"10.0.0.0/8"},
+				Deny:  []string{"127.0.0.1"},
+			},
+			expectedWarnings: 1,
+			msg:              "allow and deny policies",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{Name: "rate-limit"},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/rate-limit": {
+					Spec: conf_v1.PolicySpec{
+						RateLimit: &conf_v1.RateLimit{
+							Key:      "${binary_remote_addr}",
+							Rate:     "10r/s",
+							ZoneSize: "10M",
+						},
+					},
+				},
+			},
+			expected:         transportServerPoliciesCfg{},
+			expectedWarnings: 1,
+			msg:              "unsupported policy",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{Name: "allow-policy"},
+				{Name: "missing-policy"},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/allow-policy": {
+					Spec: conf_v1.PolicySpec{
+						AccessControl: &conf_v1.AccessControl{
+							Allow: []string{"10.0.0.0/8"},
+						},
+					},
+				},
+			},
+			expected: transportServerPoliciesCfg{
+				DenyAll: true,
+			},
+			expectedWarnings: 1,
+			msg:              "missing policy",
+		},
+	}
+
+	for _, test := range tests {
+		tsEx := &TransportServerEx{
+			TransportServer: &conf_v1.TransportServer{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "tcp-server",
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					Policies: test.policyRefs,
+				},
+			},
+			Policies: test.policies,
+		}
+
+		result, warnings := generateTransportServerPolicies(tsEx)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateTransportServerPolicies() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if len(warnings[tsEx.TransportServer]) != test.expectedWarnings {
+			t.Errorf("generateTransportServerPolicies() returned warnings %v but expected %d for the case of %s", warnings, test.expectedWarnings, test.msg)
+		}
+	}
+}
+
+func TestGenerateStreamSSLClientVerification(t *testing.T) {
+	t.Parallel()
+
+	ts := &conf_v1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "tcp-server",
+			Namespace: "default",
+		},
+	}
+
+	validTests := []struct {
+		tls         *conf_v1.TransportServerTLS
+		secretRefs  map[string]*secrets.SecretReference
+		expectedSSL *version2.StreamSSL
+		msg         string
+	}{
+		{
+			tls:         &conf_v1.TransportServerTLS{Secret: "secret"},
+			secretRefs:  map[string]*secrets.SecretReference{},
+			expectedSSL: &version2.StreamSSL{Enabled: true},
+			msg:         "no client verification",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:           "secret",
+				ClientCertSecret: "ca-secret",
+			},
+			secretRefs: map[string]*secrets.SecretReference{
+				"default/ca-secret": {
+					Secret: &api_v1.Secret{
+						Type: secrets.SecretTypeCA,
+					},
+					Path: "/etc/nginx/secrets/default-ca-secret-ca.crt",
+				},
+			},
+			expectedSSL: &version2.StreamSSL{
+				Enabled:      true,
+				ClientCert:   "/etc/nginx/secrets/default-ca-secret-ca.crt",
+				VerifyClient: "on",
+				VerifyDepth:  1,
+			},
+			msg: "client verification with defaults",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:           "secret",
+				ClientCertSecret: "ca-secret",
+				VerifyClient:     "optional",
+				VerifyDepth:      createPointerFromInt(2),
+			},
+			secretRefs: map[string]*secrets.SecretReference{
+				"default/ca-secret": {
+					Secret: &api_v1.Secret{
+						Type: secrets.SecretTypeCA,
+						Data: map[string][]byte{
+							"ca.crl": []byte("crl"),
+						},
+					},
+					Path: "/etc/nginx/secrets/default-ca-secret-ca.crt /etc/nginx/secrets/default-ca-secret-ca.crl",
+				},
+			},
+			expectedSSL: &version2.StreamSSL{
+				Enabled:      true,
+				ClientCert:   "/etc/nginx/secrets/default-ca-secret-ca.crt",
+				ClientCrl:    "/etc/nginx/secrets/default-ca-secret-ca.crl",
+				VerifyClient: "optional",
+				VerifyDepth:  2,
+			},
+			msg: "client verification with crl in the secret",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:           "secret",
+				ClientCertSecret: "ca-secret",
+				CrlFileName:      "default-ca-secret.crl",
+			},
+			secretRefs: map[string]*secrets.SecretReference{
+				"default/ca-secret": {
+					Secret: &api_v1.Secret{
+						Type: secrets.SecretTypeCA,
+					},
+					Path: "/etc/nginx/secrets/default-ca-secret-ca.crt",
+				},
+			},
+			expectedSSL: &version2.StreamSSL{
+				Enabled:      true,
+				ClientCert:   "/etc/nginx/secrets/default-ca-secret-ca.crt",
+				ClientCrl:    "/etc/nginx/secrets/default-ca-secret.crl",
+				VerifyClient: "on",
+				VerifyDepth:  1,
+			},
+			msg: "client verification with crl file name",
+		},
+	}
+
+	for _, test := range validTests {
+		ssl := &version2.StreamSSL{Enabled: true}
+		valid, warnings := generateStreamSSLClientVerification(ts, test.tls, ssl, test.secretRefs)
+		if !valid {
+			t.Errorf("generateStreamSSLClientVerification() returned false for the case of %s", test.msg)
+		}
+		if diff := cmp.Diff(test.expectedSSL, ssl); diff != "" {
+			t.Errorf("generateStreamSSLClientVerification() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if len(warnings) != 0 {
+			t.Errorf("generateStreamSSLClientVerification() returned unexpected warnings %v for the case of %s", warnings, test.msg)
+		}
+	}
+
+	invalidTests := []struct {
+		tls        *conf_v1.TransportServerTLS
+		sslEnabled bool
+		secretRefs map[string]*secrets.SecretReference
+		msg        string
+	}{
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:           "secret",
+				ClientCertSecret: "ca-secret",
+			},
+			sslEnabled: false,
+			secretRefs: map[string]*secrets.SecretReference{},
+			msg:        "ssl is not enabled",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:           "secret",
+				ClientCertSecret: "ca-secret",
+			},
+			sslEnabled: true,
+			secretRefs: map[string]*secrets.SecretReference{
+				"default/ca-secret": {
+					Secret: &api_v1.Secret{
+						Type: api_v1.SecretTypeTLS,
+					},
+				},
+			},
+			msg: "wrong secret type",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:           "secret",
+				ClientCertSecret: "ca-secret",
+			},
+			sslEnabled: true,
+			secretRefs: map[string]*secrets.SecretReference{
+				"default/ca-secret": {
+					Error: errors.New("secret doesn't exist"),
+				},
+			},
+			msg: "missing secret",
+		},
+	}
+
+	for _, test := range invalidTests {
+		ssl := &version2.StreamSSL{Enabled: test.sslEnabled}
+		valid, warnings := generateStreamSSLClientVerification(ts, test.tls, ssl, test.secretRefs)
+		if valid {
+			t.Errorf("generateStreamSSLClientVerification() returned true for the case of %s", test.msg)
+		}
+		if len(warnings) == 0 {
+			t.Errorf("generateStreamSSLClientVerification() returned no warnings for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestGenerateTransportServerUpstreamStatuses(t *testing.T) {
+	t.Parallel()
+
+	transportServer := &conf_v1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "tcp-server",
+			Namespace: "default",
+		},
+		Spec: conf_v1.TransportServerSpec{
+			Upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "healthy", Service: "healthy-svc", Port: 5001},
+				{Name: "degraded", Service: "degraded-svc", Port: 5002},
+				{Name: "unhealthy", Service: "unhealthy-svc", Port: 5003},
+				{Name: "empty", Service: "empty-svc", Port: 5004},
+				{Name: "missing", Service: "missing-svc", Port: 5005},
+			},
+		},
+	}
+
+	streamUpstreams := client.StreamUpstreams{
+		"ts_default_tcp-server_healthy": client.StreamUpstream{
+			Peers: []client.StreamPeer{{State: "up"}, {State: "up"}},
+		},
+		"ts_default_tcp-server_degraded": client.StreamUpstream{
+			Peers: []client.StreamPeer{{State: "up"}, {State: "unhealthy"}, {State: "down"}},
+		},
+		"ts_default_tcp-server_unhealthy": client.StreamUpstream{
+			Peers: []client.StreamPeer{{State: "unavail"}},
+		},
+		"ts_default_tcp-server_empty": client.StreamUpstream{},
+		"ts_default_other_healthy": client.StreamUpstream{
+			Peers: []client.StreamPeer{{State: "up"}},
+		},
+	}
+
+	expected := []conf_v1.TransportServerUpstreamStatus{
+		{Name: "healthy", Health: conf_v1.UpstreamHealthHealthy, Servers: 2, Up: 2},
+		{Name: "degraded", Health: conf_v1.UpstreamHealthDegraded, Servers: 3, Up: 1},
+		{Name: "unhealthy", Health: conf_v1.UpstreamHealthUnhealthy, Servers: 1, Up: 0},
+		{Name: "empty", Health: conf_v1.UpstreamHealthUnhealthy, Servers: 0, Up: 0},
+	}
+
+	result := generateTransportServerUpstreamStatuses(transportServer, streamUpstreams)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generateTransportServerUpstreamStatuses() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateTransportServerPortRangeProxyPass(t *testing.T) {
+	t.Parallel()
+
+	transportServerEx := &TransportServerEx{
+		TransportServer: &conf_v1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "ftp-passive",
+				Namespace: "default",
+			},
+			Spec: conf_v1.TransportServerSpec{
+				Upstreams: []conf_v1.TransportServerUpstream{
+					{
+						Name:    "ftp",
+						Service: "ftp-svc",
+						Port:    30000,
+					},
+				},
+				Action: &conf_v1.TransportServerAction{
+					Pass: "ftp",
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/ftp-svc:30000": {
+				"10.0.0.30:30000",
+				"10.0.0.20:30000",
+				"[fd00::10]:30000",
+			},
+		},
+		ListenerPort:    30000,
+		ListenerEndPort: 30100,
+	}
+
+	expectedSplitClients := []version2.SplitClient{
+		{
+			Source:   "$remote_addr",
+			Variable: "$ts_default_ftp_passive_endpoint",
+			Distributions: []version2.Distribution{
+				{Weight: "33.33%", Value: "10.0.0.20"},
+				{Weight: "33.33%", Value: "10.0.0.30"},
+				{Weight: "*", Value: "[fd00::10]"},
+			},
+		},
+	}
+
+	var cfg observabilityCfg
+	proxyPass, warnings := generateTransportServerPortRangeProxyPass(transportServerEx, "$ts_default_ftp_passive", &cfg)
+	if proxyPass != "$ts_default_ftp_passive_endpoint:$server_port" {
+		t.Errorf("generateTransportServerPortRangeProxyPass() returned %q", proxyPass)
+	}
+	if diff := cmp.Diff(expectedSplitClients, cfg.SplitClients); diff != "" {
+		t.Errorf("generateTransportServerPortRangeProxyPass() mismatch (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateTransportServerPortRangeProxyPass() returned unexpected warnings %v", warnings)
+	}
+
+	singleEndpointTransportServerEx := *transportServerEx
+	singleEndpointTransportServerEx.Endpoints = map[string][]string{
+		"default/ftp-svc:30000": {
+			"10.0.0.20:30000",
+		},
+	}
+	cfg = observabilityCfg{}
+	proxyPass, _ = generateTransportServerPortRangeProxyPass(&singleEndpointTransportServerEx, "$ts_default_ftp_passive", &cfg)
+	if proxyPass != "10.0.0.20:$server_port" {
+		t.Errorf("generateTransportServerPortRangeProxyPass() returned %q for a single endpoint", proxyPass)
+	}
+	if len(cfg.SplitClients) != 0 {
+		t.Errorf("generateTransportServerPortRangeProxyPass() added unexpected split clients %v for a single endpoint", cfg.SplitClients)
+	}
+
+	noEndpointsTransportServerEx := *transportServerEx
+	noEndpointsTransportServerEx.Endpoints = map[string][]string{}
+	cfg = observabilityCfg{}
+	proxyPass, _ = generateTransportServerPortRangeProxyPass(&noEndpointsTransportServerEx, "$ts_default_ftp_passive", &cfg)
+	if proxyPass != nginxNonExistingUnixSocket {
+		t.Errorf("generateTransportServerPortRangeProxyPass() returned %q for no endpoints", proxyPass)
+	}
+
+	splitsTransportServerEx := *transportServerEx
+	splitsTransportServerEx.TransportServer = transportServerEx.TransportServer.DeepCopy()
+	splitsTransportServerEx.TransportServer.Spec.Action = &conf_v1.TransportServerAction{
+		Splits: []conf_v1.TransportServerSplit{
+			{Weight: 50, Pass: "ftp"},
+			{Weight: 50, Pass: "ftp"},
+		},
+	}
+	cfg = observabilityCfg{}
+	proxyPass, warnings = generateTransportServerPortRangeProxyPass(&splitsTransportServerEx, "$ts_default_ftp_passive", &cfg)
+	if proxyPass != nginxNonExistingUnixSocket {
+		t.Errorf("generateTransportServerPortRangeProxyPass() returned %q for splits", proxyPass)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("generateTransportServerPortRangeProxyPass() returned %d warnings but expected 1 for splits", len(warnings))
+	}
+}
+
+func TestGenerateStreamSSLCertificates(t *testing.T) {
+	t.Parallel()
+
+	ts := &conf_v1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "tcp-server",
+			Namespace: "default",
+		},
+	}
+	tls := &conf_v1.TransportServerTLS{
+		Secret: "cafe-secret",
+		Certificates: []conf_v1.TransportServerTLSCertificate{
+			{ServerName: "tea.example.com", Secret: "tea-secret"},
+			{ServerName: "coffee.example.com", Secret: "coffee-secret"},
+			{ServerName: "juice.example.com", Secret: "juice-secret"},
+		},
+	}
+	secretRefs := map[string]*secrets.SecretReference{
+		"default/tea-secret": {
+			Secret: &api_v1.Secret{
+				Type: api_v1.SecretTypeTLS,
+			},
+			Path: "/etc/nginx/secrets/default-tea-secret",
+		},
+		"default/coffee-secret": {
+			Secret: &api_v1.Secret{
+				Type: secrets.SecretTypeCA,
+			},
+		},
+		"default/juice-secret": {
+			Error: errors.New("secret doesn't exist"),
+		},
+	}
+
+	ssl := &version2.StreamSSL{Enabled: true}
+	expectedSSL := &version2.StreamSSL{
+		Enabled:             true,
+		CertificateVariable: "$ts_default_tcp_server_certificate",
+		Certificates: []version2.StreamSSLCertificate{
+			{ServerName: "tea.example.com", Certificate: "/etc/nginx/secrets/default-tea-secret"},
+		},
+	}
+
+	warnings := generateStreamSSLCertificates(ts, tls, ssl, secretRefs, "$ts_default_tcp_server")
+	if diff := cmp.Diff(expectedSSL, ssl); diff != "" {
+		t.Errorf("generateStreamSSLCertificates() mismatch (-want +got):\n%s", diff)
+	}
+	if len(warnings[ts]) != 2 {
+		t.Errorf("generateStreamSSLCertificates() returned %d warnings but expected 2 for the invalid secrets", len(warnings[ts]))
+	}
+
+	ssl = &version2.StreamSSL{Enabled: false}
+	warnings = generateStreamSSLCertificates(ts, tls, ssl, secretRefs, "$ts_default_tcp_server")
+	if diff := cmp.Diff(&version2.StreamSSL{Enabled: false}, ssl); diff != "" {
+		t.Errorf("generateStreamSSLCertificates() mismatch for disabled ssl (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateStreamSSLCertificates() returned unexpected warnings %v for disabled ssl", warnings)
+	}
+}
+
+func TestGenerateStreamSSLALPN(t *testing.T) {
+	t.Parallel()
+
+	ts := &conf_v1.TransportServer{
+		Spec: conf_v1.TransportServerSpec{
+			Routes: []conf_v1.TransportServerRoute{
+				{ServerName: "mqtt.example.com", Pass: "mqtt"},
+				{ServerName: "web.example.com", ALPN: "h2", Pass: "web"},
+				{ALPN: "http/1.1", Pass: "web"},
+				{ALPN: "h2", Pass: "web"},
+			},
+		},
+	}
+
+	ssl := &version2.StreamSSL{Enabled: true}
+	generateStreamSSLALPN(ts, ssl)
+	expected := []string{"h2", "http/1.1"}
+	if !reflect.DeepEqual(ssl.ALPN, expected) {
+		t.Errorf("generateStreamSSLALPN() returned %v but expected %v", ssl.ALPN, expected)
+	}
+
+	ssl = &version2.StreamSSL{Enabled: false}
+	generateStreamSSLALPN(ts, ssl)
+	if ssl.ALPN != nil {
+		t.Errorf("generateStreamSSLALPN() returned %v but expected nil for disabled ssl", ssl.ALPN)
+	}
+}
+
+func TestGenerateStreamProxyConnection(t *testing.T) {
+	t.Parallel()
+
+	newTS := func(upstream conf_v1.TransportServerUpstream) *conf_v1.TransportServer {
+		return &conf_v1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "tcp-server",
+				Namespace: "default",
+			},
+			Spec: conf_v1.TransportServerSpec{
+				Upstreams: []conf_v1.TransportServerUpstream{upstream},
+				Action: &conf_v1.TransportServerAction{
+					Pass: "tcp-app",
+				},
+			},
+		}
+	}
+
+	validTests := []struct {
+		upstream              conf_v1.TransportServerUpstream
+		secretRefs            map[string]*secrets.SecretReference
+		expectedSSL           *version2.StreamProxySSL
+		expectedProxyProtocol bool
+		msg                   string
+	}{
+		{
+			upstream: conf_v1.TransportServerUpstream{Name: "tcp-app"},
+			msg:      "no tls and no proxy protocol",
+		},
+		{
+			upstream:              conf_v1.TransportServerUpstream{Name: "tcp-app", ProxyProtocol: true},
+			expectedProxyProtocol: true,
+			msg:                   "proxy protocol",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{
+				Name: "tcp-app",
+				TLS:  &conf_v1.TransportServerUpstreamTLS{Enable: false, TLSSecret: "client-secret"},
+			},
+			msg: "tls is not enabled",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{
+				Name: "tcp-app",
+				TLS:  &conf_v1.TransportServerUpstreamTLS{Enable: true},
+			},
+			expectedSSL: &version2.StreamProxySSL{
+				VerifyDepth: 1,
+			},
+			msg: "tls with defaults",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{
+				Name:          "tcp-app",
+				ProxyProtocol: true,
+				TLS: &conf_v1.TransportServerUpstreamTLS{
+					Enable:            true,
+					TLSSecret:         "client-secret",
+					VerifyServer:      true,
+					VerifyDepth:       createPointerFromInt(2),
+					TrustedCertSecret: "ca-secret",
+					ServerName:        true,
+					SSLName:           "backend.example.com",
+				},
+			},
+			secretRefs: map[string]*secrets.SecretReference{
+				"default/client-secret": {
+					Secret: &api_v1.Secret{
+						Type: api_v1.SecretTypeTLS,
+					},
+					Path: "/etc/nginx/secrets/default-client-secret",
+				},
+				"default/ca-secret": {
+					Secret: &api_v1.Secret{
+						Type: secrets.SecretTypeCA,
+					},
+					Path: "/etc/nginx/secrets/default-ca-secret-ca.crt",
+				},
+			},
+			expectedSSL: &version2.StreamProxySSL{
+				Certificate:    "/etc/nginx/secrets/default-client-secret",
+				CertificateKey: "/etc/nginx/secrets/default-client-secret",
+				TrustedCert:    "/etc/nginx/secrets/default-ca-secret-ca.crt",
+				VerifyServer:   true,
+				VerifyDepth:    2,
+				ServerName:     true,
+				SSLName:        "backend.example.com",
+			},
+			expectedProxyProtocol: true,
+			msg:                   "tls with client certificate and verification",
+		},
+	}
+
+	for _, test := range validTests {
+		ssl, proxyProtocol, valid, warnings := generateStreamProxyConnection(newTS(test.upstream), test.secretRefs)
+		if !valid {
+			t.Errorf("generateStreamProxyConnection() returned false for the case of %s", test.msg)
+		}
+		if diff := cmp.Diff(test.expectedSSL, ssl); diff != "" {
+			t.Errorf("generateStreamProxyConnection() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if proxyProtocol != test.expectedProxyProtocol {
+			t.Errorf("generateStreamProxyConnection() returned proxy protocol %v but expected %v for the case of %s", proxyProtocol, test.expectedProxyProtocol, test.msg)
+		}
+		if len(warnings) != 0 {
+			t.Errorf("generateStreamProxyConnection() returned unexpected warnings %v for the case of %s", warnings, test.msg)
+		}
+	}
+
+	invalidTests := []struct {
+		tls        *conf_v1.TransportServerUpstreamTLS
+		secretRefs map[string]*secrets.SecretReference
+		msg        string
+	}{
+		{
+			tls: &conf_v1.TransportServerUpstreamTLS{Enable: true, TLSSecret: "client-secret"},
+			secretRefs: map[string]*secrets.SecretReference{
+				"default/client-secret": {
+					Secret: &api_v1.Secret{
+						Type: secrets.SecretTypeCA,
+					},
+				},
+			},
+			msg: "wrong tls secret type",
+		},
+		{
+			tls: &conf_v1.TransportServerUpstreamTLS{Enable: true, TLSSecret: "client-secret"},
+			secretRefs: map[string]*secrets.SecretReference{
+				"default/client-secret": {
+					Error: errors.New("secret doesn't exist"),
+				},
+			},
+			msg: "missing tls secret",
+		},
+		{
+			tls: &conf_v1.TransportServerUpstreamTLS{Enable: true, TrustedCertSecret: "ca-secret", VerifyServer: true},
+			secretRefs: map[string]*secrets.SecretReference{
+				"default/ca-secret": {
+					Secret: &api_v1.Secret{
+						Type: api_v1.SecretTypeTLS,
+					},
+				},
+			},
+			msg: "wrong trusted cert secret type",
+		},
+		{
+			tls: &conf_v1.TransportServerUpstreamTLS{Enable: true, TrustedCertSecret: "ca-secret", VerifyServer: true},
+			secretRefs: map[string]*secrets.SecretReference{
+				"default/ca-secret": {
+					Error: errors.New("secret doesn't exist"),
+				},
+			},
+			msg: "missing trusted cert secret",
+		},
+	}
+
+	for _, test := range invalidTests {
+		ts := newTS(conf_v1.TransportServerUpstream{Name: "tcp-app", TLS: test.tls})
+		ssl, _, valid, warnings := generateStreamProxyConnection(ts, test.secretRefs)
+		if valid {
+			t.Errorf("generateStreamProxyConnection() returned true for the case of %s", test.msg)
+		}
+		if ssl != nil {
+			t.Errorf("generateStreamProxyConnection() returned %v but expected nil for the case of %s", ssl, test.msg)
+		}
+		if len(warnings) == 0 {
+			t.Errorf("generateStreamProxyConnection() returned no warnings for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestGenerateStreamLoadBalancingMethod(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		upstream conf_v1.TransportServerUpstream
+		expected string
+		msg      string
+	}{
+		{
+			upstream: conf_v1.TransportServerUpstream{},
+			expected: "random two least_conn",
+			msg:      "default method",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{LoadBalancingMethod: "least_conn"},
+			expected: "least_conn",
+			msg:      "free-form method",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{LoadBalancing: &conf_v1.TransportServerLoadBalancing{Method: "round_robin"}},
+			expected: "",
+			msg:      "round robin",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{LoadBalancing: &conf_v1.TransportServerLoadBalancing{Method: "least_conn"}},
+			expected: "least_conn",
+			msg:      "least connections",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{LoadBalancing: &conf_v1.TransportServerLoadBalancing{Method: "hash"}},
+			expected: "hash $remote_addr",
+			msg:      "hash with the default key",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{LoadBalancing: &conf_v1.TransportServerLoadBalancing{Method: "hash", Key: "${remote_addr}:${remote_port}", Consistent: true}},
+			expected: "hash ${remote_addr}:${remote_port} consistent",
+			msg:      "consistent hash on a custom key",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{LoadBalancing: &conf_v1.TransportServerLoadBalancing{Method: "random"}},
+			expected: "random",
+			msg:      "random",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{LoadBalancing: &conf_v1.TransportServerLoadBalancing{Method: "random_two"}},
+			expected: "random two least_conn",
+			msg:      "random two with least connections",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{LoadBalancing: &conf_v1.TransportServerLoadBalancing{Method: "random_two", LeastTime: "first_byte"}},
+			expected: "random two least_time=first_byte",
+			msg:      "random two with least time",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{LoadBalancing: &conf_v1.TransportServerLoadBalancing{Method: "least_time"}},
+			expected: "least_time connect",
+			msg:      "least time with the default time",
+		},
+		{
+			upstream: conf_v1.TransportServerUpstream{LoadBalancing: &conf_v1.TransportServerLoadBalancing{Method: "least_time", LeastTime: "last_byte", Inflight: true}},
+			expected: "least_time last_byte inflight",
+			msg:      "least time with inflight",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateStreamLoadBalancingMethod(test.upstream)
+		if result != test.expected {
+			t.Errorf("generateStreamLoadBalancingMethod() returned %q but expected %q for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGenerateTransportServerProxyPass(t *testing.T) {
+	t.Parallel()
+
+	ts := &conf_v1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "mqtt-broker",
+			Namespace: "default",
+		},
+		Spec: conf_v1.TransportServerSpec{
+			Upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "broker-v1"},
+				{Name: "broker-v2"},
+				{Name: "broker-v3"},
+			},
+			Action: &conf_v1.TransportServerAction{
+				Pass: "broker-v1",
+			},
+		},
+	}
+	upstreamNamer := newUpstreamNamerForTransportServer(ts)
+
+	var cfg observabilityCfg
+	proxyPass, warnings := generateTransportServerProxyPass(ts, upstreamNamer, "$ts_default_mqtt_broker", &cfg)
+	if proxyPass != "ts_default_mqtt-broker_broker-v1" {
+		t.Errorf("generateTransportServerProxyPass() returned %q for pass", proxyPass)
+	}
+	if len(cfg.SplitClients) != 0 || len(cfg.Maps) != 0 {
+		t.Errorf("generateTransportServerProxyPass() added split clients %v and maps %v but expected none for pass", cfg.SplitClients, cfg.Maps)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateTransportServerProxyPass() returned unexpected warnings %v for pass", warnings)
+	}
+
+	tsWithSplits := ts.DeepCopy()
+	tsWithSplits.Spec.Action = &conf_v1.TransportServerAction{
+		Splits: []conf_v1.TransportServerSplit{
+			{Weight: 90, Pass: "broker-v1"},
+			{Weight: 10, Pass: "broker-v2"},
+			{Weight: 0, Pass: "broker-v3"},
+		},
+	}
+	tsWithSplits.Spec.Upstreams[1].HealthCheck = &conf_v1.TransportServerHealthCheck{Enabled: true}
+
+	expectedSplitClients := []version2.SplitClient{
+		{
+			Source:   "$remote_addr",
+			Variable: "$ts_default_mqtt_broker_split",
+			Distributions: []version2.Distribution{
+				{Weight: "90%", Value: "ts_default_mqtt-broker_broker-v1"},
+				{Weight: "10%", Value: "ts_default_mqtt-broker_broker-v2"},
+			},
+		},
+	}
+
+	cfg = observabilityCfg{}
+	proxyPass, warnings = generateTransportServerProxyPass(tsWithSplits, upstreamNamer, "$ts_default_mqtt_broker", &cfg)
+	if proxyPass != "$ts_default_mqtt_broker_split" {
+		t.Errorf("generateTransportServerProxyPass() returned %q for splits", proxyPass)
+	}
+	if diff := cmp.Diff(expectedSplitClients, cfg.SplitClients); diff != "" {
+		t.Errorf("generateTransportServerProxyPass() mismatch for splits (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("generateTransportServerProxyPass() returned %d warnings but expected 1 for the health check of a split upstream", len(warnings))
+	}
+
+	tsWithRoutes := tsWithSplits.DeepCopy()
+	tsWithRoutes.Spec.Upstreams[1].HealthCheck = nil
+	tsWithRoutes.Spec.Routes = []conf_v1.TransportServerRoute{
+		{ServerName: "mqtt.example.com", ALPN: "mqtt", Pass: "broker-v3"},
+		{ServerName: "v2.example.com", Pass: "broker-v2"},
+		{ALPN: "x-amzn-mqtt-ca", Pass: "broker-v1"},
+	}
+
+	expectedMaps := []version2.Map{
+		{
+			Source:   "$ssl_server_name:$ssl_alpn_protocol",
+			Variable: "$ts_default_mqtt_broker_route",
+			Parameters: []version2.Parameter{
+				{Value: `"mqtt.example.com:mqtt"`, Result: "ts_default_mqtt-broker_broker-v3"},
+				{Value: `"~^v2\.example\.com:"`, Result: "ts_default_mqtt-broker_broker-v2"},
+				{Value: `"~:x-amzn-mqtt-ca$"`, Result: "ts_default_mqtt-broker_broker-v1"},
+				{Value: "default", Result: "$ts_default_mqtt_broker_split"},
+			},
+		},
+	}
+
+	cfg = observabilityCfg{}
+	proxyPass, warnings = generateTransportServerProxyPass(tsWithRoutes, upstreamNamer, "$ts_default_mqtt_broker", &cfg)
+	if proxyPass != "$ts_default_mqtt_broker_route" {
+		t.Errorf("generateTransportServerProxyPass() returned %q for routes", proxyPass)
+	}
+	if diff := cmp.Diff(expectedSplitClients, cfg.SplitClients); diff != "" {
+		t.Errorf("generateTransportServerProxyPass() mismatch of split clients for routes (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedMaps, cfg.Maps); diff != "" {
+		t.Errorf("generateTransportServerProxyPass() mismatch of maps for routes (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateTransportServerProxyPass() returned unexpected warnings %v for routes", warnings)
+	}
+}
@@ -23,6 +23,7 @@ type IngressNginxConfig struct {
 	DynamicSSLReloadEnabled bool
 	StaticSSLPath           string
 	LimitReqZones           []LimitReqZone
+	OtelSamplers            []OtelSampler
 }
 
 // Ingress holds information about an Ingress resource.
@@ -42,6 +43,14 @@ type Upstream struct {
 	QueueTimeout     int64
 	UpstreamZoneSize string
 	UpstreamLabels   UpstreamLabels
+	SessionAffinity  *SessionAffinity
+}
+
+// SessionAffinity describes session affinity for an NGINX upstream.
+// The maps return the hash key of the upstream and the value of the Set-Cookie header for the CookieVariable.
+type SessionAffinity struct {
+	Maps           []version2.Map
+	CookieVariable string
 }
 
 // UpstreamServer describes a server in an NGINX upstream.
@@ -52,6 +61,10 @@ type UpstreamServer struct {
 	FailTimeout string
 	SlowStart   string
 	Resolve     bool
+	// Drain marks a terminating endpoint. NGINX Plus drains it, NGINX uses it as a backup server.
+	Drain bool
+	// Backup marks an endpoint in another zone when topology-aware routing is enabled.
+	Backup bool
 }
 
 // HealthCheck describes an active HTTP health check.
@@ -76,6 +89,12 @@ type LimitReqZone struct {
 	Sync bool
 }
 
+// OtelSampler describes a variable that enables OpenTelemetry tracing for a percentage of requests.
+type OtelSampler struct {
+	Variable   string
+	Percentage int
+}
+
 // Server describes an NGINX server.
 type Server struct {
 	ServerSnippets        []string
@@ -97,6 +116,7 @@ type Server struct {
 	HSTSMaxAge            int64
 	HSTSIncludeSubdomains bool
 	HSTSBehindProxy       bool
+	RequestIDHeader       string
 	ProxyHideHeaders      []string
 	ProxyPassHeaders      []string
 
@@ -187,6 +207,7 @@ type Location struct {
 	BasicAuth            *BasicAuth
 	ServiceName          string
 	LimitReq             *LimitReq
+	Tracing              *version2.Tracing
 
 	MinionIngress *Ingress
 }
@@ -202,6 +223,12 @@ type ZoneSyncConfig struct {
 	ResolverIPV6  *bool
 }
 
+// RequestID defines the request ID settings shared by all servers.
+type RequestID struct {
+	HeaderVariable string
+	TrustIncoming  bool
+}
+
 // MGMTConfig is tbe configuration for the MGMT block.
 type MGMTConfig struct {
 	SSLVerify            *bool
@@ -231,6 +258,7 @@ type MainConfig struct {
 	HealthStatus                       bool
 	HealthStatusURI                    string
 	HTTP2                              bool
+	HTTP3                              bool
 	HTTPSnippets                       []string
 	KeepaliveRequests                  int64
 	KeepaliveTimeout                   string
@@ -254,6 +282,7 @@ type MainConfig struct {
 	ResolverValid                      string
 	RealIPHeader                       string
 	RealIPRecursive                    bool
+	RequestID                          *RequestID
 	SetRealIPFrom                      []string
 	ServerNamesHashBucketSize          string
 	ServerNamesHashMaxSize             string
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gkampitakis/go-snaps/snaps"
 	"github.com/nginx/kubernetes-ingress/internal/configs/commonhelpers"
+	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
 	"github.com/nginx/kubernetes-ingress/internal/nginx"
 )
 
@@ -868,6 +869,68 @@ func TestExecuteTemplate_ForMainForNGINXPlusWithHTTP2On(t *testing.T) {
 	snaps.MatchSnapshot(t, buf.String())
 }
 
+func TestExecuteTemplate_ForMainForNGINXWithHTTP3On(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXMainTmpl(t)
+	buf := &bytes.Buffer{}
+
+	mainCfg := mainCfgHTTP2On
+	mainCfg.HTTP3 = true
+
+	err := tmpl.Execute(buf, mainCfg)
+	t.Log(buf.String())
+
+	if err != nil {
+		t.Fatalf("Failed to write template %v", err)
+	}
+
+	wantDirectives := []string{
+		"listen 443 ssl default_server;",
+		"listen 443 quic reuseport;",
+		"listen [::]:443 quic reuseport;",
+	}
+
+	mainConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(mainConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestExecuteTemplate_ForMainForNGINXPlusWithHTTP3On(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXPlusMainTmpl(t)
+	buf := &bytes.Buffer{}
+
+	mainCfg := mainCfgHTTP2On
+	mainCfg.HTTP3 = true
+
+	err := tmpl.Execute(buf, mainCfg)
+	t.Log(buf.String())
+
+	if err != nil {
+		t.Fatalf("Failed to write template %v", err)
+	}
+
+	wantDirectives := []string{
+		"listen 443 ssl default_server;",
+		"listen 443 quic reuseport;",
+		"listen [::]:443 quic reuseport;",
+	}
+
+	mainConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(mainConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
 func TestExecuteTemplate_ForMainForNGINXWithHTTP2Off(t *testing.T) {
 	t.Parallel()
 
@@ -1110,6 +1173,426 @@ func TestExecuteTemplate_ForMainForNGINXWithOtel(t *testing.T) {
 	snaps.MatchSnapshot(t, buf.String())
 }
 
+func TestExecuteTemplate_ForMainForNGINXPlusWithRequestID(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXPlusMainTmpl(t)
+	buf := &bytes.Buffer{}
+
+	cfg := mainCfg
+	cfg.LogFormat = nil
+	cfg.RequestID = &RequestID{
+		HeaderVariable: "$http_x_request_id",
+	}
+
+	err := tmpl.Execute(buf, cfg)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatalf("Failed to write template %v", err)
+	}
+
+	wantDirectives := []string{
+		"map $http_x_request_id $correlation_id {\n        default $request_id;\n    }",
+		`"$http_user_agent" "$http_x_forwarded_for" "$correlation_id"';`,
+	}
+
+	mainConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(mainConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestExecuteTemplate_ForMainForNGINXWithRequestIDTrustIncoming(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXMainTmpl(t)
+	buf := &bytes.Buffer{}
+
+	cfg := mainCfg
+	cfg.LogFormat = nil
+	cfg.RequestID = &RequestID{
+		HeaderVariable: "$http_x_correlation_id",
+		TrustIncoming:  true,
+	}
+
+	err := tmpl.Execute(buf, cfg)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatalf("Failed to write template %v", err)
+	}
+
+	wantDirectives := []string{
+		"map $http_x_correlation_id $correlation_id {\n        default $http_x_correlation_id;\n        '' $request_id;\n    }",
+		`"$http_user_agent" "$http_x_forwarded_for" "$correlation_id"';`,
+	}
+
+	mainConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(mainConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestExecuteTemplate_ForIngressForNGINXWithRequestID(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXIngressTmpl(t)
+	buf := &bytes.Buffer{}
+
+	server := ingressCfg.Servers[0]
+	server.RequestIDHeader = "X-Request-ID"
+	cfg := ingressCfg
+	cfg.Servers = []Server{server}
+
+	err := tmpl.Execute(buf, cfg)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantDirectives := []string{
+		"add_header X-Request-ID $correlation_id always;",
+		"proxy_set_header X-Request-ID $correlation_id;",
+	}
+
+	ingConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(ingConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestExecuteTemplate_ForIngressForNGINXPlusWithTracing(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXPlusIngressTmpl(t)
+	buf := &bytes.Buffer{}
+
+	server := ingressCfg.Servers[0]
+	location := server.Locations[0]
+	location.Tracing = &version2.Tracing{
+		Trace:    "$ing_default_cafe_ingress_otel_sample_25",
+		SpanName: "cafe ${request_method}",
+		SpanAttributes: []version2.SpanAttribute{
+			{Name: "http.user_agent", Value: "$http_user_agent"},
+		},
+	}
+	server.Locations = []Location{location}
+	cfg := ingressCfg
+	cfg.Servers = []Server{server}
+	cfg.OtelSamplers = []OtelSampler{
+		{Variable: "$ing_default_cafe_ingress_otel_sample_25", Percentage: 25},
+	}
+
+	err := tmpl.Execute(buf, cfg)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantDirectives := []string{
+		"split_clients $otel_trace_id $ing_default_cafe_ingress_otel_sample_25 {",
+		"25% on;",
+		"otel_trace $ing_default_cafe_ingress_otel_sample_25;",
+		`otel_span_name "cafe ${request_method}";`,
+		`otel_span_attr http.user_agent "$http_user_agent";`,
+	}
+
+	ingConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(ingConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestExecuteTemplate_ForIngressForNGINXWithTracing(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXIngressTmpl(t)
+	buf := &bytes.Buffer{}
+
+	server := ingressCfg.Servers[0]
+	location := server.Locations[0]
+	location.Tracing = &version2.Tracing{
+		Trace:    "$ing_default_cafe_ingress_otel_sample_25",
+		SpanName: "cafe ${request_method}",
+		SpanAttributes: []version2.SpanAttribute{
+			{Name: "http.user_agent", Value: "$http_user_agent"},
+		},
+	}
+	server.Locations = []Location{location}
+	cfg := ingressCfg
+	cfg.Servers = []Server{server}
+	cfg.OtelSamplers = []OtelSampler{
+		{Variable: "$ing_default_cafe_ingress_otel_sample_25", Percentage: 25},
+	}
+
+	err := tmpl.Execute(buf, cfg)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantDirectives := []string{
+		"split_clients $otel_trace_id $ing_default_cafe_ingress_otel_sample_25 {",
+		"25% on;",
+		"otel_trace $ing_default_cafe_ingress_otel_sample_25;",
+		`otel_span_name "cafe ${request_method}";`,
+		`otel_span_attr http.user_agent "$http_user_agent";`,
+	}
+
+	ingConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(ingConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestExecuteTemplate_ForIngressForNGINXPlusWithDrainingUpstreamServer(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXPlusIngressTmpl(t)
+	buf := &bytes.Buffer{}
+
+	upstream := testUpstream
+	upstream.UpstreamServers = []UpstreamServer{
+		{
+			Address:     "10.0.0.1:8080",
+			MaxFails:    1,
+			FailTimeout: "10s",
+		},
+		{
+			Address:     "10.0.0.2:8080",
+			MaxFails:    1,
+			FailTimeout: "10s",
+			Drain:       true,
+		},
+	}
+	cfg := ingressCfg
+	cfg.Upstreams = []Upstream{upstream}
+
+	err := tmpl.Execute(buf, cfg)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantDirectives := []string{
+		"server 10.0.0.1:8080 max_fails=1 fail_timeout=10s max_conns=0;",
+		"server 10.0.0.2:8080 max_fails=1 fail_timeout=10s max_conns=0 drain;",
+	}
+
+	ingConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(ingConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestExecuteTemplate_ForIngressForNGINXWithDrainingUpstreamServer(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXIngressTmpl(t)
+	buf := &bytes.Buffer{}
+
+	upstream := testUpstream
+	upstream.UpstreamServers = []UpstreamServer{
+		{
+			Address:     "10.0.0.1:8080",
+			MaxFails:    1,
+			FailTimeout: "10s",
+		},
+		{
+			Address:     "10.0.0.2:8080",
+			MaxFails:    1,
+			FailTimeout: "10s",
+			Drain:       true,
+		},
+	}
+	cfg := ingressCfg
+	cfg.Upstreams = []Upstream{upstream}
+
+	err := tmpl.Execute(buf, cfg)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantDirectives := []string{
+		"server 10.0.0.1:8080 max_fails=1 fail_timeout=10s max_conns=0;",
+		"server 10.0.0.2:8080 max_fails=1 fail_timeout=10s max_conns=0 backup;",
+	}
+
+	ingConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(ingConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestExecuteTemplate_ForIngressForNGINXWithSessionAffinity(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXIngressTmpl(t)
+	buf := &bytes.Buffer{}
+
+	upstream := testUpstream
+	upstream.LBMethod = "hash $test_session_affinity consistent"
+	upstream.SessionAffinity = &SessionAffinity{
+		Maps: []version2.Map{
+			{
+				Source:   "$cookie_srv_id",
+				Variable: "$test_session_affinity",
+				Parameters: []version2.Parameter{
+					{Value: `""`, Result: "$request_id"},
+					{Value: "default", Result: "$cookie_srv_id"},
+				},
+			},
+			{
+				Source:   "$cookie_srv_id",
+				Variable: "$test_session_affinity_cookie",
+				Parameters: []version2.Parameter{
+					{Value: `""`, Result: `"srv_id=$request_id; Path=/tea"`},
+					{Value: "default", Result: `""`},
+				},
+			},
+		},
+		CookieVariable: "$test_session_affinity_cookie",
+	}
+	server := ingressCfg.Servers[0]
+	server.RequestIDHeader = "X-Request-ID"
+	server.Locations = []Location{server.Locations[0]}
+	server.Locations[0].Upstream = upstream
+	cfg := ingressCfg
+	cfg.Upstreams = []Upstream{upstream}
+	cfg.Servers = []Server{server}
+
+	err := tmpl.Execute(buf, cfg)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantDirectives := []string{
+		"map $cookie_srv_id $test_session_affinity {",
+		`"" "srv_id=$request_id; Path=/tea";`,
+		"hash $test_session_affinity consistent;",
+		`add_header Set-Cookie "$test_session_affinity_cookie";`,
+		"add_header X-Request-ID $correlation_id always;",
+	}
+
+	ingConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(ingConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestExecuteTemplate_ForIngressForNGINXPlusWithOtherZoneUpstreamServer(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXPlusIngressTmpl(t)
+	buf := &bytes.Buffer{}
+
+	upstream := testUpstream
+	upstream.LBMethod = "least_conn"
+	upstream.UpstreamServers = []UpstreamServer{
+		{
+			Address:     "10.0.0.1:8080",
+			MaxFails:    1,
+			FailTimeout: "10s",
+		},
+		{
+			Address:     "10.0.1.1:8080",
+			MaxFails:    1,
+			FailTimeout: "10s",
+			Backup:      true,
+		},
+	}
+	cfg := ingressCfg
+	cfg.Upstreams = []Upstream{upstream}
+
+	err := tmpl.Execute(buf, cfg)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantDirectives := []string{
+		"server 10.0.0.1:8080 max_fails=1 fail_timeout=10s max_conns=0;",
+		"server 10.0.1.1:8080 max_fails=1 fail_timeout=10s max_conns=0 backup;",
+	}
+
+	ingConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(ingConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestExecuteTemplate_ForIngressForNGINXWithOtherZoneUpstreamServer(t *testing.T) {
+	t.Parallel()
+
+	tmpl := newNGINXIngressTmpl(t)
+	buf := &bytes.Buffer{}
+
+	upstream := testUpstream
+	upstream.LBMethod = "least_conn"
+	upstream.UpstreamServers = []UpstreamServer{
+		{
+			Address:     "10.0.0.1:8080",
+			MaxFails:    1,
+			FailTimeout: "10s",
+		},
+		{
+			Address:     "10.0.1.1:8080",
+			MaxFails:    1,
+			FailTimeout: "10s",
+			Backup:      true,
+		},
+	}
+	cfg := ingressCfg
+	cfg.Upstreams = []Upstream{upstream}
+
+	err := tmpl.Execute(buf, cfg)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantDirectives := []string{
+		"server 10.0.0.1:8080 max_fails=1 fail_timeout=10s max_conns=0;",
+		"server 10.0.1.1:8080 max_fails=1 fail_timeout=10s max_conns=0 backup;",
+	}
+
+	ingConf := buf.String()
+	for _, want := range wantDirectives {
+		if !strings.Contains(ingConf, want) {
+			t.Errorf("want %q in generated config", want)
+		}
+	}
+	snaps.MatchSnapshot(t, buf.String())
+}
+
 func TestExecuteTemplate_ForIngressForNGINXWithProxySetHeadersAnnotationWithDefaultValue(t *testing.T) {
 	t.Parallel()
 
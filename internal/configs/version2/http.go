@@ -31,6 +31,7 @@ type VirtualServerConfig struct {
 	Upstreams               []Upstream
 	DynamicSSLReloadEnabled bool
 	StaticSSLPath           string
+	LogFormat               *LogFormat
 }
 
 // AuthJWTClaimSet defines the values for the `auth_jwt_claim_set` directive
@@ -61,6 +62,10 @@ type Upstream struct {
 // UpstreamServer defines an upstream server.
 type UpstreamServer struct {
 	Address string
+	// Drain marks a terminating endpoint. NGINX Plus drains it, NGINX uses it as a backup server.
+	Drain bool
+	// Backup marks an endpoint in another zone when topology-aware routing is enabled.
+	Backup bool
 }
 
 // Server defines a server.
@@ -109,6 +114,69 @@ type Server struct {
 	VSName                    string
 	DisableIPV6               bool
 	Gunzip                    bool
+	Maintenance               *Maintenance
+	RedirectMaps              []RedirectMap
+	RequestID                 *RequestID
+	AccessLog                 *AccessLog
+	Tracing                   *Tracing
+	HTTP3                     *HTTP3
+}
+
+// HTTP3 defines the QUIC listeners of a TLS server.
+type HTTP3 struct {
+	Port      int
+	ReusePort bool
+}
+
+// Maintenance defines the maintenance mode of a server.
+type Maintenance struct {
+	Variable      string
+	AllowVariable string
+	Allow         []string
+	Code          int
+	LocationName  string
+}
+
+// RedirectMap defines a variable holding the targets of the bulk redirects with the same status code.
+type RedirectMap struct {
+	Variable string
+	Code     int
+}
+
+// RequestID defines the header that carries the request ID and the variable holding its value.
+type RequestID struct {
+	Header   string
+	Variable string
+}
+
+// LogFormat defines a log format of a VirtualServer.
+type LogFormat struct {
+	Name     string
+	Escaping string
+	Format   []string
+}
+
+// AccessLog defines the access log of a server or a location.
+// Condition is the variable that enables the logging of a request.
+type AccessLog struct {
+	Off         bool
+	Destination string
+	Format      string
+	Condition   string
+}
+
+// Tracing defines the OpenTelemetry tracing of a server or a location.
+// Trace is on, off or the variable that enables the tracing of a request.
+type Tracing struct {
+	Trace          string
+	SpanName       string
+	SpanAttributes []SpanAttribute
+}
+
+// SpanAttribute defines an attribute of a span.
+type SpanAttribute struct {
+	Name  string
+	Value string
 }
 
 // SSL defines SSL configuration for a server.
@@ -230,6 +298,8 @@ type Location struct {
 	APIKey                   *APIKey
 	WAF                      *WAF
 	Dos                      *Dos
+	AccessLog                *AccessLog
+	Tracing                  *Tracing
 	PoliciesErrorReturn      *Return
 	Cache                    *Cache
 	ServiceName              string
@@ -237,6 +307,29 @@ type Location struct {
 	VSRName                  string
 	VSRNamespace             string
 	GRPCPass                 string
+	GRPCWeb                  *GRPCWeb
+	WebSocket                *WebSocket
+	WebSocketUpstream        string
+}
+
+// GRPCWeb defines the translation of gRPC-Web requests to gRPC in a location.
+// TextPath is the internal location that translates the gRPC-Web text requests of the location,
+// and Text is true for that internal location.
+type GRPCWeb struct {
+	AllowOrigin string
+	TextPath    string
+	Text        bool
+	BufferSize  string
+}
+
+// WebSocket defines the internal location that handles the requests of a location that upgrade the connection.
+type WebSocket struct {
+	Path             string
+	Upstream         string
+	ProxyPass        string
+	Rewrites         []string
+	ProxyReadTimeout string
+	ProxySendTimeout string
 }
 
 // ReturnLocation defines a location for returning a fixed response.
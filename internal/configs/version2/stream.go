@@ -6,6 +6,9 @@ type TransportServerConfig struct {
 	Upstreams               []StreamUpstream
 	StreamSnippets          []string
 	Match                   *Match
+	LimitConnZones          []StreamLimitConnZone
+	Maps                    []Map
+	SplitClients            []SplitClient
 	DisableIPV6             bool
 	DynamicSSLReloadEnabled bool
 	StaticSSLPath           string
@@ -41,11 +44,14 @@ type StreamServer struct {
 	TLSPassthrough           bool
 	UnixSocket               string
 	Port                     int
+	EndPort                  int
 	UDP                      bool
 	StatusZone               string
 	ProxyRequests            *int
 	ProxyResponses           *int
 	ProxyPass                string
+	ProxyProtocol            bool
+	ProxySSL                 *StreamProxySSL
 	Name                     string
 	Namespace                string
 	ProxyTimeout             string
@@ -53,31 +59,84 @@ type StreamServer struct {
 	ProxyNextUpstream        bool
 	ProxyNextUpstreamTimeout string
 	ProxyNextUpstreamTries   int
+	ProxyDownloadRate        string
+	ProxyUploadRate          string
 	HealthCheck              *StreamHealthCheck
 	ServerSnippets           []string
 	DisableIPV6              bool
 	SSL                      *StreamSSL
 	IPv4                     string
 	IPv6                     string
+	AccessLog                *AccessLog
+	Allow                    []string
+	Deny                     []string
+	DenyAll                  bool
+	LimitConns               []StreamLimitConn
+	LimitConnOptions         StreamLimitConnOptions
 }
 
-// StreamSSL defines SSL configuration for a server.
-type StreamSSL struct {
-	Enabled        bool
+// StreamProxySSL defines the TLS configuration for the connections to the upstream servers.
+type StreamProxySSL struct {
 	Certificate    string
 	CertificateKey string
+	TrustedCert    string
+	VerifyServer   bool
+	VerifyDepth    int
+	ServerName     bool
+	SSLName        string
+}
+
+// StreamLimitConnZone defines a shared memory zone for limiting the number of connections per key.
+type StreamLimitConnZone struct {
+	ZoneName string
+	Key      string
+	ZoneSize string
+}
+
+// StreamLimitConn defines a limit on the number of connections.
+type StreamLimitConn struct {
+	ZoneName    string
+	Connections int
+}
+
+// StreamLimitConnOptions defines the options for the limit_conn directives of a server.
+type StreamLimitConnOptions struct {
+	DryRun   bool
+	LogLevel string
+}
+
+// StreamSSL defines SSL configuration for a server.
+type StreamSSL struct {
+	Enabled             bool
+	Certificate         string
+	CertificateKey      string
+	CertificateVariable string
+	Certificates        []StreamSSLCertificate
+	ClientCert          string
+	ClientCrl           string
+	VerifyClient        string
+	VerifyDepth         int
+	ALPN                []string
+}
+
+// StreamSSLCertificate defines a certificate chosen by the server name requested by a client.
+type StreamSSLCertificate struct {
+	ServerName  string
+	Certificate string
 }
 
 // StreamHealthCheck defines a health check for a StreamUpstream in a StreamServer.
 type StreamHealthCheck struct {
-	Enabled  bool
-	Interval string
-	Port     int
-	Passes   int
-	Jitter   string
-	Fails    int
-	Timeout  string
-	Match    string
+	Enabled    bool
+	Interval   string
+	Port       int
+	Passes     int
+	Jitter     string
+	Fails      int
+	Timeout    string
+	Match      string
+	Mandatory  bool
+	Persistent bool
 }
 
 // Match defines a match block for a health check
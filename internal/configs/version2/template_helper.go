@@ -29,6 +29,8 @@ type listen struct {
 	tls           bool
 	proxyProtocol bool
 	udp           bool
+	quic          bool
+	reusePort     bool
 	ipType        ipType
 }
 
@@ -117,6 +119,26 @@ func buildListenerDirectives(listenerType protocol, s Server, port string) strin
 				ipType:        ipv6,
 			})
 		}
+		if s.HTTP3 != nil {
+			directives += spacing
+			directives += buildListenDirective(listen{
+				ipAddress: s.HTTPSIPv4,
+				port:      port,
+				quic:      true,
+				reusePort: s.HTTP3.ReusePort,
+				ipType:    ipv4,
+			})
+			if !s.DisableIPV6 {
+				directives += spacing
+				directives += buildListenDirective(listen{
+					ipAddress: s.HTTPSIPv6,
+					port:      port,
+					quic:      true,
+					reusePort: s.HTTP3.ReusePort,
+					ipType:    ipv6,
+				})
+			}
+		}
 	}
 
 	return directives
@@ -167,6 +189,14 @@ func buildListenDirective(l listen) string {
 		directive += " udp"
 	}
 
+	if l.quic {
+		directive += " quic"
+	}
+
+	if l.reusePort {
+		directive += " reuseport"
+	}
+
 	directive += ";\n"
 	return directive
 }
@@ -182,6 +212,9 @@ func makeHTTPSListener(s Server) string {
 func makeTransportListener(s StreamServer) string {
 	var directives string
 	port := strconv.Itoa(s.Port)
+	if s.EndPort > 0 {
+		port += "-" + strconv.Itoa(s.EndPort)
+	}
 
 	directives += buildListenDirective(listen{
 		ipAddress:     s.IPv4,
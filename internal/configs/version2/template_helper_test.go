@@ -473,6 +473,47 @@ func TestMakeHTTPSListenerWithCustomIPV6(t *testing.T) {
 	}
 }
 
+func TestMakeHTTPSListenerWithHTTP3(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		server   Server
+		expected string
+	}{
+		{server: Server{
+			CustomListeners: false,
+			DisableIPV6:     true,
+			HTTP3:           &HTTP3{Port: 443},
+		}, expected: "listen 443 ssl;\n    listen 443 quic;\n"},
+		{server: Server{
+			CustomListeners: false,
+			DisableIPV6:     false,
+			ProxyProtocol:   true,
+			HTTP3:           &HTTP3{Port: 443},
+		}, expected: "listen 443 ssl proxy_protocol;\n    listen [::]:443 ssl proxy_protocol;\n    listen 443 quic;\n    listen [::]:443 quic;\n"},
+		{server: Server{
+			CustomListeners: true,
+			HTTPSPort:       8443,
+			DisableIPV6:     true,
+			HTTP3:           &HTTP3{Port: 8443, ReusePort: true},
+		}, expected: "listen 8443 ssl;\n    listen 8443 quic reuseport;\n"},
+		{server: Server{
+			CustomListeners: true,
+			HTTPSPort:       8443,
+			HTTPSIPv4:       "127.0.0.1",
+			HTTPSIPv6:       "::1",
+			HTTP3:           &HTTP3{Port: 8443},
+		}, expected: "listen 127.0.0.1:8443 ssl;\n    listen [::1]:8443 ssl;\n    listen 127.0.0.1:8443 quic;\n    listen [::1]:8443 quic;\n"},
+	}
+
+	for _, tc := range testCases {
+		got := makeHTTPSListener(tc.server)
+		if got != tc.expected {
+			t.Errorf("Function generated wrong config, got %v but expected %v.", got, tc.expected)
+		}
+	}
+}
+
 func TestMakeTransportListener(t *testing.T) {
 	t.Parallel()
 
@@ -529,6 +570,15 @@ func TestMakeTransportListener(t *testing.T) {
 			DisableIPV6: false,
 			Port:        5353,
 		}, expected: "listen 5353 ssl udp;\n    listen [::]:5353 ssl udp;\n"},
+		{server: StreamServer{
+			UDP: false,
+			SSL: &StreamSSL{
+				Enabled: false,
+			},
+			DisableIPV6: false,
+			Port:        30000,
+			EndPort:     30100,
+		}, expected: "listen 30000-30100;\n    listen [::]:30000-30100;\n"},
 	}
 
 	for _, tc := range testCases {
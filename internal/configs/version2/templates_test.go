@@ -93,91 +93,90 @@ func TestExecuteVirtualServerTemplate_RendersTemplateWithServerGunzipNotSet(t *t
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersTemplateWithRateLimitJWTClaim(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithMaintenance(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithRateLimitJWTClaim)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithMaintenance)
 	if err != nil {
 		t.Error(err)
 	}
-	wantedStrings := []string{
-		"auth_jwt_claim_set",
-		"$rate_limit_default_webapp_group_consumer_group_type",
-		"$jwt_default_webapp_group_consumer_group_type",
-		"Group1",
-		"Group2",
-		"Group3",
-		"$http_bronze",
-		"$http_silver",
-		"$http_gold",
+	wantStrings := []string{
+		"keyval_zone zone=vs_default_cafe_keyval_zone_maintenance:32k state=/etc/nginx/state_files/vs_default_cafe_keyval_zone_maintenance.json;",
+		`keyval "vs_default_cafe_keyval_key_maintenance" $vs_default_cafe_keyval_maintenance zone=vs_default_cafe_keyval_zone_maintenance;`,
+		"geo $vs_default_cafe_maintenance_allow {",
+		"10.0.0.0/8 1;",
+		"error_page 418 =503 @maintenance;",
+		"if ($vs_default_cafe_maintenance) {",
+		"location @maintenance {",
 	}
-	for _, value := range wantedStrings {
-		if !bytes.Contains(got, []byte(value)) {
-			t.Errorf("didn't get `%s`", value)
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
 		}
 	}
-
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersTemplateWithRateLimitVariableAPIKey(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithRedirectMap(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithRateLimitVariableAPIKey)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithRedirectMapKeyVal)
 	if err != nil {
 		t.Error(err)
 	}
-	wantedStrings := []string{
-		"limit_req_zone $pol_rl_default_basic_rate_limit_policy_default_cafe",
-		"limit_req_zone $pol_rl_default_premium_rate_limit_policy_default_cafe",
-		"$pol_rl_default_basic_rate_limit_policy_default_cafe {",
-		"$pol_rl_default_premium_rate_limit_policy_default_cafe {",
-		"$rl_default_cafe_variable_apikey_client_name",
-		"default rl_default_cafe_match_basic_rate_limit_policy",
-		"\"basic\" rl_default_cafe_match_basic_rate_limit_policy",
-		"\"premium\" rl_default_cafe_match_premium_rate_limit_policy",
-		"rl_default_cafe_match_basic_rate_limit_policy Val$apikey_client_name",
-		"rl_default_cafe_match_premium_rate_limit_policy Val$apikey_client_name",
-		"map $apikey_auth_token",
-		"map $apikey_client_name",
+	wantStrings := []string{
+		"keyval_zone zone=vs_default_cafe_keyval_zone_redirect_map:5m state=/etc/nginx/state_files/vs_default_cafe_keyval_zone_redirect_map.json;",
+		"keyval $uri $vs_default_cafe_redirect_map zone=vs_default_cafe_keyval_zone_redirect_map;",
+		"map $vs_default_cafe_redirect_map $vs_default_cafe_redirect_map_301 {",
+		"if ($vs_default_cafe_redirect_map_301) {",
+		"return 301 $vs_default_cafe_redirect_map_301;",
 	}
-	for _, value := range wantedStrings {
-		if !bytes.Contains(got, []byte(value)) {
-			t.Errorf("didn't get `%s`", value)
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
 		}
 	}
-
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersTemplateWithSessionCookieSameSite(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithRedirectMap(t *testing.T) {
 	t.Parallel()
-	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithSessionCookieSameSite)
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithRedirectMap)
 	if err != nil {
 		t.Error(err)
 	}
-	if !bytes.Contains(got, []byte("samesite=strict")) {
-		t.Error("want `samesite=strict` in generated template")
+	wantStrings := []string{
+		"map $uri $vs_default_cafe_redirect_map {",
+		`"/old-tea" "301 /tea";`,
+		`"~^302 (.+)$" $1;`,
+		"if ($vs_default_cafe_redirect_map_301) {",
+		"return 301 $vs_default_cafe_redirect_map_301;",
+		"return 302 $vs_default_cafe_redirect_map_302;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
 	}
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListener(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithRequestID(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCustomListener)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithRequestID)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 8082",
-		"listen [::]:8082",
-		"listen 8443 ssl",
-		"listen [::]:8443 ssl",
+		"map $http_x_correlation_id $vs_default_cafe_request_id {",
+		`"" $request_id;`,
+		"add_header X-Correlation-ID $vs_default_cafe_request_id always;",
+		"proxy_set_header X-Correlation-ID $vs_default_cafe_request_id;",
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
@@ -188,18 +187,18 @@ func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListener(t *testi
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerIP(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithRequestID(t *testing.T) {
 	t.Parallel()
-	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCustomListenerIP)
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithRequestID)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 127.0.0.1:8082",
-		"listen [::1]:8082",
-		"listen 127.0.0.2:8443 ssl",
-		"listen [::2]:8443 ssl",
+		"map $http_x_correlation_id $vs_default_cafe_request_id {",
+		`"" $request_id;`,
+		"add_header X-Correlation-ID $vs_default_cafe_request_id always;",
+		"proxy_set_header X-Correlation-ID $vs_default_cafe_request_id;",
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
@@ -210,23 +209,46 @@ func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerIP(t *tes
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPIPV4Only(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersTemplateWithRequestIDLogFormat(t *testing.T) {
 	t.Parallel()
-	vsCfg := virtualServerCfgWithCustomListenerIP
 
-	vsCfg.Server.HTTPIPv6 = ""
-	vsCfg.Server.HTTPSIPv6 = ""
-	vsCfg.Server.HTTPSIPv4 = ""
-	vsCfg.Server.HTTPSPort = 0
+	cfg := virtualServerCfgWithRequestID
+	cfg.LogFormat = &LogFormat{
+		Name:     "vs_default_cafe_request_id",
+		Escaping: "json",
+		Format:   []string{`{"request":"$request",`, ` "request_id":"$vs_default_cafe_request_id"}`},
+	}
+	cfg.Server.AccessLog = &AccessLog{Destination: "/dev/stdout", Format: "vs_default_cafe_request_id"}
 
+	wantStrings := []string{
+		`log_format vs_default_cafe_request_id escape=json '{"request":"$request",' ' "request_id":"$vs_default_cafe_request_id"}';`,
+		"access_log /dev/stdout vs_default_cafe_request_id;",
+	}
+
+	for _, executor := range []*TemplateExecutor{newTmplExecutorNGINX(t), newTmplExecutorNGINXPlus(t)} {
+		got, err := executor.ExecuteVirtualServerTemplate(&cfg)
+		if err != nil {
+			t.Error(err)
+		}
+		for _, want := range wantStrings {
+			if !bytes.Contains(got, []byte(want)) {
+				t.Errorf("want `%s` in generated template", want)
+			}
+		}
+	}
+}
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithHTTP3(t *testing.T) {
+	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&vsCfg)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithHTTP3)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 127.0.0.1:8082",
-		"listen [::]:8082",
+		"listen 8443 ssl;",
+		"listen 8443 quic reuseport;",
+		"listen [::]:8443 quic reuseport;",
+		`add_header Alt-Svc 'h3=":8443"; ma=86400' always;`,
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
@@ -237,23 +259,18 @@ func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPIPV4O
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPIPV6Only(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithHTTP3(t *testing.T) {
 	t.Parallel()
-	vsCfg := virtualServerCfgWithCustomListenerIP
-
-	vsCfg.Server.HTTPIPv4 = ""
-	vsCfg.Server.HTTPSIPv6 = ""
-	vsCfg.Server.HTTPSIPv4 = ""
-	vsCfg.Server.HTTPSPort = 0
-
-	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&vsCfg)
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithHTTP3)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 8082",
-		"listen [::1]:8082",
+		"listen 8443 ssl;",
+		"listen 8443 quic reuseport;",
+		"listen [::]:8443 quic reuseport;",
+		`add_header Alt-Svc 'h3=":8443"; ma=86400' always;`,
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
@@ -264,23 +281,26 @@ func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPIPV6O
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPSIPV4Only(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithGRPCWeb(t *testing.T) {
 	t.Parallel()
-	vsCfg := virtualServerCfgWithCustomListenerIP
-
-	vsCfg.Server.HTTPIPv6 = ""
-	vsCfg.Server.HTTPSIPv6 = ""
-	vsCfg.Server.HTTPIPv4 = ""
-	vsCfg.Server.HTTPPort = 0
-
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&vsCfg)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithGRPCWeb)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 127.0.0.2:8443 ssl",
-		"listen [::]:8443 ssl",
+		`js_var $grpc_web_allow_origin "https://app.example.com";`,
+		"js_header_filter grpc_web.headerFilter;",
+		"js_body_filter grpc_web.bodyFilter buffer_type=buffer;",
+		"grpc_set_header Content-Type $grpc_web_content_type;",
+		"grpc_set_header Content-Length $grpc_web_content_length;",
+		"grpc_set_header TE trailers;",
+		"if ($request_method = OPTIONS) {",
+		"grpc_pass grpc://vs_default_cafe_greeter;",
+		"subrequest_output_buffer_size 1m;",
+		"rewrite ^ /internal_location_grpc_web_text_0 last;",
+		"client_body_in_single_buffer on;",
+		"js_content grpc_web.textContent;",
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
@@ -291,23 +311,26 @@ func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPSIPV4
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPSIPV6Only(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithGRPCWeb(t *testing.T) {
 	t.Parallel()
-	vsCfg := virtualServerCfgWithCustomListenerIP
-
-	vsCfg.Server.HTTPIPv6 = ""
-	vsCfg.Server.HTTPIPv4 = ""
-	vsCfg.Server.HTTPSIPv4 = ""
-	vsCfg.Server.HTTPPort = 0
-
-	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&vsCfg)
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithGRPCWeb)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 8443 ssl",
-		"listen [::2]:8443 ssl",
+		`js_var $grpc_web_allow_origin "https://app.example.com";`,
+		"js_header_filter grpc_web.headerFilter;",
+		"js_body_filter grpc_web.bodyFilter buffer_type=buffer;",
+		"grpc_set_header Content-Type $grpc_web_content_type;",
+		"grpc_set_header Content-Length $grpc_web_content_length;",
+		"grpc_set_header TE trailers;",
+		"if ($request_method = OPTIONS) {",
+		"grpc_pass grpc://vs_default_cafe_greeter;",
+		"subrequest_output_buffer_size 1m;",
+		"rewrite ^ /internal_location_grpc_web_text_0 last;",
+		"client_body_in_single_buffer on;",
+		"js_content grpc_web.textContent;",
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
@@ -318,280 +341,262 @@ func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPSIPV6
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPOnly(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithWebSocket(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCustomListenerHTTPOnly)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithWebSocket)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 8082",
-		"listen [::]:8082",
-	}
-	unwantStrings := []string{
-		"listen 8443 ssl",
-		"listen [::]:8443 ssl",
+		"if ($http_upgrade) {",
+		"rewrite ^ /internal_location_websocket_0 last;",
+		"js_var $websocket_upstream vs_default_cafe_chat;",
+		"js_header_filter websocket.headerFilter;",
+		"access_log /dev/null main if=$websocket_closed;",
+		"proxy_read_timeout 1h;",
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
 			t.Errorf("want `%s` in generated template", want)
 		}
 	}
-	for _, want := range unwantStrings {
-		if bytes.Contains(got, []byte(want)) {
-			t.Errorf("unwant  `%s` in generated template", want)
-		}
-	}
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPSOnly(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithWebSocket(t *testing.T) {
 	t.Parallel()
-	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCustomListenerHTTPSOnly)
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithWebSocket)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 8443 ssl",
-		"listen [::]:8443 ssl",
-	}
-	unwantStrings := []string{
-		"listen 8082",
-		"listen [::]:8082",
+		"if ($http_upgrade) {",
+		"rewrite ^ /internal_location_websocket_0 last;",
+		"js_var $websocket_upstream vs_default_cafe_chat;",
+		"js_header_filter websocket.headerFilter;",
+		"access_log /dev/null main if=$websocket_closed;",
+		"proxy_read_timeout 1h;",
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
 			t.Errorf("want `%s` in generated template", want)
 		}
 	}
-	for _, want := range unwantStrings {
-		if bytes.Contains(got, []byte(want)) {
-			t.Errorf("want no `%s` in generated template", want)
-		}
-	}
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithHTTP2On(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithAccessLog(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithHTTP2On)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithAccessLog)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 443 ssl proxy_protocol;",
-		"listen [::]:443 ssl proxy_protocol;",
-		"http2 on;",
+		`split_clients "${msec}${request_id}" $vs_default_cafe_access_log_sample_10 {`,
+		"access_log /dev/stdout main if=$vs_default_cafe_access_log_sample_10;",
+		"access_log off;",
+		"access_log syslog:server=logs.example.com:514 main if=$vs_default_cafe_access_log_5xx;",
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
 			t.Errorf("want `%s` in generated template", want)
 		}
 	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
 
-	unwantStrings := []string{
-		"listen 443 ssl http2 proxy_protocol;",
-		"listen [::]:443 ssl http2 proxy_protocol;",
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithAccessLog(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithAccessLog)
+	if err != nil {
+		t.Error(err)
 	}
-
-	for _, want := range unwantStrings {
-		if bytes.Contains(got, []byte(want)) {
-			t.Errorf("unwant  `%s` in generated template", want)
+	wantStrings := []string{
+		`split_clients "${msec}${request_id}" $vs_default_cafe_access_log_sample_10 {`,
+		"access_log /dev/stdout main if=$vs_default_cafe_access_log_sample_10;",
+		"access_log off;",
+		"access_log syslog:server=logs.example.com:514 main if=$vs_default_cafe_access_log_5xx;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
 		}
 	}
 	snaps.MatchSnapshot(t, string(got))
-
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithHTTP2Off(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithTracing(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithHTTP2Off)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithTracing)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 443 ssl proxy_protocol;",
-		"listen [::]:443 ssl proxy_protocol;",
+		"split_clients $otel_trace_id $vs_default_cafe_otel_sample_25 {",
+		"otel_trace $vs_default_cafe_otel_sample_25;",
+		`otel_span_attr team "cafe";`,
+		"otel_trace off;",
+		"otel_trace on;",
+		`otel_span_name "payments ${request_method}";`,
+		`otel_span_attr http.user_agent "${http_user_agent}";`,
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
 			t.Errorf("want `%s` in generated template", want)
 		}
 	}
-
-	unwantStrings := []string{
-		"http2 on;",
-	}
-
-	for _, want := range unwantStrings {
-		if bytes.Contains(got, []byte(want)) {
-			t.Errorf("unwant  `%s` in generated template", want)
-		}
-	}
 	snaps.MatchSnapshot(t, string(got))
-
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithHTTP2On(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithTracing(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINX(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithHTTP2On)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithTracing)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 443 ssl proxy_protocol;",
-		"listen [::]:443 ssl proxy_protocol;",
-		"http2 on;",
+		"split_clients $otel_trace_id $vs_default_cafe_otel_sample_25 {",
+		"otel_trace $vs_default_cafe_otel_sample_25;",
+		`otel_span_attr team "cafe";`,
+		"otel_trace off;",
+		"otel_trace on;",
+		`otel_span_name "payments ${request_method}";`,
+		`otel_span_attr http.user_agent "${http_user_agent}";`,
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
 			t.Errorf("want `%s` in generated template", want)
 		}
 	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
 
-	unwantStrings := []string{
-		"listen 443 ssl http2 proxy_protocol;",
-		"listen [::]:443 ssl http2 proxy_protocol;",
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithDrainingUpstreamServer(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithDrainingUpstreamServer)
+	if err != nil {
+		t.Error(err)
 	}
-
-	for _, want := range unwantStrings {
-		if bytes.Contains(got, []byte(want)) {
-			t.Errorf("unwant  `%s` in generated template", want)
+	wantStrings := []string{
+		"server 10.0.0.1:8080 max_fails=1 fail_timeout=10s max_conns=0;",
+		"server 10.0.0.2:8080 max_fails=1 fail_timeout=10s max_conns=0 drain;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
 		}
 	}
 	snaps.MatchSnapshot(t, string(got))
-
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithHTTP2Off(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithDrainingUpstreamServer(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINX(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithHTTP2Off)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithDrainingUpstreamServer)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 443 ssl proxy_protocol;",
-		"listen [::]:443 ssl proxy_protocol;",
+		"server 10.0.0.1:8080 max_fails=1 fail_timeout=10s max_conns=0;",
+		"server 10.0.0.2:8080 max_fails=1 fail_timeout=10s max_conns=0 backup;",
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
 			t.Errorf("want `%s` in generated template", want)
 		}
 	}
-
-	unwantStrings := []string{
-		"http2 on;",
-	}
-
-	for _, want := range unwantStrings {
-		if bytes.Contains(got, []byte(want)) {
-			t.Errorf("unwant  `%s` in generated template", want)
-		}
-	}
 	snaps.MatchSnapshot(t, string(got))
-
 	t.Log(string(got))
 }
 
-func TestVirtualServerForNginxPlusWithWAFApBundle(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithOtherZoneUpstreamServer(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithWAFApBundle)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithOtherZoneUpstreamServer)
 	if err != nil {
-		t.Errorf("Failed to execute template: %v", err)
+		t.Error(err)
+	}
+	wantStrings := []string{
+		"server 10.0.0.1:8080 max_fails=1 fail_timeout=10s max_conns=0;",
+		"server 10.0.1.1:8080 max_fails=1 fail_timeout=10s max_conns=0 backup;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
 	}
 	snaps.MatchSnapshot(t, string(got))
-
 	t.Log(string(got))
 }
 
-func TestVirtualServerForNginx(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithOtherZoneUpstreamServer(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINX(t)
-	data, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfg)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithOtherZoneUpstreamServer)
 	if err != nil {
-		t.Errorf("Failed to execute template: %v", err)
+		t.Error(err)
 	}
-	snaps.MatchSnapshot(t, string(data))
-	t.Log(string(data))
-}
-
-func TestTransportServerForNginxPlus(t *testing.T) {
-	t.Parallel()
-	executor := newTmplExecutorNGINXPlus(t)
-	data, err := executor.ExecuteTransportServerTemplate(&transportServerCfg)
-	if err != nil {
-		t.Errorf("Failed to execute template: %v", err)
-	}
-	t.Log(string(data))
-}
-
-func TestExecuteTemplateForTransportServerWithResolver(t *testing.T) {
-	t.Parallel()
-	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithResolver)
-	if err != nil {
-		t.Errorf("Failed to execute template: %v", err)
+	wantStrings := []string{
+		"server 10.0.0.1:8080 max_fails=1 fail_timeout=10s max_conns=0;",
+		"server 10.0.1.1:8080 max_fails=1 fail_timeout=10s max_conns=0 backup;",
 	}
-	snaps.MatchSnapshot(t, string(got))
-}
-
-func TestExecuteTemplateForNGINXOSSTransportServerWithSNI(t *testing.T) {
-	t.Parallel()
-	executor := newTmplExecutorNGINX(t)
-	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithSNI)
-	if err != nil {
-		t.Errorf("Failed to execute template: %v", err)
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
 	}
 	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
 }
 
-func TestExecuteTemplateForNGINXPlusTransportServerWithSNI(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithCaptureGroupRewrites(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithSNI)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCaptureGroupRewrites)
 	if err != nil {
-		t.Errorf("Failed to execute template: %v", err)
+		t.Error(err)
+	}
+	wantStrings := []string{
+		`location ~ "^/images/([a-z]+)/(.*)\\.jpg$" {`,
+		`rewrite "^/images/([a-z]+)/(.*)\\.jpg$" "/pictures/$2?category=$1" break;`,
+		`location ~* "^/old/(.*)$" {`,
+		`error_page 418 =301 "${scheme}://${host}/new/$1";`,
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
 	}
 	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
 }
 
-func TestTransportServerForNginx(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithCaptureGroupRewrites(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINX(t)
-	data, err := executor.ExecuteTransportServerTemplate(&transportServerCfg)
-	if err != nil {
-		t.Errorf("Failed to execute template: %v", err)
-	}
-	snaps.MatchSnapshot(t, string(data))
-	t.Log(string(data))
-}
-
-func TestExecuteTemplateForTransportServerWithTCPIPListener(t *testing.T) {
-	t.Parallel()
-	executor := newTmplExecutorNGINXPlus(t)
-	customIPListenerTransportServerCfg := transportServerCfgWithSSL
-	customIPListenerTransportServerCfg.Server.IPv4 = "127.0.0.1"
-	customIPListenerTransportServerCfg.Server.IPv6 = "::1"
-
-	got, err := executor.ExecuteTransportServerTemplate(&customIPListenerTransportServerCfg)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCaptureGroupRewrites)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 127.0.0.1:1234 ssl udp;",
-		"listen [::1]:1234 ssl udp;",
+		`location ~ "^/images/([a-z]+)/(.*)\\.jpg$" {`,
+		`rewrite "^/images/([a-z]+)/(.*)\\.jpg$" "/pictures/$2?category=$1" break;`,
+		`location ~* "^/old/(.*)$" {`,
+		`error_page 418 =301 "${scheme}://${host}/new/$1";`,
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
@@ -602,21 +607,18 @@ func TestExecuteTemplateForTransportServerWithTCPIPListener(t *testing.T) {
 	t.Log(string(got))
 }
 
-func TestExecuteTemplateForTransportServerWithUDPIPListener(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithMaintenance(t *testing.T) {
 	t.Parallel()
-	executor := newTmplExecutorNGINXPlus(t)
-	customIPListenerTransportServerCfg := transportServerCfgWithSSL
-	customIPListenerTransportServerCfg.Server.IPv4 = "127.0.0.1"
-	customIPListenerTransportServerCfg.Server.IPv6 = "::1"
-	customIPListenerTransportServerCfg.Server.UDP = false
-
-	got, err := executor.ExecuteTransportServerTemplate(&customIPListenerTransportServerCfg)
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithMaintenance)
 	if err != nil {
 		t.Error(err)
 	}
 	wantStrings := []string{
-		"listen 127.0.0.1:1234 ssl;",
-		"listen [::1]:1234 ssl;",
+		"geo $vs_default_cafe_maintenance_allow {",
+		"error_page 418 =503 @maintenance;",
+		"if ($vs_default_cafe_maintenance) {",
+		"location @maintenance {",
 	}
 	for _, want := range wantStrings {
 		if !bytes.Contains(got, []byte(want)) {
@@ -627,367 +629,1654 @@ func TestExecuteTemplateForTransportServerWithUDPIPListener(t *testing.T) {
 	t.Log(string(got))
 }
 
-func tsConfig() TransportServerConfig {
-	return TransportServerConfig{
-		Upstreams: []StreamUpstream{
-			{
-				Name: "udp-upstream",
-				Servers: []StreamUpstreamServer{
-					{
-						Address: "10.0.0.20:5001",
-					},
-				},
-			},
-		},
-		Match: &Match{
-			Name:                "match_udp-upstream",
-			Send:                `GET / HTTP/1.0\r\nHost: localhost\r\n\r\n`,
-			ExpectRegexModifier: "~*",
-			Expect:              "200 OK",
-		},
-		Server: StreamServer{
-			Port:                     1234,
-			UDP:                      true,
-			StatusZone:               "udp-app",
-			ProxyRequests:            createPointerFromInt(1),
-			ProxyResponses:           createPointerFromInt(2),
-			ProxyPass:                "udp-upstream",
-			ProxyTimeout:             "10s",
-			ProxyConnectTimeout:      "10s",
-			ProxyNextUpstream:        true,
-			ProxyNextUpstreamTimeout: "10s",
-			ProxyNextUpstreamTries:   5,
-			HealthCheck: &StreamHealthCheck{
-				Enabled:  false,
-				Timeout:  "5s",
-				Jitter:   "0",
-				Port:     8080,
-				Interval: "5s",
-				Passes:   1,
-				Fails:    1,
-				Match:    "match_udp-upstream",
-			},
-		},
-	}
-}
-
-func TestExecuteTemplateForTransportServerWithBackupServerForNGINXPlus(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersTemplateWithRateLimitJWTClaim(t *testing.T) {
 	t.Parallel()
-
-	tsCfg := tsConfig()
-	tsCfg.Upstreams[0].BackupServers = []StreamUpstreamBackupServer{
-		{
-			Address: "clustertwo.corp.local:8080",
-		},
-	}
-	e := newTmplExecutorNGINXPlus(t)
-	got, err := e.ExecuteTransportServerTemplate(&tsCfg)
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithRateLimitJWTClaim)
 	if err != nil {
 		t.Error(err)
 	}
-
-	want := fmt.Sprintf("server %s resolve backup;", tsCfg.Upstreams[0].BackupServers[0].Address)
-	if !bytes.Contains(got, []byte(want)) {
-		t.Errorf("want backup %q in the transport server config", want)
+	wantedStrings := []string{
+		"auth_jwt_claim_set",
+		"$rate_limit_default_webapp_group_consumer_group_type",
+		"$jwt_default_webapp_group_consumer_group_type",
+		"Group1",
+		"Group2",
+		"Group3",
+		"$http_bronze",
+		"$http_silver",
+		"$http_gold",
+	}
+	for _, value := range wantedStrings {
+		if !bytes.Contains(got, []byte(value)) {
+			t.Errorf("didn't get `%s`", value)
+		}
 	}
+
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestTransportServerWithSSL(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersTemplateWithRateLimitVariableAPIKey(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	data, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithSSL)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithRateLimitVariableAPIKey)
 	if err != nil {
-		t.Errorf("Failed to execute template: %v", err)
+		t.Error(err)
 	}
-	snaps.MatchSnapshot(t, string(data))
-	t.Log(string(data))
-}
-
-func TestTLSPassthroughHosts(t *testing.T) {
-	t.Parallel()
-	executor := newTmplExecutorNGINX(t)
-
-	unixSocketsCfg := TLSPassthroughHostsConfig{
-		"app.example.com": "unix:/var/lib/nginx/passthrough-default_secure-app.sock",
+	wantedStrings := []string{
+		"limit_req_zone $pol_rl_default_basic_rate_limit_policy_default_cafe",
+		"limit_req_zone $pol_rl_default_premium_rate_limit_policy_default_cafe",
+		"$pol_rl_default_basic_rate_limit_policy_default_cafe {",
+		"$pol_rl_default_premium_rate_limit_policy_default_cafe {",
+		"$rl_default_cafe_variable_apikey_client_name",
+		"default rl_default_cafe_match_basic_rate_limit_policy",
+		"\"basic\" rl_default_cafe_match_basic_rate_limit_policy",
+		"\"premium\" rl_default_cafe_match_premium_rate_limit_policy",
+		"rl_default_cafe_match_basic_rate_limit_policy Val$apikey_client_name",
+		"rl_default_cafe_match_premium_rate_limit_policy Val$apikey_client_name",
+		"map $apikey_auth_token",
+		"map $apikey_client_name",
 	}
-
-	data, err := executor.ExecuteTLSPassthroughHostsTemplate(&unixSocketsCfg)
-	if err != nil {
-		t.Errorf("Failed to execute template: %v", err)
+	for _, value := range wantedStrings {
+		if !bytes.Contains(got, []byte(value)) {
+			t.Errorf("didn't get `%s`", value)
+		}
 	}
-	snaps.MatchSnapshot(t, string(data))
-	t.Log(string(data))
+
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplateWithJWKSWithToken(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersTemplateWithSessionCookieSameSite(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithJWTPolicyJWKSWithToken)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithSessionCookieSameSite)
 	if err != nil {
 		t.Error(err)
 	}
-	if !bytes.Contains(got, []byte("token=$http_token")) {
-		t.Error("want `token=$http_token` in generated template")
-	}
-
-	if !bytes.Contains(got, []byte("proxy_ssl_server_name on;")) {
-		t.Error("want `proxy_ssl_server_name on;` in generated template")
-	}
-
-	if !bytes.Contains(got, []byte("proxy_ssl_name sni.idp.spec.example.com;")) {
-		t.Error("want `proxy_ssl_name sni.idp.spec.example.com;` in generated template")
+	if !bytes.Contains(got, []byte("samesite=strict")) {
+		t.Error("want `samesite=strict` in generated template")
 	}
-
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplateWithJWKSWithoutToken(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListener(t *testing.T) {
 	t.Parallel()
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithJWTPolicyJWKSWithoutToken)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCustomListener)
 	if err != nil {
 		t.Error(err)
 	}
-	if bytes.Contains(got, []byte("token=$http_token")) {
-		t.Error("want no `token=$http_token` string in generated template")
+	wantStrings := []string{
+		"listen 8082",
+		"listen [::]:8082",
+		"listen 8443 ssl",
+		"listen [::]:8443 ssl",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
 	}
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplateWithBackupServerNGINXPlus(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerIP(t *testing.T) {
 	t.Parallel()
-
-	externalName := "clustertwo.corp.local:8080"
-	vscfg := vsConfig()
-	vscfg.Upstreams[0].BackupServers = []UpstreamServer{
-		{
-			Address: externalName,
-		},
-	}
-
-	e := newTmplExecutorNGINXPlus(t)
-	got, err := e.ExecuteVirtualServerTemplate(&vscfg)
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCustomListenerIP)
 	if err != nil {
 		t.Error(err)
 	}
-
-	want := fmt.Sprintf("server %s backup resolve;", externalName)
-	if !bytes.Contains(got, []byte(want)) {
-		t.Errorf("want %q in generated template", want)
+	wantStrings := []string{
+		"listen 127.0.0.1:8082",
+		"listen [::1]:8082",
+		"listen 127.0.0.2:8443 ssl",
+		"listen [::2]:8443 ssl",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
 	}
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplateWithAPIKeyPolicyNGINXPlus(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPIPV4Only(t *testing.T) {
 	t.Parallel()
+	vsCfg := virtualServerCfgWithCustomListenerIP
 
-	vscfg := vsConfig()
-	vscfg.Server.APIKey = &APIKey{
-		Header:  []string{"X-header-name", "other-header"},
-		Query:   []string{"myQuery", "myOtherQuery"},
-		MapName: "vs_default_cafe_apikey_policy",
-	}
+	vsCfg.Server.HTTPIPv6 = ""
+	vsCfg.Server.HTTPSIPv6 = ""
+	vsCfg.Server.HTTPSIPv4 = ""
+	vsCfg.Server.HTTPSPort = 0
 
-	e := newTmplExecutorNGINXPlus(t)
-	got, err := e.ExecuteVirtualServerTemplate(&vscfg)
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&vsCfg)
 	if err != nil {
 		t.Error(err)
 	}
-
-	want := "js_var $header_query_value \"${http_x_header_name}${http_other_header}${arg_myQuery}${arg_myOtherQuery}\";"
-
-	if !bytes.Contains(got, []byte(want)) {
-		t.Errorf("want %q in generated template", want)
+	wantStrings := []string{
+		"listen 127.0.0.1:8082",
+		"listen [::]:8082",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
 	}
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplate_WithCustomOIDCRedirectLocation(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPIPV6Only(t *testing.T) {
 	t.Parallel()
+	vsCfg := virtualServerCfgWithCustomListenerIP
+
+	vsCfg.Server.HTTPIPv4 = ""
+	vsCfg.Server.HTTPSIPv6 = ""
+	vsCfg.Server.HTTPSIPv4 = ""
+	vsCfg.Server.HTTPSPort = 0
+
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfg)
+	got, err := executor.ExecuteVirtualServerTemplate(&vsCfg)
 	if err != nil {
 		t.Error(err)
 	}
-
-	expectedCustomLocation := "location = /custom-location {"
-	if !bytes.Contains(got, []byte(expectedCustomLocation)) {
-		t.Errorf("Custom redirectURI should generate location block: %s", expectedCustomLocation)
-	}
-
-	expectedDirectives := []string{
-		"status_zone \"OIDC code exchange\";",
-		"js_content oidc.codeExchange;",
-		"error_page 500 502 504 @oidc_error;",
+	wantStrings := []string{
+		"listen 8082",
+		"listen [::1]:8082",
 	}
-
-	for _, directive := range expectedDirectives {
-		if !bytes.Contains(got, []byte(directive)) {
-			t.Errorf("Custom location should contain directive: %s", directive)
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
 		}
 	}
-
-	expectedRedirVar := `set $redir_location "/custom-location";`
-	if !bytes.Contains(got, []byte(expectedRedirVar)) {
-		t.Errorf("Should set $redir_location to custom value: %s", expectedRedirVar)
-	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplateWithOIDCAndPKCEPolicyNGINXPlus(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPSIPV4Only(t *testing.T) {
 	t.Parallel()
+	vsCfg := virtualServerCfgWithCustomListenerIP
 
-	e := newTmplExecutorNGINXPlus(t)
-	got, err := e.ExecuteVirtualServerTemplate(&virtualServerCfgWithOIDCAndPKCETurnedOn)
+	vsCfg.Server.HTTPIPv6 = ""
+	vsCfg.Server.HTTPSIPv6 = ""
+	vsCfg.Server.HTTPIPv4 = ""
+	vsCfg.Server.HTTPPort = 0
+
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&vsCfg)
 	if err != nil {
 		t.Error(err)
 	}
-
-	want := "include oidc/oidc_pkce_supplements.conf"
-	want2 := "include oidc/oidc.conf;"
-
-	if !bytes.Contains(got, []byte(want)) {
-		t.Errorf("want %q in generated template", want)
+	wantStrings := []string{
+		"listen 127.0.0.2:8443 ssl",
+		"listen [::]:8443 ssl",
 	}
-
-	if !bytes.Contains(got, []byte(want2)) {
-		t.Errorf("want %q in generated template", want2)
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
 	}
-
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplateWithCachePolicyNGINXPlus(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPSIPV6Only(t *testing.T) {
 	t.Parallel()
+	vsCfg := virtualServerCfgWithCustomListenerIP
+
+	vsCfg.Server.HTTPIPv6 = ""
+	vsCfg.Server.HTTPIPv4 = ""
+	vsCfg.Server.HTTPSIPv4 = ""
+	vsCfg.Server.HTTPPort = 0
+
 	executor := newTmplExecutorNGINXPlus(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCachePolicyNGINXPlus)
+	got, err := executor.ExecuteVirtualServerTemplate(&vsCfg)
 	if err != nil {
 		t.Error(err)
 	}
-
-	// Check cache zone declaration
-	expectedCacheZone := "proxy_cache_path /var/cache/nginx/test_cache_full_advanced levels=2:2 keys_zone=test_cache_full_advanced:50m;"
-	if !bytes.Contains(got, []byte(expectedCacheZone)) {
-		t.Errorf("Expected cache zone declaration: %s", expectedCacheZone)
+	wantStrings := []string{
+		"listen 8443 ssl",
+		"listen [::2]:8443 ssl",
 	}
-
-	// Check cache purge configuration for NGINX Plus
-	expectedPurgeGeo := "geo $purge_allowed_test_cache_full_advanced {"
-	if !bytes.Contains(got, []byte(expectedPurgeGeo)) {
-		t.Errorf("Expected purge geo block: %s", expectedPurgeGeo)
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
 	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
 
-	expectedPurgeMap := "map $request_method $cache_purge_test_cache_full_advanced {"
-	if !bytes.Contains(got, []byte(expectedPurgeMap)) {
-		t.Errorf("Expected purge map block: %s", expectedPurgeMap)
+func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPOnly(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCustomListenerHTTPOnly)
+	if err != nil {
+		t.Error(err)
 	}
-
-	// Check server-level cache configuration
-	expectedServerCacheDirectives := []string{
-		"proxy_cache test_cache_full_advanced;",
-		"proxy_cache_key $scheme$proxy_host$request_uri;",
-		"proxy_ignore_headers Cache-Control Expires Set-Cookie Vary X-Accel-Expires;",
-		"proxy_cache_valid 200 2h;",
-		"proxy_cache_valid 404 2h;",
-		"proxy_cache_valid 301 2h;",
-		"proxy_cache_methods GET HEAD POST;",
-		"proxy_cache_purge $cache_purge_test_cache_full_advanced;",
+	wantStrings := []string{
+		"listen 8082",
+		"listen [::]:8082",
 	}
-
-	for _, directive := range expectedServerCacheDirectives {
-		if !bytes.Contains(got, []byte(directive)) {
-			t.Errorf("Expected server cache directive: %s", directive)
-		}
+	unwantStrings := []string{
+		"listen 8443 ssl",
+		"listen [::]:8443 ssl",
 	}
-
-	// Check location-level cache configuration
-	expectedLocationCacheDirectives := []string{
-		"proxy_cache test_cache_location_location_cache;",
-		"proxy_cache_valid any 1h;",
-		"proxy_cache_methods GET HEAD;",
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
 	}
-
-	for _, directive := range expectedLocationCacheDirectives {
-		if !bytes.Contains(got, []byte(directive)) {
-			t.Errorf("Expected location cache directive: %s", directive)
+	for _, want := range unwantStrings {
+		if bytes.Contains(got, []byte(want)) {
+			t.Errorf("unwant  `%s` in generated template", want)
 		}
 	}
-
 	snaps.MatchSnapshot(t, string(got))
 	t.Log(string(got))
 }
 
-func TestExecuteVirtualServerTemplateWithCachePolicyOSS(t *testing.T) {
+func TestExecuteVirtualServerTemplate_RendersTemplateWithCustomListenerHTTPSOnly(t *testing.T) {
 	t.Parallel()
-	executor := newTmplExecutorNGINX(t)
-	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCachePolicyOSS)
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCustomListenerHTTPSOnly)
 	if err != nil {
 		t.Error(err)
 	}
-
-	// Check cache zone declaration
-	expectedCacheZone := "proxy_cache_path /var/cache/nginx/test_cache_basic_cache levels=1:2 keys_zone=test_cache_basic_cache:10m;"
-	if !bytes.Contains(got, []byte(expectedCacheZone)) {
-		t.Errorf("Expected cache zone declaration: %s", expectedCacheZone)
+	wantStrings := []string{
+		"listen 8443 ssl",
+		"listen [::]:8443 ssl",
 	}
-
-	// Ensure no purge configuration for OSS (cachePurgeAllow should be ignored)
-	if bytes.Contains(got, []byte("geo $purge_allowed")) {
-		t.Error("OSS template should not contain cache purge geo blocks")
+	unwantStrings := []string{
+		"listen 8082",
+		"listen [::]:8082",
 	}
-
-	if bytes.Contains(got, []byte("map $request_method $cache_purge")) {
-		t.Error("OSS template should not contain cache purge map blocks")
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
 	}
-
-	if bytes.Contains(got, []byte("proxy_cache_purge")) {
-		t.Error("OSS template should not contain proxy_cache_purge directive")
+	for _, want := range unwantStrings {
+		if bytes.Contains(got, []byte(want)) {
+			t.Errorf("want no `%s` in generated template", want)
+		}
 	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
 
-	// Check server-level cache configuration
-	expectedServerCacheDirectives := []string{
-		"proxy_cache test_cache_basic_cache;",
-		"proxy_cache_key $scheme$proxy_host$request_uri;",
-		"proxy_ignore_headers Cache-Control Expires Set-Cookie Vary X-Accel-Expires;",
-		"proxy_cache_valid any 1h;",
-		"proxy_cache_methods GET HEAD;",
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithHTTP2On(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithHTTP2On)
+	if err != nil {
+		t.Error(err)
 	}
-
-	for _, directive := range expectedServerCacheDirectives {
-		if !bytes.Contains(got, []byte(directive)) {
-			t.Errorf("Expected server cache directive: %s", directive)
+	wantStrings := []string{
+		"listen 443 ssl proxy_protocol;",
+		"listen [::]:443 ssl proxy_protocol;",
+		"http2 on;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
 		}
 	}
 
-	// Check location-level cache configuration
-	expectedLocationCacheDirectives := []string{
-		"proxy_cache test_cache_location_simple_cache;",
-		"proxy_cache_valid 200 30m;",
-		"proxy_cache_valid 404 30m;",
+	unwantStrings := []string{
+		"listen 443 ssl http2 proxy_protocol;",
+		"listen [::]:443 ssl http2 proxy_protocol;",
 	}
 
-	for _, directive := range expectedLocationCacheDirectives {
-		if !bytes.Contains(got, []byte(directive)) {
-			t.Errorf("Expected location cache directive: %s", directive)
+	for _, want := range unwantStrings {
+		if bytes.Contains(got, []byte(want)) {
+			t.Errorf("unwant  `%s` in generated template", want)
 		}
 	}
-
 	snaps.MatchSnapshot(t, string(got))
+
 	t.Log(string(got))
 }
 
-func vsConfig() VirtualServerConfig {
-	return VirtualServerConfig{
-		LimitReqZones: []LimitReqZone{
-			{
-				ZoneName: "pol_rl_test_test_test", Rate: "10r/s", ZoneSize: "10m", Key: "$url",
-			},
+func TestExecuteVirtualServerTemplate_RendersPlusTemplateWithHTTP2Off(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithHTTP2Off)
+	if err != nil {
+		t.Error(err)
+	}
+	wantStrings := []string{
+		"listen 443 ssl proxy_protocol;",
+		"listen [::]:443 ssl proxy_protocol;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+
+	unwantStrings := []string{
+		"http2 on;",
+	}
+
+	for _, want := range unwantStrings {
+		if bytes.Contains(got, []byte(want)) {
+			t.Errorf("unwant  `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+
+	t.Log(string(got))
+}
+
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithHTTP2On(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithHTTP2On)
+	if err != nil {
+		t.Error(err)
+	}
+	wantStrings := []string{
+		"listen 443 ssl proxy_protocol;",
+		"listen [::]:443 ssl proxy_protocol;",
+		"http2 on;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+
+	unwantStrings := []string{
+		"listen 443 ssl http2 proxy_protocol;",
+		"listen [::]:443 ssl http2 proxy_protocol;",
+	}
+
+	for _, want := range unwantStrings {
+		if bytes.Contains(got, []byte(want)) {
+			t.Errorf("unwant  `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+
+	t.Log(string(got))
+}
+
+func TestExecuteVirtualServerTemplate_RendersOSSTemplateWithHTTP2Off(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithHTTP2Off)
+	if err != nil {
+		t.Error(err)
+	}
+	wantStrings := []string{
+		"listen 443 ssl proxy_protocol;",
+		"listen [::]:443 ssl proxy_protocol;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+
+	unwantStrings := []string{
+		"http2 on;",
+	}
+
+	for _, want := range unwantStrings {
+		if bytes.Contains(got, []byte(want)) {
+			t.Errorf("unwant  `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+
+	t.Log(string(got))
+}
+
+func TestVirtualServerForNginxPlusWithWAFApBundle(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithWAFApBundle)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	snaps.MatchSnapshot(t, string(got))
+
+	t.Log(string(got))
+}
+
+func TestVirtualServerForNginx(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	data, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfg)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	snaps.MatchSnapshot(t, string(data))
+	t.Log(string(data))
+}
+
+func TestTransportServerForNginxPlus(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	data, err := executor.ExecuteTransportServerTemplate(&transportServerCfg)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	t.Log(string(data))
+}
+
+func TestExecuteTemplateForTransportServerWithResolver(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithResolver)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXOSSTransportServerWithSNI(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithSNI)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXPlusTransportServerWithSNI(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithSNI)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXOSSTransportServerWithClientCertVerification(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithClientCertVerification)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	wantStrings := []string{
+		"ssl_client_certificate /etc/nginx/secrets/default-cafe-ca-secret-ca.crt;",
+		"ssl_crl /etc/nginx/secrets/default-cafe-ca-secret-ca.crl;",
+		"ssl_verify_client on;",
+		"ssl_verify_depth 2;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXPlusTransportServerWithClientCertVerification(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithClientCertVerification)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	wantStrings := []string{
+		"ssl_client_certificate /etc/nginx/secrets/default-cafe-ca-secret-ca.crt;",
+		"ssl_crl /etc/nginx/secrets/default-cafe-ca-secret-ca.crl;",
+		"ssl_verify_client on;",
+		"ssl_verify_depth 2;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXOSSTransportServerWithUpstreamTLS(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithUpstreamTLS)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	wantStrings := []string{
+		"proxy_protocol on;",
+		"proxy_ssl on;",
+		"proxy_ssl_certificate /etc/nginx/secrets/default-cafe-client-secret;",
+		"proxy_ssl_certificate_key /etc/nginx/secrets/default-cafe-client-secret;",
+		"proxy_ssl_trusted_certificate /etc/nginx/secrets/default-cafe-ca-secret-ca.crt;",
+		"proxy_ssl_verify on;",
+		"proxy_ssl_verify_depth 2;",
+		"proxy_ssl_server_name on;",
+		"proxy_ssl_name cafe-backend.example.com;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXPlusTransportServerWithUpstreamTLS(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithUpstreamTLS)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	wantStrings := []string{
+		"proxy_protocol on;",
+		"proxy_ssl on;",
+		"proxy_ssl_certificate /etc/nginx/secrets/default-cafe-client-secret;",
+		"proxy_ssl_certificate_key /etc/nginx/secrets/default-cafe-client-secret;",
+		"proxy_ssl_trusted_certificate /etc/nginx/secrets/default-cafe-ca-secret-ca.crt;",
+		"proxy_ssl_verify on;",
+		"proxy_ssl_verify_depth 2;",
+		"proxy_ssl_server_name on;",
+		"proxy_ssl_name cafe-backend.example.com;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXOSSTransportServerWithRoutes(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithRoutes)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	wantStrings := []string{
+		"map $ssl_server_name:$ssl_alpn_protocol $ts_default_cafe_route {",
+		"map $ssl_server_name $ts_default_cafe_certificate {",
+		"default /etc/nginx/secrets/default-cafe-secret;",
+		"tea.example.com /etc/nginx/secrets/default-tea-secret;",
+		"ssl_certificate $ts_default_cafe_certificate;",
+		"ssl_certificate_key $ts_default_cafe_certificate;",
+		"proxy_pass $ts_default_cafe_route;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXPlusTransportServerWithRoutes(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithRoutes)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	wantStrings := []string{
+		"map $ssl_server_name:$ssl_alpn_protocol $ts_default_cafe_route {",
+		"map $ssl_server_name $ts_default_cafe_certificate {",
+		"default /etc/nginx/secrets/default-cafe-secret;",
+		"tea.example.com /etc/nginx/secrets/default-tea-secret;",
+		"ssl_certificate $ts_default_cafe_certificate;",
+		"ssl_certificate_key $ts_default_cafe_certificate;",
+		"proxy_pass $ts_default_cafe_route;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXOSSTransportServerWithALPNRoutes(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithALPNRoutes)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	wantStrings := []string{
+		"map $ssl_server_name:$ssl_alpn_protocol $ts_default_cafe_route {",
+		"ssl_alpn h2 http/1.1;",
+		"proxy_pass $ts_default_cafe_route;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXPlusTransportServerWithALPNRoutes(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithALPNRoutes)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	wantStrings := []string{
+		"map $ssl_server_name:$ssl_alpn_protocol $ts_default_cafe_route {",
+		"ssl_alpn h2 http/1.1;",
+		"proxy_pass $ts_default_cafe_route;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXOSSTransportServerWithRateLimits(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	rateLimitedTransportServerCfg := transportServerCfgWithUpstreamTLS
+	rateLimitedTransportServerCfg.Server.ProxyDownloadRate = "1m"
+	rateLimitedTransportServerCfg.Server.ProxyUploadRate = "512k"
+
+	got, err := executor.ExecuteTransportServerTemplate(&rateLimitedTransportServerCfg)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	wantStrings := []string{
+		"proxy_download_rate 1m;",
+		"proxy_upload_rate 512k;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXPlusTransportServerWithRateLimits(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	rateLimitedTransportServerCfg := transportServerCfgWithUpstreamTLS
+	rateLimitedTransportServerCfg.Server.ProxyDownloadRate = "1m"
+	rateLimitedTransportServerCfg.Server.ProxyUploadRate = "512k"
+
+	got, err := executor.ExecuteTransportServerTemplate(&rateLimitedTransportServerCfg)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	wantStrings := []string{
+		"proxy_download_rate 1m;",
+		"proxy_upload_rate 512k;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestExecuteTemplateForNGINXPlusTransportServerWithMandatoryHealthCheck(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	mandatoryHealthCheckTransportServerCfg := transportServerCfgWithSSL
+	healthCheck := *transportServerCfgWithSSL.Server.HealthCheck
+	healthCheck.Mandatory = true
+	healthCheck.Persistent = true
+	mandatoryHealthCheckTransportServerCfg.Server.HealthCheck = &healthCheck
+
+	got, err := executor.ExecuteTransportServerTemplate(&mandatoryHealthCheckTransportServerCfg)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	want := "match=match_udp-upstream mandatory persistent;"
+	if !bytes.Contains(got, []byte(want)) {
+		t.Errorf("want `%s` in generated template", want)
+	}
+	snaps.MatchSnapshot(t, string(got))
+}
+
+func TestTransportServerForNginx(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	data, err := executor.ExecuteTransportServerTemplate(&transportServerCfg)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	snaps.MatchSnapshot(t, string(data))
+	t.Log(string(data))
+}
+
+func TestExecuteTemplateForNGINXPlusTransportServerWithAccessLog(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	accessLogTransportServerCfg := transportServerCfg
+	accessLogTransportServerCfg.Maps = []Map{
+		{
+			Source:   "$status",
+			Variable: "$ts_default_udp_app_access_log_errors",
+			Parameters: []Parameter{
+				{Value: "~^[45]", Result: "1"},
+				{Value: "default", Result: "0"},
+			},
+		},
+	}
+	accessLogTransportServerCfg.Server.AccessLog = &AccessLog{
+		Destination: "syslog:server=logs.example.com:514",
+		Format:      "stream-main",
+		Condition:   "$ts_default_udp_app_access_log_errors",
+	}
+
+	got, err := executor.ExecuteTransportServerTemplate(&accessLogTransportServerCfg)
+	if err != nil {
+		t.Error(err)
+	}
+	wantStrings := []string{
+		"map $status $ts_default_udp_app_access_log_errors {",
+		"access_log syslog:server=logs.example.com:514 stream-main if=$ts_default_udp_app_access_log_errors;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteTemplateForNGINXPlusTransportServerWithPolicies(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithPolicies)
+	if err != nil {
+		t.Error(err)
+	}
+	wantStrings := []string{
+		"limit_conn_zone ${binary_remote_addr} zone=pol_cl_default_connection_limit_default_tcp_server:10M;",
+		"allow 10.0.0.0/8;",
+		"deny all;",
+		"limit_conn_log_level warn;",
+		"limit_conn pol_cl_default_connection_limit_default_tcp_server 10;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteTemplateForNGINXOSSTransportServerWithPolicies(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+
+	got, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithPolicies)
+	if err != nil {
+		t.Error(err)
+	}
+	wantStrings := []string{
+		"limit_conn_zone ${binary_remote_addr} zone=pol_cl_default_connection_limit_default_tcp_server:10M;",
+		"allow 10.0.0.0/8;",
+		"deny all;",
+		"limit_conn_log_level warn;",
+		"limit_conn pol_cl_default_connection_limit_default_tcp_server 10;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteTemplateForTransportServerWithMissingPolicy(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+
+	tsCfg := transportServerCfg
+	tsCfg.Server.DenyAll = true
+
+	got, err := executor.ExecuteTransportServerTemplate(&tsCfg)
+	if err != nil {
+		t.Error(err)
+	}
+	if !bytes.Contains(got, []byte("deny all;")) {
+		t.Error("want `deny all;` in generated template")
+	}
+	if bytes.Contains(got, []byte("allow all;")) {
+		t.Error("want no `allow all;` in generated template")
+	}
+}
+
+func TestExecuteTemplateForNGINXOSSTransportServerWithAccessLog(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	accessLogTransportServerCfg := transportServerCfg
+	accessLogTransportServerCfg.Maps = []Map{
+		{
+			Source:   "$status",
+			Variable: "$ts_default_udp_app_access_log_errors",
+			Parameters: []Parameter{
+				{Value: "~^[45]", Result: "1"},
+				{Value: "default", Result: "0"},
+			},
+		},
+	}
+	accessLogTransportServerCfg.Server.AccessLog = &AccessLog{
+		Destination: "syslog:server=logs.example.com:514",
+		Format:      "stream-main",
+		Condition:   "$ts_default_udp_app_access_log_errors",
+	}
+
+	got, err := executor.ExecuteTransportServerTemplate(&accessLogTransportServerCfg)
+	if err != nil {
+		t.Error(err)
+	}
+	wantStrings := []string{
+		"map $status $ts_default_udp_app_access_log_errors {",
+		"access_log syslog:server=logs.example.com:514 stream-main if=$ts_default_udp_app_access_log_errors;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteTemplateForTransportServerWithTCPIPListener(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	customIPListenerTransportServerCfg := transportServerCfgWithSSL
+	customIPListenerTransportServerCfg.Server.IPv4 = "127.0.0.1"
+	customIPListenerTransportServerCfg.Server.IPv6 = "::1"
+
+	got, err := executor.ExecuteTransportServerTemplate(&customIPListenerTransportServerCfg)
+	if err != nil {
+		t.Error(err)
+	}
+	wantStrings := []string{
+		"listen 127.0.0.1:1234 ssl udp;",
+		"listen [::1]:1234 ssl udp;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteTemplateForTransportServerWithUDPIPListener(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	customIPListenerTransportServerCfg := transportServerCfgWithSSL
+	customIPListenerTransportServerCfg.Server.IPv4 = "127.0.0.1"
+	customIPListenerTransportServerCfg.Server.IPv6 = "::1"
+	customIPListenerTransportServerCfg.Server.UDP = false
+
+	got, err := executor.ExecuteTransportServerTemplate(&customIPListenerTransportServerCfg)
+	if err != nil {
+		t.Error(err)
+	}
+	wantStrings := []string{
+		"listen 127.0.0.1:1234 ssl;",
+		"listen [::1]:1234 ssl;",
+	}
+	for _, want := range wantStrings {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("want `%s` in generated template", want)
+		}
+	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func tsConfig() TransportServerConfig {
+	return TransportServerConfig{
+		Upstreams: []StreamUpstream{
+			{
+				Name: "udp-upstream",
+				Servers: []StreamUpstreamServer{
+					{
+						Address: "10.0.0.20:5001",
+					},
+				},
+			},
+		},
+		Match: &Match{
+			Name:                "match_udp-upstream",
+			Send:                `GET / HTTP/1.0\r\nHost: localhost\r\n\r\n`,
+			ExpectRegexModifier: "~*",
+			Expect:              "200 OK",
+		},
+		Server: StreamServer{
+			Port:                     1234,
+			UDP:                      true,
+			StatusZone:               "udp-app",
+			ProxyRequests:            createPointerFromInt(1),
+			ProxyResponses:           createPointerFromInt(2),
+			ProxyPass:                "udp-upstream",
+			ProxyTimeout:             "10s",
+			ProxyConnectTimeout:      "10s",
+			ProxyNextUpstream:        true,
+			ProxyNextUpstreamTimeout: "10s",
+			ProxyNextUpstreamTries:   5,
+			HealthCheck: &StreamHealthCheck{
+				Enabled:  false,
+				Timeout:  "5s",
+				Jitter:   "0",
+				Port:     8080,
+				Interval: "5s",
+				Passes:   1,
+				Fails:    1,
+				Match:    "match_udp-upstream",
+			},
+		},
+	}
+}
+
+func TestExecuteTemplateForTransportServerWithBackupServerForNGINXPlus(t *testing.T) {
+	t.Parallel()
+
+	tsCfg := tsConfig()
+	tsCfg.Upstreams[0].BackupServers = []StreamUpstreamBackupServer{
+		{
+			Address: "clustertwo.corp.local:8080",
+		},
+	}
+	e := newTmplExecutorNGINXPlus(t)
+	got, err := e.ExecuteTransportServerTemplate(&tsCfg)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := fmt.Sprintf("server %s resolve backup;", tsCfg.Upstreams[0].BackupServers[0].Address)
+	if !bytes.Contains(got, []byte(want)) {
+		t.Errorf("want backup %q in the transport server config", want)
+	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestTransportServerWithSSL(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	data, err := executor.ExecuteTransportServerTemplate(&transportServerCfgWithSSL)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	snaps.MatchSnapshot(t, string(data))
+	t.Log(string(data))
+}
+
+func TestTLSPassthroughHosts(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+
+	unixSocketsCfg := TLSPassthroughHostsConfig{
+		"app.example.com": "unix:/var/lib/nginx/passthrough-default_secure-app.sock",
+	}
+
+	data, err := executor.ExecuteTLSPassthroughHostsTemplate(&unixSocketsCfg)
+	if err != nil {
+		t.Errorf("Failed to execute template: %v", err)
+	}
+	snaps.MatchSnapshot(t, string(data))
+	t.Log(string(data))
+}
+
+func TestExecuteVirtualServerTemplateWithJWKSWithToken(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithJWTPolicyJWKSWithToken)
+	if err != nil {
+		t.Error(err)
+	}
+	if !bytes.Contains(got, []byte("token=$http_token")) {
+		t.Error("want `token=$http_token` in generated template")
+	}
+
+	if !bytes.Contains(got, []byte("proxy_ssl_server_name on;")) {
+		t.Error("want `proxy_ssl_server_name on;` in generated template")
+	}
+
+	if !bytes.Contains(got, []byte("proxy_ssl_name sni.idp.spec.example.com;")) {
+		t.Error("want `proxy_ssl_name sni.idp.spec.example.com;` in generated template")
+	}
+
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteVirtualServerTemplateWithJWKSWithoutToken(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithJWTPolicyJWKSWithoutToken)
+	if err != nil {
+		t.Error(err)
+	}
+	if bytes.Contains(got, []byte("token=$http_token")) {
+		t.Error("want no `token=$http_token` string in generated template")
+	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteVirtualServerTemplateWithBackupServerNGINXPlus(t *testing.T) {
+	t.Parallel()
+
+	externalName := "clustertwo.corp.local:8080"
+	vscfg := vsConfig()
+	vscfg.Upstreams[0].BackupServers = []UpstreamServer{
+		{
+			Address: externalName,
+		},
+	}
+
+	e := newTmplExecutorNGINXPlus(t)
+	got, err := e.ExecuteVirtualServerTemplate(&vscfg)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := fmt.Sprintf("server %s backup resolve;", externalName)
+	if !bytes.Contains(got, []byte(want)) {
+		t.Errorf("want %q in generated template", want)
+	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteVirtualServerTemplateWithAPIKeyPolicyNGINXPlus(t *testing.T) {
+	t.Parallel()
+
+	vscfg := vsConfig()
+	vscfg.Server.APIKey = &APIKey{
+		Header:  []string{"X-header-name", "other-header"},
+		Query:   []string{"myQuery", "myOtherQuery"},
+		MapName: "vs_default_cafe_apikey_policy",
+	}
+
+	e := newTmplExecutorNGINXPlus(t)
+	got, err := e.ExecuteVirtualServerTemplate(&vscfg)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := "js_var $header_query_value \"${http_x_header_name}${http_other_header}${arg_myQuery}${arg_myOtherQuery}\";"
+
+	if !bytes.Contains(got, []byte(want)) {
+		t.Errorf("want %q in generated template", want)
+	}
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteVirtualServerTemplate_WithCustomOIDCRedirectLocation(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfg)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expectedCustomLocation := "location = /custom-location {"
+	if !bytes.Contains(got, []byte(expectedCustomLocation)) {
+		t.Errorf("Custom redirectURI should generate location block: %s", expectedCustomLocation)
+	}
+
+	expectedDirectives := []string{
+		"status_zone \"OIDC code exchange\";",
+		"js_content oidc.codeExchange;",
+		"error_page 500 502 504 @oidc_error;",
+	}
+
+	for _, directive := range expectedDirectives {
+		if !bytes.Contains(got, []byte(directive)) {
+			t.Errorf("Custom location should contain directive: %s", directive)
+		}
+	}
+
+	expectedRedirVar := `set $redir_location "/custom-location";`
+	if !bytes.Contains(got, []byte(expectedRedirVar)) {
+		t.Errorf("Should set $redir_location to custom value: %s", expectedRedirVar)
+	}
+}
+
+func TestExecuteVirtualServerTemplateWithOIDCAndPKCEPolicyNGINXPlus(t *testing.T) {
+	t.Parallel()
+
+	e := newTmplExecutorNGINXPlus(t)
+	got, err := e.ExecuteVirtualServerTemplate(&virtualServerCfgWithOIDCAndPKCETurnedOn)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := "include oidc/oidc_pkce_supplements.conf"
+	want2 := "include oidc/oidc.conf;"
+
+	if !bytes.Contains(got, []byte(want)) {
+		t.Errorf("want %q in generated template", want)
+	}
+
+	if !bytes.Contains(got, []byte(want2)) {
+		t.Errorf("want %q in generated template", want2)
+	}
+
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteVirtualServerTemplateWithCachePolicyNGINXPlus(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINXPlus(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCachePolicyNGINXPlus)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check cache zone declaration
+	expectedCacheZone := "proxy_cache_path /var/cache/nginx/test_cache_full_advanced levels=2:2 keys_zone=test_cache_full_advanced:50m;"
+	if !bytes.Contains(got, []byte(expectedCacheZone)) {
+		t.Errorf("Expected cache zone declaration: %s", expectedCacheZone)
+	}
+
+	// Check cache purge configuration for NGINX Plus
+	expectedPurgeGeo := "geo $purge_allowed_test_cache_full_advanced {"
+	if !bytes.Contains(got, []byte(expectedPurgeGeo)) {
+		t.Errorf("Expected purge geo block: %s", expectedPurgeGeo)
+	}
+
+	expectedPurgeMap := "map $request_method $cache_purge_test_cache_full_advanced {"
+	if !bytes.Contains(got, []byte(expectedPurgeMap)) {
+		t.Errorf("Expected purge map block: %s", expectedPurgeMap)
+	}
+
+	// Check server-level cache configuration
+	expectedServerCacheDirectives := []string{
+		"proxy_cache test_cache_full_advanced;",
+		"proxy_cache_key $scheme$proxy_host$request_uri;",
+		"proxy_ignore_headers Cache-Control Expires Set-Cookie Vary X-Accel-Expires;",
+		"proxy_cache_valid 200 2h;",
+		"proxy_cache_valid 404 2h;",
+		"proxy_cache_valid 301 2h;",
+		"proxy_cache_methods GET HEAD POST;",
+		"proxy_cache_purge $cache_purge_test_cache_full_advanced;",
+	}
+
+	for _, directive := range expectedServerCacheDirectives {
+		if !bytes.Contains(got, []byte(directive)) {
+			t.Errorf("Expected server cache directive: %s", directive)
+		}
+	}
+
+	// Check location-level cache configuration
+	expectedLocationCacheDirectives := []string{
+		"proxy_cache test_cache_location_location_cache;",
+		"proxy_cache_valid any 1h;",
+		"proxy_cache_methods GET HEAD;",
+	}
+
+	for _, directive := range expectedLocationCacheDirectives {
+		if !bytes.Contains(got, []byte(directive)) {
+			t.Errorf("Expected location cache directive: %s", directive)
+		}
+	}
+
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func TestExecuteVirtualServerTemplateWithCachePolicyOSS(t *testing.T) {
+	t.Parallel()
+	executor := newTmplExecutorNGINX(t)
+	got, err := executor.ExecuteVirtualServerTemplate(&virtualServerCfgWithCachePolicyOSS)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check cache zone declaration
+	expectedCacheZone := "proxy_cache_path /var/cache/nginx/test_cache_basic_cache levels=1:2 keys_zone=test_cache_basic_cache:10m;"
+	if !bytes.Contains(got, []byte(expectedCacheZone)) {
+		t.Errorf("Expected cache zone declaration: %s", expectedCacheZone)
+	}
+
+	// Ensure no purge configuration for OSS (cachePurgeAllow should be ignored)
+	if bytes.Contains(got, []byte("geo $purge_allowed")) {
+		t.Error("OSS template should not contain cache purge geo blocks")
+	}
+
+	if bytes.Contains(got, []byte("map $request_method $cache_purge")) {
+		t.Error("OSS template should not contain cache purge map blocks")
+	}
+
+	if bytes.Contains(got, []byte("proxy_cache_purge")) {
+		t.Error("OSS template should not contain proxy_cache_purge directive")
+	}
+
+	// Check server-level cache configuration
+	expectedServerCacheDirectives := []string{
+		"proxy_cache test_cache_basic_cache;",
+		"proxy_cache_key $scheme$proxy_host$request_uri;",
+		"proxy_ignore_headers Cache-Control Expires Set-Cookie Vary X-Accel-Expires;",
+		"proxy_cache_valid any 1h;",
+		"proxy_cache_methods GET HEAD;",
+	}
+
+	for _, directive := range expectedServerCacheDirectives {
+		if !bytes.Contains(got, []byte(directive)) {
+			t.Errorf("Expected server cache directive: %s", directive)
+		}
+	}
+
+	// Check location-level cache configuration
+	expectedLocationCacheDirectives := []string{
+		"proxy_cache test_cache_location_simple_cache;",
+		"proxy_cache_valid 200 30m;",
+		"proxy_cache_valid 404 30m;",
+	}
+
+	for _, directive := range expectedLocationCacheDirectives {
+		if !bytes.Contains(got, []byte(directive)) {
+			t.Errorf("Expected location cache directive: %s", directive)
+		}
+	}
+
+	snaps.MatchSnapshot(t, string(got))
+	t.Log(string(got))
+}
+
+func vsConfig() VirtualServerConfig {
+	return VirtualServerConfig{
+		LimitReqZones: []LimitReqZone{
+			{
+				ZoneName: "pol_rl_test_test_test", Rate: "10r/s", ZoneSize: "10m", Key: "$url",
+			},
+		},
+		Upstreams: []Upstream{
+			{
+				Name: "test-upstream",
+				Servers: []UpstreamServer{
+					{
+						Address: "10.0.0.20:8001",
+					},
+				},
+				LBMethod:         "random",
+				Keepalive:        32,
+				MaxFails:         4,
+				FailTimeout:      "10s",
+				MaxConns:         31,
+				SlowStart:        "10s",
+				UpstreamZoneSize: "256k",
+				Queue:            &Queue{Size: 10, Timeout: "60s"},
+				SessionCookie:    &SessionCookie{Enable: true, Name: "test", Path: "/tea", Expires: "25s"},
+				NTLM:             true,
+			},
+			{
+				Name: "coffee-v1",
+				Servers: []UpstreamServer{
+					{
+						Address: "10.0.0.31:8001",
+					},
+				},
+				MaxFails:         8,
+				FailTimeout:      "15s",
+				MaxConns:         2,
+				UpstreamZoneSize: "256k",
+			},
+			{
+				Name: "coffee-v2",
+				Servers: []UpstreamServer{
+					{
+						Address: "10.0.0.32:8001",
+					},
+				},
+				MaxFails:         12,
+				FailTimeout:      "20s",
+				MaxConns:         4,
+				UpstreamZoneSize: "256k",
+			},
+		},
+		SplitClients: []SplitClient{
+			{
+				Source:   "$request_id",
+				Variable: "$split_0",
+				Distributions: []Distribution{
+					{
+						Weight: "50%",
+						Value:  "@loc0",
+					},
+					{
+						Weight: "50%",
+						Value:  "@loc1",
+					},
+				},
+			},
+		},
+		Maps: []Map{
+			{
+				Source:   "$match_0_0",
+				Variable: "$match",
+				Parameters: []Parameter{
+					{
+						Value:  "~^1",
+						Result: "@match_loc_0",
+					},
+					{
+						Value:  "default",
+						Result: "@match_loc_default",
+					},
+				},
+			},
+			{
+				Source:   "$http_x_version",
+				Variable: "$match_0_0",
+				Parameters: []Parameter{
+					{
+						Value:  "v2",
+						Result: "1",
+					},
+					{
+						Value:  "default",
+						Result: "0",
+					},
+				},
+			},
+		},
+		HTTPSnippets: []string{"# HTTP snippet"},
+		Server: Server{
+			ServerName:    "example.com",
+			StatusZone:    "example.com",
+			ProxyProtocol: true,
+			SSL: &SSL{
+				HTTP2:          true,
+				Certificate:    "cafe-secret.pem",
+				CertificateKey: "cafe-secret.pem",
+			},
+			TLSRedirect: &TLSRedirect{
+				BasedOn: "$scheme",
+				Code:    301,
+			},
+			ServerTokens:    "off",
+			SetRealIPFrom:   []string{"0.0.0.0/0"},
+			RealIPHeader:    "X-Real-IP",
+			RealIPRecursive: true,
+			Allow:           []string{"127.0.0.1"},
+			Deny:            []string{"127.0.0.1"},
+			LimitReqs: []LimitReq{
+				{
+					ZoneName: "pol_rl_test_test_test",
+					Delay:    10,
+					Burst:    5,
+				},
+			},
+			LimitReqOptions: LimitReqOptions{
+				LogLevel:   "error",
+				RejectCode: 503,
+			},
+			JWTAuth: &JWTAuth{
+				Realm:  "My Api",
+				Secret: "jwk-secret",
+			},
+			IngressMTLS: &IngressMTLS{
+				ClientCert:   "ingress-mtls-secret",
+				VerifyClient: "on",
+				VerifyDepth:  2,
+			},
+			WAF: &WAF{
+				ApPolicy:            "/etc/nginx/waf/nac-policies/default-dataguard-alarm",
+				ApSecurityLogEnable: true,
+				Enable:              "on",
+				ApLogConf:           []string{"/etc/nginx/waf/nac-logconfs/default-logconf"},
+			},
+			Dos: &Dos{
+				Enable:                 "on",
+				Name:                   "my-dos-coffee",
+				ApDosMonitorURI:        "test.example.com",
+				ApDosMonitorProtocol:   "http",
+				ApDosAccessLogDest:     "svc.dns.com:123",
+				ApDosPolicy:            "/test/policy.json",
+				ApDosSecurityLogEnable: true,
+				ApDosLogConf:           "/test/log.json",
+				ApDosMonitorTimeout:    30,
+				AllowListPath:          "/etc/nginx/dos/allowlist/default_test.example.com",
+			},
+			Snippets: []string{"# server snippet"},
+			InternalRedirectLocations: []InternalRedirectLocation{
+				{
+					Path:        "/split",
+					Destination: "@split_0",
+				},
+				{
+					Path:        "/coffee",
+					Destination: "@match",
+				},
+			},
+			HealthChecks: []HealthCheck{
+				{
+					Name:          "coffee",
+					URI:           "/",
+					Interval:      "5s",
+					Jitter:        "0s",
+					Fails:         1,
+					Passes:        1,
+					Port:          50,
+					ProxyPass:     "http://coffee-v2",
+					Mandatory:     true,
+					Persistent:    true,
+					KeepaliveTime: "60s",
+					IsGRPC:        false,
+				},
+				{
+					Name:        "tea",
+					Interval:    "5s",
+					Jitter:      "0s",
+					Fails:       1,
+					Passes:      1,
+					Port:        50,
+					ProxyPass:   "http://tea-v2",
+					GRPCPass:    "grpc://tea-v3",
+					GRPCStatus:  createPointerFromInt(12),
+					GRPCService: "tea-servicev2",
+					IsGRPC:      true,
+				},
+			},
+			Locations: []Location{
+				{
+					Path:     "/",
+					Snippets: []string{"# location snippet"},
+					Allow:    []string{"127.0.0.1"},
+					Deny:     []string{"127.0.0.1"},
+					LimitReqs: []LimitReq{
+						{
+							ZoneName: "loc_pol_rl_test_test_test",
+						},
+					},
+					ProxyConnectTimeout:      "30s",
+					ProxyReadTimeout:         "31s",
+					ProxySendTimeout:         "32s",
+					ClientMaxBodySize:        "1m",
+					ProxyBuffering:           true,
+					ProxyBuffers:             "8 4k",
+					ProxyBufferSize:          "4k",
+					ProxyBusyBuffersSize:     "8k",
+					ProxyMaxTempFileSize:     "1024m",
+					ProxyPass:                "http://test-upstream",
+					ProxyNextUpstream:        "error timeout",
+					ProxyNextUpstreamTimeout: "5s",
+					Internal:                 true,
+					ProxyPassRequestHeaders:  false,
+					ProxyPassHeaders:         []string{"Host"},
+					ProxyPassRewrite:         "$request_uri",
+					ProxyHideHeaders:         []string{"Header"},
+					ProxyIgnoreHeaders:       "Cache",
+					Rewrites:                 []string{"$request_uri $request_uri", "$request_uri $request_uri"},
+					AddHeaders: []AddHeader{
+						{
+							Header: Header{
+								Name:  "Header-Name",
+								Value: "Header Value",
+							},
+							Always: true,
+						},
+					},
+					EgressMTLS: &EgressMTLS{
+						Certificate:    "egress-mtls-secret.pem",
+						CertificateKey: "egress-mtls-secret.pem",
+						VerifyServer:   true,
+						VerifyDepth:    1,
+						Ciphers:        "DEFAULT",
+						Protocols:      "TLSv1.3",
+						TrustedCert:    "trusted-cert.pem",
+						SessionReuse:   true,
+						ServerName:     true,
+					},
+				},
+				{
+					Path:                     "@loc0",
+					ProxyConnectTimeout:      "30s",
+					ProxyReadTimeout:         "31s",
+					ProxySendTimeout:         "32s",
+					ClientMaxBodySize:        "1m",
+					ProxyPass:                "http://coffee-v1",
+					ProxyNextUpstream:        "error timeout",
+					ProxyNextUpstreamTimeout: "5s",
+					ProxyInterceptErrors:     true,
+					ErrorPages: []ErrorPage{
+						{
+							Name:         "@error_page_1",
+							Codes:        "400 500",
+							ResponseCode: 200,
+						},
+						{
+							Name:         "@error_page_2",
+							Codes:        "500",
+							ResponseCode: 0,
+						},
+					},
+				},
+				{
+					Path:                     "@loc1",
+					ProxyConnectTimeout:      "30s",
+					ProxyReadTimeout:         "31s",
+					ProxySendTimeout:         "32s",
+					ClientMaxBodySize:        "1m",
+					ProxyPass:                "http://coffee-v2",
+					ProxyNextUpstream:        "error timeout",
+					ProxyNextUpstreamTimeout: "5s",
+				},
+				{
+					Path:                "@loc2",
+					ProxyConnectTimeout: "30s",
+					ProxyReadTimeout:    "31s",
+					ProxySendTimeout:    "32s",
+					ClientMaxBodySize:   "1m",
+					ProxyPass:           "http://coffee-v2",
+					GRPCPass:            "grpc://coffee-v3",
+				},
+				{
+					Path:                     "@match_loc_0",
+					ProxyConnectTimeout:      "30s",
+					ProxyReadTimeout:         "31s",
+					ProxySendTimeout:         "32s",
+					ClientMaxBodySize:        "1m",
+					ProxyPass:                "http://coffee-v2",
+					ProxyNextUpstream:        "error timeout",
+					ProxyNextUpstreamTimeout: "5s",
+				},
+				{
+					Path:                     "@match_loc_default",
+					ProxyConnectTimeout:      "30s",
+					ProxyReadTimeout:         "31s",
+					ProxySendTimeout:         "32s",
+					ClientMaxBodySize:        "1m",
+					ProxyPass:                "http://coffee-v1",
+					ProxyNextUpstream:        "error timeout",
+					ProxyNextUpstreamTimeout: "5s",
+				},
+				{
+					Path:                 "/return",
+					ProxyInterceptErrors: true,
+					ErrorPages: []ErrorPage{
+						{
+							Name:         "@return_0",
+							Codes:        "418",
+							ResponseCode: 200,
+						},
+					},
+					InternalProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+				},
+			},
+			ErrorPageLocations: []ErrorPageLocation{
+				{
+					Name:        "@vs_cafe_cafe_vsr_tea_tea_tea__tea_error_page_0",
+					DefaultType: "application/json",
+					Return: &Return{
+						Code: 200,
+						Text: "Hello World",
+					},
+					Headers: nil,
+				},
+				{
+					Name:        "@vs_cafe_cafe_vsr_tea_tea_tea__tea_error_page_1",
+					DefaultType: "",
+					Return: &Return{
+						Code: 200,
+						Text: "Hello World",
+					},
+					Headers: []Header{
+						{
+							Name:  "Set-Cookie",
+							Value: "cookie1=test",
+						},
+						{
+							Name:  "Set-Cookie",
+							Value: "cookie2=test; Secure",
+						},
+					},
+				},
+			},
+			ReturnLocations: []ReturnLocation{
+				{
+					Name:        "@return_0",
+					DefaultType: "text/html",
+					Return: Return{
+						Code: 200,
+						Text: "Hello!",
+					},
+				},
+			},
+		},
+	}
+}
+
+var (
+	virtualServerCfg = VirtualServerConfig{
+		LimitReqZones: []LimitReqZone{
+			{
+				ZoneName: "pol_rl_test_test_test", Rate: "10r/s", ZoneSize: "10m", Key: "$url",
+			},
 		},
 		Upstreams: []Upstream{
 			{
@@ -1114,6 +2403,18 @@ func vsConfig() VirtualServerConfig {
 				Realm:  "My Api",
 				Secret: "jwk-secret",
 			},
+			OIDC: &OIDC{
+				AuthEndpoint:          "https://idp.example.com/auth",
+				ClientID:              "test-client",
+				ClientSecret:          "test-secret",
+				JwksURI:               "https://idp.example.com/jwks",
+				TokenEndpoint:         "https://idp.example.com/token",
+				EndSessionEndpoint:    "https://idp.example.com/logout",
+				RedirectURI:           "/custom-location",
+				PostLogoutRedirectURI: "https://example.com/logout",
+				ZoneSyncLeeway:        0,
+				Scope:                 "openid+profile+email",
+			},
 			IngressMTLS: &IngressMTLS{
 				ClientCert:   "ingress-mtls-secret",
 				VerifyClient: "on",
@@ -1125,18 +2426,6 @@ func vsConfig() VirtualServerConfig {
 				Enable:              "on",
 				ApLogConf:           []string{"/etc/nginx/waf/nac-logconfs/default-logconf"},
 			},
-			Dos: &Dos{
-				Enable:                 "on",
-				Name:                   "my-dos-coffee",
-				ApDosMonitorURI:        "test.example.com",
-				ApDosMonitorProtocol:   "http",
-				ApDosAccessLogDest:     "svc.dns.com:123",
-				ApDosPolicy:            "/test/policy.json",
-				ApDosSecurityLogEnable: true,
-				ApDosLogConf:           "/test/log.json",
-				ApDosMonitorTimeout:    30,
-				AllowListPath:          "/etc/nginx/dos/allowlist/default_test.example.com",
-			},
 			Snippets: []string{"# server snippet"},
 			InternalRedirectLocations: []InternalRedirectLocation{
 				{
@@ -1344,13 +2633,11 @@ func vsConfig() VirtualServerConfig {
 			},
 		},
 	}
-}
 
-var (
-	virtualServerCfg = VirtualServerConfig{
+	virtualServerCfgPlus = VirtualServerConfig{
 		LimitReqZones: []LimitReqZone{
 			{
-				ZoneName: "pol_rl_test_test_test", Rate: "10r/s", ZoneSize: "10m", Key: "$url",
+				ZoneName: "pol_rl_test_test_test_sync", Rate: "10r/s", ZoneSize: "10m", Key: "$url", Sync: true,
 			},
 		},
 		Upstreams: []Upstream{
@@ -1465,7 +2752,7 @@ var (
 			Deny:            []string{"127.0.0.1"},
 			LimitReqs: []LimitReq{
 				{
-					ZoneName: "pol_rl_test_test_test",
+					ZoneName: "pol_rl_test_test_test_sync",
 					Delay:    10,
 					Burst:    5,
 				},
@@ -1478,18 +2765,6 @@ var (
 				Realm:  "My Api",
 				Secret: "jwk-secret",
 			},
-			OIDC: &OIDC{
-				AuthEndpoint:          "https://idp.example.com/auth",
-				ClientID:              "test-client",
-				ClientSecret:          "test-secret",
-				JwksURI:               "https://idp.example.com/jwks",
-				TokenEndpoint:         "https://idp.example.com/token",
-				EndSessionEndpoint:    "https://idp.example.com/logout",
-				RedirectURI:           "/custom-location",
-				PostLogoutRedirectURI: "https://example.com/logout",
-				ZoneSyncLeeway:        0,
-				Scope:                 "openid+profile+email",
-			},
 			IngressMTLS: &IngressMTLS{
 				ClientCert:   "ingress-mtls-secret",
 				VerifyClient: "on",
@@ -1549,7 +2824,7 @@ var (
 					Deny:     []string{"127.0.0.1"},
 					LimitReqs: []LimitReq{
 						{
-							ZoneName: "loc_pol_rl_test_test_test",
+							ZoneName: "loc_pol_rl_test_test_test_sync",
 						},
 					},
 					ProxyConnectTimeout:      "30s",
@@ -1709,350 +2984,529 @@ var (
 		},
 	}
 
-	virtualServerCfgPlus = VirtualServerConfig{
-		LimitReqZones: []LimitReqZone{
+	virtualServerCfgWithMaintenance = VirtualServerConfig{
+		KeyValZones: []KeyValZone{
 			{
-				ZoneName: "pol_rl_test_test_test_sync", Rate: "10r/s", ZoneSize: "10m", Key: "$url", Sync: true,
+				Name:  "vs_default_cafe_keyval_zone_maintenance",
+				Size:  "32k",
+				State: "/etc/nginx/state_files/vs_default_cafe_keyval_zone_maintenance.json",
 			},
 		},
-		Upstreams: []Upstream{
+		KeyVals: []KeyVal{
 			{
-				Name: "test-upstream",
-				Servers: []UpstreamServer{
-					{
-						Address: "10.0.0.20:8001",
+				Key:      `"vs_default_cafe_keyval_key_maintenance"`,
+				Variable: "$vs_default_cafe_keyval_maintenance",
+				ZoneName: "vs_default_cafe_keyval_zone_maintenance",
+			},
+		},
+		Maps: []Map{
+			{
+				Source:   `"${vs_default_cafe_keyval_maintenance}${vs_default_cafe_maintenance_allow}"`,
+				Variable: "$vs_default_cafe_maintenance",
+				Parameters: []Parameter{
+					{Value: `"10"`, Result: "1"},
+					{Value: "default", Result: "0"},
+				},
+			},
+		},
+		Server: Server{
+			ServerName:   "cafe.example.com",
+			StatusZone:   "cafe.example.com",
+			ServerTokens: "off",
+			VSNamespace:  "default",
+			VSName:       "cafe",
+			Maintenance: &Maintenance{
+				Variable:      "$vs_default_cafe_maintenance",
+				AllowVariable: "$vs_default_cafe_maintenance_allow",
+				Allow:         []string{"10.0.0.0/8"},
+				Code:          503,
+				LocationName:  "@maintenance",
+			},
+			ReturnLocations: []ReturnLocation{
+				{
+					Name:        "@maintenance",
+					DefaultType: "text/html",
+					Return: Return{
+						Text: "<h1>Down for maintenance</h1>",
 					},
 				},
-				LBMethod:         "random",
-				Keepalive:        32,
-				MaxFails:         4,
-				FailTimeout:      "10s",
-				MaxConns:         31,
-				SlowStart:        "10s",
-				UpstreamZoneSize: "256k",
-				Queue:            &Queue{Size: 10, Timeout: "60s"},
-				SessionCookie:    &SessionCookie{Enable: true, Name: "test", Path: "/tea", Expires: "25s"},
-				NTLM:             true,
+			},
+			Locations: []Location{
+				{
+					Path:      "/",
+					ProxyPass: "http://vs_default_cafe_tea",
+				},
+			},
+		},
+	}
+
+	virtualServerCfgWithRedirectMap = VirtualServerConfig{
+		Maps: []Map{
+			{
+				Source:   "$uri",
+				Variable: "$vs_default_cafe_redirect_map",
+				Parameters: []Parameter{
+					{Value: `"/old-tea"`, Result: `"301 /tea"`},
+					{Value: `"/old-coffee"`, Result: `"302 https://coffee.example.com/"`},
+					{Value: "default", Result: `""`},
+				},
 			},
 			{
-				Name: "coffee-v1",
-				Servers: []UpstreamServer{
-					{
-						Address: "10.0.0.31:8001",
-					},
+				Source:   "$vs_default_cafe_redirect_map",
+				Variable: "$vs_default_cafe_redirect_map_301",
+				Parameters: []Parameter{
+					{Value: `"~^301 (.+)$"`, Result: "$1"},
+					{Value: "default", Result: `""`},
 				},
-				MaxFails:         8,
-				FailTimeout:      "15s",
-				MaxConns:         2,
-				UpstreamZoneSize: "256k",
 			},
 			{
-				Name: "coffee-v2",
-				Servers: []UpstreamServer{
-					{
-						Address: "10.0.0.32:8001",
-					},
+				Source:   "$vs_default_cafe_redirect_map",
+				Variable: "$vs_default_cafe_redirect_map_302",
+				Parameters: []Parameter{
+					{Value: `"~^302 (.+)$"`, Result: "$1"},
+					{Value: "default", Result: `""`},
 				},
-				MaxFails:         12,
-				FailTimeout:      "20s",
-				MaxConns:         4,
-				UpstreamZoneSize: "256k",
 			},
 		},
-		SplitClients: []SplitClient{
+		Server: Server{
+			ServerName:   "cafe.example.com",
+			StatusZone:   "cafe.example.com",
+			ServerTokens: "off",
+			VSNamespace:  "default",
+			VSName:       "cafe",
+			RedirectMaps: []RedirectMap{
+				{Variable: "$vs_default_cafe_redirect_map_301", Code: 301},
+				{Variable: "$vs_default_cafe_redirect_map_302", Code: 302},
+			},
+			Locations: []Location{
+				{
+					Path:      "/",
+					ProxyPass: "http://vs_default_cafe_tea",
+				},
+			},
+		},
+	}
+
+	virtualServerCfgWithRequestID = VirtualServerConfig{
+		Maps: []Map{
 			{
-				Source:   "$request_id",
-				Variable: "$split_0",
-				Distributions: []Distribution{
-					{
-						Weight: "50%",
-						Value:  "@loc0",
+				Source:   "$http_x_correlation_id",
+				Variable: "$vs_default_cafe_request_id",
+				Parameters: []Parameter{
+					{Value: `""`, Result: "$request_id"},
+					{Value: "default", Result: "$http_x_correlation_id"},
+				},
+			},
+		},
+		Server: Server{
+			ServerName:   "cafe.example.com",
+			StatusZone:   "cafe.example.com",
+			ServerTokens: "off",
+			VSNamespace:  "default",
+			VSName:       "cafe",
+			RequestID: &RequestID{
+				Header:   "X-Correlation-ID",
+				Variable: "$vs_default_cafe_request_id",
+			},
+			Locations: []Location{
+				{
+					Path:      "/tea",
+					ProxyPass: "http://vs_default_cafe_tea",
+					AddHeaders: []AddHeader{
+						{
+							Header: Header{Name: "X-Served-By", Value: "tea"},
+							Always: true,
+						},
 					},
-					{
-						Weight: "50%",
-						Value:  "@loc1",
+				},
+				{
+					Path:      "/coffee",
+					ProxyPass: "http://vs_default_cafe_coffee",
+					ProxySetHeaders: []Header{
+						{Name: "X-Correlation-ID", Value: "coffee"},
+					},
+				},
+			},
+		},
+	}
+
+	virtualServerCfgWithHTTP3 = VirtualServerConfig{
+		Server: Server{
+			ServerName:      "cafe.example.com",
+			StatusZone:      "cafe.example.com",
+			ServerTokens:    "off",
+			VSNamespace:     "default",
+			VSName:          "cafe",
+			CustomListeners: true,
+			HTTPSPort:       8443,
+			SSL: &SSL{
+				HTTP2:          true,
+				Certificate:    "cafe-secret.pem",
+				CertificateKey: "cafe-secret.pem",
+			},
+			HTTP3: &HTTP3{
+				Port:      8443,
+				ReusePort: true,
+			},
+			Locations: []Location{
+				{
+					Path:      "/tea",
+					ProxyPass: "http://vs_default_cafe_tea",
+					AddHeaders: []AddHeader{
+						{
+							Header: Header{Name: "X-Served-By", Value: "tea"},
+							Always: true,
+						},
 					},
 				},
 			},
 		},
-		Maps: []Map{
-			{
-				Source:   "$match_0_0",
-				Variable: "$match",
-				Parameters: []Parameter{
-					{
-						Value:  "~^1",
-						Result: "@match_loc_0",
-					},
-					{
-						Value:  "default",
-						Result: "@match_loc_default",
-					},
-				},
-			},
+	}
+
+	virtualServerCfgWithGRPCWeb = VirtualServerConfig{
+		Upstreams: []Upstream{
 			{
-				Source:   "$http_x_version",
-				Variable: "$match_0_0",
-				Parameters: []Parameter{
-					{
-						Value:  "v2",
-						Result: "1",
-					},
+				Name: "vs_default_cafe_greeter",
+				Servers: []UpstreamServer{
 					{
-						Value:  "default",
-						Result: "0",
+						Address: "10.0.0.20:50051",
 					},
 				},
 			},
 		},
-		HTTPSnippets: []string{"# HTTP snippet"},
 		Server: Server{
-			ServerName:    "example.com",
-			StatusZone:    "example.com",
-			ProxyProtocol: true,
+			ServerName:   "cafe.example.com",
+			StatusZone:   "cafe.example.com",
+			ServerTokens: "off",
+			VSNamespace:  "default",
+			VSName:       "cafe",
 			SSL: &SSL{
 				HTTP2:          true,
 				Certificate:    "cafe-secret.pem",
 				CertificateKey: "cafe-secret.pem",
 			},
-			TLSRedirect: &TLSRedirect{
-				BasedOn: "$scheme",
-				Code:    301,
-			},
-			ServerTokens:    "off",
-			SetRealIPFrom:   []string{"0.0.0.0/0"},
-			RealIPHeader:    "X-Real-IP",
-			RealIPRecursive: true,
-			Allow:           []string{"127.0.0.1"},
-			Deny:            []string{"127.0.0.1"},
-			LimitReqs: []LimitReq{
+			Locations: []Location{
 				{
-					ZoneName: "pol_rl_test_test_test_sync",
-					Delay:    10,
-					Burst:    5,
+					Path:     "/helloworld.Greeter",
+					GRPCPass: "grpc://vs_default_cafe_greeter",
+					GRPCWeb: &GRPCWeb{
+						AllowOrigin: "https://app.example.com",
+						TextPath:    "/internal_location_grpc_web_text_0",
+						BufferSize:  "1m",
+					},
+				},
+				{
+					Path:     "/internal_location_grpc_web_text_0",
+					Internal: true,
+					GRPCWeb: &GRPCWeb{
+						AllowOrigin: "https://app.example.com",
+						Text:        true,
+						BufferSize:  "1m",
+					},
 				},
 			},
-			LimitReqOptions: LimitReqOptions{
-				LogLevel:   "error",
-				RejectCode: 503,
-			},
-			JWTAuth: &JWTAuth{
-				Realm:  "My Api",
-				Secret: "jwk-secret",
-			},
-			IngressMTLS: &IngressMTLS{
-				ClientCert:   "ingress-mtls-secret",
-				VerifyClient: "on",
-				VerifyDepth:  2,
-			},
-			WAF: &WAF{
-				ApPolicy:            "/etc/nginx/waf/nac-policies/default-dataguard-alarm",
-				ApSecurityLogEnable: true,
-				Enable:              "on",
-				ApLogConf:           []string{"/etc/nginx/waf/nac-logconfs/default-logconf"},
+		},
+	}
+
+	virtualServerCfgWithWebSocket = VirtualServerConfig{
+		Upstreams: []Upstream{
+			{
+				Name: "vs_default_cafe_chat",
+				Servers: []UpstreamServer{
+					{
+						Address: "10.0.0.30:8080",
+					},
+				},
 			},
-			Snippets: []string{"# server snippet"},
-			InternalRedirectLocations: []InternalRedirectLocation{
+		},
+		Server: Server{
+			ServerName:   "cafe.example.com",
+			StatusZone:   "cafe.example.com",
+			ServerTokens: "off",
+			VSNamespace:  "default",
+			VSName:       "cafe",
+			Locations: []Location{
 				{
-					Path:        "/split",
-					Destination: "@split_0",
+					Path:                    "/chat",
+					ProxyPass:               "http://vs_default_cafe_chat",
+					ProxyConnectTimeout:     "30s",
+					ProxyReadTimeout:        "60s",
+					ProxySendTimeout:        "60s",
+					ClientMaxBodySize:       "1m",
+					ProxyPassRequestHeaders: true,
+					ProxySetHeaders:         []Header{{Name: "Host", Value: "$host"}},
+					WebSocket: &WebSocket{
+						Path:             "/internal_location_websocket_0",
+						Upstream:         "vs_default_cafe_chat",
+						ProxyPass:        "http://vs_default_cafe_chat$request_uri",
+						ProxyReadTimeout: "1h",
+						ProxySendTimeout: "1h",
+					},
 				},
 				{
-					Path:        "/coffee",
-					Destination: "@match",
+					Path:                    "/internal_location_websocket_0",
+					Internal:                true,
+					ProxyPass:               "http://vs_default_cafe_chat$request_uri",
+					ProxyConnectTimeout:     "30s",
+					ProxyReadTimeout:        "1h",
+					ProxySendTimeout:        "1h",
+					ClientMaxBodySize:       "1m",
+					ProxyPassRequestHeaders: true,
+					ProxySetHeaders:         []Header{{Name: "Host", Value: "$host"}},
+					AccessLog:               &AccessLog{Destination: "/dev/stdout", Format: "main"},
+					WebSocketUpstream:       "vs_default_cafe_chat",
 				},
 			},
-			HealthChecks: []HealthCheck{
-				{
-					Name:          "coffee",
-					URI:           "/",
-					Interval:      "5s",
-					Jitter:        "0s",
-					Fails:         1,
-					Passes:        1,
-					Port:          50,
-					ProxyPass:     "http://coffee-v2",
-					Mandatory:     true,
-					Persistent:    true,
-					KeepaliveTime: "60s",
-					IsGRPC:        false,
+		},
+	}
+
+	virtualServerCfgWithAccessLog = VirtualServerConfig{
+		SplitClients: []SplitClient{
+			{
+				Source:   `"${msec}${request_id}"`,
+				Variable: "$vs_default_cafe_access_log_sample_10",
+				Distributions: []Distribution{
+					{Weight: "10%", Value: "1"},
+					{Weight: "*", Value: "0"},
 				},
-				{
-					Name:        "tea",
-					Interval:    "5s",
-					Jitter:      "0s",
-					Fails:       1,
-					Passes:      1,
-					Port:        50,
-					ProxyPass:   "http://tea-v2",
-					GRPCPass:    "grpc://tea-v3",
-					GRPCStatus:  createPointerFromInt(12),
-					GRPCService: "tea-servicev2",
-					IsGRPC:      true,
+			},
+		},
+		Maps: []Map{
+			{
+				Source:   "$status",
+				Variable: "$vs_default_cafe_access_log_5xx",
+				Parameters: []Parameter{
+					{Value: "~^5", Result: "1"},
+					{Value: "default", Result: "0"},
 				},
 			},
+		},
+		Server: Server{
+			ServerName:   "cafe.example.com",
+			StatusZone:   "cafe.example.com",
+			ServerTokens: "off",
+			VSNamespace:  "default",
+			VSName:       "cafe",
+			AccessLog: &AccessLog{
+				Destination: "/dev/stdout",
+				Format:      "main",
+				Condition:   "$vs_default_cafe_access_log_sample_10",
+			},
 			Locations: []Location{
 				{
-					Path:     "/",
-					Snippets: []string{"# location snippet"},
-					Allow:    []string{"127.0.0.1"},
-					Deny:     []string{"127.0.0.1"},
-					LimitReqs: []LimitReq{
-						{
-							ZoneName: "loc_pol_rl_test_test_test_sync",
-						},
-					},
-					ProxyConnectTimeout:      "30s",
-					ProxyReadTimeout:         "31s",
-					ProxySendTimeout:         "32s",
-					ClientMaxBodySize:        "1m",
-					ProxyBuffering:           true,
-					ProxyBuffers:             "8 4k",
-					ProxyBufferSize:          "4k",
-					ProxyBusyBuffersSize:     "8k",
-					ProxyMaxTempFileSize:     "1024m",
-					ProxyPass:                "http://test-upstream",
-					ProxyNextUpstream:        "error timeout",
-					ProxyNextUpstreamTimeout: "5s",
-					Internal:                 true,
-					ProxyPassRequestHeaders:  false,
-					ProxyPassHeaders:         []string{"Host"},
-					ProxyPassRewrite:         "$request_uri",
-					ProxyHideHeaders:         []string{"Header"},
-					ProxyIgnoreHeaders:       "Cache",
-					Rewrites:                 []string{"$request_uri $request_uri", "$request_uri $request_uri"},
-					AddHeaders: []AddHeader{
-						{
-							Header: Header{
-								Name:  "Header-Name",
-								Value: "Header Value",
-							},
-							Always: true,
-						},
-					},
-					EgressMTLS: &EgressMTLS{
-						Certificate:    "egress-mtls-secret.pem",
-						CertificateKey: "egress-mtls-secret.pem",
-						VerifyServer:   true,
-						VerifyDepth:    1,
-						Ciphers:        "DEFAULT",
-						Protocols:      "TLSv1.3",
-						TrustedCert:    "trusted-cert.pem",
-						SessionReuse:   true,
-						ServerName:     true,
+					Path:      "/health",
+					ProxyPass: "http://vs_default_cafe_tea",
+					AccessLog: &AccessLog{
+						Off: true,
 					},
 				},
 				{
-					Path:                     "@loc0",
-					ProxyConnectTimeout:      "30s",
-					ProxyReadTimeout:         "31s",
-					ProxySendTimeout:         "32s",
-					ClientMaxBodySize:        "1m",
-					ProxyPass:                "http://coffee-v1",
-					ProxyNextUpstream:        "error timeout",
-					ProxyNextUpstreamTimeout: "5s",
-					ProxyInterceptErrors:     true,
-					ErrorPages: []ErrorPage{
-						{
-							Name:         "@error_page_1",
-							Codes:        "400 500",
-							ResponseCode: 200,
-						},
-						{
-							Name:         "@error_page_2",
-							Codes:        "500",
-							ResponseCode: 0,
-						},
+					Path:      "/payments",
+					ProxyPass: "http://vs_default_cafe_coffee",
+					AccessLog: &AccessLog{
+						Destination: "syslog:server=logs.example.com:514",
+						Format:      "main",
+						Condition:   "$vs_default_cafe_access_log_5xx",
 					},
 				},
-				{
-					Path:                     "@loc1",
-					ProxyConnectTimeout:      "30s",
-					ProxyReadTimeout:         "31s",
-					ProxySendTimeout:         "32s",
-					ClientMaxBodySize:        "1m",
-					ProxyPass:                "http://coffee-v2",
-					ProxyNextUpstream:        "error timeout",
-					ProxyNextUpstreamTimeout: "5s",
-				},
-				{
-					Path:                "@loc2",
-					ProxyConnectTimeout: "30s",
-					ProxyReadTimeout:    "31s",
-					ProxySendTimeout:    "32s",
-					ClientMaxBodySize:   "1m",
-					ProxyPass:           "http://coffee-v2",
-					GRPCPass:            "grpc://coffee-v3",
+			},
+		},
+	}
+
+	virtualServerCfgWithTracing = VirtualServerConfig{
+		SplitClients: []SplitClient{
+			{
+				Source:   "$otel_trace_id",
+				Variable: "$vs_default_cafe_otel_sample_25",
+				Distributions: []Distribution{
+					{Weight: "25%", Value: "on"},
+					{Weight: "*", Value: "off"},
 				},
-				{
-					Path:                     "@match_loc_0",
-					ProxyConnectTimeout:      "30s",
-					ProxyReadTimeout:         "31s",
-					ProxySendTimeout:         "32s",
-					ClientMaxBodySize:        "1m",
-					ProxyPass:                "http://coffee-v2",
-					ProxyNextUpstream:        "error timeout",
-					ProxyNextUpstreamTimeout: "5s",
+			},
+		},
+		Server: Server{
+			ServerName:   "cafe.example.com",
+			StatusZone:   "cafe.example.com",
+			ServerTokens: "off",
+			VSNamespace:  "default",
+			VSName:       "cafe",
+			Tracing: &Tracing{
+				Trace: "$vs_default_cafe_otel_sample_25",
+				SpanAttributes: []SpanAttribute{
+					{Name: "team", Value: "cafe"},
 				},
+			},
+			Locations: []Location{
 				{
-					Path:                     "@match_loc_default",
-					ProxyConnectTimeout:      "30s",
-					ProxyReadTimeout:         "31s",
-					ProxySendTimeout:         "32s",
-					ClientMaxBodySize:        "1m",
-					ProxyPass:                "http://coffee-v1",
-					ProxyNextUpstream:        "error timeout",
-					ProxyNextUpstreamTimeout: "5s",
+					Path:      "/health",
+					ProxyPass: "http://vs_default_cafe_tea",
+					Tracing: &Tracing{
+						Trace: "off",
+					},
 				},
 				{
-					Path:                 "/return",
-					ProxyInterceptErrors: true,
-					ErrorPages: []ErrorPage{
-						{
-							Name:         "@return_0",
-							Codes:        "418",
-							ResponseCode: 200,
+					Path:      "/payments",
+					ProxyPass: "http://vs_default_cafe_coffee",
+					Tracing: &Tracing{
+						Trace:    "on",
+						SpanName: "payments ${request_method}",
+						SpanAttributes: []SpanAttribute{
+							{Name: "http.user_agent", Value: "${http_user_agent}"},
 						},
 					},
-					InternalProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
 				},
 			},
-			ErrorPageLocations: []ErrorPageLocation{
-				{
-					Name:        "@vs_cafe_cafe_vsr_tea_tea_tea__tea_error_page_0",
-					DefaultType: "application/json",
-					Return: &Return{
-						Code: 200,
-						Text: "Hello World",
+		},
+	}
+
+	virtualServerCfgWithDrainingUpstreamServer = VirtualServerConfig{
+		Upstreams: []Upstream{
+			{
+				Name: "vs_default_cafe_tea",
+				Servers: []UpstreamServer{
+					{
+						Address: "10.0.0.1:8080",
+					},
+					{
+						Address: "10.0.0.2:8080",
+						Drain:   true,
 					},
-					Headers: nil,
 				},
+				MaxFails:         1,
+				FailTimeout:      "10s",
+				UpstreamZoneSize: "512k",
+			},
+		},
+		Server: Server{
+			ServerName:   "cafe.example.com",
+			StatusZone:   "cafe.example.com",
+			ServerTokens: "off",
+			VSNamespace:  "default",
+			VSName:       "cafe",
+			Locations: []Location{
 				{
-					Name:        "@vs_cafe_cafe_vsr_tea_tea_tea__tea_error_page_1",
-					DefaultType: "",
-					Return: &Return{
-						Code: 200,
-						Text: "Hello World",
+					Path:      "/tea",
+					ProxyPass: "http://vs_default_cafe_tea",
+				},
+			},
+		},
+	}
+
+	virtualServerCfgWithOtherZoneUpstreamServer = VirtualServerConfig{
+		Upstreams: []Upstream{
+			{
+				Name: "vs_default_cafe_tea",
+				Servers: []UpstreamServer{
+					{
+						Address: "10.0.0.1:8080",
 					},
-					Headers: []Header{
-						{
-							Name:  "Set-Cookie",
-							Value: "cookie1=test",
-						},
-						{
-							Name:  "Set-Cookie",
-							Value: "cookie2=test; Secure",
-						},
+					{
+						Address: "10.0.1.1:8080",
+						Backup:  true,
 					},
 				},
+				LBMethod:         "least_conn",
+				MaxFails:         1,
+				FailTimeout:      "10s",
+				UpstreamZoneSize: "512k",
 			},
-			ReturnLocations: []ReturnLocation{
+		},
+		Server: Server{
+			ServerName:   "cafe.example.com",
+			StatusZone:   "cafe.example.com",
+			ServerTokens: "off",
+			VSNamespace:  "default",
+			VSName:       "cafe",
+			Locations: []Location{
 				{
-					Name:        "@return_0",
-					DefaultType: "text/html",
-					Return: Return{
-						Code: 200,
-						Text: "Hello!",
+					Path:      "/tea",
+					ProxyPass: "http://vs_default_cafe_tea",
+				},
+			},
+		},
+	}
+
+	virtualServerCfgWithRedirectMapKeyVal = VirtualServerConfig{
+		KeyValZones: []KeyValZone{
+			{
+				Name:  "vs_default_cafe_keyval_zone_redirect_map",
+				Size:  "5m",
+				State: "/etc/nginx/state_files/vs_default_cafe_keyval_zone_redirect_map.json",
+			},
+		},
+		KeyVals: []KeyVal{
+			{
+				Key:      "$uri",
+				Variable: "$vs_default_cafe_redirect_map",
+				ZoneName: "vs_default_cafe_keyval_zone_redirect_map",
+			},
+		},
+		Maps: []Map{
+			{
+				Source:   "$vs_default_cafe_redirect_map",
+				Variable: "$vs_default_cafe_redirect_map_301",
+				Parameters: []Parameter{
+					{Value: `"~^301 (.+)$"`, Result: "$1"},
+					{Value: "default", Result: `""`},
+				},
+			},
+		},
+		Server: Server{
+			ServerName:   "cafe.example.com",
+			StatusZone:   "cafe.example.com",
+			ServerTokens: "off",
+			VSNamespace:  "default",
+			VSName:       "cafe",
+			RedirectMaps: []RedirectMap{
+				{Variable: "$vs_default_cafe_redirect_map_301", Code: 301},
+			},
+			Locations: []Location{
+				{
+					Path:      "/",
+					ProxyPass: "http://vs_default_cafe_tea",
+				},
+			},
+		},
+	}
+
+	virtualServerCfgWithCaptureGroupRewrites = VirtualServerConfig{
+		Upstreams: []Upstream{
+			{
+				Name: "vs_default_cafe_images",
+				Servers: []UpstreamServer{
+					{
+						Address: "10.0.0.20:8001",
+					},
+				},
+			},
+		},
+		Server: Server{
+			ServerName:   "cafe.example.com",
+			StatusZone:   "cafe.example.com",
+			ServerTokens: "off",
+			VSNamespace:  "default",
+			VSName:       "cafe",
+			Locations: []Location{
+				{
+					Path:                `~ "^/images/([a-z]+)/(.*)\\.jpg$"`,
+					ProxyPass:           "http://vs_default_cafe_images",
+					Rewrites:            []string{`"^/images/([a-z]+)/(.*)\\.jpg$" "/pictures/$2?category=$1" break`},
+					ProxyConnectTimeout: "30s",
+					ProxyReadTimeout:    "30s",
+					ProxySendTimeout:    "30s",
+					ClientMaxBodySize:   "1m",
+					ProxyBuffering:      true,
+				},
+				{
+					Path:                 `~* "^/old/(.*)$"`,
+					ProxyInterceptErrors: true,
+					InternalProxyPass:    "http://unix:/var/lib/nginx/nginx-418-server.sock",
+					ErrorPages: []ErrorPage{
+						{
+							Name:         "${scheme}://${host}/new/$1",
+							Codes:        "418",
+							ResponseCode: 301,
+						},
 					},
 				},
 			},
@@ -2954,6 +4408,43 @@ var (
 		},
 	}
 
+	transportServerCfgWithPolicies = TransportServerConfig{
+		Upstreams: []StreamUpstream{
+			{
+				Name: "tcp-upstream",
+				Servers: []StreamUpstreamServer{
+					{
+						Address: "10.0.0.20:5001",
+					},
+				},
+			},
+		},
+		LimitConnZones: []StreamLimitConnZone{
+			{
+				ZoneName: "pol_cl_default_connection_limit_default_tcp_server",
+				Key:      "${binary_remote_addr}",
+				ZoneSize: "10M",
+			},
+		},
+		Server: StreamServer{
+			Port:                1234,
+			StatusZone:          "tcp-app",
+			ProxyPass:           "tcp-upstream",
+			ProxyTimeout:        "10s",
+			ProxyConnectTimeout: "10s",
+			Allow:               []string{"10.0.0.0/8"},
+			LimitConns: []StreamLimitConn{
+				{
+					ZoneName:    "pol_cl_default_connection_limit_default_tcp_server",
+					Connections: 10,
+				},
+			},
+			LimitConnOptions: StreamLimitConnOptions{
+				LogLevel: "warn",
+			},
+		},
+	}
+
 	transportServerCfgWithSNI = TransportServerConfig{
 		Upstreams: []StreamUpstream{
 			{
@@ -2985,6 +4476,154 @@ var (
 		},
 	}
 
+	transportServerCfgWithClientCertVerification = TransportServerConfig{
+		Upstreams: []StreamUpstream{
+			{
+				Name: "cafe-upstream",
+				Servers: []StreamUpstreamServer{
+					{
+						Address: "10.0.0.20:5001",
+					},
+				},
+			},
+		},
+		Server: StreamServer{
+			Port:       1234,
+			ServerName: "cafe.example.com",
+			SSL: &StreamSSL{
+				Enabled:        true,
+				Certificate:    "cafe-secret.pem",
+				CertificateKey: "cafe-secret.pem",
+				ClientCert:     "/etc/nginx/secrets/default-cafe-ca-secret-ca.crt",
+				ClientCrl:      "/etc/nginx/secrets/default-cafe-ca-secret-ca.crl",
+				VerifyClient:   "on",
+				VerifyDepth:    2,
+			},
+			ProxyPass:           "cafe-upstream",
+			ProxyTimeout:        "10s",
+			ProxyConnectTimeout: "10s",
+		},
+	}
+
+	transportServerCfgWithUpstreamTLS = TransportServerConfig{
+		Upstreams: []StreamUpstream{
+			{
+				Name: "cafe-upstream",
+				Servers: []StreamUpstreamServer{
+					{
+						Address: "10.0.0.20:5001",
+					},
+				},
+			},
+		},
+		Server: StreamServer{
+			Port:          1234,
+			ProxyPass:     "cafe-upstream",
+			ProxyProtocol: true,
+			ProxySSL: &StreamProxySSL{
+				Certificate:    "/etc/nginx/secrets/default-cafe-client-secret",
+				CertificateKey: "/etc/nginx/secrets/default-cafe-client-secret",
+				TrustedCert:    "/etc/nginx/secrets/default-cafe-ca-secret-ca.crt",
+				VerifyServer:   true,
+				VerifyDepth:    2,
+				ServerName:     true,
+				SSLName:        "cafe-backend.example.com",
+			},
+			ProxyTimeout:        "10s",
+			ProxyConnectTimeout: "10s",
+		},
+	}
+
+	transportServerCfgWithRoutes = TransportServerConfig{
+		Upstreams: []StreamUpstream{
+			{
+				Name: "cafe-upstream",
+				Servers: []StreamUpstreamServer{
+					{
+						Address: "10.0.0.20:5001",
+					},
+				},
+			},
+			{
+				Name: "tea-upstream",
+				Servers: []StreamUpstreamServer{
+					{
+						Address: "10.0.0.30:5001",
+					},
+				},
+			},
+		},
+		Maps: []Map{
+			{
+				Source:   "$ssl_server_name:$ssl_alpn_protocol",
+				Variable: "$ts_default_cafe_route",
+				Parameters: []Parameter{
+					{Value: `"~^tea\.example\.com:"`, Result: "tea-upstream"},
+					{Value: "default", Result: "cafe-upstream"},
+				},
+			},
+		},
+		Server: StreamServer{
+			Port: 1234,
+			SSL: &StreamSSL{
+				Enabled:             true,
+				Certificate:         "/etc/nginx/secrets/default-cafe-secret",
+				CertificateKey:      "/etc/nginx/secrets/default-cafe-secret",
+				CertificateVariable: "$ts_default_cafe_certificate",
+				Certificates: []StreamSSLCertificate{
+					{ServerName: "tea.example.com", Certificate: "/etc/nginx/secrets/default-tea-secret"},
+				},
+			},
+			ProxyPass:           "$ts_default_cafe_route",
+			ProxyTimeout:        "10s",
+			ProxyConnectTimeout: "10s",
+		},
+	}
+
+	transportServerCfgWithALPNRoutes = TransportServerConfig{
+		Upstreams: []StreamUpstream{
+			{
+				Name: "cafe-upstream",
+				Servers: []StreamUpstreamServer{
+					{
+						Address: "10.0.0.20:5001",
+					},
+				},
+			},
+			{
+				Name: "web-upstream",
+				Servers: []StreamUpstreamServer{
+					{
+						Address: "10.0.0.40:5001",
+					},
+				},
+			},
+		},
+		Maps: []Map{
+			{
+				Source:   "$ssl_server_name:$ssl_alpn_protocol",
+				Variable: "$ts_default_cafe_route",
+				Parameters: []Parameter{
+					{Value: `"~:h2$"`, Result: "web-upstream"},
+					{Value: `"~:http/1\.1$"`, Result: "web-upstream"},
+					{Value: "default", Result: "cafe-upstream"},
+				},
+			},
+		},
+		Server: StreamServer{
+			Port: 1234,
+			SSL: &StreamSSL{
+				Enabled:        true,
+				Certificate:    "/etc/nginx/secrets/default-cafe-secret",
+				CertificateKey: "/etc/nginx/secrets/default-cafe-secret",
+				ALPN:           []string{"h2", "http/1.1"},
+			},
+			ProxyPass:           "$ts_default_cafe_route",
+			ProxyTimeout:        "10s",
+			ProxyConnectTimeout: "10s",
+		},
+	}
+
 	transportServerCfgWithSSL = TransportServerConfig{
 		Upstreams: []StreamUpstream{
 			{
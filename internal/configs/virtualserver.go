@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -34,8 +36,24 @@ const (
 	subRouteContext                                 = "subroute"
 	keyvalZoneBasePath                              = "/etc/nginx/state_files"
 	splitClientsKeyValZoneSize                      = "100k"
+	maintenanceKeyValZoneSize                       = "32k"
+	maintenanceLocationName                         = "@maintenance"
+	defaultMaintenanceCode                          = 503
+	defaultMaintenanceType                          = "text/html"
+	defaultMaintenanceBody                          = "<html><body><h1>Service Unavailable</h1><p>The service is undergoing maintenance.</p></body></html>"
+	defaultMaintenanceConfigMapKey                  = "maintenance.html"
+	redirectMapKeyValZoneSize                       = "5m"
+	defaultRedirectMapCode                          = 301
+	defaultRedirectMapConfigMapKey                  = "redirects"
+	globalRequestIDVariable                         = "$correlation_id"
+	defaultAccessLogDestination                     = "/dev/stdout"
+	defaultAccessLogFormat                          = "main"
+	defaultStreamAccessLogFormat                    = "stream-main"
+	accessLogSampleSource                           = `"${msec}${request_id}"`
+	streamAccessLogSampleSource                     = `"${msec}${connection}"`
 	splitClientAmountWhenWeightChangesDynamicReload = 101
 	defaultLogOutput                                = "syslog:server=localhost:514"
+	grpcWebTextBufferSize                           = "1m"
 )
 
 var grpcConflictingErrors = map[int]bool{
@@ -94,7 +112,12 @@ type VirtualServerEx struct {
 	HTTPIPv6            string
 	HTTPSIPv4           string
 	HTTPSIPv6           string
+	HTTPSQUIC           bool
+	HTTPSQUICReusePort  bool
 	Endpoints           map[string][]string
+	DrainingEndpoints   map[string][]string
+	OtherZoneEndpoints  map[string][]string
+	AppProtocols        map[string]string
 	VirtualServerRoutes []*conf_v1.VirtualServerRoute
 	ExternalNameSvcs    map[string]bool
 	Policies            map[string]*conf_v1.Policy
@@ -104,9 +127,33 @@ type VirtualServerEx struct {
 	LogConfRefs         map[string]*unstructured.Unstructured
 	DosProtectedRefs    map[string]*unstructured.Unstructured
 	DosProtectedEx      map[string]*DosEx
+	ConfigMaps          map[string]*api_v1.ConfigMap
 	ZoneSync            bool
 }
 
+// generateHTTP3 generates the QUIC listeners of the TLS server of a VirtualServer.
+// With the default listeners, the reuseport parameter is set by the default server.
+func (vsc *virtualServerConfigurator) generateHTTP3(vsEx *VirtualServerEx, useCustomListeners bool, sslConfig *version2.SSL) *version2.HTTP3 {
+	if sslConfig == nil || vsc.isTLSPassthrough {
+		return nil
+	}
+	if useCustomListeners {
+		if !vsEx.HTTPSQUIC || vsEx.HTTPSPort == 0 {
+			return nil
+		}
+		return &version2.HTTP3{
+			Port:      vsEx.HTTPSPort,
+			ReusePort: vsEx.HTTPSQUICReusePort,
+		}
+	}
+	if !vsc.cfgParams.HTTP3 {
+		return nil
+	}
+	return &version2.HTTP3{
+		Port: 443,
+	}
+}
+
 func (vsx *VirtualServerEx) String() string {
 	if vsx == nil {
 		return "<nil>"
@@ -253,6 +300,61 @@ func (namer *VariableNamer) GetNameForVariableForMatchesRouteMainMap(matchesInde
 	return fmt.Sprintf("$vs_%s_matches_%d", namer.safeNsName, matchesIndex)
 }
 
+// GetNameForMaintenanceVariable gets the name of the variable that enables the maintenance response.
+func (namer *VariableNamer) GetNameForMaintenanceVariable() string {
+	return fmt.Sprintf("$vs_%s_maintenance", namer.safeNsName)
+}
+
+// GetNameForMaintenanceAllowVariable gets the name of the geo variable for the maintenance allow list.
+func (namer *VariableNamer) GetNameForMaintenanceAllowVariable() string {
+	return fmt.Sprintf("$vs_%s_maintenance_allow", namer.safeNsName)
+}
+
+// GetNameForMaintenanceBypassVariable gets the name of the map variable for the maintenance bypass header.
+func (namer *VariableNamer) GetNameForMaintenanceBypassVariable() string {
+	return fmt.Sprintf("$vs_%s_maintenance_bypass", namer.safeNsName)
+}
+
+// GetNameForRedirectMapVariable gets the name of the variable that holds the code and the target of a bulk redirect.
+func (namer *VariableNamer) GetNameForRedirectMapVariable() string {
+	return fmt.Sprintf("$vs_%s_redirect_map", namer.safeNsName)
+}
+
+// GetNameForRequestIDLogFormat gets the name of the log format that includes the request ID.
+func (namer *VariableNamer) GetNameForRequestIDLogFormat() string {
+	return fmt.Sprintf("vs_%s_request_id", namer.safeNsName)
+}
+
+// GetNameForRequestIDVariable gets the name of the variable that holds the request ID.
+func (namer *VariableNamer) GetNameForRequestIDVariable() string {
+	return fmt.Sprintf("$vs_%s_request_id", namer.safeNsName)
+}
+
+// GetNameForRedirectMapCodeVariable gets the name of the variable that holds the target of a bulk redirect with the given code.
+func (namer *VariableNamer) GetNameForRedirectMapCodeVariable(code int) string {
+	return fmt.Sprintf("$vs_%s_redirect_map_%d", namer.safeNsName, code)
+}
+
+// GetNameOfKeyvalZoneForRedirectMap returns a unique name for a keyval zone for the bulk redirects.
+func (namer *VariableNamer) GetNameOfKeyvalZoneForRedirectMap() string {
+	return fmt.Sprintf("vs_%s_keyval_zone_redirect_map", namer.safeNsName)
+}
+
+// GetNameOfKeyvalZoneForMaintenance returns a unique name for a keyval zone for the maintenance mode.
+func (namer *VariableNamer) GetNameOfKeyvalZoneForMaintenance() string {
+	return fmt.Sprintf("vs_%s_keyval_zone_maintenance", namer.safeNsName)
+}
+
+// GetNameOfKeyvalForMaintenance returns a unique name for a keyval for the maintenance mode.
+func (namer *VariableNamer) GetNameOfKeyvalForMaintenance() string {
+	return fmt.Sprintf("$vs_%s_keyval_maintenance", namer.safeNsName)
+}
+
+// GetNameOfKeyvalKeyForMaintenance returns a unique name for a keyval key for the maintenance mode.
+func (namer *VariableNamer) GetNameOfKeyvalKeyForMaintenance() string {
+	return fmt.Sprintf("\"vs_%s_keyval_key_maintenance\"", namer.safeNsName)
+}
+
 func newHealthCheckWithDefaults(upstream conf_v1.Upstream, upstreamName string, cfgParams *ConfigParams) *version2.HealthCheck {
 	uri := "/"
 	if isGRPC(upstream.Type) {
@@ -357,6 +459,11 @@ func (vsc *virtualServerConfigurator) generateEndpointsForUpstream(
 	externalNameSvcKey := GenerateExternalNameSvcKey(namespace, upstream.Service)
 	endpoints := virtualServerEx.Endpoints[endpointsKey]
 	if !vsc.isPlus && len(endpoints) == 0 {
+		// NGINX OSS does not allow an upstream with only backup servers, so draining endpoints
+		// are used as regular servers while they are the only ones that can serve requests.
+		if drainingEndpoints := virtualServerEx.DrainingEndpoints[endpointsKey]; len(drainingEndpoints) > 0 {
+			return drainingEndpoints
+		}
 		return []string{nginx502Server}
 	}
 
@@ -370,6 +477,19 @@ func (vsc *virtualServerConfigurator) generateEndpointsForUpstream(
 	return endpoints
 }
 
+func (vsc *virtualServerConfigurator) generateDrainingEndpointsForUpstream(
+	namespace string,
+	upstream conf_v1.Upstream,
+	virtualServerEx *VirtualServerEx,
+) []string {
+	endpointsKey := GenerateEndpointsKey(namespace, upstream.Service, upstream.Subselector, upstream.Port)
+	if !vsc.isPlus && len(virtualServerEx.Endpoints[endpointsKey]) == 0 {
+		// draining endpoints are already used as regular servers, see generateEndpointsForUpstream
+		return nil
+	}
+	return virtualServerEx.DrainingEndpoints[endpointsKey]
+}
+
 func (vsc *virtualServerConfigurator) generateBackupEndpointsForUpstream(
 	owner runtime.Object,
 	namespace string,
@@ -491,6 +611,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			healthChecks,
 			statusMatches,
 		)
+		upstreamName := virtualServerUpstreamNamer.GetNameForUpstream(u.Name)
+		maps = append(maps, generateSessionAffinityMaps(upstreamName, crUpstreams[upstreamName].SessionAffinity)...)
 	}
 	// generate upstreams for each VirtualServerRoute
 	for _, vsr := range vsEx.VirtualServerRoutes {
@@ -509,6 +631,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				healthChecks,
 				statusMatches,
 			)
+			upstreamName := upstreamNamer.GetNameForUpstream(u.Name)
+			maps = append(maps, generateSessionAffinityMaps(upstreamName, crUpstreams[upstreamName].SessionAffinity)...)
 		}
 	}
 
@@ -524,11 +648,19 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 	vsrErrorPagesRouteIndex := make(map[string]int)
 	vsrLocationSnippetsFromVs := make(map[string]string)
 	vsrPoliciesFromVs := make(map[string][]conf_v1.PolicyReference)
+	vsrAccessLogsFromVs := make(map[string]*conf_v1.AccessLog)
+	vsrTracingFromVs := make(map[string]*conf_v1.Tracing)
 	isVSR := false
 	matchesRoutes := 0
 
 	VariableNamer := NewVSVariableNamer(vsEx.VirtualServer)
 
+	var observability observabilityCfg
+	accessLogPrefix := fmt.Sprintf("$vs_%s", VariableNamer.safeNsName)
+	accessLogDestination := getAccessLogDestination(vsc.cfgParams.MainAccessLog)
+	serverAccessLog := generateAccessLog(vsEx.VirtualServer.Spec.AccessLog, accessLogPrefix, accessLogDestination, defaultAccessLogFormat, accessLogSampleSource, &observability)
+	serverTracing := vsc.generateTracing(vsEx.VirtualServer, vsEx.VirtualServer.Spec.Tracing, accessLogPrefix, &observability)
+
 	// generates config for VirtualServer routes
 	for _, r := range vsEx.VirtualServer.Spec.Routes {
 		errorPages := generateErrorPageDetails(r.ErrorPages, errorPageLocations, vsEx.VirtualServer)
@@ -557,6 +689,16 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				vsrPoliciesFromVs[name] = r.Policies
 			}
 
+			// store route access log for the referenced VirtualServerRoute in case they don't define their own
+			if r.AccessLog != nil {
+				vsrAccessLogsFromVs[name] = r.AccessLog
+			}
+
+			// store route tracing for the referenced VirtualServerRoute in case they don't define their own
+			if r.Tracing != nil {
+				vsrTracingFromVs[name] = r.Tracing
+			}
+
 			continue
 		}
 
@@ -611,6 +753,9 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		addCacheZone(&cacheZones, routePoliciesCfg.Cache)
 
 		dosRouteCfg := generateDosCfg(dosResources[r.Path])
+		r.Path = GetRoutePath(r)
+		routeAccessLog := generateAccessLog(r.AccessLog, accessLogPrefix, accessLogDestination, defaultAccessLogFormat, accessLogSampleSource, &observability)
+		routeTracing := vsc.generateTracing(vsEx.VirtualServer, r.Tracing, accessLogPrefix, &observability)
 
 		if len(r.Matches) > 0 {
 			cfg := generateMatchesConfig(
@@ -632,6 +777,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			)
 			addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
 			addDosConfigToLocations(dosRouteCfg, cfg.Locations)
+			addAccessLogToLocations(routeAccessLog, cfg.Locations)
+			addTracingToLocations(routeTracing, cfg.Locations)
 
 			maps = append(maps, cfg.Maps...)
 			locations = append(locations, cfg.Locations...)
@@ -647,6 +794,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				vsc.cfgParams, errorPages, r.Path, vsLocSnippets, vsc.enableSnippets, len(returnLocations), isVSR, "", "", vsc.warnings, vsc.DynamicWeightChangesReload)
 			addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
 			addDosConfigToLocations(dosRouteCfg, cfg.Locations)
+			addAccessLogToLocations(routeAccessLog, cfg.Locations)
+			addTracingToLocations(routeTracing, cfg.Locations)
 			splitClients = append(splitClients, cfg.SplitClients...)
 			locations = append(locations, cfg.Locations...)
 			internalRedirectLocations = append(internalRedirectLocations, cfg.InternalRedirectLocation)
@@ -665,6 +814,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				proxySSLName, r.Path, vsLocSnippets, vsc.enableSnippets, len(returnLocations), isVSR, "", "", vsc.warnings)
 			addPoliciesCfgToLocation(routePoliciesCfg, &loc)
 			loc.Dos = dosRouteCfg
+			loc.AccessLog = routeAccessLog
+			loc.Tracing = routeTracing
 
 			locations = append(locations, loc)
 			if returnLoc != nil {
@@ -763,6 +914,21 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			addCacheZone(&cacheZones, routePoliciesCfg.Cache)
 
 			dosRouteCfg := generateDosCfg(dosResources[r.Path])
+			r.Path = GetRoutePath(r)
+
+			routeAccessLogSpec := r.AccessLog
+			// use the VirtualServer route access log if the route does not define any
+			if routeAccessLogSpec == nil {
+				routeAccessLogSpec = vsrAccessLogsFromVs[vsrNamespaceName]
+			}
+			routeAccessLog := generateAccessLog(routeAccessLogSpec, accessLogPrefix, accessLogDestination, defaultAccessLogFormat, accessLogSampleSource, &observability)
+
+			routeTracingSpec := r.Tracing
+			// use the VirtualServer route tracing if the route does not define any
+			if routeTracingSpec == nil {
+				routeTracingSpec = vsrTracingFromVs[vsrNamespaceName]
+			}
+			routeTracing := vsc.generateTracing(vsr, routeTracingSpec, accessLogPrefix, &observability)
 
 			if len(r.Matches) > 0 {
 				cfg := generateMatchesConfig(
@@ -785,6 +951,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				)
 				addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
 				addDosConfigToLocations(dosRouteCfg, cfg.Locations)
+				addAccessLogToLocations(routeAccessLog, cfg.Locations)
+				addTracingToLocations(routeTracing, cfg.Locations)
 
 				maps = append(maps, cfg.Maps...)
 				locations = append(locations, cfg.Locations...)
@@ -800,6 +968,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 					errorPages, r.Path, locSnippets, vsc.enableSnippets, len(returnLocations), isVSR, vsr.Name, vsr.Namespace, vsc.warnings, vsc.DynamicWeightChangesReload)
 				addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
 				addDosConfigToLocations(dosRouteCfg, cfg.Locations)
+				addAccessLogToLocations(routeAccessLog, cfg.Locations)
+				addTracingToLocations(routeTracing, cfg.Locations)
 
 				splitClients = append(splitClients, cfg.SplitClients...)
 				locations = append(locations, cfg.Locations...)
@@ -818,6 +988,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 					proxySSLName, r.Path, locSnippets, vsc.enableSnippets, len(returnLocations), isVSR, vsr.Name, vsr.Namespace, vsc.warnings)
 				addPoliciesCfgToLocation(routePoliciesCfg, &loc)
 				loc.Dos = dosRouteCfg
+				loc.AccessLog = routeAccessLog
+				loc.Tracing = routeTracing
 
 				locations = append(locations, loc)
 				if returnLoc != nil {
@@ -831,6 +1003,25 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		maps = append(maps, *generateAPIKeyClientMap(mapName, apiKeyClients))
 	}
 
+	maintenance := vsc.generateMaintenance(vsEx, VariableNamer)
+	if maintenance.Server != nil {
+		maps = append(maps, maintenance.Maps...)
+		keyValZones = append(keyValZones, maintenance.KeyValZones...)
+		keyVals = append(keyVals, maintenance.KeyVals...)
+		returnLocations = append(returnLocations, maintenance.ReturnLocation)
+	}
+
+	redirectMap := vsc.generateRedirectMap(vsEx, VariableNamer)
+	maps = append(maps, redirectMap.Maps...)
+	keyValZones = append(keyValZones, redirectMap.KeyValZones...)
+	keyVals = append(keyVals, redirectMap.KeyVals...)
+
+	requestID, requestIDMaps := vsc.generateRequestID(vsEx, VariableNamer)
+	maps = append(maps, requestIDMaps...)
+
+	maps = append(maps, observability.Maps...)
+	splitClients = append(splitClients, observability.SplitClients...)
+
 	httpSnippets := generateSnippets(vsc.enableSnippets, vsEx.VirtualServer.Spec.HTTPSnippets, []string{})
 	serverSnippets := generateSnippets(
 		vsc.enableSnippets,
@@ -842,6 +1033,15 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		return upstreams[i].Name < upstreams[j].Name
 	})
 
+	locations = generateWebSocketLocations(locations, serverAccessLog, vsc.cfgParams.MainAccessLog)
+	locations = generateGRPCWebTextLocations(locations)
+	sortLocations(locations)
+
+	requestIDLogFormat := vsc.generateRequestIDLogFormat(requestID, VariableNamer)
+	if requestIDLogFormat != nil {
+		serverAccessLog = applyRequestIDLogFormat(requestIDLogFormat.Name, serverAccessLog, locations, vsc.cfgParams.MainAccessLog)
+	}
+
 	vsCfg := version2.VirtualServerConfig{
 		Upstreams:        upstreams,
 		SplitClients:     splitClients,
@@ -896,6 +1096,12 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			VSNamespace:               vsEx.VirtualServer.Namespace,
 			VSName:                    vsEx.VirtualServer.Name,
 			DisableIPV6:               vsc.isIPV6Disabled,
+			Maintenance:               maintenance.Server,
+			RedirectMaps:              redirectMap.Server,
+			RequestID:                 requestID,
+			AccessLog:                 serverAccessLog,
+			Tracing:                   serverTracing,
+			HTTP3:                     vsc.generateHTTP3(vsEx, useCustomListeners, sslConfig),
 		},
 		SpiffeCerts:             enabledInternalRoutes,
 		SpiffeClientCerts:       vsc.spiffeCerts && !enabledInternalRoutes,
@@ -904,6 +1110,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		KeyValZones:             keyValZones,
 		KeyVals:                 keyVals,
 		TwoWaySplitClients:      twoWaySplitClients,
+		LogFormat:               requestIDLogFormat,
 	}
 
 	return vsCfg, vsc.warnings
@@ -922,17 +1129,32 @@ func generateUpstreams(
 	healthChecks []version2.HealthCheck,
 	statusMatches []version2.StatusMatch,
 ) ([]version2.Upstream, []version2.HealthCheck, []version2.StatusMatch) {
+	if appProtocol, exists := vsEx.AppProtocols[GenerateEndpointsKey(ownerNamespace, u.Service, u.Subselector, u.Port)]; exists {
+		u = vsc.applyAppProtocol(owner, u, appProtocol, sslConfig != nil && vsc.cfgParams.HTTP2)
+	}
+
 	if (sslConfig == nil || !vsc.cfgParams.HTTP2) && isGRPC(u.Type) {
 		vsc.addWarningf(owner, "gRPC cannot be configured for upstream %s. gRPC requires enabled HTTP/2 and TLS termination", u.Name)
 	}
 
+	if u.GRPCWeb != nil && u.GRPCWeb.Enable && !isGRPC(u.Type) {
+		vsc.addWarningf(owner, "gRPC-Web is ignored for upstream %s. gRPC-Web requires the grpc type", u.Name)
+	}
+
+	if u.WebSocket != nil && u.WebSocket.Enable && isGRPC(u.Type) {
+		vsc.addWarningf(owner, "WebSocket is ignored for upstream %s. WebSocket requires the http type", u.Name)
+	}
+
 	upstreamName := upstreamNamer.GetNameForUpstream(u.Name)
+	u = vsc.applySessionAffinity(owner, u, upstreamName)
 	endpoints := vsc.generateEndpointsForUpstream(owner, ownerNamespace, u, vsEx)
+	draining := vsc.generateDrainingEndpointsForUpstream(ownerNamespace, u, vsEx)
+	otherZone := vsEx.OtherZoneEndpoints[GenerateEndpointsKey(ownerNamespace, u.Service, u.Subselector, u.Port)]
 	backup := vsc.generateBackupEndpointsForUpstream(vsEx.VirtualServer, ownerNamespace, u, vsEx)
 
 	// isExternalNameSvc is always false for OSS
 	_, isExternalNameSvc := vsEx.ExternalNameSvcs[GenerateExternalNameSvcKey(ownerNamespace, u.Service)]
-	ups := vsc.generateUpstream(owner, upstreamName, u, isExternalNameSvc, endpoints, backup)
+	ups := vsc.generateUpstream(owner, upstreamName, u, isExternalNameSvc, endpoints, draining, otherZone, backup)
 	upstreams = append(upstreams, ups)
 	u.TLS.Enable = isTLSEnabled(u, vsc.spiffeCerts, vsEx.VirtualServer.Spec.InternalRoute)
 	crUpstreams[upstreamName] = u
@@ -949,6 +1171,63 @@ func generateUpstreams(
 	return upstreams, healthChecks, statusMatches
 }
 
+// applyAppProtocol sets the type and TLS of the upstream according to the appProtocol of its Service port.
+// The type and TLS of the upstream take precedence over the appProtocol.
+func (vsc *virtualServerConfigurator) applyAppProtocol(owner runtime.Object, u conf_v1.Upstream, value string, grpcSupported bool) conf_v1.Upstream {
+	protocol, ok := parseAppProtocol(value)
+	if !ok {
+		return u
+	}
+
+	switch {
+	case u.Type == "" && protocol.grpc:
+		if grpcSupported {
+			u.Type = "grpc"
+		} else {
+			vsc.addWarningf(owner, "appProtocol %s of the service of upstream %s is ignored. gRPC requires enabled HTTP/2 and TLS termination", value, u.Name)
+		}
+	case u.Type != "" && isGRPC(u.Type) != protocol.grpc:
+		vsc.addWarningf(owner, "type %s of upstream %s conflicts with appProtocol %s of its service, using type %s", u.Type, u.Name, value, u.Type)
+	}
+
+	switch {
+	case u.TLS.Enable && protocol.cleartext:
+		vsc.addWarningf(owner, "TLS of upstream %s conflicts with appProtocol %s of its service, using TLS", u.Name, value)
+	case protocol.tls:
+		u.TLS.Enable = true
+	}
+
+	return u
+}
+
+// applySessionAffinity maps the session affinity of the upstream to the session cookie for NGINX Plus.
+// For NGINX, it sets the lb method to the consistent hash on the session affinity key.
+func (vsc *virtualServerConfigurator) applySessionAffinity(owner runtime.Object, u conf_v1.Upstream, upstreamName string) conf_v1.Upstream {
+	if u.SessionAffinity == nil {
+		return u
+	}
+
+	if !u.SessionAffinity.Enable {
+		u.SessionAffinity = nil
+		return u
+	}
+
+	if vsc.isPlus {
+		if u.SessionCookie == nil {
+			u.SessionCookie = u.SessionAffinity
+		}
+		u.SessionAffinity = nil
+		return u
+	}
+
+	if u.LBMethod != "" {
+		vsc.addWarningf(owner, "lb-method %s of upstream %s is ignored because session affinity is enabled", u.LBMethod, u.Name)
+	}
+	u.LBMethod = generateSessionAffinityLBMethod(upstreamName)
+
+	return u
+}
+
 // rateLimit hold the configuration for the ratelimiting Policy
 type rateLimit struct {
 	Reqs             []version2.LimitReq
@@ -1763,6 +2042,9 @@ func (vsc *virtualServerConfigurator) generatePolicies(
 				res = config.addWAFConfig(vsc.cfgParams.Context, pol.Spec.WAF, key, polNamespace, policyOpts.apResources)
 			case pol.Spec.Cache != nil:
 				res = config.addCacheConfig(pol.Spec.Cache, key, ownerDetails.vsNamespace, ownerDetails.vsName, ownerDetails.ownerNamespace, ownerDetails.ownerName)
+			case pol.Spec.ConnectionLimit != nil:
+				res = newValidationResults()
+				res.addWarningf("ConnectionLimit policy %s is only supported in TransportServer and will be ignored", key)
 			default:
 				res = newValidationResults()
 			}
@@ -2115,8 +2397,18 @@ func (vsc *virtualServerConfigurator) generateUpstream(
 	upstream conf_v1.Upstream,
 	isExternalNameSvc bool,
 	endpoints []string,
+	drainingEndpoints []string,
+	otherZoneEndpoints []string,
 	backupEndpoints []string,
 ) version2.Upstream {
+	lbMethod := generateLBMethod(upstream.LBMethod, vsc.cfgParams.LBMethod)
+
+	if len(otherZoneEndpoints) > 0 && isIncompatibleLBMethodForBackup(lbMethod) {
+		msgFmt := "Endpoints of upstream %v in other zones will not be used as backup servers because lb method '%v' is incompatible with backup servers"
+		vsc.addWarningf(owner, msgFmt, upstream.Name, lbMethod)
+	}
+	endpoints, otherZoneEndpoints = generateZoneAwareEndpoints(endpoints, otherZoneEndpoints, lbMethod)
+
 	var upsServers []version2.UpstreamServer
 	for _, e := range endpoints {
 		s := version2.UpstreamServer{
@@ -2124,6 +2416,25 @@ func (vsc *virtualServerConfigurator) generateUpstream(
 		}
 		upsServers = append(upsServers, s)
 	}
+	for _, e := range otherZoneEndpoints {
+		s := version2.UpstreamServer{
+			Address: e,
+			Backup:  true,
+		}
+		upsServers = append(upsServers, s)
+	}
+	if len(drainingEndpoints) > 0 && !vsc.isPlus && isIncompatibleLBMethodForBackup(lbMethod) {
+		msgFmt := "Terminating endpoints of upstream %v will not be drained because lb method '%v' is incompatible with backup servers"
+		vsc.addWarningf(owner, msgFmt, upstream.Name, lbMethod)
+		drainingEndpoints = nil
+	}
+	for _, e := range drainingEndpoints {
+		s := version2.UpstreamServer{
+			Address: e,
+			Drain:   true,
+		}
+		upsServers = append(upsServers, s)
+	}
 	sort.Slice(upsServers, func(i, j int) bool {
 		return upsServers[i].Address < upsServers[j].Address
 	})
@@ -2139,8 +2450,6 @@ func (vsc *virtualServerConfigurator) generateUpstream(
 		return upsBackupServers[i].Address < upsBackupServers[j].Address
 	})
 
-	lbMethod := generateLBMethod(upstream.LBMethod, vsc.cfgParams.LBMethod)
-
 	upstreamLabels := getUpstreamResourceLabels(owner)
 	upstreamLabels.Service = upstream.Service
 
@@ -2188,6 +2497,11 @@ func (vsc *virtualServerConfigurator) generateSlowStartForPlus(
 	return generateTime(upstream.SlowStart)
 }
 
+// isIncompatibleLBMethodForBackup reports whether the lb method does not allow backup servers.
+func isIncompatibleLBMethodForBackup(lbMethod string) bool {
+	return lbMethod == "ip_hash" || strings.HasPrefix(lbMethod, "hash") || strings.HasPrefix(lbMethod, "random")
+}
+
 func generateHealthCheck(
 	upstream conf_v1.Upstream,
 	upstreamName string,
@@ -2277,6 +2591,103 @@ func generateSessionCookie(sc *conf_v1.SessionCookie) *version2.SessionCookie {
 	}
 }
 
+var sessionAffinityVariableReplacer = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// generateSessionAffinityVariable returns the name of a variable that implements session affinity for an upstream with NGINX.
+func generateSessionAffinityVariable(upstreamName string, suffix string) string {
+	return fmt.Sprintf("$%s_session_affinity%s", sessionAffinityVariableReplacer.ReplaceAllString(upstreamName, "_"), suffix)
+}
+
+// generateSessionAffinityLBMethod returns the lb method that routes requests with the same session affinity key
+// to the same upstream server.
+func generateSessionAffinityLBMethod(upstreamName string) string {
+	return fmt.Sprintf("hash %s consistent", generateSessionAffinityVariable(upstreamName, ""))
+}
+
+// generateSessionAffinityMaps returns the maps for session affinity with NGINX.
+// The first map returns the session affinity key: the value of the cookie or, if the client has no cookie yet, the request ID.
+// The second map returns the cookie that stores the request ID when the client has no cookie yet.
+func generateSessionAffinityMaps(upstreamName string, sc *conf_v1.SessionCookie) []version2.Map {
+	if sc == nil {
+		return nil
+	}
+
+	source := "$cookie_" + sc.Name
+	return []version2.Map{
+		{
+			Source:   source,
+			Variable: generateSessionAffinityVariable(upstreamName, ""),
+			Parameters: []version2.Parameter{
+				{Value: `""`, Result: "$request_id"},
+				{Value: "default", Result: source},
+			},
+		},
+		{
+			Source:   source,
+			Variable: generateSessionAffinityVariable(upstreamName, "_cookie"),
+			Parameters: []version2.Parameter{
+				{Value: `""`, Result: fmt.Sprintf("%q", generateSessionAffinityCookie(sc))},
+				{Value: "default", Result: `""`},
+			},
+		},
+	}
+}
+
+// generateSessionAffinityCookie returns the value of the Set-Cookie header for session affinity with NGINX.
+// The attributes match the ones of the sticky cookie of NGINX Plus.
+func generateSessionAffinityCookie(sc *conf_v1.SessionCookie) string {
+	attrs := []string{sc.Name + "=$request_id"}
+
+	switch sc.Expires {
+	case "":
+	case "max":
+		attrs = append(attrs, "Expires=Thu, 31 Dec 2037 23:55:55 GMT", "Max-Age=315360000")
+	default:
+		if d, err := ParseDuration(sc.Expires); err == nil {
+			attrs = append(attrs, fmt.Sprintf("Max-Age=%d", int64(d.Seconds())))
+		}
+	}
+	if sc.Domain != "" {
+		attrs = append(attrs, "Domain="+sc.Domain)
+	}
+	if sc.Path != "" {
+		attrs = append(attrs, "Path="+sc.Path)
+	}
+	if sc.HTTPOnly {
+		attrs = append(attrs, "HttpOnly")
+	}
+	if sc.Secure {
+		attrs = append(attrs, "Secure")
+	}
+	switch strings.ToLower(sc.SameSite) {
+	case "strict":
+		attrs = append(attrs, "SameSite=Strict")
+	case "lax":
+		attrs = append(attrs, "SameSite=Lax")
+	case "none":
+		attrs = append(attrs, "SameSite=None")
+	}
+
+	return strings.Join(attrs, "; ")
+}
+
+// generateSessionAffinityAddHeaders returns the header that sets the session affinity cookie with NGINX.
+// NGINX does not add the header when the value is empty, that is when the client already has the cookie.
+func generateSessionAffinityAddHeaders(upstreamName string, sc *conf_v1.SessionCookie) []version2.AddHeader {
+	if sc == nil {
+		return nil
+	}
+
+	return []version2.AddHeader{
+		{
+			Header: version2.Header{
+				Name:  "Set-Cookie",
+				Value: generateSessionAffinityVariable(upstreamName, "_cookie"),
+			},
+		},
+	}
+}
+
 func generateStatusMatchName(upstreamName string) string {
 	return fmt.Sprintf("%s_match", upstreamName)
 }
@@ -2333,6 +2744,13 @@ func generateRewrites(path string, proxy *conf_v1.ActionProxy, internal bool, or
 		isRegex = true
 	}
 
+	// The rewrite must match the same way as the location, so that the capture groups referenced in the rewrite path
+	// are set.
+	modifier := ""
+	if strings.HasPrefix(path, "~*") {
+		modifier = "(?i)"
+	}
+
 	trimmedPath := strings.TrimPrefix(strings.TrimPrefix(path, "~"), "*")
 	trimmedPath = strings.TrimSpace(trimmedPath)
 
@@ -2347,7 +2765,7 @@ func generateRewrites(path string, proxy *conf_v1.ActionProxy, internal bool, or
 	}
 
 	if isRegex {
-		rewrites = append(rewrites, fmt.Sprintf(`"^%v" "%v" break`, trimmedPath, proxy.RewritePath))
+		rewrites = append(rewrites, fmt.Sprintf(`"%v^%v" "%v" break`, modifier, strings.TrimPrefix(trimmedPath, "^"), proxy.RewritePath))
 	} else if internal {
 		rewrites = append(rewrites, fmt.Sprintf(`"^%v(.*)$" "%v$1" break`, trimmedPath, proxy.RewritePath))
 	}
@@ -2457,6 +2875,36 @@ func generatePath(path string) string {
 	return path
 }
 
+// sortLocations orders the locations by the type of their path: exact matches first, then prefixes and regular expressions last.
+// The sort is stable, so the regular expressions keep the order of the routes, as NGINX checks them in the order of their appearance.
+func sortLocations(locations []version2.Location) {
+	sort.SliceStable(locations, func(i, j int) bool {
+		return locationPathRank(locations[i].Path) < locationPathRank(locations[j].Path)
+	})
+}
+
+func locationPathRank(path string) int {
+	switch {
+	case strings.HasPrefix(path, "="):
+		return 0
+	case strings.HasPrefix(path, "~"):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// GetRoutePath returns the path of a route with the location modifier that corresponds to its path type.
+func GetRoutePath(route conf_v1.Route) string {
+	switch route.PathType {
+	case conf_v1.PathTypeExact:
+		return "=" + route.Path
+	case conf_v1.PathTypeRegularExpression:
+		return "~ " + route.Path
+	}
+	return route.Path
+}
+
 func generateReturnBlock(text string, code int, defaultCode int) *version2.Return {
 	returnBlock := &version2.Return{
 		Code: defaultCode,
@@ -2604,7 +3052,7 @@ func generateLocationForProxying(path string, upstreamName string, upstream conf
 		ProxyHideHeaders:         generateProxyHideHeaders(proxy),
 		ProxyPassHeaders:         generateProxyPassHeaders(proxy),
 		ProxyIgnoreHeaders:       generateProxyIgnoreHeaders(proxy),
-		AddHeaders:               generateProxyAddHeaders(proxy),
+		AddHeaders:               append(generateProxyAddHeaders(proxy), generateSessionAffinityAddHeaders(upstreamName, upstream.SessionAffinity)...),
 		ProxyPassRewrite:         generateProxyPassRewrite(path, proxy, internal),
 		Rewrites:                 generateRewrites(path, proxy, internal, originalPath, isGRPC(upstream.Type)),
 		HasKeepalive:             upstreamHasKeepalive(upstream, cfgParams),
@@ -2615,6 +3063,124 @@ func generateLocationForProxying(path string, upstreamName string, upstream conf
 		VSRName:                  vsrName,
 		VSRNamespace:             vsrNamespace,
 		GRPCPass:                 generateGRPCPass(isGRPC(upstream.Type), upstream.TLS.Enable, upstreamName),
+		GRPCWeb:                  generateGRPCWeb(upstream),
+		WebSocket:                generateWebSocket(path, upstreamName, upstream, cfgParams, proxy, originalPath),
+	}
+}
+
+func generateGRPCWeb(upstream conf_v1.Upstream) *version2.GRPCWeb {
+	if !isGRPC(upstream.Type) || upstream.GRPCWeb == nil || !upstream.GRPCWeb.Enable {
+		return nil
+	}
+
+	return &version2.GRPCWeb{
+		AllowOrigin: generateString(upstream.GRPCWeb.AllowOrigin, "*"),
+	}
+}
+
+// generateGRPCWebTextLocations adds the internal location that translates the gRPC-Web text requests of each location
+// with gRPC-Web. The internal location is a copy of the location, so that the same policies apply to the requests.
+// It decodes the request and passes it to the gRPC upstream in a subrequest to the original URI.
+func generateGRPCWebTextLocations(locations []version2.Location) []version2.Location {
+	var textLocations []version2.Location
+
+	for i := range locations {
+		grpcWeb := locations[i].GRPCWeb
+		if grpcWeb == nil {
+			continue
+		}
+
+		grpcWeb.TextPath = fmt.Sprintf("/%vgrpc_web_text_%d", internalLocationPrefix, len(textLocations))
+		grpcWeb.BufferSize = grpcWebTextBufferSize
+
+		loc := locations[i]
+		loc.Path = grpcWeb.TextPath
+		loc.Internal = true
+		loc.GRPCPass = ""
+		loc.GRPCWeb = &version2.GRPCWeb{
+			AllowOrigin: grpcWeb.AllowOrigin,
+			Text:        true,
+			BufferSize:  grpcWebTextBufferSize,
+		}
+
+		textLocations = append(textLocations, loc)
+	}
+
+	return append(locations, textLocations...)
+}
+
+// generateWebSocket generates the internal location for the requests of a location that upgrade the connection.
+// The URI of the request is restored in the internal location the same way as in the internal locations of splits and matches.
+func generateWebSocket(path string, upstreamName string, upstream conf_v1.Upstream, cfgParams *ConfigParams,
+	proxy *conf_v1.ActionProxy, originalPath string,
+) *version2.WebSocket {
+	if isGRPC(upstream.Type) || upstream.WebSocket == nil || !upstream.WebSocket.Enable {
+		return nil
+	}
+
+	readTimeout := generateTimeWithDefault(upstream.ProxyReadTimeout, cfgParams.ProxyReadTimeout)
+	sendTimeout := generateTimeWithDefault(upstream.ProxySendTimeout, cfgParams.ProxySendTimeout)
+
+	return &version2.WebSocket{
+		Upstream:         upstreamName,
+		ProxyPass:        generateProxyPass(upstream.TLS.Enable, upstreamName, true, proxy),
+		Rewrites:         generateRewrites(path, proxy, true, originalPath, false),
+		ProxyReadTimeout: generateTimeWithDefault(upstream.WebSocket.IdleTimeout, readTimeout),
+		ProxySendTimeout: generateTimeWithDefault(upstream.WebSocket.IdleTimeout, sendTimeout),
+	}
+}
+
+// generateWebSocketLocations adds the internal location for the upgraded connections of each location with WebSocket.
+// The internal location is a copy of the location, so that the same policies and headers apply to the upgraded connections.
+// The access log that counts the closed connections replaces the inherited access logs, so they are set explicitly.
+func generateWebSocketLocations(locations []version2.Location, serverAccessLog *version2.AccessLog, mainAccessLog string) []version2.Location {
+	var wsLocations []version2.Location
+
+	for i := range locations {
+		ws := locations[i].WebSocket
+		if ws == nil {
+			continue
+		}
+
+		ws.Path = fmt.Sprintf("/%vwebsocket_%d", internalLocationPrefix, len(wsLocations))
+
+		loc := locations[i]
+		loc.Path = ws.Path
+		loc.Internal = true
+		loc.ProxyPass = ws.ProxyPass
+		loc.ProxyPassRewrite = ""
+		loc.Rewrites = ws.Rewrites
+		loc.ProxyReadTimeout = ws.ProxyReadTimeout
+		loc.ProxySendTimeout = ws.ProxySendTimeout
+		loc.WebSocket = nil
+		loc.WebSocketUpstream = ws.Upstream
+
+		if loc.AccessLog == nil {
+			loc.AccessLog = serverAccessLog
+		}
+		if loc.AccessLog == nil {
+			loc.AccessLog = generateMainAccessLog(mainAccessLog)
+		}
+		if loc.AccessLog != nil && loc.AccessLog.Off {
+			loc.AccessLog = nil
+		}
+
+		wsLocations = append(wsLocations, loc)
+	}
+
+	return append(locations, wsLocations...)
+}
+
+// generateMainAccessLog generates the access log of the access-log ConfigMap key.
+func generateMainAccessLog(mainAccessLog string) *version2.AccessLog {
+	fields := strings.Fields(mainAccessLog)
+	if len(fields) == 0 || fields[0] == "off" {
+		return nil
+	}
+
+	return &version2.AccessLog{
+		Destination: fields[0],
+		Format:      strings.Join(fields[1:], " "),
 	}
 }
 
@@ -2693,6 +3259,364 @@ func generateLocationForReturn(path string, locationSnippets []string, actionRet
 		}
 }
 
+// NewMaintenanceKeyValUpdate returns the keyval update that toggles the maintenance mode of a VirtualServer without reloading.
+func NewMaintenanceKeyValUpdate(vs *conf_v1.VirtualServer) WeightUpdate {
+	namer := NewVSVariableNamer(vs)
+	value := "0"
+	if vs.Spec.Maintenance != nil && vs.Spec.Maintenance.Enable {
+		value = "1"
+	}
+	return WeightUpdate{
+		Zone:  namer.GetNameOfKeyvalZoneForMaintenance(),
+		Key:   namer.GetNameOfKeyvalKeyForMaintenance(),
+		Value: value,
+	}
+}
+
+// maintenanceCfg holds the configuration for the maintenance mode of a VirtualServer.
+type maintenanceCfg struct {
+	Server         *version2.Maintenance
+	Maps           []version2.Map
+	KeyValZones    []version2.KeyValZone
+	KeyVals        []version2.KeyVal
+	ReturnLocation version2.ReturnLocation
+}
+
+// generateMaintenance generates the maintenance mode configuration of a VirtualServer.
+// With NGINX Plus, the configuration is generated even if the maintenance mode is disabled,
+// so that it can be toggled via the keyval without a reload.
+func (vsc *virtualServerConfigurator) generateMaintenance(vsEx *VirtualServerEx, namer *VariableNamer) maintenanceCfg {
+	m := vsEx.VirtualServer.Spec.Maintenance
+	if m == nil || (!m.Enable && !vsc.isPlus) {
+		return maintenanceCfg{}
+	}
+
+	var cfg maintenanceCfg
+
+	// the maintenance response is returned when the source of the map matches the expected value:
+	// the maintenance mode is enabled, the client is not in the allow list and the bypass header is not present.
+	source := "1"
+	expected := "1"
+	if vsc.isPlus {
+		zoneName := namer.GetNameOfKeyvalZoneForMaintenance()
+		cfg.KeyValZones = append(cfg.KeyValZones, version2.KeyValZone{
+			Name:  zoneName,
+			Size:  maintenanceKeyValZoneSize,
+			State: fmt.Sprintf("%s/%s.json", keyvalZoneBasePath, zoneName),
+		})
+		cfg.KeyVals = append(cfg.KeyVals, version2.KeyVal{
+			Key:      namer.GetNameOfKeyvalKeyForMaintenance(),
+			Variable: namer.GetNameOfKeyvalForMaintenance(),
+			ZoneName: zoneName,
+		})
+		source = fmt.Sprintf("${%s}", strings.TrimPrefix(namer.GetNameOfKeyvalForMaintenance(), "$"))
+	}
+
+	server := &version2.Maintenance{
+		Variable:     namer.GetNameForMaintenanceVariable(),
+		Code:         defaultMaintenanceCode,
+		LocationName: maintenanceLocationName,
+	}
+	if m.Code != 0 {
+		server.Code = m.Code
+	}
+
+	if len(m.Allow) > 0 {
+		server.AllowVariable = namer.GetNameForMaintenanceAllowVariable()
+		server.Allow = m.Allow
+		source += fmt.Sprintf("${%s}", strings.TrimPrefix(server.AllowVariable, "$"))
+		expected += "0"
+	}
+
+	if m.BypassHeader != nil {
+		bypassVariable := namer.GetNameForMaintenanceBypassVariable()
+		cfg.Maps = append(cfg.Maps, version2.Map{
+			Source:   getHeaderVariableName(m.BypassHeader.Name),
+			Variable: bypassVariable,
+			Parameters: []version2.Parameter{
+				{
+					Value:  fmt.Sprintf(`"%s"`, m.BypassHeader.Value),
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+		})
+		source += fmt.Sprintf("${%s}", strings.TrimPrefix(bypassVariable, "$"))
+		expected += "0"
+	}
+
+	cfg.Maps = append(cfg.Maps, version2.Map{
+		Source:   fmt.Sprintf(`"%s"`, source),
+		Variable: server.Variable,
+		Parameters: []version2.Parameter{
+			{
+				Value:  fmt.Sprintf(`"%s"`, expected),
+				Result: "1",
+			},
+			{
+				Value:  "default",
+				Result: "0",
+			},
+		},
+	})
+
+	defaultType := m.Type
+	if defaultType == "" {
+		defaultType = defaultMaintenanceType
+	}
+
+	cfg.Server = server
+	cfg.ReturnLocation = version2.ReturnLocation{
+		Name:        maintenanceLocationName,
+		DefaultType: defaultType,
+		Return: version2.Return{
+			Text: vsc.generateMaintenanceBody(vsEx),
+		},
+	}
+
+	return cfg
+}
+
+// generateMaintenanceBody returns the body of the maintenance response either from the VirtualServer
+// or from the referenced ConfigMap. The content of the ConfigMap is escaped to be used in a return directive.
+func (vsc *virtualServerConfigurator) generateMaintenanceBody(vsEx *VirtualServerEx) string {
+	m := vsEx.VirtualServer.Spec.Maintenance
+	if m.Body != "" {
+		return m.Body
+	}
+	if m.ConfigMap == nil {
+		return defaultMaintenanceBody
+	}
+
+	cmKey := fmt.Sprintf("%s/%s", vsEx.VirtualServer.Namespace, m.ConfigMap.Name)
+	dataKey := m.ConfigMap.Key
+	if dataKey == "" {
+		dataKey = defaultMaintenanceConfigMapKey
+	}
+
+	cm, exists := vsEx.ConfigMaps[cmKey]
+	if !exists || cm == nil {
+		vsc.addWarningf(vsEx.VirtualServer, "ConfigMap %s for the maintenance page doesn't exist, using the default page", cmKey)
+		return defaultMaintenanceBody
+	}
+
+	page, exists := cm.Data[dataKey]
+	if !exists {
+		vsc.addWarningf(vsEx.VirtualServer, "ConfigMap %s doesn't have the key %s for the maintenance page, using the default page", cmKey, dataKey)
+		return defaultMaintenanceBody
+	}
+
+	if strings.Contains(page, "$") {
+		vsc.addWarningf(vsEx.VirtualServer, "The maintenance page in the key %s of ConfigMap %s must not contain '$', using the default page", dataKey, cmKey)
+		return defaultMaintenanceBody
+	}
+
+	return maintenancePageReplacer.Replace(page)
+}
+
+var maintenancePageReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// redirectMapCodes are the status codes supported by the bulk redirects.
+var redirectMapCodes = []int{301, 302, 307, 308}
+
+// redirectMapEntry defines a bulk redirect.
+type redirectMapEntry struct {
+	Source string
+	Target string
+	Code   int
+}
+
+// redirectMapCfg holds the configuration for the bulk redirects of a VirtualServer.
+type redirectMapCfg struct {
+	Server      []version2.RedirectMap
+	Maps        []version2.Map
+	KeyValZones []version2.KeyValZone
+	KeyVals     []version2.KeyVal
+}
+
+// generateRedirectMap generates the configuration for the bulk redirects of a VirtualServer.
+// The URI of a request is mapped to the code and the target of a redirect, which are then split into a variable per code,
+// because the code of the return directive cannot be a variable.
+// With NGINX Plus, the redirects are stored in a keyval zone, so that they can be updated without a reload.
+func (vsc *virtualServerConfigurator) generateRedirectMap(vsEx *VirtualServerEx, namer *VariableNamer) redirectMapCfg {
+	if vsEx.VirtualServer.Spec.RedirectMap == nil {
+		return redirectMapCfg{}
+	}
+
+	var cfg redirectMapCfg
+
+	entries, problems := getRedirectMapEntries(vsEx)
+	vsc.addWarnings(vsEx.VirtualServer, problems)
+
+	variable := namer.GetNameForRedirectMapVariable()
+	if vsc.isPlus {
+		zoneName := namer.GetNameOfKeyvalZoneForRedirectMap()
+		cfg.KeyValZones = append(cfg.KeyValZones, version2.KeyValZone{
+			Name:  zoneName,
+			Size:  redirectMapKeyValZoneSize,
+			State: fmt.Sprintf("%s/%s.json", keyvalZoneBasePath, zoneName),
+		})
+		cfg.KeyVals = append(cfg.KeyVals, version2.KeyVal{
+			Key:      "$uri",
+			Variable: variable,
+			ZoneName: zoneName,
+		})
+	} else {
+		params := make([]version2.Parameter, 0, len(entries)+1)
+		for _, e := range entries {
+			params = append(params, version2.Parameter{
+				Value:  fmt.Sprintf(`"%s"`, e.Source),
+				Result: fmt.Sprintf(`"%d %s"`, e.Code, e.Target),
+			})
+		}
+		params = append(params, version2.Parameter{
+			Value:  "default",
+			Result: `""`,
+		})
+		cfg.Maps = append(cfg.Maps, version2.Map{
+			Source:     "$uri",
+			Variable:   variable,
+			Parameters: params,
+		})
+	}
+
+	for _, code := range redirectMapCodes {
+		codeVariable := namer.GetNameForRedirectMapCodeVariable(code)
+		cfg.Maps = append(cfg.Maps, version2.Map{
+			Source:   variable,
+			Variable: codeVariable,
+			Parameters: []version2.Parameter{
+				{
+					Value:  fmt.Sprintf(`"~^%d (.+)$"`, code),
+					Result: "$1",
+				},
+				{
+					Value:  "default",
+					Result: `""`,
+				},
+			},
+		})
+		cfg.Server = append(cfg.Server, version2.RedirectMap{
+			Variable: codeVariable,
+			Code:     code,
+		})
+	}
+
+	return cfg
+}
+
+// NewRedirectMapKeyValUpdate returns the keyval update that replaces the bulk redirects of a VirtualServer without reloading,
+// along with the problems found in the redirects.
+func NewRedirectMapKeyValUpdate(vsEx *VirtualServerEx) (WeightUpdate, []string) {
+	namer := NewVSVariableNamer(vsEx.VirtualServer)
+	entries, problems := getRedirectMapEntries(vsEx)
+
+	pairs := make(map[string]string, len(entries))
+	for _, e := range entries {
+		pairs[e.Source] = fmt.Sprintf("%d %s", e.Code, e.Target)
+	}
+
+	return WeightUpdate{
+		Zone:  namer.GetNameOfKeyvalZoneForRedirectMap(),
+		Pairs: pairs,
+	}, problems
+}
+
+// getRedirectMapEntries returns the bulk redirects from the ConfigMap referenced by a VirtualServer,
+// along with the problems found in the ConfigMap.
+func getRedirectMapEntries(vsEx *VirtualServerEx) ([]redirectMapEntry, []string) {
+	rm := vsEx.VirtualServer.Spec.RedirectMap
+
+	cmKey := fmt.Sprintf("%s/%s", vsEx.VirtualServer.Namespace, rm.ConfigMap)
+	dataKey := rm.Key
+	if dataKey == "" {
+		dataKey = defaultRedirectMapConfigMapKey
+	}
+	code := rm.Code
+	if code == 0 {
+		code = defaultRedirectMapCode
+	}
+
+	cm, exists := vsEx.ConfigMaps[cmKey]
+	if !exists || cm == nil {
+		return nil, []string{fmt.Sprintf("ConfigMap %s for the redirect map doesn't exist", cmKey)}
+	}
+
+	data, exists := cm.Data[dataKey]
+	if !exists {
+		return nil, []string{fmt.Sprintf("ConfigMap %s doesn't have the key %s for the redirect map", cmKey, dataKey)}
+	}
+
+	entries, problems := parseRedirectMap(data, code)
+	for i := range problems {
+		problems[i] = fmt.Sprintf("Redirect map in the key %s of ConfigMap %s: %s", dataKey, cmKey, problems[i])
+	}
+
+	return entries, problems
+}
+
+// parseRedirectMap parses the bulk redirects, one per line in the format: source target [code].
+// Empty lines and lines starting with # are ignored. Invalid and duplicated redirects are skipped and reported as problems.
+func parseRedirectMap(data string, defaultCode int) ([]redirectMapEntry, []string) {
+	var entries []redirectMapEntry
+	var problems []string
+
+	lineNumbers := make(map[string]int)
+
+	for i, line := range strings.Split(data, "\n") {
+		lineNumber := i + 1
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) > 3 {
+			problems = append(problems, fmt.Sprintf("line %d must be in the format: source target [code]", lineNumber))
+			continue
+		}
+		if len(fields) < 2 {
+			problems = append(problems, fmt.Sprintf("line %d must have a target", lineNumber))
+			continue
+		}
+
+		entry := redirectMapEntry{
+			Source: fields[0],
+			Target: fields[1],
+			Code:   defaultCode,
+		}
+
+		if !strings.HasPrefix(entry.Source, "/") || strings.ContainsAny(entry.Source, `"\$`) {
+			problems = append(problems, fmt.Sprintf("line %d has the invalid source %s: must start with / and must not include '\"', '\\' or '$'", lineNumber, entry.Source))
+			continue
+		}
+		if (!strings.HasPrefix(entry.Target, "/") && !strings.Contains(entry.Target, "://")) || strings.ContainsAny(entry.Target, `"\$`) {
+			problems = append(problems, fmt.Sprintf("line %d has the invalid target %s: must be a path starting with / or a URL, and must not include '\"', '\\' or '$'", lineNumber, entry.Target))
+			continue
+		}
+		if len(fields) == 3 {
+			code, err := strconv.Atoi(fields[2])
+			if err != nil || !slices.Contains(redirectMapCodes, code) {
+				problems = append(problems, fmt.Sprintf("line %d has the invalid code %s: must be one of 301, 302, 307 or 308", lineNumber, fields[2]))
+				continue
+			}
+			entry.Code = code
+		}
+
+		if previous, exists := lineNumbers[entry.Source]; exists {
+			problems = append(problems, fmt.Sprintf("line %d duplicates the source %s of line %d", lineNumber, entry.Source, previous))
+			continue
+		}
+		lineNumbers[entry.Source] = lineNumber
+
+		entries = append(entries, entry)
+	}
+
+	return entries, problems
+}
+
 type routingCfg struct {
 	Maps                     []version2.Map
 	SplitClients             []version2.SplitClient
@@ -2704,6 +3628,310 @@ type routingCfg struct {
 	TwoWaySplitClients       []version2.TwoWaySplitClients
 }
 
+// generateRequestID generates the request ID configuration of a VirtualServer.
+// Without an override in the VirtualServer, the request ID settings of the ConfigMap apply, which are available
+// through a variable of the main configuration. Otherwise, the VirtualServer either uses the generated $request_id or,
+// when the incoming request ID is trusted, a map that falls back to $request_id when the header is missing or empty.
+func (vsc *virtualServerConfigurator) generateRequestID(vsEx *VirtualServerEx, namer *VariableNamer) (*version2.RequestID, []version2.Map) {
+	enable := vsc.cfgParams.RequestID
+	header := vsc.cfgParams.RequestIDHeader
+	trustIncoming := vsc.cfgParams.RequestIDTrustIncoming
+
+	rid := vsEx.VirtualServer.Spec.RequestID
+	if rid != nil {
+		if rid.Enable != nil {
+			enable = *rid.Enable
+		}
+		if rid.Header != "" {
+			header = rid.Header
+		}
+		if rid.TrustIncoming != nil {
+			trustIncoming = *rid.TrustIncoming
+		}
+	}
+
+	if !enable {
+		return nil, nil
+	}
+
+	if vsc.cfgParams.RequestID && strings.EqualFold(header, vsc.cfgParams.RequestIDHeader) && trustIncoming == vsc.cfgParams.RequestIDTrustIncoming {
+		return &version2.RequestID{Header: header, Variable: globalRequestIDVariable}, nil
+	}
+
+	if !trustIncoming {
+		return &version2.RequestID{Header: header, Variable: "$request_id"}, nil
+	}
+
+	headerVariable := getHeaderVariableName(header)
+	variable := namer.GetNameForRequestIDVariable()
+	requestIDMap := version2.Map{
+		Source:   headerVariable,
+		Variable: variable,
+		Parameters: []version2.Parameter{
+			{
+				Value:  `""`,
+				Result: "$request_id",
+			},
+			{
+				Value:  "default",
+				Result: headerVariable,
+			},
+		},
+	}
+
+	return &version2.RequestID{Header: header, Variable: variable}, []version2.Map{requestIDMap}
+}
+
+// generateRequestIDLogFormat generates the log format for a VirtualServer that doesn't use the request ID variable of
+// the main configuration. The log format is the main log format of the ConfigMap, where the $correlation_id variable
+// is replaced with the request ID variable of the VirtualServer, or the default log format with the request ID.
+func (vsc *virtualServerConfigurator) generateRequestIDLogFormat(requestID *version2.RequestID, namer *VariableNamer) *version2.LogFormat {
+	if requestID == nil || requestID.Variable == globalRequestIDVariable {
+		return nil
+	}
+
+	if len(vsc.cfgParams.MainLogFormat) == 0 {
+		return &version2.LogFormat{
+			Name: namer.GetNameForRequestIDLogFormat(),
+			Format: []string{
+				`$remote_addr - $remote_user [$time_local] "$request" `,
+				`$status $body_bytes_sent "$http_referer" `,
+				fmt.Sprintf(`"$http_user_agent" "$http_x_forwarded_for" "%s"`, requestID.Variable),
+			},
+		}
+	}
+
+	var format []string
+	for i, line := range vsc.cfgParams.MainLogFormat {
+		if i > 0 {
+			line = " " + line
+		}
+		format = append(format, strings.ReplaceAll(line, globalRequestIDVariable, requestID.Variable))
+	}
+
+	return &version2.LogFormat{
+		Name:     namer.GetNameForRequestIDLogFormat(),
+		Escaping: vsc.cfgParams.MainLogFormatEscaping,
+		Format:   format,
+	}
+}
+
+// applyRequestIDLogFormat makes the access logs of a VirtualServer that use the main log format use the log format with
+// the request ID instead. Without an access log of its own, the server gets the access log of the ConfigMap.
+func applyRequestIDLogFormat(logFormat string, serverAccessLog *version2.AccessLog, locations []version2.Location, mainAccessLog string) *version2.AccessLog {
+	if serverAccessLog == nil {
+		serverAccessLog = generateMainAccessLog(mainAccessLog)
+	}
+
+	accessLogs := []*version2.AccessLog{serverAccessLog}
+	for _, l := range locations {
+		accessLogs = append(accessLogs, l.AccessLog)
+	}
+
+	for _, al := range accessLogs {
+		if al != nil && !al.Off && al.Format == defaultAccessLogFormat {
+			al.Format = logFormat
+		}
+	}
+
+	return serverAccessLog
+}
+
+// accessLogConditionPatterns maps the conditions of an access log to the patterns of the matching status codes.
+var accessLogConditionPatterns = map[string]string{
+	conf_v1.AccessLogCondition4xx:    "~^4",
+	conf_v1.AccessLogCondition5xx:    "~^5",
+	conf_v1.AccessLogConditionErrors: "~^[45]",
+}
+
+// observabilityCfg holds the variables evaluating the conditions and the sampling of the access logs and the tracing of a resource.
+type observabilityCfg struct {
+	Maps         []version2.Map
+	SplitClients []version2.SplitClient
+}
+
+func (cfg *observabilityCfg) addMap(m version2.Map) {
+	for _, existing := range cfg.Maps {
+		if existing.Variable == m.Variable {
+			return
+		}
+	}
+	cfg.Maps = append(cfg.Maps, m)
+}
+
+func (cfg *observabilityCfg) addSplitClient(sc version2.SplitClient) {
+	for _, existing := range cfg.SplitClients {
+		if existing.Variable == sc.Variable {
+			return
+		}
+	}
+	cfg.SplitClients = append(cfg.SplitClients, sc)
+}
+
+// generateAccessLog generates an access log of a VirtualServer, a route or a TransportServer.
+// The condition and the sampling are evaluated in variables that start with the prefix. Sampling is based on
+// split_clients with the sampleSource, which differs from the source of the splits of a route, so that
+// the sampled requests are not all proxied to the same split. When both are set, a map combines them into a single variable,
+// because the if parameter of the access_log directive accepts only one variable.
+func generateAccessLog(al *conf_v1.AccessLog, prefix string, defaultDestination string, defaultFormat string, sampleSource string, cfg *observabilityCfg) *version2.AccessLog {
+	if al == nil {
+		return nil
+	}
+
+	if al.Enable != nil && !*al.Enable {
+		return &version2.AccessLog{Off: true}
+	}
+
+	accessLog := &version2.AccessLog{
+		Destination: al.Destination,
+		Format:      al.Format,
+	}
+	if accessLog.Destination == "" {
+		accessLog.Destination = defaultDestination
+	}
+	if accessLog.Format == "" {
+		accessLog.Format = defaultFormat
+	}
+
+	var conditionVariable string
+	if al.Condition != "" {
+		conditionVariable = fmt.Sprintf("%s_access_log_%s", prefix, al.Condition)
+		cfg.addMap(version2.Map{
+			Source:   "$status",
+			Variable: conditionVariable,
+			Parameters: []version2.Parameter{
+				{
+					Value:  accessLogConditionPatterns[al.Condition],
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+		})
+	}
+
+	var sampleVariable string
+	if al.Sampling > 0 && al.Sampling < 100 {
+		sampleVariable = fmt.Sprintf("%s_access_log_sample_%d", prefix, al.Sampling)
+		cfg.addSplitClient(version2.SplitClient{
+			Source:   sampleSource,
+			Variable: sampleVariable,
+			Distributions: []version2.Distribution{
+				{
+					Weight: fmt.Sprintf("%d%%", al.Sampling),
+					Value:  "1",
+				},
+				{
+					Weight: "*",
+					Value:  "0",
+				},
+			},
+		})
+	}
+
+	switch {
+	case conditionVariable != "" && sampleVariable != "":
+		accessLog.Condition = fmt.Sprintf("%s_access_log_%s_sample_%d", prefix, al.Condition, al.Sampling)
+		cfg.addMap(version2.Map{
+			Source:   fmt.Sprintf(`"${%s}${%s}"`, strings.TrimPrefix(conditionVariable, "$"), strings.TrimPrefix(sampleVariable, "$")),
+			Variable: accessLog.Condition,
+			Parameters: []version2.Parameter{
+				{
+					Value:  `"11"`,
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+		})
+	case conditionVariable != "":
+		accessLog.Condition = conditionVariable
+	case sampleVariable != "":
+		accessLog.Condition = sampleVariable
+	}
+
+	return accessLog
+}
+
+// getAccessLogDestination returns the destination of the access-log ConfigMap key, which also includes the log format.
+func getAccessLogDestination(mainAccessLog string) string {
+	fields := strings.Fields(mainAccessLog)
+	if len(fields) == 0 || fields[0] == "off" {
+		return defaultAccessLogDestination
+	}
+	return fields[0]
+}
+
+func addAccessLogToLocations(accessLog *version2.AccessLog, locations []version2.Location) {
+	for i := range locations {
+		locations[i].AccessLog = accessLog
+	}
+}
+
+// generateTracing generates the OpenTelemetry tracing of a VirtualServer or a route.
+// Sampling is based on split_clients with the trace ID, so that the sampling decision is consistent across the spans of a trace.
+func (vsc *virtualServerConfigurator) generateTracing(owner runtime.Object, tracing *conf_v1.Tracing, prefix string, cfg *observabilityCfg) *version2.Tracing {
+	if tracing == nil {
+		return nil
+	}
+
+	if !vsc.cfgParams.MainOtelLoadModule {
+		vsc.addWarningf(owner, "Tracing cannot be configured. Tracing requires the otel-exporter-endpoint ConfigMap key")
+		return nil
+	}
+
+	if tracing.Enable != nil && !*tracing.Enable {
+		return &version2.Tracing{Trace: "off"}
+	}
+
+	t := &version2.Tracing{
+		Trace:    "on",
+		SpanName: tracing.SpanName,
+	}
+
+	if tracing.Sampling > 0 && tracing.Sampling < 100 {
+		t.Trace = fmt.Sprintf("%s_otel_sample_%d", prefix, tracing.Sampling)
+		cfg.addSplitClient(version2.SplitClient{
+			Source:   "$otel_trace_id",
+			Variable: t.Trace,
+			Distributions: []version2.Distribution{
+				{
+					Weight: fmt.Sprintf("%d%%", tracing.Sampling),
+					Value:  "on",
+				},
+				{
+					Weight: "*",
+					Value:  "off",
+				},
+			},
+		})
+	}
+
+	for _, attr := range tracing.SpanAttributes {
+		t.SpanAttributes = append(t.SpanAttributes, version2.SpanAttribute{
+			Name:  attr.Name,
+			Value: attr.Value,
+		})
+	}
+
+	return t
+}
+
+func addTracingToLocations(tracing *version2.Tracing, locations []version2.Location) {
+	for i := range locations {
+		locations[i].Tracing = tracing
+	}
+}
+
+// getHeaderVariableName returns the name of the variable that holds the value of a request header.
+func getHeaderVariableName(header string) string {
+	return fmt.Sprintf("$http_%s", strings.ReplaceAll(strings.ToLower(header), "-", "_"))
+}
+
 func generateSplits(
 	splits []conf_v1.Split,
 	upstreamNamer *upstreamNamer,
@@ -2911,7 +4139,7 @@ func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, cr
 				successfulResult = VariableNamer.GetNameForVariableForMatchesRouteMap(index, i, j+1)
 			}
 
-			params := generateParametersForMatchesRouteMap(c.Value, successfulResult)
+			params := generateParametersForMatchesRouteMap(getValueForMatchesRouteMapFromCondition(c), successfulResult)
 
 			matchMap := version2.Map{
 				Source:     source,
@@ -3133,7 +4361,27 @@ func generateParametersForMatchesRouteMap(matchedValue string, successfulResult
 	return params
 }
 
+func getValueForMatchesRouteMapFromCondition(condition conf_v1.Condition) string {
+	if condition.Method != "" {
+		return condition.Method
+	}
+
+	if condition.Host != "" {
+		return condition.Host
+	}
+
+	return condition.Value
+}
+
 func getNameForSourceForMatchesRouteMapFromCondition(condition conf_v1.Condition) string {
+	if condition.Method != "" {
+		return "$request_method"
+	}
+
+	if condition.Host != "" {
+		return "$host"
+	}
+
 	if condition.Header != "" {
 		return fmt.Sprintf("$http_%s", strings.ReplaceAll(condition.Header, "-", "_"))
 	}
@@ -3220,12 +4468,39 @@ func createEndpointsFromUpstream(upstream version2.Upstream) []string {
 	var endpoints []string
 
 	for _, server := range upstream.Servers {
+		if server.Drain || server.Backup {
+			continue
+		}
 		endpoints = append(endpoints, server.Address)
 	}
 
 	return endpoints
 }
 
+func createOtherZoneEndpointsFromUpstream(upstream version2.Upstream) []string {
+	var endpoints []string
+
+	for _, server := range upstream.Servers {
+		if server.Backup {
+			endpoints = append(endpoints, server.Address)
+		}
+	}
+
+	return endpoints
+}
+
+func createDrainingEndpointsFromUpstream(upstream version2.Upstream) []string {
+	var endpoints []string
+
+	for _, server := range upstream.Servers {
+		if server.Drain {
+			endpoints = append(endpoints, server.Address)
+		}
+	}
+
+	return endpoints
+}
+
 func createUpstreamsForPlus(
 	virtualServerEx *VirtualServerEx,
 	baseCfgParams *ConfigParams,
@@ -3250,13 +4525,15 @@ func createUpstreamsForPlus(
 
 		endpointsKey := GenerateEndpointsKey(upstreamNamespace, u.Service, u.Subselector, u.Port)
 		endpoints := virtualServerEx.Endpoints[endpointsKey]
+		drainingEndpoints := virtualServerEx.DrainingEndpoints[endpointsKey]
+		otherZoneEndpoints := virtualServerEx.OtherZoneEndpoints[endpointsKey]
 
 		backupEndpoints := []string{}
 		if u.Backup != "" {
 			backupEndpointsKey := GenerateEndpointsKey(upstreamNamespace, u.Backup, u.Subselector, *u.BackupPort)
 			backupEndpoints = virtualServerEx.Endpoints[backupEndpointsKey]
 		}
-		ups := vsc.generateUpstream(virtualServerEx.VirtualServer, upstreamName, u, isExternalNameSvc, endpoints, backupEndpoints)
+		ups := vsc.generateUpstream(virtualServerEx.VirtualServer, upstreamName, u, isExternalNameSvc, endpoints, drainingEndpoints, otherZoneEndpoints, backupEndpoints)
 		upstreams = append(upstreams, ups)
 	}
 
@@ -3274,6 +4551,8 @@ func createUpstreamsForPlus(
 
 			endpointsKey := GenerateEndpointsKey(upstreamNamespace, u.Service, u.Subselector, u.Port)
 			endpoints := virtualServerEx.Endpoints[endpointsKey]
+			drainingEndpoints := virtualServerEx.DrainingEndpoints[endpointsKey]
+			otherZoneEndpoints := virtualServerEx.OtherZoneEndpoints[endpointsKey]
 
 			// BackupService
 			backupEndpoints := []string{}
@@ -3281,7 +4560,7 @@ func createUpstreamsForPlus(
 				backupEndpointsKey := GenerateEndpointsKey(upstreamNamespace, u.Backup, u.Subselector, *u.BackupPort)
 				backupEndpoints = virtualServerEx.Endpoints[backupEndpointsKey]
 			}
-			ups := vsc.generateUpstream(vsr, upstreamName, u, isExternalNameSvc, endpoints, backupEndpoints)
+			ups := vsc.generateUpstream(vsr, upstreamName, u, isExternalNameSvc, endpoints, drainingEndpoints, otherZoneEndpoints, backupEndpoints)
 			upstreams = append(upstreams, ups)
 		}
 	}
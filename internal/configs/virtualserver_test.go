@@ -15512,7 +15512,7 @@ func TestGenerateUpstream(t *testing.T) {
 	}
 
 	vsc := newVirtualServerConfigurator(&cfgParams, false, false, &StaticConfigParams{}, false, &fakeBV)
-	result := vsc.generateUpstream(nil, name, upstream, false, endpoints, backupEndpoints)
+	result := vsc.generateUpstream(nil, name, upstream, false, endpoints, nil, nil, backupEndpoints)
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("generateUpstream() returned %v but expected %v", result, expected)
 	}
@@ -15591,7 +15591,7 @@ func TestGenerateUpstreamWithKeepalive(t *testing.T) {
 
 	for _, test := range tests {
 		vsc := newVirtualServerConfigurator(test.cfgParams, false, false, &StaticConfigParams{}, false, &fakeBV)
-		result := vsc.generateUpstream(nil, name, test.upstream, false, endpoints, nil)
+		result := vsc.generateUpstream(nil, name, test.upstream, false, endpoints, nil, nil, nil)
 		if !reflect.DeepEqual(result, test.expected) {
 			t.Errorf("generateUpstream() returned %v but expected %v for the case of %v", result, test.expected, test.msg)
 		}
@@ -15623,7 +15623,7 @@ func TestGenerateUpstreamForExternalNameService(t *testing.T) {
 	}
 
 	vsc := newVirtualServerConfigurator(&cfgParams, true, true, &StaticConfigParams{}, false, &fakeBV)
-	result := vsc.generateUpstream(nil, name, upstream, true, endpoints, nil)
+	result := vsc.generateUpstream(nil, name, upstream, true, endpoints, nil, nil, nil)
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("generateUpstream() returned %v but expected %v", result, expected)
 	}
@@ -15670,7 +15670,7 @@ func TestGenerateUpstreamWithNTLM(t *testing.T) {
 	}
 
 	vsc := newVirtualServerConfigurator(&cfgParams, true, false, &StaticConfigParams{}, false, &fakeBV)
-	result := vsc.generateUpstream(nil, name, upstream, false, endpoints, nil)
+	result := vsc.generateUpstream(nil, name, upstream, false, endpoints, nil, nil, nil)
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("generateUpstream() returned %v but expected %v", result, expected)
 	}
@@ -15680,6 +15680,141 @@ func TestGenerateUpstreamWithNTLM(t *testing.T) {
 	}
 }
 
+func TestGenerateUpstreamWithDrainingEndpoints(t *testing.T) {
+	t.Parallel()
+	name := "test-upstream"
+	upstream := conf_v1.Upstream{Service: name, Port: 80}
+	endpoints := []string{
+		"192.168.10.10:8080",
+	}
+	drainingEndpoints := []string{
+		"192.168.10.11:8080",
+	}
+	cfgParams := ConfigParams{
+		Context:          context.Background(),
+		MaxFails:         1,
+		FailTimeout:      "10s",
+		UpstreamZoneSize: "256k",
+	}
+
+	expected := version2.Upstream{
+		Name: "test-upstream",
+		UpstreamLabels: version2.UpstreamLabels{
+			Service: "test-upstream",
+		},
+		Servers: []version2.UpstreamServer{
+			{
+				Address: "192.168.10.10:8080",
+			},
+			{
+				Address: "192.168.10.11:8080",
+				Drain:   true,
+			},
+		},
+		MaxFails:         1,
+		FailTimeout:      "10s",
+		UpstreamZoneSize: "256k",
+	}
+
+	for _, isPlus := range []bool{true, false} {
+		vsc := newVirtualServerConfigurator(&cfgParams, isPlus, false, &StaticConfigParams{}, false, &fakeBV)
+		result := vsc.generateUpstream(nil, name, upstream, false, endpoints, drainingEndpoints, nil, nil)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("generateUpstream() returned %v but expected %v for isPlus %t", result, expected, isPlus)
+		}
+		if len(vsc.warnings) != 0 {
+			t.Errorf("generateUpstream() returned unexpected warnings %v", vsc.warnings)
+		}
+	}
+}
+
+func TestGenerateUpstreamWithDrainingEndpointsAndIncompatibleLBMethod(t *testing.T) {
+	t.Parallel()
+	name := "test-upstream"
+	upstream := conf_v1.Upstream{Service: name, Port: 80, LBMethod: "hash $request_uri consistent"}
+	endpoints := []string{
+		"192.168.10.10:8080",
+	}
+	drainingEndpoints := []string{
+		"192.168.10.11:8080",
+	}
+	cfgParams := ConfigParams{Context: context.Background()}
+
+	vsc := newVirtualServerConfigurator(&cfgParams, false, false, &StaticConfigParams{}, false, &fakeBV)
+	result := vsc.generateUpstream(&conf_v1.VirtualServer{}, name, upstream, false, endpoints, drainingEndpoints, nil, nil)
+
+	expectedServers := []version2.UpstreamServer{
+		{
+			Address: "192.168.10.10:8080",
+		},
+	}
+	if !reflect.DeepEqual(result.Servers, expectedServers) {
+		t.Errorf("generateUpstream() returned servers %v but expected %v", result.Servers, expectedServers)
+	}
+	if len(vsc.warnings) != 1 {
+		t.Errorf("generateUpstream() returned %d warnings, expected 1", len(vsc.warnings))
+	}
+}
+
+func TestGenerateUpstreamWithOtherZoneEndpoints(t *testing.T) {
+	t.Parallel()
+	name := "test-upstream"
+	endpoints := []string{
+		"192.168.10.10:8080",
+	}
+	otherZoneEndpoints := []string{
+		"192.168.20.10:8080",
+	}
+	cfgParams := ConfigParams{
+		Context:  context.Background(),
+		LBMethod: "random two least_conn",
+	}
+
+	tests := []struct {
+		upstream     conf_v1.Upstream
+		wantServers  []version2.UpstreamServer
+		wantWarnings int
+		msg          string
+	}{
+		{
+			upstream: conf_v1.Upstream{Service: name, Port: 80, LBMethod: "least_conn"},
+			wantServers: []version2.UpstreamServer{
+				{
+					Address: "192.168.10.10:8080",
+				},
+				{
+					Address: "192.168.20.10:8080",
+					Backup:  true,
+				},
+			},
+			wantWarnings: 0,
+			msg:          "lb method compatible with backup servers",
+		},
+		{
+			upstream: conf_v1.Upstream{Service: name, Port: 80},
+			wantServers: []version2.UpstreamServer{
+				{
+					Address: "192.168.10.10:8080",
+				},
+			},
+			wantWarnings: 1,
+			msg:          "lb method incompatible with backup servers",
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(&cfgParams, true, false, &StaticConfigParams{}, false, &fakeBV)
+		vs := &conf_v1.VirtualServer{}
+		result := vsc.generateUpstream(vs, name, test.upstream, false, endpoints, nil, otherZoneEndpoints, nil)
+		if !reflect.DeepEqual(result.Servers, test.wantServers) {
+			t.Errorf("generateUpstream() returned servers %v but expected %v for the case of %s", result.Servers, test.wantServers, test.msg)
+		}
+		if len(vsc.warnings[vs]) != test.wantWarnings {
+			t.Errorf("generateUpstream() returned %d warnings, expected %d for the case of %s", len(vsc.warnings[vs]), test.wantWarnings, test.msg)
+		}
+	}
+}
+
 func TestGenerateProxyPass(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -15814,6 +15949,285 @@ func TestGenerateGRPCPassProtocol(t *testing.T) {
 	}
 }
 
+func TestGenerateGRPCWeb(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		upstream conf_v1.Upstream
+		expected *version2.GRPCWeb
+		msg      string
+	}{
+		{
+			upstream: conf_v1.Upstream{Type: "grpc"},
+			expected: nil,
+			msg:      "no gRPC-Web",
+		},
+		{
+			upstream: conf_v1.Upstream{Type: "grpc", GRPCWeb: &conf_v1.GRPCWeb{}},
+			expected: nil,
+			msg:      "disabled gRPC-Web",
+		},
+		{
+			upstream: conf_v1.Upstream{GRPCWeb: &conf_v1.GRPCWeb{Enable: true}},
+			expected: nil,
+			msg:      "gRPC-Web with http type",
+		},
+		{
+			upstream: conf_v1.Upstream{Type: "grpc", GRPCWeb: &conf_v1.GRPCWeb{Enable: true}},
+			expected: &version2.GRPCWeb{AllowOrigin: "*"},
+			msg:      "gRPC-Web with default origin",
+		},
+		{
+			upstream: conf_v1.Upstream{Type: "grpc", GRPCWeb: &conf_v1.GRPCWeb{Enable: true, AllowOrigin: "https://app.example.com"}},
+			expected: &version2.GRPCWeb{AllowOrigin: "https://app.example.com"},
+			msg:      "gRPC-Web with origin",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateGRPCWeb(test.upstream)
+		if !cmp.Equal(test.expected, result) {
+			t.Errorf("generateGRPCWeb() returned unexpected result for the case of %s: %s", test.msg, cmp.Diff(test.expected, result))
+		}
+	}
+}
+
+func TestGenerateWebSocket(t *testing.T) {
+	t.Parallel()
+	cfgParams := &ConfigParams{ProxyReadTimeout: "60s", ProxySendTimeout: "60s"}
+	tests := []struct {
+		upstream conf_v1.Upstream
+		proxy    *conf_v1.ActionProxy
+		expected *version2.WebSocket
+		msg      string
+	}{
+		{
+			upstream: conf_v1.Upstream{},
+			expected: nil,
+			msg:      "no WebSocket",
+		},
+		{
+			upstream: conf_v1.Upstream{WebSocket: &conf_v1.WebSocket{}},
+			expected: nil,
+			msg:      "disabled WebSocket",
+		},
+		{
+			upstream: conf_v1.Upstream{Type: "grpc", WebSocket: &conf_v1.WebSocket{Enable: true}},
+			expected: nil,
+			msg:      "WebSocket with grpc type",
+		},
+		{
+			upstream: conf_v1.Upstream{ProxyReadTimeout: "30s", WebSocket: &conf_v1.WebSocket{Enable: true}},
+			expected: &version2.WebSocket{
+				Upstream:         "vs_default_cafe_chat",
+				ProxyPass:        "http://vs_default_cafe_chat$request_uri",
+				ProxyReadTimeout: "30s",
+				ProxySendTimeout: "60s",
+			},
+			msg: "WebSocket with the timeouts of the upstream",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				ProxyReadTimeout: "30s",
+				TLS:              conf_v1.UpstreamTLS{Enable: true},
+				WebSocket:        &conf_v1.WebSocket{Enable: true, IdleTimeout: "1h"},
+			},
+			proxy: &conf_v1.ActionProxy{Upstream: "chat", RewritePath: "/"},
+			expected: &version2.WebSocket{
+				Upstream:         "vs_default_cafe_chat",
+				ProxyPass:        "https://vs_default_cafe_chat",
+				Rewrites:         []string{"^ $request_uri_no_args", `"^/chat(.*)$" "/$1" break`},
+				ProxyReadTimeout: "1h",
+				ProxySendTimeout: "1h",
+			},
+			msg: "WebSocket with idle timeout, TCP keepalive and rewrite",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateWebSocket("/chat", "vs_default_cafe_chat", test.upstream, cfgParams, test.proxy, "/chat")
+		if !cmp.Equal(test.expected, result) {
+			t.Errorf("generateWebSocket() returned unexpected result for the case of %s: %s", test.msg, cmp.Diff(test.expected, result))
+		}
+	}
+}
+
+func TestGenerateWebSocketLocations(t *testing.T) {
+	t.Parallel()
+	routeAccessLog := &version2.AccessLog{Destination: "/dev/stdout", Format: "json"}
+	serverAccessLog := &version2.AccessLog{Destination: "/dev/stderr", Format: "main"}
+	locations := []version2.Location{
+		{
+			Path:             "/",
+			ProxyPass:        "http://vs_default_cafe_tea",
+			ProxyReadTimeout: "60s",
+			ProxySendTimeout: "60s",
+		},
+		{
+			Path:             "/chat",
+			ProxyPass:        "http://vs_default_cafe_chat/",
+			ProxyPassRewrite: "/",
+			ProxyReadTimeout: "60s",
+			ProxySendTimeout: "60s",
+			AccessLog:        routeAccessLog,
+			WebSocket: &version2.WebSocket{
+				Upstream:         "vs_default_cafe_chat",
+				ProxyPass:        "http://vs_default_cafe_chat",
+				Rewrites:         []string{"^ $request_uri_no_args", `"^/chat(.*)$" "/$1" break`},
+				ProxyReadTimeout: "1h",
+				ProxySendTimeout: "1h",
+			},
+		},
+		{
+			Path:             "/events",
+			ProxyPass:        "http://vs_default_cafe_events",
+			ProxyReadTimeout: "60s",
+			ProxySendTimeout: "60s",
+			WebSocket: &version2.WebSocket{
+				Upstream:         "vs_default_cafe_events",
+				ProxyPass:        "http://vs_default_cafe_events$request_uri",
+				ProxyReadTimeout: "60s",
+				ProxySendTimeout: "60s",
+			},
+		},
+	}
+
+	expected := []version2.Location{
+		locations[0],
+		{
+			Path:             "/chat",
+			ProxyPass:        "http://vs_default_cafe_chat/",
+			ProxyPassRewrite: "/",
+			ProxyReadTimeout: "60s",
+			ProxySendTimeout: "60s",
+			AccessLog:        routeAccessLog,
+			WebSocket: &version2.WebSocket{
+				Path:             "/internal_location_websocket_0",
+				Upstream:         "vs_default_cafe_chat",
+				ProxyPass:        "http://vs_default_cafe_chat",
+				Rewrites:         []string{"^ $request_uri_no_args", `"^/chat(.*)$" "/$1" break`},
+				ProxyReadTimeout: "1h",
+				ProxySendTimeout: "1h",
+			},
+		},
+		{
+			Path:             "/events",
+			ProxyPass:        "http://vs_default_cafe_events",
+			ProxyReadTimeout: "60s",
+			ProxySendTimeout: "60s",
+			WebSocket: &version2.WebSocket{
+				Path:             "/internal_location_websocket_1",
+				Upstream:         "vs_default_cafe_events",
+				ProxyPass:        "http://vs_default_cafe_events$request_uri",
+				ProxyReadTimeout: "60s",
+				ProxySendTimeout: "60s",
+			},
+		},
+		{
+			Path:              "/internal_location_websocket_0",
+			Internal:          true,
+			ProxyPass:         "http://vs_default_cafe_chat",
+			Rewrites:          []string{"^ $request_uri_no_args", `"^/chat(.*)$" "/$1" break`},
+			ProxyReadTimeout:  "1h",
+			ProxySendTimeout:  "1h",
+			AccessLog:         routeAccessLog,
+			WebSocketUpstream: "vs_default_cafe_chat",
+		},
+		{
+			Path:              "/internal_location_websocket_1",
+			Internal:          true,
+			ProxyPass:         "http://vs_default_cafe_events$request_uri",
+			ProxyReadTimeout:  "60s",
+			ProxySendTimeout:  "60s",
+			AccessLog:         serverAccessLog,
+			WebSocketUpstream: "vs_default_cafe_events",
+		},
+	}
+
+	result := generateWebSocketLocations(locations, serverAccessLog, "/dev/stdout main")
+	if !cmp.Equal(expected, result) {
+		t.Errorf("generateWebSocketLocations() returned unexpected result: %s", cmp.Diff(expected, result))
+	}
+}
+
+func TestGenerateGRPCWebTextLocations(t *testing.T) {
+	t.Parallel()
+	locations := []version2.Location{
+		{
+			Path:     "/helloworld.Greeter",
+			GRPCPass: "grpc://vs_default_cafe_greeter",
+			Allow:    []string{"10.0.0.0/8"},
+			GRPCWeb: &version2.GRPCWeb{
+				AllowOrigin: "https://app.example.com",
+			},
+		},
+		{
+			Path:     "/routeguide.RouteGuide",
+			GRPCPass: "grpc://vs_default_cafe_routeguide",
+		},
+	}
+
+	expected := []version2.Location{
+		{
+			Path:     "/helloworld.Greeter",
+			GRPCPass: "grpc://vs_default_cafe_greeter",
+			Allow:    []string{"10.0.0.0/8"},
+			GRPCWeb: &version2.GRPCWeb{
+				AllowOrigin: "https://app.example.com",
+				TextPath:    "/internal_location_grpc_web_text_0",
+				BufferSize:  "1m",
+			},
+		},
+		locations[1],
+		{
+			Path:     "/internal_location_grpc_web_text_0",
+			Internal: true,
+			Allow:    []string{"10.0.0.0/8"},
+			GRPCWeb: &version2.GRPCWeb{
+				AllowOrigin: "https://app.example.com",
+				Text:        true,
+				BufferSize:  "1m",
+			},
+		},
+	}
+
+	result := generateGRPCWebTextLocations(locations)
+	if !cmp.Equal(expected, result) {
+		t.Errorf("generateGRPCWebTextLocations() returned unexpected result: %s", cmp.Diff(expected, result))
+	}
+}
+
+func TestGenerateMainAccessLog(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		mainAccessLog string
+		expected      *version2.AccessLog
+	}{
+		{
+			mainAccessLog: "/dev/stdout main",
+			expected:      &version2.AccessLog{Destination: "/dev/stdout", Format: "main"},
+		},
+		{
+			mainAccessLog: "syslog:server=localhost:514 main buffer=32k",
+			expected:      &version2.AccessLog{Destination: "syslog:server=localhost:514", Format: "main buffer=32k"},
+		},
+		{
+			mainAccessLog: "off",
+			expected:      nil,
+		},
+		{
+			mainAccessLog: "",
+			expected:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		result := generateMainAccessLog(test.mainAccessLog)
+		if !cmp.Equal(test.expected, result) {
+			t.Errorf("generateMainAccessLog(%q) returned unexpected result: %s", test.mainAccessLog, cmp.Diff(test.expected, result))
+		}
+	}
+}
+
 func TestGenerateString(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -16145,44 +16559,894 @@ func TestGenerateLocationForReturn(t *testing.T) {
 				Body: "hello",
 			},
 
-			expectedLocation: version2.Location{
-				Path:     "/",
-				Snippets: []string{"# location snippet"},
-				ErrorPages: []version2.ErrorPage{
-					{
-						Name:         "@return_1",
-						Codes:        "418",
-						ResponseCode: 400,
-					},
+			expectedLocation: version2.Location{
+				Path:     "/",
+				Snippets: []string{"# location snippet"},
+				ErrorPages: []version2.ErrorPage{
+					{
+						Name:         "@return_1",
+						Codes:        "418",
+						ResponseCode: 400,
+					},
+				},
+				ProxyInterceptErrors: true,
+				InternalProxyPass:    "http://unix:/var/lib/nginx/nginx-418-server.sock",
+			},
+			expectedReturnLocation: &version2.ReturnLocation{
+				Name:        "@return_1",
+				DefaultType: "text/html",
+				Return: version2.Return{
+					Code: 0,
+					Text: "hello",
+				},
+			},
+			msg: "return with all fields defined",
+		},
+	}
+	path := "/"
+	snippets := []string{"# location snippet"}
+	returnLocationIndex := 1
+
+	for _, test := range tests {
+		location, returnLocation := generateLocationForReturn(path, snippets, test.actionReturn, returnLocationIndex)
+		if !reflect.DeepEqual(location, test.expectedLocation) {
+			t.Errorf("generateLocationForReturn() returned  \n%+v but expected \n%+v for the case of %s",
+				location, test.expectedLocation, test.msg)
+		}
+		if !reflect.DeepEqual(returnLocation, test.expectedReturnLocation) {
+			t.Errorf("generateLocationForReturn() returned  \n%+v but expected \n%+v for the case of %s",
+				returnLocation, test.expectedReturnLocation, test.msg)
+		}
+	}
+}
+
+func TestGenerateMaintenance(t *testing.T) {
+	t.Parallel()
+
+	newVS := func(m *conf_v1.Maintenance) *conf_v1.VirtualServer {
+		return &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host:        "cafe.example.com",
+				Maintenance: m,
+			},
+		}
+	}
+
+	tests := []struct {
+		vsEx     *VirtualServerEx
+		isPlus   bool
+		expected maintenanceCfg
+		warnings int
+		msg      string
+	}{
+		{
+			vsEx:     &VirtualServerEx{VirtualServer: newVS(nil)},
+			isPlus:   true,
+			expected: maintenanceCfg{},
+			msg:      "no maintenance",
+		},
+		{
+			vsEx:     &VirtualServerEx{VirtualServer: newVS(&conf_v1.Maintenance{})},
+			isPlus:   false,
+			expected: maintenanceCfg{},
+			msg:      "disabled maintenance with NGINX",
+		},
+		{
+			vsEx: &VirtualServerEx{VirtualServer: newVS(&conf_v1.Maintenance{
+				Code:  500,
+				Type:  "text/plain",
+				Body:  "Back soon",
+				Allow: []string{"10.0.0.0/8"},
+				BypassHeader: &conf_v1.Header{
+					Name:  "X-Maintenance-Bypass",
+					Value: "secret",
+				},
+			})},
+			isPlus: true,
+			expected: maintenanceCfg{
+				Server: &version2.Maintenance{
+					Variable:      "$vs_default_cafe_maintenance",
+					AllowVariable: "$vs_default_cafe_maintenance_allow",
+					Allow:         []string{"10.0.0.0/8"},
+					Code:          500,
+					LocationName:  "@maintenance",
+				},
+				Maps: []version2.Map{
+					{
+						Source:   "$http_x_maintenance_bypass",
+						Variable: "$vs_default_cafe_maintenance_bypass",
+						Parameters: []version2.Parameter{
+							{Value: `"secret"`, Result: "1"},
+							{Value: "default", Result: "0"},
+						},
+					},
+					{
+						Source:   `"${vs_default_cafe_keyval_maintenance}${vs_default_cafe_maintenance_allow}${vs_default_cafe_maintenance_bypass}"`,
+						Variable: "$vs_default_cafe_maintenance",
+						Parameters: []version2.Parameter{
+							{Value: `"100"`, Result: "1"},
+							{Value: "default", Result: "0"},
+						},
+					},
+				},
+				KeyValZones: []version2.KeyValZone{
+					{
+						Name:  "vs_default_cafe_keyval_zone_maintenance",
+						Size:  "32k",
+						State: "/etc/nginx/state_files/vs_default_cafe_keyval_zone_maintenance.json",
+					},
+				},
+				KeyVals: []version2.KeyVal{
+					{
+						Key:      `"vs_default_cafe_keyval_key_maintenance"`,
+						Variable: "$vs_default_cafe_keyval_maintenance",
+						ZoneName: "vs_default_cafe_keyval_zone_maintenance",
+					},
+				},
+				ReturnLocation: version2.ReturnLocation{
+					Name:        "@maintenance",
+					DefaultType: "text/plain",
+					Return:      version2.Return{Text: "Back soon"},
+				},
+			},
+			msg: "disabled maintenance with NGINX Plus, allow list and bypass header",
+		},
+		{
+			vsEx: &VirtualServerEx{
+				VirtualServer: newVS(&conf_v1.Maintenance{
+					Enable:    true,
+					ConfigMap: &conf_v1.MaintenanceConfigMap{Name: "maintenance"},
+				}),
+				ConfigMaps: map[string]*api_v1.ConfigMap{
+					"default/maintenance": {
+						Data: map[string]string{
+							"maintenance.html": `<p class="title">Maintenance</p>`,
+						},
+					},
+				},
+			},
+			isPlus: false,
+			expected: maintenanceCfg{
+				Server: &version2.Maintenance{
+					Variable:     "$vs_default_cafe_maintenance",
+					Code:         503,
+					LocationName: "@maintenance",
+				},
+				Maps: []version2.Map{
+					{
+						Source:   `"1"`,
+						Variable: "$vs_default_cafe_maintenance",
+						Parameters: []version2.Parameter{
+							{Value: `"1"`, Result: "1"},
+							{Value: "default", Result: "0"},
+						},
+					},
+				},
+				ReturnLocation: version2.ReturnLocation{
+					Name:        "@maintenance",
+					DefaultType: "text/html",
+					Return:      version2.Return{Text: `<p class=\"title\">Maintenance</p>`},
+				},
+			},
+			msg: "enabled maintenance with NGINX and a page from a ConfigMap",
+		},
+		{
+			vsEx: &VirtualServerEx{
+				VirtualServer: newVS(&conf_v1.Maintenance{
+					Enable:    true,
+					ConfigMap: &conf_v1.MaintenanceConfigMap{Name: "maintenance"},
+				}),
+			},
+			isPlus: false,
+			expected: maintenanceCfg{
+				Server: &version2.Maintenance{
+					Variable:     "$vs_default_cafe_maintenance",
+					Code:         503,
+					LocationName: "@maintenance",
+				},
+				Maps: []version2.Map{
+					{
+						Source:   `"1"`,
+						Variable: "$vs_default_cafe_maintenance",
+						Parameters: []version2.Parameter{
+							{Value: `"1"`, Result: "1"},
+							{Value: "default", Result: "0"},
+						},
+					},
+				},
+				ReturnLocation: version2.ReturnLocation{
+					Name:        "@maintenance",
+					DefaultType: "text/html",
+					Return:      version2.Return{Text: defaultMaintenanceBody},
+				},
+			},
+			warnings: 1,
+			msg:      "enabled maintenance with NGINX and a missing ConfigMap",
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(&baseCfgParams, test.isPlus, false, &StaticConfigParams{}, false, &fakeBV)
+		result := vsc.generateMaintenance(test.vsEx, NewVSVariableNamer(test.vsEx.VirtualServer))
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateMaintenance() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if len(vsc.warnings[test.vsEx.VirtualServer]) != test.warnings {
+			t.Errorf("generateMaintenance() returned warnings %v for the case of %s", vsc.warnings, test.msg)
+		}
+	}
+}
+
+func TestNewMaintenanceKeyValUpdate(t *testing.T) {
+	t.Parallel()
+
+	vs := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+		Spec: conf_v1.VirtualServerSpec{
+			Maintenance: &conf_v1.Maintenance{
+				Enable: true,
+			},
+		},
+	}
+
+	expected := WeightUpdate{
+		Zone:  "vs_default_cafe_keyval_zone_maintenance",
+		Key:   `"vs_default_cafe_keyval_key_maintenance"`,
+		Value: "1",
+	}
+	if result := NewMaintenanceKeyValUpdate(vs); !cmp.Equal(expected, result) {
+		t.Errorf("NewMaintenanceKeyValUpdate() returned %v but expected %v", result, expected)
+	}
+
+	vs.Spec.Maintenance.Enable = false
+	expected.Value = "0"
+	if result := NewMaintenanceKeyValUpdate(vs); !cmp.Equal(expected, result) {
+		t.Errorf("NewMaintenanceKeyValUpdate() returned %v but expected %v", result, expected)
+	}
+}
+
+func TestGenerateRedirectMap(t *testing.T) {
+	t.Parallel()
+
+	newVSEx := func(rm *conf_v1.RedirectMap, configMaps map[string]*api_v1.ConfigMap) *VirtualServerEx {
+		return &VirtualServerEx{
+			VirtualServer: &conf_v1.VirtualServer{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "cafe",
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerSpec{
+					Host:        "cafe.example.com",
+					RedirectMap: rm,
+				},
+			},
+			ConfigMaps: configMaps,
+		}
+	}
+
+	redirectsConfigMap := map[string]*api_v1.ConfigMap{
+		"default/redirects": {
+			Data: map[string]string{
+				"redirects": "# legacy pages\n/old-tea /tea\n/old-coffee https://coffee.example.com/ 302\n",
+			},
+		},
+	}
+
+	codeMaps := []version2.Map{
+		{
+			Source:   "$vs_default_cafe_redirect_map",
+			Variable: "$vs_default_cafe_redirect_map_301",
+			Parameters: []version2.Parameter{
+				{Value: `"~^301 (.+)$"`, Result: "$1"},
+				{Value: "default", Result: `""`},
+			},
+		},
+		{
+			Source:   "$vs_default_cafe_redirect_map",
+			Variable: "$vs_default_cafe_redirect_map_302",
+			Parameters: []version2.Parameter{
+				{Value: `"~^302 (.+)$"`, Result: "$1"},
+				{Value: "default", Result: `""`},
+			},
+		},
+		{
+			Source:   "$vs_default_cafe_redirect_map",
+			Variable: "$vs_default_cafe_redirect_map_307",
+			Parameters: []version2.Parameter{
+				{Value: `"~^307 (.+)$"`, Result: "$1"},
+				{Value: "default", Result: `""`},
+			},
+		},
+		{
+			Source:   "$vs_default_cafe_redirect_map",
+			Variable: "$vs_default_cafe_redirect_map_308",
+			Parameters: []version2.Parameter{
+				{Value: `"~^308 (.+)$"`, Result: "$1"},
+				{Value: "default", Result: `""`},
+			},
+		},
+	}
+	server := []version2.RedirectMap{
+		{Variable: "$vs_default_cafe_redirect_map_301", Code: 301},
+		{Variable: "$vs_default_cafe_redirect_map_302", Code: 302},
+		{Variable: "$vs_default_cafe_redirect_map_307", Code: 307},
+		{Variable: "$vs_default_cafe_redirect_map_308", Code: 308},
+	}
+
+	tests := []struct {
+		vsEx     *VirtualServerEx
+		isPlus   bool
+		expected redirectMapCfg
+		warnings int
+		msg      string
+	}{
+		{
+			vsEx:     newVSEx(nil, nil),
+			isPlus:   false,
+			expected: redirectMapCfg{},
+			msg:      "no redirect map",
+		},
+		{
+			vsEx:   newVSEx(&conf_v1.RedirectMap{ConfigMap: "redirects"}, redirectsConfigMap),
+			isPlus: false,
+			expected: redirectMapCfg{
+				Server: server,
+				Maps: append([]version2.Map{
+					{
+						Source:   "$uri",
+						Variable: "$vs_default_cafe_redirect_map",
+						Parameters: []version2.Parameter{
+							{Value: `"/old-tea"`, Result: `"301 /tea"`},
+							{Value: `"/old-coffee"`, Result: `"302 https://coffee.example.com/"`},
+							{Value: "default", Result: `""`},
+						},
+					},
+				}, codeMaps...),
+			},
+			msg: "redirect map with NGINX",
+		},
+		{
+			vsEx:   newVSEx(&conf_v1.RedirectMap{ConfigMap: "redirects"}, nil),
+			isPlus: false,
+			expected: redirectMapCfg{
+				Server: server,
+				Maps: append([]version2.Map{
+					{
+						Source:   "$uri",
+						Variable: "$vs_default_cafe_redirect_map",
+						Parameters: []version2.Parameter{
+							{Value: "default", Result: `""`},
+						},
+					},
+				}, codeMaps...),
+			},
+			warnings: 1,
+			msg:      "redirect map with NGINX and a missing ConfigMap",
+		},
+		{
+			vsEx:   newVSEx(&conf_v1.RedirectMap{ConfigMap: "redirects"}, redirectsConfigMap),
+			isPlus: true,
+			expected: redirectMapCfg{
+				Server: server,
+				Maps:   codeMaps,
+				KeyValZones: []version2.KeyValZone{
+					{
+						Name:  "vs_default_cafe_keyval_zone_redirect_map",
+						Size:  "5m",
+						State: "/etc/nginx/state_files/vs_default_cafe_keyval_zone_redirect_map.json",
+					},
+				},
+				KeyVals: []version2.KeyVal{
+					{
+						Key:      "$uri",
+						Variable: "$vs_default_cafe_redirect_map",
+						ZoneName: "vs_default_cafe_keyval_zone_redirect_map",
+					},
+				},
+			},
+			msg: "redirect map with NGINX Plus",
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(&baseCfgParams, test.isPlus, false, &StaticConfigParams{}, false, &fakeBV)
+		result := vsc.generateRedirectMap(test.vsEx, NewVSVariableNamer(test.vsEx.VirtualServer))
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateRedirectMap() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if len(vsc.warnings[test.vsEx.VirtualServer]) != test.warnings {
+			t.Errorf("generateRedirectMap() returned warnings %v for the case of %s", vsc.warnings, test.msg)
+		}
+	}
+}
+
+func TestGenerateRequestID(t *testing.T) {
+	t.Parallel()
+
+	newVSEx := func(rid *conf_v1.RequestID) *VirtualServerEx {
+		return &VirtualServerEx{
+			VirtualServer: &conf_v1.VirtualServer{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "cafe",
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerSpec{
+					Host:      "cafe.example.com",
+					RequestID: rid,
+				},
+			},
+		}
+	}
+
+	defaultCfgParams := ConfigParams{
+		Context:         context.Background(),
+		RequestIDHeader: "X-Request-ID",
+	}
+
+	globalCfgParams := ConfigParams{
+		Context:         context.Background(),
+		RequestID:       true,
+		RequestIDHeader: "X-Request-ID",
+	}
+
+	trustedMap := version2.Map{
+		Source:   "$http_x_correlation_id",
+		Variable: "$vs_default_cafe_request_id",
+		Parameters: []version2.Parameter{
+			{Value: `""`, Result: "$request_id"},
+			{Value: "default", Result: "$http_x_correlation_id"},
+		},
+	}
+
+	tests := []struct {
+		vsEx         *VirtualServerEx
+		cfgParams    ConfigParams
+		expected     *version2.RequestID
+		expectedMaps []version2.Map
+		msg          string
+	}{
+		{
+			vsEx:      newVSEx(nil),
+			cfgParams: defaultCfgParams,
+			expected:  nil,
+			msg:       "disabled",
+		},
+		{
+			vsEx:      newVSEx(nil),
+			cfgParams: globalCfgParams,
+			expected:  &version2.RequestID{Header: "X-Request-ID", Variable: "$correlation_id"},
+			msg:       "enabled in the ConfigMap",
+		},
+		{
+			vsEx:      newVSEx(&conf_v1.RequestID{Enable: createPointerFromBool(false)}),
+			cfgParams: globalCfgParams,
+			expected:  nil,
+			msg:       "enabled in the ConfigMap and disabled in the VirtualServer",
+		},
+		{
+			vsEx:      newVSEx(&conf_v1.RequestID{Enable: createPointerFromBool(true)}),
+			cfgParams: defaultCfgParams,
+			expected:  &version2.RequestID{Header: "X-Request-ID", Variable: "$request_id"},
+			msg:       "enabled in the VirtualServer",
+		},
+		{
+			vsEx:      newVSEx(&conf_v1.RequestID{Header: "x-request-id"}),
+			cfgParams: globalCfgParams,
+			expected:  &version2.RequestID{Header: "x-request-id", Variable: "$correlation_id"},
+			msg:       "same header as the ConfigMap in a different case",
+		},
+		{
+			vsEx:         newVSEx(&conf_v1.RequestID{Header: "X-Correlation-ID", TrustIncoming: createPointerFromBool(true)}),
+			cfgParams:    globalCfgParams,
+			expected:     &version2.RequestID{Header: "X-Correlation-ID", Variable: "$vs_default_cafe_request_id"},
+			expectedMaps: []version2.Map{trustedMap},
+			msg:          "custom header with trusted incoming request ID",
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(&test.cfgParams, false, false, &StaticConfigParams{}, false, &fakeBV)
+		result, maps := vsc.generateRequestID(test.vsEx, NewVSVariableNamer(test.vsEx.VirtualServer))
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateRequestID() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedMaps, maps); diff != "" {
+			t.Errorf("generateRequestID() maps mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateRequestIDLogFormat(t *testing.T) {
+	t.Parallel()
+
+	vs := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+
+	tests := []struct {
+		requestID *version2.RequestID
+		cfgParams ConfigParams
+		expected  *version2.LogFormat
+		msg       string
+	}{
+		{
+			requestID: nil,
+			expected:  nil,
+			msg:       "request ID disabled",
+		},
+		{
+			requestID: &version2.RequestID{Header: "X-Request-ID", Variable: "$correlation_id"},
+			expected:  nil,
+			msg:       "request ID of the ConfigMap",
+		},
+		{
+			requestID: &version2.RequestID{Header: "X-Request-ID", Variable: "$request_id"},
+			expected: &version2.LogFormat{
+				Name: "vs_default_cafe_request_id",
+				Format: []string{
+					`$remote_addr - $remote_user [$time_local] "$request" `,
+					`$status $body_bytes_sent "$http_referer" `,
+					`"$http_user_agent" "$http_x_forwarded_for" "$request_id"`,
+				},
+			},
+			msg: "default log format",
+		},
+		{
+			requestID: &version2.RequestID{Header: "X-Correlation-ID", Variable: "$vs_default_cafe_request_id"},
+			cfgParams: ConfigParams{
+				MainLogFormat:         []string{`{"request":"$request",`, `"request_id":"$correlation_id"}`},
+				MainLogFormatEscaping: "json",
+			},
+			expected: &version2.LogFormat{
+				Name:     "vs_default_cafe_request_id",
+				Escaping: "json",
+				Format:   []string{`{"request":"$request",`, ` "request_id":"$vs_default_cafe_request_id"}`},
+			},
+			msg: "custom log format",
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(&test.cfgParams, false, false, &StaticConfigParams{}, false, &fakeBV)
+		result := vsc.generateRequestIDLogFormat(test.requestID, NewVSVariableNamer(vs))
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateRequestIDLogFormat() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestApplyRequestIDLogFormat(t *testing.T) {
+	t.Parallel()
+
+	locations := []version2.Location{
+		{Path: "/tea"},
+		{Path: "/coffee", AccessLog: &version2.AccessLog{Destination: "/dev/stdout", Format: "main"}},
+		{Path: "/juice", AccessLog: &version2.AccessLog{Destination: "/dev/stdout", Format: "json"}},
+		{Path: "/health", AccessLog: &version2.AccessLog{Off: true}},
+	}
+
+	serverAccessLog := applyRequestIDLogFormat("vs_default_cafe_request_id", nil, locations, "/dev/stdout main")
+
+	expectedServerAccessLog := &version2.AccessLog{Destination: "/dev/stdout", Format: "vs_default_cafe_request_id"}
+	if diff := cmp.Diff(expectedServerAccessLog, serverAccessLog); diff != "" {
+		t.Errorf("applyRequestIDLogFormat() server access log mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedLocationAccessLogs := []*version2.AccessLog{
+		nil,
+		{Destination: "/dev/stdout", Format: "vs_default_cafe_request_id"},
+		{Destination: "/dev/stdout", Format: "json"},
+		{Off: true},
+	}
+	for i, l := range locations {
+		if diff := cmp.Diff(expectedLocationAccessLogs[i], l.AccessLog); diff != "" {
+			t.Errorf("applyRequestIDLogFormat() access log mismatch for location %s (-want +got):\n%s", l.Path, diff)
+		}
+	}
+
+	if serverAccessLog := applyRequestIDLogFormat("vs_default_cafe_request_id", nil, nil, "off"); serverAccessLog != nil {
+		t.Errorf("applyRequestIDLogFormat() returned %v for the access log off in the ConfigMap", serverAccessLog)
+	}
+}
+
+func TestGenerateAccessLog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		accessLog            *conf_v1.AccessLog
+		expected             *version2.AccessLog
+		expectedMaps         []version2.Map
+		expectedSplitClients []version2.SplitClient
+		msg                  string
+	}{
+		{
+			accessLog: nil,
+			expected:  nil,
+			msg:       "no access log",
+		},
+		{
+			accessLog: &conf_v1.AccessLog{Enable: createPointerFromBool(false)},
+			expected:  &version2.AccessLog{Off: true},
+			msg:       "access log off",
+		},
+		{
+			accessLog: &conf_v1.AccessLog{},
+			expected:  &version2.AccessLog{Destination: "/dev/stdout", Format: "main"},
+			msg:       "defaults",
+		},
+		{
+			accessLog: &conf_v1.AccessLog{Format: "json", Destination: "/dev/stderr", Sampling: 100},
+			expected:  &version2.AccessLog{Destination: "/dev/stderr", Format: "json"},
+			msg:       "custom format and destination with full sampling",
+		},
+		{
+			accessLog: &conf_v1.AccessLog{Condition: "errors"},
+			expected: &version2.AccessLog{
+				Destination: "/dev/stdout",
+				Format:      "main",
+				Condition:   "$vs_default_cafe_access_log_errors",
+			},
+			expectedMaps: []version2.Map{
+				{
+					Source:   "$status",
+					Variable: "$vs_default_cafe_access_log_errors",
+					Parameters: []version2.Parameter{
+						{Value: "~^[45]", Result: "1"},
+						{Value: "default", Result: "0"},
+					},
+				},
+			},
+			msg: "condition",
+		},
+		{
+			accessLog: &conf_v1.AccessLog{Sampling: 10},
+			expected: &version2.AccessLog{
+				Destination: "/dev/stdout",
+				Format:      "main",
+				Condition:   "$vs_default_cafe_access_log_sample_10",
+			},
+			expectedSplitClients: []version2.SplitClient{
+				{
+					Source:   `"${msec}${request_id}"`,
+					Variable: "$vs_default_cafe_access_log_sample_10",
+					Distributions: []version2.Distribution{
+						{Weight: "10%", Value: "1"},
+						{Weight: "*", Value: "0"},
+					},
+				},
+			},
+			msg: "sampling",
+		},
+	}
+
+	for _, test := range tests {
+		var cfg observabilityCfg
+		result := generateAccessLog(test.accessLog, "$vs_default_cafe", "/dev/stdout", "main", `"${msec}${request_id}"`, &cfg)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateAccessLog() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedMaps, cfg.Maps); diff != "" {
+			t.Errorf("generateAccessLog() maps mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedSplitClients, cfg.SplitClients); diff != "" {
+			t.Errorf("generateAccessLog() split clients mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGetAccessLogDestination(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mainAccessLog string
+		expected      string
+	}{
+		{mainAccessLog: "/dev/stdout main", expected: "/dev/stdout"},
+		{mainAccessLog: "syslog:server=localhost:514", expected: "syslog:server=localhost:514"},
+		{mainAccessLog: "off", expected: "/dev/stdout"},
+		{mainAccessLog: "", expected: "/dev/stdout"},
+	}
+
+	for _, test := range tests {
+		result := getAccessLogDestination(test.mainAccessLog)
+		if result != test.expected {
+			t.Errorf("getAccessLogDestination(%q) returned %q but expected %q", test.mainAccessLog, result, test.expected)
+		}
+	}
+}
+
+func TestGenerateTracing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tracing              *conf_v1.Tracing
+		expected             *version2.Tracing
+		expectedSplitClients []version2.SplitClient
+		msg                  string
+	}{
+		{
+			tracing:  nil,
+			expected: nil,
+			msg:      "no tracing",
+		},
+		{
+			tracing:  &conf_v1.Tracing{Enable: createPointerFromBool(false)},
+			expected: &version2.Tracing{Trace: "off"},
+			msg:      "tracing off",
+		},
+		{
+			tracing: &conf_v1.Tracing{
+				Sampling: 100,
+				SpanName: "${request_method} ${host}",
+				SpanAttributes: []conf_v1.SpanAttribute{
+					{Name: "team", Value: "coffee"},
+				},
+			},
+			expected: &version2.Tracing{
+				Trace:    "on",
+				SpanName: "${request_method} ${host}",
+				SpanAttributes: []version2.SpanAttribute{
+					{Name: "team", Value: "coffee"},
+				},
+			},
+			msg: "span name and attributes with full sampling",
+		},
+		{
+			tracing:  &conf_v1.Tracing{Sampling: 25},
+			expected: &version2.Tracing{Trace: "$vs_default_cafe_otel_sample_25"},
+			expectedSplitClients: []version2.SplitClient{
+				{
+					Source:   "$otel_trace_id",
+					Variable: "$vs_default_cafe_otel_sample_25",
+					Distributions: []version2.Distribution{
+						{Weight: "25%", Value: "on"},
+						{Weight: "*", Value: "off"},
+					},
+				},
+			},
+			msg: "sampling",
+		},
+	}
+
+	cfgParams := ConfigParams{MainOtelLoadModule: true}
+	vs := &conf_v1.VirtualServer{ObjectMeta: meta_v1.ObjectMeta{Name: "cafe", Namespace: "default"}}
+
+	for _, test := range tests {
+		var cfg observabilityCfg
+		vsc := newVirtualServerConfigurator(&cfgParams, false, false, &StaticConfigParams{}, false, &fakeBV)
+		result := vsc.generateTracing(vs, test.tracing, "$vs_default_cafe", &cfg)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateTracing() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedSplitClients, cfg.SplitClients); diff != "" {
+			t.Errorf("generateTracing() split clients mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if len(vsc.warnings[vs]) != 0 {
+			t.Errorf("generateTracing() returned unexpected warnings %v for the case of %s", vsc.warnings[vs], test.msg)
+		}
+	}
+}
+
+func TestGenerateTracingWithoutOtelModule(t *testing.T) {
+	t.Parallel()
+
+	vs := &conf_v1.VirtualServer{ObjectMeta: meta_v1.ObjectMeta{Name: "cafe", Namespace: "default"}}
+	vsc := newVirtualServerConfigurator(&ConfigParams{}, false, false, &StaticConfigParams{}, false, &fakeBV)
+
+	var cfg observabilityCfg
+	result := vsc.generateTracing(vs, &conf_v1.Tracing{Sampling: 25}, "$vs_default_cafe", &cfg)
+	if result != nil {
+		t.Errorf("generateTracing() returned %v but expected nil", result)
+	}
+	if len(cfg.SplitClients) != 0 {
+		t.Errorf("generateTracing() returned unexpected split clients %v", cfg.SplitClients)
+	}
+	if len(vsc.warnings[vs]) != 1 {
+		t.Errorf("generateTracing() returned %d warnings but expected 1", len(vsc.warnings[vs]))
+	}
+}
+
+func TestParseRedirectMap(t *testing.T) {
+	t.Parallel()
+
+	data := `
+# legacy pages
+/old-tea        /tea
+/old-coffee     https://coffee.example.com/menu 308
+
+/old-tea        /green-tea
+/old-juice
+/old-$var       /juice
+old-water       /water
+/old-milk       /milk?source="old"
+/old-cake       /cake 200
+/old-pie        /pie 301 extra
+`
+
+	expected := []redirectMapEntry{
+		{Source: "/old-tea", Target: "/tea", Code: 302},
+		{Source: "/old-coffee", Target: "https://coffee.example.com/menu", Code: 308},
+	}
+
+	entries, problems := parseRedirectMap(data, 302)
+	if diff := cmp.Diff(expected, entries); diff != "" {
+		t.Errorf("parseRedirectMap() returned unexpected entries (-want +got):\n%s", diff)
+	}
+
+	expectedProblems := []string{
+		"line 6 duplicates the source /old-tea of line 3",
+		"line 7 must have a target",
+		`line 8 has the invalid source /old-$var: must start with / and must not include '"', '\' or '$'`,
+		`line 9 has the invalid source old-water: must start with / and must not include '"', '\' or '$'`,
+		`line 10 has the invalid target /milk?source="old": must be a path starting with / or a URL, and must not include '"', '\' or '$'`,
+		"line 11 has the invalid code 200: must be one of 301, 302, 307 or 308",
+		"line 12 must be in the format: source target [code]",
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("parseRedirectMap() returned unexpected problems (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewRedirectMapKeyValUpdate(t *testing.T) {
+	t.Parallel()
+
+	vsEx := &VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				RedirectMap: &conf_v1.RedirectMap{
+					ConfigMap: "redirects",
+					Key:       "legacy",
 				},
-				ProxyInterceptErrors: true,
-				InternalProxyPass:    "http://unix:/var/lib/nginx/nginx-418-server.sock",
 			},
-			expectedReturnLocation: &version2.ReturnLocation{
-				Name:        "@return_1",
-				DefaultType: "text/html",
-				Return: version2.Return{
-					Code: 0,
-					Text: "hello",
+		},
+		ConfigMaps: map[string]*api_v1.ConfigMap{
+			"default/redirects": {
+				Data: map[string]string{
+					"legacy": "/old-tea /tea\n/old-coffee /coffee 307\n/old-tea /green-tea",
 				},
 			},
-			msg: "return with all fields defined",
 		},
 	}
-	path := "/"
-	snippets := []string{"# location snippet"}
-	returnLocationIndex := 1
 
-	for _, test := range tests {
-		location, returnLocation := generateLocationForReturn(path, snippets, test.actionReturn, returnLocationIndex)
-		if !reflect.DeepEqual(location, test.expectedLocation) {
-			t.Errorf("generateLocationForReturn() returned  \n%+v but expected \n%+v for the case of %s",
-				location, test.expectedLocation, test.msg)
-		}
-		if !reflect.DeepEqual(returnLocation, test.expectedReturnLocation) {
-			t.Errorf("generateLocationForReturn() returned  \n%+v but expected \n%+v for the case of %s",
-				returnLocation, test.expectedReturnLocation, test.msg)
-		}
+	expected := WeightUpdate{
+		Zone: "vs_default_cafe_keyval_zone_redirect_map",
+		Pairs: map[string]string{
+			"/old-tea":    "301 /tea",
+			"/old-coffee": "307 /coffee",
+		},
+	}
+
+	result, problems := NewRedirectMapKeyValUpdate(vsEx)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("NewRedirectMapKeyValUpdate() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if len(problems) != 1 {
+		t.Errorf("NewRedirectMapKeyValUpdate() returned problems %v, but expected 1 problem", problems)
+	}
+
+	vsEx.ConfigMaps = nil
+	expected.Pairs = map[string]string{}
+
+	result, problems = NewRedirectMapKeyValUpdate(vsEx)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("NewRedirectMapKeyValUpdate() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if len(problems) != 1 {
+		t.Errorf("NewRedirectMapKeyValUpdate() returned problems %v, but expected 1 problem", problems)
 	}
 }
 
@@ -19735,6 +20999,18 @@ func TestGetNameForSourceForMatchesRouteMapFromCondition(t *testing.T) {
 			},
 			expected: "$request_method",
 		},
+		{
+			input: conf_v1.Condition{
+				Method: "POST",
+			},
+			expected: "$request_method",
+		},
+		{
+			input: conf_v1.Condition{
+				Host: "cafe.example.com",
+			},
+			expected: "$host",
+		},
 	}
 
 	for _, test := range tests {
@@ -20489,7 +21765,7 @@ func TestGenerateUpstreamWithQueue(t *testing.T) {
 
 	for _, test := range tests {
 		vsc := newVirtualServerConfigurator(&ConfigParams{Context: context.Background()}, test.isPlus, false, &StaticConfigParams{}, false, &fakeBV)
-		result := vsc.generateUpstream(nil, test.name, test.upstream, false, []string{}, []string{})
+		result := vsc.generateUpstream(nil, test.name, test.upstream, false, []string{}, nil, nil, []string{})
 		if !reflect.DeepEqual(result, test.expected) {
 			t.Errorf("generateUpstream() returned %v but expected %v for the case of %v", result, test.expected, test.msg)
 		}
@@ -20598,6 +21874,68 @@ func TestGeneratePath(t *testing.T) {
 	}
 }
 
+func TestGetRoutePath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		route    conf_v1.Route
+		expected string
+	}{
+		{
+			route:    conf_v1.Route{Path: "/coffee"},
+			expected: "/coffee",
+		},
+		{
+			route:    conf_v1.Route{Path: "=/coffee"},
+			expected: "=/coffee",
+		},
+		{
+			route:    conf_v1.Route{Path: "/coffee", PathType: conf_v1.PathTypePrefix},
+			expected: "/coffee",
+		},
+		{
+			route:    conf_v1.Route{Path: "/coffee", PathType: conf_v1.PathTypeExact},
+			expected: "=/coffee",
+		},
+		{
+			route:    conf_v1.Route{Path: "*.jpg$", PathType: conf_v1.PathTypeRegularExpression},
+			expected: "~ *.jpg$",
+		},
+	}
+
+	for _, test := range tests {
+		result := GetRoutePath(test.route)
+		if result != test.expected {
+			t.Errorf("GetRoutePath() returned %q, but expected %q for route %v", result, test.expected, test.route)
+		}
+	}
+}
+
+func TestSortLocations(t *testing.T) {
+	t.Parallel()
+	locations := []version2.Location{
+		{Path: `~ "^/tea/[a-z]+$"`},
+		{Path: "/coffee"},
+		{Path: "=/tea"},
+		{Path: `~* "\\.jpg$"`},
+		{Path: "/"},
+		{Path: "=/coffee"},
+	}
+	expected := []version2.Location{
+		{Path: "=/tea"},
+		{Path: "=/coffee"},
+		{Path: "/coffee"},
+		{Path: "/"},
+		{Path: `~ "^/tea/[a-z]+$"`},
+		{Path: `~* "\\.jpg$"`},
+	}
+
+	sortLocations(locations)
+
+	if !cmp.Equal(expected, locations) {
+		t.Errorf("sortLocations() returned unexpected result (-want +got):\n%s", cmp.Diff(expected, locations))
+	}
+}
+
 func TestGenerateErrorPageName(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -20989,6 +22327,24 @@ func TestGenerateRewrites(t *testing.T) {
 			expected: []string{`"^/regex" "/rewrite" break`},
 			msg:      "regex rewrite for non-internal location",
 		},
+		{
+			path:     `~ ^/images/([a-z]+)/(.*)\.jpg$`,
+			internal: false,
+			proxy: &conf_v1.ActionProxy{
+				RewritePath: "/pictures/$2?category=$1",
+			},
+			expected: []string{`"^/images/([a-z]+)/(.*)\.jpg$" "/pictures/$2?category=$1" break`},
+			msg:      "regex rewrite with capture groups for non-internal location",
+		},
+		{
+			path:     `~* ^/images/(.*)$`,
+			internal: false,
+			proxy: &conf_v1.ActionProxy{
+				RewritePath: "/pictures/$1",
+			},
+			expected: []string{`"(?i)^/images/(.*)$" "/pictures/$1" break`},
+			msg:      "case-insensitive regex rewrite with capture groups for non-internal location",
+		},
 		{
 			path:     "/_internal_path",
 			internal: true,
@@ -22505,3 +23861,344 @@ func TestRFC1123ToSnake(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyAppProtocol(t *testing.T) {
+	t.Parallel()
+	cfgParams := ConfigParams{Context: context.Background()}
+
+	tests := []struct {
+		upstream      conf_v1.Upstream
+		appProtocol   string
+		grpcSupported bool
+		wantType      string
+		wantTLS       bool
+		wantWarnings  int
+		msg           string
+	}{
+		{
+			upstream:      conf_v1.Upstream{Name: "tea"},
+			appProtocol:   "kubernetes.io/h2c",
+			grpcSupported: true,
+			wantType:      "grpc",
+			msg:           "h2c appProtocol selects gRPC",
+		},
+		{
+			upstream:      conf_v1.Upstream{Name: "tea"},
+			appProtocol:   "grpc",
+			grpcSupported: false,
+			wantWarnings:  1,
+			msg:           "grpc appProtocol without HTTP/2 and TLS termination",
+		},
+		{
+			upstream:    conf_v1.Upstream{Name: "tea"},
+			appProtocol: "https",
+			wantTLS:     true,
+			msg:         "https appProtocol enables TLS",
+		},
+		{
+			upstream:    conf_v1.Upstream{Name: "tea"},
+			appProtocol: "kubernetes.io/wss",
+			wantTLS:     true,
+			msg:         "wss appProtocol enables TLS",
+		},
+		{
+			upstream:      conf_v1.Upstream{Name: "tea", Type: "http"},
+			appProtocol:   "grpc",
+			grpcSupported: true,
+			wantType:      "http",
+			wantWarnings:  1,
+			msg:           "explicit type conflicts with appProtocol",
+		},
+		{
+			upstream:     conf_v1.Upstream{Name: "tea", TLS: conf_v1.UpstreamTLS{Enable: true}},
+			appProtocol:  "http",
+			wantTLS:      true,
+			wantWarnings: 1,
+			msg:          "explicit TLS conflicts with appProtocol",
+		},
+		{
+			upstream:    conf_v1.Upstream{Name: "tea"},
+			appProtocol: "example.com/custom",
+			msg:         "unsupported appProtocol",
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(&cfgParams, false, false, &StaticConfigParams{}, false, &fakeBV)
+		vs := &conf_v1.VirtualServer{}
+		result := vsc.applyAppProtocol(vs, test.upstream, test.appProtocol, test.grpcSupported)
+		if result.Type != test.wantType {
+			t.Errorf("applyAppProtocol() returned type %q but expected %q for the case of %s", result.Type, test.wantType, test.msg)
+		}
+		if result.TLS.Enable != test.wantTLS {
+			t.Errorf("applyAppProtocol() returned TLS %v but expected %v for the case of %s", result.TLS.Enable, test.wantTLS, test.msg)
+		}
+		if len(vsc.warnings[vs]) != test.wantWarnings {
+			t.Errorf("applyAppProtocol() returned %d warnings, expected %d for the case of %s", len(vsc.warnings[vs]), test.wantWarnings, test.msg)
+		}
+	}
+}
+
+func TestApplySessionAffinity(t *testing.T) {
+	t.Parallel()
+	cfgParams := ConfigParams{Context: context.Background()}
+	sessionAffinity := &conf_v1.SessionCookie{Enable: true, Name: "srv_id"}
+
+	tests := []struct {
+		upstream            conf_v1.Upstream
+		isPlus              bool
+		wantLBMethod        string
+		wantSessionCookie   *conf_v1.SessionCookie
+		wantSessionAffinity *conf_v1.SessionCookie
+		wantWarnings        int
+		msg                 string
+	}{
+		{
+			upstream:            conf_v1.Upstream{Name: "tea", SessionAffinity: sessionAffinity},
+			wantLBMethod:        "hash $vs_default_cafe_tea_session_affinity consistent",
+			wantSessionAffinity: sessionAffinity,
+			msg:                 "session affinity with NGINX",
+		},
+		{
+			upstream:            conf_v1.Upstream{Name: "tea", LBMethod: "least_conn", SessionAffinity: sessionAffinity},
+			wantLBMethod:        "hash $vs_default_cafe_tea_session_affinity consistent",
+			wantSessionAffinity: sessionAffinity,
+			wantWarnings:        1,
+			msg:                 "session affinity with NGINX overrides lb-method",
+		},
+		{
+			upstream:          conf_v1.Upstream{Name: "tea", SessionAffinity: sessionAffinity},
+			isPlus:            true,
+			wantSessionCookie: sessionAffinity,
+			msg:               "session affinity with NGINX Plus",
+		},
+		{
+			upstream: conf_v1.Upstream{Name: "tea", SessionAffinity: &conf_v1.SessionCookie{Name: "srv_id"}},
+			msg:      "disabled session affinity",
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(&cfgParams, test.isPlus, false, &StaticConfigParams{}, false, &fakeBV)
+		vs := &conf_v1.VirtualServer{}
+		result := vsc.applySessionAffinity(vs, test.upstream, "vs_default_cafe_tea")
+		if result.LBMethod != test.wantLBMethod {
+			t.Errorf("applySessionAffinity() returned lb method %q but expected %q for the case of %s", result.LBMethod, test.wantLBMethod, test.msg)
+		}
+		if !cmp.Equal(test.wantSessionCookie, result.SessionCookie) {
+			t.Errorf("applySessionAffinity() returned unexpected session cookie for the case of %s: %s", test.msg, cmp.Diff(test.wantSessionCookie, result.SessionCookie))
+		}
+		if !cmp.Equal(test.wantSessionAffinity, result.SessionAffinity) {
+			t.Errorf("applySessionAffinity() returned unexpected session affinity for the case of %s: %s", test.msg, cmp.Diff(test.wantSessionAffinity, result.SessionAffinity))
+		}
+		if len(vsc.warnings[vs]) != test.wantWarnings {
+			t.Errorf("applySessionAffinity() returned %d warnings, expected %d for the case of %s", len(vsc.warnings[vs]), test.wantWarnings, test.msg)
+		}
+	}
+}
+
+func TestGenerateSessionAffinityMaps(t *testing.T) {
+	t.Parallel()
+	sc := &conf_v1.SessionCookie{
+		Enable:   true,
+		Name:     "srv_id",
+		Path:     "/tea",
+		Expires:  "1h",
+		Domain:   ".example.com",
+		HTTPOnly: true,
+		Secure:   true,
+		SameSite: "lax",
+	}
+
+	want := []version2.Map{
+		{
+			Source:   "$cookie_srv_id",
+			Variable: "$vs_default_cafe_tea_session_affinity",
+			Parameters: []version2.Parameter{
+				{Value: `""`, Result: "$request_id"},
+				{Value: "default", Result: "$cookie_srv_id"},
+			},
+		},
+		{
+			Source:   "$cookie_srv_id",
+			Variable: "$vs_default_cafe_tea_session_affinity_cookie",
+			Parameters: []version2.Parameter{
+				{Value: `""`, Result: `"srv_id=$request_id; Max-Age=3600; Domain=.example.com; Path=/tea; HttpOnly; Secure; SameSite=Lax"`},
+				{Value: "default", Result: `""`},
+			},
+		},
+	}
+
+	got := generateSessionAffinityMaps("vs_default_cafe_tea", sc)
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestGenerateSessionAffinityCookie(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		sc   *conf_v1.SessionCookie
+		want string
+	}{
+		{
+			sc:   &conf_v1.SessionCookie{Name: "srv_id"},
+			want: "srv_id=$request_id",
+		},
+		{
+			sc:   &conf_v1.SessionCookie{Name: "srv_id", Expires: "max", Path: "/"},
+			want: "srv_id=$request_id; Expires=Thu, 31 Dec 2037 23:55:55 GMT; Max-Age=315360000; Path=/",
+		},
+		{
+			sc:   &conf_v1.SessionCookie{Name: "srv_id", Expires: "2h30m", SameSite: "Strict"},
+			want: "srv_id=$request_id; Max-Age=9000; SameSite=Strict",
+		},
+	}
+
+	for _, test := range tests {
+		got := generateSessionAffinityCookie(test.sc)
+		if got != test.want {
+			t.Errorf("generateSessionAffinityCookie(%+v) returned %q but expected %q", test.sc, got, test.want)
+		}
+	}
+}
+
+func TestGenerateVirtualServerConfigWithSessionAffinity(t *testing.T) {
+	t.Parallel()
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:            "tea",
+						Service:         "tea-svc",
+						Port:            80,
+						SessionAffinity: &conf_v1.SessionCookie{Enable: true, Name: "srv_id"},
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/tea",
+						Action: &conf_v1.Action{
+							Pass: "tea",
+						},
+					},
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tea-svc:80": {"10.0.0.20:80"},
+		},
+	}
+
+	cfgParams := ConfigParams{Context: context.Background(), LBMethod: "random two least_conn"}
+
+	tests := []struct {
+		isPlus            bool
+		wantLBMethod      string
+		wantSessionCookie *version2.SessionCookie
+		wantMaps          int
+		wantAddHeaders    []version2.AddHeader
+	}{
+		{
+			wantLBMethod: "hash $vs_default_cafe_tea_session_affinity consistent",
+			wantMaps:     2,
+			wantAddHeaders: []version2.AddHeader{
+				{Header: version2.Header{Name: "Set-Cookie", Value: "$vs_default_cafe_tea_session_affinity_cookie"}},
+			},
+		},
+		{
+			isPlus:            true,
+			wantLBMethod:      "random two least_conn",
+			wantSessionCookie: &version2.SessionCookie{Enable: true, Name: "srv_id"},
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(&cfgParams, test.isPlus, false, &StaticConfigParams{}, false, &fakeBV)
+		got, _ := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil, nil)
+
+		ups := got.Upstreams[0]
+		if ups.LBMethod != test.wantLBMethod {
+			t.Errorf("GenerateVirtualServerConfig() returned lb method %q but expected %q (isPlus %v)", ups.LBMethod, test.wantLBMethod, test.isPlus)
+		}
+		if !cmp.Equal(test.wantSessionCookie, ups.SessionCookie) {
+			t.Errorf("GenerateVirtualServerConfig() returned unexpected session cookie (isPlus %v): %s", test.isPlus, cmp.Diff(test.wantSessionCookie, ups.SessionCookie))
+		}
+		if len(got.Maps) != test.wantMaps {
+			t.Errorf("GenerateVirtualServerConfig() returned %d maps but expected %d (isPlus %v)", len(got.Maps), test.wantMaps, test.isPlus)
+		}
+		if !cmp.Equal(test.wantAddHeaders, got.Server.Locations[0].AddHeaders) {
+			t.Errorf("GenerateVirtualServerConfig() returned unexpected add headers (isPlus %v): %s", test.isPlus, cmp.Diff(test.wantAddHeaders, got.Server.Locations[0].AddHeaders))
+		}
+	}
+}
+
+func TestGenerateHTTP3(t *testing.T) {
+	t.Parallel()
+	vs := &conf_v1.VirtualServer{}
+	sslConfig := &version2.SSL{Certificate: "cafe-secret.pem", CertificateKey: "cafe-secret.pem"}
+
+	tests := []struct {
+		vsEx               *VirtualServerEx
+		http3              bool
+		isTLSPassthrough   bool
+		useCustomListeners bool
+		ssl                *version2.SSL
+		want               *version2.HTTP3
+		msg                string
+	}{
+		{
+			vsEx:  &VirtualServerEx{VirtualServer: vs},
+			http3: true,
+			ssl:   sslConfig,
+			want:  &version2.HTTP3{Port: 443},
+			msg:   "default listener with http3 enabled",
+		},
+		{
+			vsEx: &VirtualServerEx{VirtualServer: vs},
+			ssl:  sslConfig,
+			msg:  "default listener with http3 disabled",
+		},
+		{
+			vsEx:  &VirtualServerEx{VirtualServer: vs},
+			http3: true,
+			msg:   "no tls",
+		},
+		{
+			vsEx:             &VirtualServerEx{VirtualServer: vs},
+			http3:            true,
+			isTLSPassthrough: true,
+			ssl:              sslConfig,
+			msg:              "tls passthrough enabled",
+		},
+		{
+			vsEx:               &VirtualServerEx{VirtualServer: vs, HTTPSPort: 8443, HTTPSQUIC: true, HTTPSQUICReusePort: true},
+			useCustomListeners: true,
+			ssl:                sslConfig,
+			want:               &version2.HTTP3{Port: 8443, ReusePort: true},
+			msg:                "custom listener with quic",
+		},
+		{
+			vsEx:               &VirtualServerEx{VirtualServer: vs, HTTPSPort: 8443},
+			http3:              true,
+			useCustomListeners: true,
+			ssl:                sslConfig,
+			msg:                "custom listener without quic",
+		},
+	}
+
+	for _, test := range tests {
+		cfgParams := ConfigParams{Context: context.Background(), HTTP3: test.http3}
+		vsc := newVirtualServerConfigurator(&cfgParams, false, false, &StaticConfigParams{TLSPassthrough: test.isTLSPassthrough}, false, &fakeBV)
+		got := vsc.generateHTTP3(test.vsEx, test.useCustomListeners, test.ssl)
+		if !cmp.Equal(test.want, got) {
+			t.Errorf("generateHTTP3() returned unexpected result for the case of %s: %s", test.msg, cmp.Diff(test.want, got))
+		}
+	}
+}
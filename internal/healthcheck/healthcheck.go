@@ -23,10 +23,11 @@ import (
 )
 
 // RunHealthCheck starts the deep healthcheck service.
-func RunHealthCheck(port int, plusClient *client.NginxClient, cnf *configs.Configurator, healthProbeTLSSecret *v1.Secret) {
+// With NGINX, the prober provides the state of the upstream servers instead of the NGINX Plus API.
+func RunHealthCheck(port int, plusClient *client.NginxClient, prober *Prober, cnf *configs.Configurator, healthProbeTLSSecret *v1.Secret) {
 	l := nl.LoggerFromContext(cnf.CfgParams.Context)
 	addr := fmt.Sprintf(":%s", strconv.Itoa(port))
-	hs, err := NewHealthServer(addr, plusClient, cnf, healthProbeTLSSecret)
+	hs, err := NewHealthServer(addr, plusClient, prober, cnf, healthProbeTLSSecret)
 	if err != nil {
 		nl.Fatal(l, err)
 	}
@@ -47,8 +48,9 @@ type HealthServer struct {
 }
 
 // NewHealthServer creates Health Server. If secret is provided,
-// the server is configured with TLS Config.
-func NewHealthServer(addr string, nc *client.NginxClient, cnf *configs.Configurator, secret *v1.Secret) (*HealthServer, error) {
+// the server is configured with TLS Config. If the NGINX Plus client is nil,
+// the upstream stats are provided by the prober and the stream stats are not available.
+func NewHealthServer(addr string, nc *client.NginxClient, prober *Prober, cnf *configs.Configurator, secret *v1.Secret) (*HealthServer, error) {
 	hs := HealthServer{
 		Server: &http.Server{
 			Addr:         addr,
@@ -57,11 +59,15 @@ func NewHealthServer(addr string, nc *client.NginxClient, cnf *configs.Configura
 		},
 		URL:                    fmt.Sprintf("http://%s/", addr),
 		UpstreamsForHost:       cnf.UpstreamsForHost,
-		NginxUpstreams:         nc.GetUpstreams,
 		StreamUpstreamsForName: cnf.StreamUpstreamsForName,
-		NginxStreamUpstreams:   nc.GetStreamUpstreams,
 		Logger:                 nl.LoggerFromContext(cnf.CfgParams.Context),
 	}
+	if nc != nil {
+		hs.NginxUpstreams = nc.GetUpstreams
+		hs.NginxStreamUpstreams = nc.GetStreamUpstreams
+	} else if prober != nil {
+		hs.NginxUpstreams = prober.GetUpstreams
+	}
 
 	if secret != nil {
 		tlsCert, err := makeCert(secret)
@@ -81,7 +87,9 @@ func NewHealthServer(addr string, nc *client.NginxClient, cnf *configs.Configura
 func (hs *HealthServer) ListenAndServe() error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /probe/{hostname}", hs.UpstreamStats)
-	mux.HandleFunc("GET /probe/ts/{name}", hs.StreamStats)
+	if hs.NginxStreamUpstreams != nil {
+		mux.HandleFunc("GET /probe/ts/{name}", hs.StreamStats)
+	}
 	hs.Server.Handler = mux
 	if hs.Server.TLSConfig != nil {
 		return hs.Server.ListenAndServeTLS("", "")
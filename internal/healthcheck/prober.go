@@ -0,0 +1,443 @@
+package healthcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nginx/kubernetes-ingress/internal/configs"
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginx/nginx-plus-go-client/v3/client"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultProbeInterval = 5 * time.Second
+	defaultProbeTimeout  = 60 * time.Second
+	maxProbeBodySize     = 64 * 1024
+)
+
+// ProbeConfig holds the parameters of an active health check of the servers of an upstream.
+type ProbeConfig struct {
+	Path           string
+	Port           int
+	TLS            bool
+	Host           string
+	Headers        map[string]string
+	StatusMatch    string
+	Interval       time.Duration
+	ConnectTimeout time.Duration
+	ReadTimeout    time.Duration
+	Fails          int
+	Passes         int
+}
+
+// ProbeTarget is an upstream whose servers are checked by the Prober.
+// Service is the namespace/name of the Service of the upstream.
+type ProbeTarget struct {
+	Upstream string
+	Service  string
+	Config   ProbeConfig
+	Servers  []string
+}
+
+// NewProbeConfigForUpstream creates a ProbeConfig from the health check of a VirtualServer or VirtualServerRoute upstream.
+// The defaults are the same as for NGINX Plus active health checks.
+func NewProbeConfigForUpstream(u conf_v1.Upstream, host string, cfgParams *configs.ConfigParams) ProbeConfig {
+	hc := u.HealthCheck
+	connectTimeout, readTimeout := defaultProbeTimeout, defaultProbeTimeout
+	if cfgParams != nil {
+		connectTimeout = parseDurationWithDefault(cfgParams.ProxyConnectTimeout, defaultProbeTimeout)
+		readTimeout = parseDurationWithDefault(cfgParams.ProxyReadTimeout, defaultProbeTimeout)
+	}
+	cfg := ProbeConfig{
+		Path:           "/",
+		Port:           hc.Port,
+		TLS:            u.TLS.Enable,
+		Host:           host,
+		Headers:        make(map[string]string),
+		StatusMatch:    hc.StatusMatch,
+		Interval:       parseDurationWithDefault(hc.Interval, defaultProbeInterval),
+		ConnectTimeout: parseDurationWithDefault(u.ProxyConnectTimeout, connectTimeout),
+		ReadTimeout:    parseDurationWithDefault(u.ProxyReadTimeout, readTimeout),
+		Fails:          1,
+		Passes:         1,
+	}
+	if hc.Path != "" {
+		cfg.Path = hc.Path
+	}
+	if hc.TLS != nil {
+		cfg.TLS = hc.TLS.Enable
+	}
+	cfg.ConnectTimeout = parseDurationWithDefault(hc.ConnectTimeout, cfg.ConnectTimeout)
+	cfg.ReadTimeout = parseDurationWithDefault(hc.ReadTimeout, cfg.ReadTimeout)
+	if hc.Fails > 0 {
+		cfg.Fails = hc.Fails
+	}
+	if hc.Passes > 0 {
+		cfg.Passes = hc.Passes
+	}
+	for _, h := range hc.Headers {
+		cfg.Headers[h.Name] = h.Value
+	}
+	return cfg
+}
+
+// NewProbeConfigForReadinessProbe creates a ProbeConfig from the readiness probe of the pods of an Ingress backend.
+func NewProbeConfigForReadinessProbe(probe *v1.Probe, host string) ProbeConfig {
+	cfg := ProbeConfig{
+		Path:           probe.HTTPGet.Path,
+		TLS:            probe.HTTPGet.Scheme == v1.URISchemeHTTPS,
+		Host:           host,
+		Headers:        make(map[string]string),
+		Interval:       time.Duration(probe.PeriodSeconds) * time.Second,
+		ConnectTimeout: time.Duration(probe.TimeoutSeconds) * time.Second,
+		ReadTimeout:    time.Duration(probe.TimeoutSeconds) * time.Second,
+		Fails:          int(probe.FailureThreshold),
+		Passes:         int(probe.SuccessThreshold),
+	}
+	if cfg.Path == "" {
+		cfg.Path = "/"
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultProbeInterval
+	}
+	if cfg.ConnectTimeout <= 0 {
+		cfg.ConnectTimeout = defaultProbeTimeout
+		cfg.ReadTimeout = defaultProbeTimeout
+	}
+	for _, h := range probe.HTTPGet.HTTPHeaders {
+		cfg.Headers[h.Name] = h.Value
+	}
+	return cfg
+}
+
+func parseDurationWithDefault(s string, defaultDuration time.Duration) time.Duration {
+	if s == "" {
+		return defaultDuration
+	}
+	d, err := configs.ParseDuration(s)
+	if err != nil {
+		return defaultDuration
+	}
+	return d
+}
+
+// Prober performs active health checks of upstream servers on behalf of NGINX, which doesn't support them.
+// The servers of an upstream are probed with the same fails and passes semantics as NGINX Plus active health
+// checks: a server becomes unhealthy after Fails consecutive failed probes and healthy again after Passes
+// consecutive passed probes. New servers are considered healthy.
+type Prober struct {
+	// OnChange is called with the Service of an upstream when the health of one of its servers changes.
+	OnChange func(service string)
+	Logger   *slog.Logger
+
+	ctx    context.Context
+	mu     sync.Mutex
+	owners map[string]map[string]*probedUpstream
+}
+
+type probedUpstream struct {
+	service string
+	config  ProbeConfig
+	servers map[string]*probedServer
+	cancel  context.CancelFunc
+}
+
+type probedServer struct {
+	unhealthy bool
+	fails     int
+	passes    int
+}
+
+// NewProber creates a Prober. The health checks stop when the context is done.
+func NewProber(ctx context.Context) *Prober {
+	return &Prober{
+		Logger: nl.LoggerFromContext(ctx),
+		ctx:    ctx,
+		owners: make(map[string]map[string]*probedUpstream),
+	}
+}
+
+// Update replaces the upstreams checked for the owner with the targets.
+// The health checks of the upstreams that are no longer among the targets are stopped.
+func (p *Prober) Update(owner string, targets []ProbeTarget) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	current := p.owners[owner]
+	updated := make(map[string]*probedUpstream, len(targets))
+
+	for _, t := range targets {
+		if _, duplicate := updated[t.Upstream]; duplicate {
+			continue
+		}
+
+		ups, exists := current[t.Upstream]
+		if exists && !reflect.DeepEqual(ups.config, t.Config) {
+			ups.cancel()
+			exists = false
+		}
+		delete(current, t.Upstream)
+
+		if !exists {
+			ctx, cancel := context.WithCancel(p.ctx)
+			ups = &probedUpstream{
+				config:  t.Config,
+				servers: make(map[string]*probedServer),
+				cancel:  cancel,
+			}
+			go p.run(ctx, t.Upstream, ups)
+		}
+
+		servers := make(map[string]*probedServer, len(t.Servers))
+		for _, server := range t.Servers {
+			if s, ok := ups.servers[server]; ok {
+				servers[server] = s
+			} else {
+				servers[server] = &probedServer{}
+			}
+		}
+		ups.service = t.Service
+		ups.servers = servers
+		updated[t.Upstream] = ups
+	}
+
+	for _, ups := range current {
+		ups.cancel()
+	}
+
+	if len(updated) == 0 {
+		delete(p.owners, owner)
+		return
+	}
+	p.owners[owner] = updated
+}
+
+// Remove stops the health checks of all upstreams of the owner.
+func (p *Prober) Remove(owner string) {
+	p.Update(owner, nil)
+}
+
+// HealthyServers returns the servers of the upstream of the owner that are not unhealthy.
+func (p *Prober) HealthyServers(owner string, upstream string, servers []string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ups, exists := p.owners[owner][upstream]
+	if !exists {
+		return servers
+	}
+
+	var healthy []string
+	for _, server := range servers {
+		if s, ok := ups.servers[server]; ok && s.unhealthy {
+			continue
+		}
+		healthy = append(healthy, server)
+	}
+	return healthy
+}
+
+// GetUpstreams returns the checked upstreams and the state of their servers in the format of the NGINX Plus API.
+func (p *Prober) GetUpstreams(_ context.Context) (*client.Upstreams, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	upstreams := make(client.Upstreams)
+	for _, owned := range p.owners {
+		for name, ups := range owned {
+			var peers []client.Peer
+			for server, s := range ups.servers {
+				state := "up"
+				if s.unhealthy {
+					state = "unhealthy"
+				}
+				peers = append(peers, client.Peer{Server: server, State: state})
+			}
+			upstreams[name] = client.Upstream{Peers: peers}
+		}
+	}
+	return &upstreams, nil
+}
+
+func (p *Prober) run(ctx context.Context, upstream string, ups *probedUpstream) {
+	httpClient := newProbeClient(ups.config)
+	ticker := time.NewTicker(ups.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		p.mu.Lock()
+		servers := make([]string, 0, len(ups.servers))
+		for server := range ups.servers {
+			servers = append(servers, server)
+		}
+		p.mu.Unlock()
+
+		results := make([]bool, len(servers))
+		var wg sync.WaitGroup
+		for i, server := range servers {
+			wg.Add(1)
+			go func(i int, server string) {
+				defer wg.Done()
+				results[i] = probe(ctx, httpClient, ups.config, server)
+			}(i, server)
+		}
+		wg.Wait()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		changed := false
+		p.mu.Lock()
+		service := ups.service
+		for i, server := range servers {
+			s, ok := ups.servers[server]
+			if !ok {
+				continue
+			}
+			if s.update(results[i], ups.config) {
+				changed = true
+				nl.Infof(p.Logger, "Server %s of upstream %s became %s", server, upstream, healthState(s.unhealthy))
+			}
+		}
+		p.mu.Unlock()
+
+		if changed && p.OnChange != nil {
+			p.OnChange(service)
+		}
+	}
+}
+
+// update records the result of a probe and reports whether the health of the server has changed.
+func (s *probedServer) update(passed bool, cfg ProbeConfig) bool {
+	if passed {
+		s.fails = 0
+		s.passes++
+		if s.unhealthy && s.passes >= cfg.Passes {
+			s.unhealthy = false
+			return true
+		}
+		return false
+	}
+
+	s.passes = 0
+	s.fails++
+	if !s.unhealthy && s.fails >= cfg.Fails {
+		s.unhealthy = true
+		return true
+	}
+	return false
+}
+
+func healthState(unhealthy bool) string {
+	if unhealthy {
+		return "unhealthy"
+	}
+	return "healthy"
+}
+
+func newProbeClient(cfg ProbeConfig) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout: cfg.ConnectTimeout,
+			}).DialContext,
+			// NGINX doesn't verify the certificates of upstream servers by default.
+			TLSClientConfig:       &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+			ResponseHeaderTimeout: cfg.ReadTimeout,
+			DisableKeepAlives:     true,
+		},
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func probe(ctx context.Context, httpClient *http.Client, cfg ProbeConfig, server string) bool {
+	address := server
+	if cfg.Port > 0 {
+		host, _, err := net.SplitHostPort(server)
+		if err != nil {
+			return false
+		}
+		address = net.JoinHostPort(host, strconv.Itoa(cfg.Port))
+	}
+
+	scheme := "http"
+	if cfg.TLS {
+		scheme = "https"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, scheme+"://"+address+cfg.Path, nil)
+	if err != nil {
+		return false
+	}
+	if cfg.Host != "" {
+		req.Host = cfg.Host
+	}
+	for name, value := range cfg.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxProbeBodySize))
+
+	return matchStatus(cfg.StatusMatch, resp.StatusCode)
+}
+
+// matchStatus reports whether the status code matches the statusMatch of a health check,
+// for example, "200", "! 500" or "301-303 307". An empty statusMatch matches 2xx and 3xx codes.
+func matchStatus(statusMatch string, code int) bool {
+	if statusMatch == "" {
+		return code >= 200 && code < 400
+	}
+
+	values := strings.Fields(statusMatch)
+	negate := false
+	if len(values) > 0 && values[0] == "!" {
+		negate = true
+		values = values[1:]
+	}
+
+	matched := false
+	for _, value := range values {
+		low, high, isRange := strings.Cut(value, "-")
+		if !isRange {
+			high = low
+		}
+		lowCode, err := strconv.Atoi(low)
+		if err != nil {
+			continue
+		}
+		highCode, err := strconv.Atoi(high)
+		if err != nil {
+			continue
+		}
+		if code >= lowCode && code <= highCode {
+			matched = true
+			break
+		}
+	}
+	return matched != negate
+}
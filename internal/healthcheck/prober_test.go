@@ -0,0 +1,224 @@
+package healthcheck_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nginx/kubernetes-ingress/internal/configs"
+	"github.com/nginx/kubernetes-ingress/internal/healthcheck"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
+)
+
+func TestNewProbeConfigForUpstream_UsesDefaults(t *testing.T) {
+	t.Parallel()
+	u := conf_v1.Upstream{
+		HealthCheck: &conf_v1.HealthCheck{Enable: true},
+	}
+	cfgParams := &configs.ConfigParams{ProxyConnectTimeout: "10s", ProxyReadTimeout: "30s"}
+
+	got := healthcheck.NewProbeConfigForUpstream(u, "cafe.example.com", cfgParams)
+	want := healthcheck.ProbeConfig{
+		Path:           "/",
+		Host:           "cafe.example.com",
+		Headers:        map[string]string{},
+		Interval:       5 * time.Second,
+		ConnectTimeout: 10 * time.Second,
+		ReadTimeout:    30 * time.Second,
+		Fails:          1,
+		Passes:         1,
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestNewProbeConfigForUpstream_UsesHealthCheckFields(t *testing.T) {
+	t.Parallel()
+	u := conf_v1.Upstream{
+		ProxyConnectTimeout: "10s",
+		HealthCheck: &conf_v1.HealthCheck{
+			Enable:         true,
+			Path:           "/healthz",
+			Interval:       "1m",
+			Fails:          3,
+			Passes:         2,
+			Port:           8081,
+			TLS:            &conf_v1.UpstreamTLS{Enable: true},
+			ConnectTimeout: "2s",
+			ReadTimeout:    "3s",
+			StatusMatch:    "! 500",
+			Headers:        []conf_v1.Header{{Name: "X-Probe", Value: "true"}},
+		},
+	}
+
+	got := healthcheck.NewProbeConfigForUpstream(u, "cafe.example.com", nil)
+	want := healthcheck.ProbeConfig{
+		Path:           "/healthz",
+		Port:           8081,
+		TLS:            true,
+		Host:           "cafe.example.com",
+		Headers:        map[string]string{"X-Probe": "true"},
+		StatusMatch:    "! 500",
+		Interval:       time.Minute,
+		ConnectTimeout: 2 * time.Second,
+		ReadTimeout:    3 * time.Second,
+		Fails:          3,
+		Passes:         2,
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestProber_RemovesAndRestoresUnhealthyServers(t *testing.T) {
+	t.Parallel()
+	var failing atomic.Bool
+	failing.Store(true)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" || r.Host != "cafe.example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	server := strings.TrimPrefix(backend.URL, "http://")
+	changes := make(chan string, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	prober := healthcheck.NewProber(ctx)
+	prober.OnChange = func(service string) {
+		changes <- service
+	}
+
+	prober.Update("VirtualServer/default/cafe", []healthcheck.ProbeTarget{
+		{
+			Upstream: "vs_default_cafe_tea",
+			Service:  "default/tea-svc",
+			Config: healthcheck.ProbeConfig{
+				Path:           "/healthz",
+				Host:           "cafe.example.com",
+				Interval:       10 * time.Millisecond,
+				ConnectTimeout: time.Second,
+				ReadTimeout:    time.Second,
+				Fails:          2,
+				Passes:         1,
+			},
+			Servers: []string{server},
+		},
+	})
+
+	if got := prober.HealthyServers("VirtualServer/default/cafe", "vs_default_cafe_tea", []string{server}); len(got) != 1 {
+		t.Fatalf("HealthyServers() returned %v for a new server, want %v", got, []string{server})
+	}
+
+	waitForChange(t, changes, "default/tea-svc")
+	if got := prober.HealthyServers("VirtualServer/default/cafe", "vs_default_cafe_tea", []string{server}); len(got) != 0 {
+		t.Fatalf("HealthyServers() returned %v for a failing server, want none", got)
+	}
+
+	upstreams, err := prober.GetUpstreams(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state := (*upstreams)["vs_default_cafe_tea"].Peers[0].State; state != "unhealthy" {
+		t.Errorf("GetUpstreams() returned state %q for a failing server, want %q", state, "unhealthy")
+	}
+
+	failing.Store(false)
+	waitForChange(t, changes, "default/tea-svc")
+	if got := prober.HealthyServers("VirtualServer/default/cafe", "vs_default_cafe_tea", []string{server}); len(got) != 1 {
+		t.Fatalf("HealthyServers() returned %v for a recovered server, want %v", got, []string{server})
+	}
+
+	prober.Remove("VirtualServer/default/cafe")
+	upstreams, err = prober.GetUpstreams(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*upstreams) != 0 {
+		t.Errorf("GetUpstreams() returned %v after Remove(), want no upstreams", *upstreams)
+	}
+}
+
+func TestProber_MatchesStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		statusMatch string
+		status      int
+		wantHealthy bool
+	}{
+		{statusMatch: "", status: http.StatusFound, wantHealthy: true},
+		{statusMatch: "", status: http.StatusNotFound, wantHealthy: false},
+		{statusMatch: "200", status: http.StatusNoContent, wantHealthy: false},
+		{statusMatch: "200-299 404", status: http.StatusNotFound, wantHealthy: true},
+		{statusMatch: "! 500", status: http.StatusNotFound, wantHealthy: true},
+		{statusMatch: "! 500-599", status: http.StatusBadGateway, wantHealthy: false},
+	}
+
+	for _, test := range tests {
+		backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(test.status)
+		}))
+		server := strings.TrimPrefix(backend.URL, "http://")
+		changes := make(chan string, 10)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		prober := healthcheck.NewProber(ctx)
+		prober.OnChange = func(service string) {
+			changes <- service
+		}
+		prober.Update("Ingress/default/cafe", []healthcheck.ProbeTarget{
+			{
+				Upstream: "tea-svc80",
+				Service:  "default/tea-svc",
+				Config: healthcheck.ProbeConfig{
+					Path:           "/",
+					StatusMatch:    test.statusMatch,
+					Interval:       10 * time.Millisecond,
+					ConnectTimeout: time.Second,
+					ReadTimeout:    time.Second,
+					Fails:          1,
+					Passes:         1,
+				},
+				Servers: []string{server},
+			},
+		})
+
+		select {
+		case <-changes:
+		case <-time.After(200 * time.Millisecond):
+		}
+		healthy := len(prober.HealthyServers("Ingress/default/cafe", "tea-svc80", []string{server})) == 1
+		if healthy != test.wantHealthy {
+			t.Errorf("server with status %d and statusMatch %q is healthy: %t, want %t", test.status, test.statusMatch, healthy, test.wantHealthy)
+		}
+
+		cancel()
+		backend.Close()
+	}
+}
+
+func waitForChange(t *testing.T, changes <-chan string, want string) {
+	t.Helper()
+	select {
+	case got := <-changes:
+		if got != want {
+			t.Fatalf("OnChange() called with %q, want %q", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a change of the health of the server")
+	}
+}
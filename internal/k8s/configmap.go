@@ -1,9 +1,11 @@
 package k8s
 
 import (
+	"fmt"
 	"reflect"
 
 	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/tools/cache"
@@ -50,6 +52,56 @@ func createConfigMapHandlers(lbc *LoadBalancerController, name string) cache.Res
 	}
 }
 
+// createNamespacedConfigMapHandlers builds the handler funcs for config maps referenced by resources,
+// such as a maintenance page of a VirtualServer. The main and the management config maps are handled separately.
+func createNamespacedConfigMapHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			configMap := obj.(*v1.ConfigMap)
+			if !lbc.isMainOrMGMTConfigMapKey(getResourceKey(&configMap.ObjectMeta)) {
+				nl.Debugf(lbc.Logger, "Adding ConfigMap: %v", configMap.Name)
+				lbc.AddSyncQueue(obj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			configMap, isConfigMap := obj.(*v1.ConfigMap)
+			if !isConfigMap {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					nl.Debugf(lbc.Logger, "Error received unexpected object: %v", obj)
+					return
+				}
+				configMap, ok = deletedState.Obj.(*v1.ConfigMap)
+				if !ok {
+					nl.Debugf(lbc.Logger, "Error DeletedFinalStateUnknown contained non-ConfigMap object: %v", deletedState.Obj)
+					return
+				}
+			}
+			if !lbc.isMainOrMGMTConfigMapKey(getResourceKey(&configMap.ObjectMeta)) {
+				nl.Debugf(lbc.Logger, "Removing ConfigMap: %v", configMap.Name)
+				lbc.AddSyncQueue(configMap)
+			}
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			curConfigMap := cur.(*v1.ConfigMap)
+			oldConfigMap := old.(*v1.ConfigMap)
+			if !lbc.isMainOrMGMTConfigMapKey(getResourceKey(&curConfigMap.ObjectMeta)) && !reflect.DeepEqual(oldConfigMap.Data, curConfigMap.Data) {
+				nl.Debugf(lbc.Logger, "ConfigMap %v changed, syncing", curConfigMap.Name)
+				lbc.AddSyncQueue(curConfigMap)
+			}
+		},
+	}
+}
+
+// addConfigMapHandler adds the handler for config maps referenced by resources in the namespace
+func (nsi *namespacedInformer) addConfigMapHandler(handlers cache.ResourceEventHandlerFuncs) {
+	informer := nsi.sharedInformerFactory.Core().V1().ConfigMaps().Informer()
+	informer.AddEventHandler(handlers) //nolint:errcheck,gosec
+	nsi.configMapLister = informer.GetStore()
+
+	nsi.cacheSyncs = append(nsi.cacheSyncs, informer.HasSynced)
+}
+
 func (lbc *LoadBalancerController) getConfigMapHandlerOptions(handlers cache.ResourceEventHandlerFuncs, namespace string) cache.InformerOptions {
 	return cache.InformerOptions{
 		ListerWatcher: cache.NewListWatchFromClient(
@@ -114,6 +166,9 @@ func (lbc *LoadBalancerController) syncConfigMap(task task) {
 		} else {
 			lbc.mgmtConfigMap = nil
 		}
+	default:
+		lbc.syncReferencedConfigMap(task)
+		return
 	}
 
 	if !lbc.isNginxReady {
@@ -131,3 +186,111 @@ func (lbc *LoadBalancerController) syncConfigMap(task task) {
 	}
 	lbc.updateAllConfigs()
 }
+
+// isMainOrMGMTConfigMapKey checks if the key is the key of the main or the management config map.
+func (lbc *LoadBalancerController) isMainOrMGMTConfigMapKey(key string) bool {
+	return key == lbc.nginxConfigMapName || key == lbc.mgmtConfigMapName
+}
+
+// syncReferencedConfigMap updates the resources that reference a config map.
+func (lbc *LoadBalancerController) syncReferencedConfigMap(task task) {
+	key := task.Key
+
+	// it is safe to ignore the error
+	namespace, name, _ := ParseNamespaceName(key)
+
+	resources := lbc.configuration.FindResourcesForConfigMap(namespace, name)
+	if len(resources) == 0 {
+		return
+	}
+
+	if !lbc.isNginxReady {
+		nl.Debugf(lbc.Logger, "Skipping ConfigMap %v update because the pod is not ready yet", key)
+		return
+	}
+
+	nl.Debugf(lbc.Logger, "Syncing resources referencing ConfigMap %v", key)
+
+	// With NGINX Plus, the redirect maps are stored in keyval zones, so the VirtualServers that reference
+	// the config map only as a redirect map are updated without a reload.
+	var reloadResources, redirectMapResources []Resource
+	for _, r := range resources {
+		if vsc, ok := r.(*VirtualServerConfiguration); ok && lbc.isNginxPlus && isReferencedOnlyByRedirectMap(name, vsc.VirtualServer) {
+			redirectMapResources = append(redirectMapResources, r)
+		} else {
+			reloadResources = append(reloadResources, r)
+		}
+	}
+
+	if len(redirectMapResources) > 0 {
+		resourceExes := lbc.createExtendedResources(redirectMapResources)
+		warnings := lbc.configurator.UpdateVirtualServerRedirectMaps(resourceExes.VirtualServerExes)
+		lbc.updateResourcesStatusAndEvents(redirectMapResources, warnings, nil)
+	}
+
+	resourceExes := lbc.createExtendedResources(reloadResources)
+
+	// Only VirtualServers reference config maps
+	if len(resourceExes.VirtualServerExes) == 0 {
+		return
+	}
+
+	warnings, updateErr := lbc.configurator.AddOrUpdateVirtualServers(resourceExes.VirtualServerExes)
+	lbc.updateResourcesStatusAndEvents(reloadResources, warnings, updateErr)
+}
+
+// getConfigMapsForVirtualServer returns the config maps referenced by a VirtualServer keyed by namespace/name.
+func (lbc *LoadBalancerController) getConfigMapsForVirtualServer(vs *conf_v1.VirtualServer) (map[string]*v1.ConfigMap, []error) {
+	configMaps := make(map[string]*v1.ConfigMap)
+	var errors []error
+
+	var names []string
+	if m := vs.Spec.Maintenance; m != nil && m.ConfigMap != nil {
+		names = append(names, m.ConfigMap.Name)
+	}
+	if rm := vs.Spec.RedirectMap; rm != nil {
+		names = append(names, rm.ConfigMap)
+	}
+
+	for _, name := range names {
+		key := fmt.Sprintf("%s/%s", vs.Namespace, name)
+		if _, exists := configMaps[key]; exists {
+			continue
+		}
+		configMap, err := lbc.getConfigMap(key)
+		if err != nil {
+			errors = append(errors, err)
+		} else {
+			configMaps[key] = configMap
+		}
+	}
+
+	return configMaps, errors
+}
+
+// isReferencedOnlyByRedirectMap checks if a config map is referenced by a VirtualServer only as a redirect map.
+func isReferencedOnlyByRedirectMap(configMapName string, vs *conf_v1.VirtualServer) bool {
+	if vs.Spec.RedirectMap == nil || vs.Spec.RedirectMap.ConfigMap != configMapName {
+		return false
+	}
+	m := vs.Spec.Maintenance
+	return m == nil || m.ConfigMap == nil || m.ConfigMap.Name != configMapName
+}
+
+func (lbc *LoadBalancerController) getConfigMap(key string) (*v1.ConfigMap, error) {
+	ns, _, _ := cache.SplitMetaNamespaceKey(key)
+	nsi := lbc.getNamespacedInformer(ns)
+	if nsi == nil || nsi.configMapLister == nil {
+		return nil, fmt.Errorf("ConfigMap %s is in a namespace that is not watched", key)
+	}
+
+	obj, exists, err := nsi.configMapLister.GetByKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %s: %w", key, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("ConfigMap %s doesn't exist", key)
+	}
+
+	return obj.(*v1.ConfigMap), nil
+}
@@ -216,6 +216,8 @@ type VirtualServerConfiguration struct {
 	HTTPIPv6            string
 	HTTPSIPv4           string
 	HTTPSIPv6           string
+	HTTPSQUIC           bool
+	HTTPSQUICReusePort  bool
 }
 
 // NewVirtualServerConfiguration creates a VirtualServerConfiguration.
@@ -276,6 +278,7 @@ func (vsc *VirtualServerConfiguration) IsEqual(resource Resource) bool {
 // TransportServerConfiguration holds a TransportServer resource.
 type TransportServerConfiguration struct {
 	ListenerPort    int
+	ListenerEndPort int
 	IPv4            string
 	IPv6            string
 	TransportServer *conf_v1.TransportServer
@@ -318,7 +321,7 @@ func (tsc *TransportServerConfiguration) IsEqual(resource Resource) bool {
 		return false
 	}
 
-	return compareObjectMetas(tsc.GetObjectMeta(), resource.GetObjectMeta()) && tsc.ListenerPort == tsConfig.ListenerPort
+	return compareObjectMetas(tsc.GetObjectMeta(), resource.GetObjectMeta()) && tsc.ListenerPort == tsConfig.ListenerPort && tsc.ListenerEndPort == tsConfig.ListenerEndPort
 }
 
 func compareObjectMetas(meta1 *metav1.ObjectMeta, meta2 *metav1.ObjectMeta) bool {
@@ -368,6 +371,7 @@ type Configuration struct {
 	serviceReferenceChecker    *serviceReferenceChecker
 	endpointReferenceChecker   *serviceReferenceChecker
 	policyReferenceChecker     *policyReferenceChecker
+	configMapReferenceChecker  *configMapReferenceChecker
 	appPolicyReferenceChecker  *appProtectResourceReferenceChecker
 	appLogConfReferenceChecker *appProtectResourceReferenceChecker
 	appDosProtectedChecker     *dosResourceReferenceChecker
@@ -417,6 +421,7 @@ func NewConfiguration(
 		serviceReferenceChecker:      newServiceReferenceChecker(false),
 		endpointReferenceChecker:     newServiceReferenceChecker(true),
 		policyReferenceChecker:       newPolicyReferenceChecker(),
+		configMapReferenceChecker:    newConfigMapReferenceChecker(),
 		appPolicyReferenceChecker:    newAppProtectResourceReferenceChecker(configs.AppProtectPolicyAnnotation),
 		appLogConfReferenceChecker:   newAppProtectResourceReferenceChecker(configs.AppProtectLogConfAnnotation),
 		appDosProtectedChecker:       newDosResourceReferenceChecker(configs.AppProtectDosProtectedAnnotation),
@@ -679,6 +684,24 @@ func (c *Configuration) GetGlobalConfiguration() *conf_v1.GlobalConfiguration {
 	return c.globalConfiguration
 }
 
+// FindListenerForTransportServer returns the GlobalConfiguration listener referenced by the TransportServer.
+func (c *Configuration) FindListenerForTransportServer(ts *conf_v1.TransportServer) (conf_v1.Listener, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.globalConfiguration == nil {
+		return conf_v1.Listener{}, false
+	}
+
+	for _, l := range c.globalConfiguration.Spec.Listeners {
+		if ts.Spec.Listener.Name == l.Name && ts.Spec.Listener.Protocol == l.Protocol {
+			return l, true
+		}
+	}
+
+	return conf_v1.Listener{}, false
+}
+
 // AddOrUpdateTransportServer adds or updates the TransportServer.
 func (c *Configuration) AddOrUpdateTransportServer(ts *conf_v1.TransportServer) ([]ResourceChange, []ConfigurationProblem) {
 	c.lock.Lock()
@@ -822,7 +845,13 @@ func (c *Configuration) buildListenerHostsAndTSConfigurations() (map[listenerHos
 			continue
 		}
 
+		// the UDP port is taken by an HTTP listener that accepts QUIC connections
+		if _, ok := c.findQUICListenerForUDPListener(listener); ok {
+			continue
+		}
+
 		tsc.ListenerPort = listener.Port
+		tsc.ListenerEndPort = listener.EndPort
 		tsc.IPv4 = listener.IPv4
 		tsc.IPv6 = listener.IPv6
 
@@ -865,6 +894,54 @@ func (c *Configuration) buildListenersForVSConfiguration(vsc *VirtualServerConfi
 
 	assignListener(vs.Spec.Listener.HTTP, false, &vsc.HTTPPort, &vsc.HTTPIPv4, &vsc.HTTPIPv6)
 	assignListener(vs.Spec.Listener.HTTPS, true, &vsc.HTTPSPort, &vsc.HTTPSIPv4, &vsc.HTTPSIPv6)
+
+	if gcListener, ok := c.listenerMap[vs.Spec.Listener.HTTPS]; ok && vsc.HTTPSPort > 0 {
+		vsc.HTTPSQUIC = gcListener.QUIC
+	}
+}
+
+// assignQUICReusePort marks one VirtualServer per QUIC listener to render the reuseport parameter,
+// which NGINX allows only once per address and port.
+func assignQUICReusePort(hosts map[string]Resource) {
+	listenersWithReusePort := make(map[string]bool)
+
+	for _, h := range getSortedResourceKeys(hosts) {
+		vsc, ok := hosts[h].(*VirtualServerConfiguration)
+		if !ok || !vsc.HTTPSQUIC || vsc.VirtualServer.Spec.TLS == nil {
+			continue
+		}
+
+		listenerName := vsc.VirtualServer.Spec.Listener.HTTPS
+		if listenersWithReusePort[listenerName] {
+			continue
+		}
+
+		vsc.HTTPSQUICReusePort = true
+		listenersWithReusePort[listenerName] = true
+	}
+}
+
+// findQUICListenerForUDPListener returns the name of the HTTP listener that accepts QUIC connections on the same UDP address and port as the given listener, or on a port of its range.
+func (c *Configuration) findQUICListenerForUDPListener(listener conf_v1.Listener) (string, bool) {
+	if listener.Protocol != "UDP" {
+		return "", false
+	}
+
+	for _, l := range c.globalConfiguration.Spec.Listeners {
+		if !l.QUIC || l.Port < listener.Port || l.Port > max(listener.Port, listener.EndPort) {
+			continue
+		}
+		if listenerIPsOverlap(l.IPv4, listener.IPv4) || listenerIPsOverlap(l.IPv6, listener.IPv6) {
+			return l.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// listenerIPsOverlap tells if two listener IPs share an address. An empty IP means all addresses.
+func listenerIPsOverlap(ip1, ip2 string) bool {
+	return ip1 == "" || ip2 == "" || ip1 == ip2
 }
 
 // GetResources returns all configuration resources.
@@ -941,6 +1018,11 @@ func (c *Configuration) FindResourcesForPolicy(policyNamespace string, policyNam
 	return c.findResourcesForResourceReference(policyNamespace, policyName, c.policyReferenceChecker)
 }
 
+// FindResourcesForConfigMap finds resources that reference the specified ConfigMap.
+func (c *Configuration) FindResourcesForConfigMap(configMapNamespace string, configMapName string) []Resource {
+	return c.findResourcesForResourceReference(configMapNamespace, configMapName, c.configMapReferenceChecker)
+}
+
 // FindResourcesForAppProtectPolicyAnnotation finds resources that reference the specified AppProtect policy via annotation.
 func (c *Configuration) FindResourcesForAppProtectPolicyAnnotation(policyNamespace string, policyName string) []Resource {
 	return c.findResourcesForResourceReference(policyNamespace, policyName, c.appPolicyReferenceChecker)
@@ -1024,6 +1106,7 @@ func (c *Configuration) rebuildHosts() ([]ResourceChange, []ConfigurationProblem
 	newHosts, newResources := c.buildHostsAndResources()
 
 	updateActiveHostsForIngresses(newHosts, newResources)
+	assignQUICReusePort(newHosts)
 
 	removedHosts, updatedHosts, addedHosts := detectChangesInHosts(c.hosts, newHosts)
 	changes := createResourceChangesForHosts(removedHosts, updatedHosts, addedHosts, c.hosts, newHosts)
@@ -1105,11 +1188,17 @@ func (c *Configuration) addProblemsForTSConfigsWithoutActiveListener(
 		key := listenerHostKey{ListenerName: listenerName, Host: host}
 		holder, exists := c.listenerHosts[key]
 		if !exists {
+			msg := fmt.Sprintf("Listener %s doesn't exist", listenerName)
+			if gcListener, ok := c.listenerMap[listenerName]; ok {
+				if quicListener, ok := c.findQUICListenerForUDPListener(gcListener); ok {
+					msg = fmt.Sprintf("Listener %s uses the UDP port of the QUIC listener %s", listenerName, quicListener)
+				}
+			}
 			p := ConfigurationProblem{
 				Object:  tsc.TransportServer,
 				IsError: false,
 				Reason:  nl.EventReasonRejected,
-				Message: fmt.Sprintf("Listener %s doesn't exist", listenerName),
+				Message: msg,
 			}
 			problems[tsc.GetKeyWithKind()] = p
 			continue
@@ -1847,6 +1936,10 @@ func detectChangesInHosts(oldHosts map[string]Resource, newHosts map[string]Reso
 			updatedHosts = append(updatedHosts, h)
 		}
 
+		if newVsc.HTTPSQUIC != oldVsc.HTTPSQUIC || newVsc.HTTPSQUICReusePort != oldVsc.HTTPSQUICReusePort {
+			updatedHosts = append(updatedHosts, h)
+		}
+
 	}
 
 	return removedHosts, updatedHosts, addedHosts
@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -2402,6 +2403,91 @@ func TestAddTransportServerWithNonExistingListener(t *testing.T) {
 	}
 }
 
+func TestAddTransportServerWithUDPListenerUsingQUICPort(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	listeners := []conf_v1.Listener{
+		{
+			Name:     "https-8443",
+			Port:     8443,
+			Protocol: "HTTP",
+			IPv4:     "127.0.0.1",
+			IPv6:     "::1",
+			Ssl:      true,
+			QUIC:     true,
+		},
+		{
+			Name:     "udp-8443",
+			Port:     8443,
+			Protocol: "UDP",
+		},
+	}
+	addOrUpdateGlobalConfiguration(t, configuration, listeners, noChanges, noProblems)
+
+	ts := createTestTransportServer("transportserver", "udp-8443", "UDP")
+
+	expectedProblems := []ConfigurationProblem{
+		{
+			Object:  ts,
+			IsError: false,
+			Reason:  nl.EventReasonRejected,
+			Message: `Listener udp-8443 uses the UDP port of the QUIC listener https-8443`,
+		},
+	}
+	var expectedChanges []ResourceChange
+
+	changes, problems := configuration.AddOrUpdateTransportServer(ts)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateTransportServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateTransportServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestAddTransportServerWithListenerOverlappingPortRange(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	listeners := []conf_v1.Listener{
+		{
+			Name:     "tcp-range",
+			Port:     30000,
+			EndPort:  30100,
+			Protocol: "TCP",
+		},
+		{
+			Name:     "tcp-30050",
+			Port:     30050,
+			Protocol: "TCP",
+		},
+	}
+	// the validator removes the listener that overlaps the range, so it never becomes active
+	_, _, err := configuration.AddOrUpdateGlobalConfiguration(createTestGlobalConfiguration(listeners))
+	if err == nil || !strings.Contains(err.Error(), "Listener tcp-30050: Duplicated ip:port protocol combination") {
+		t.Fatalf("AddOrUpdateGlobalConfiguration() returned unexpected error %v for overlapping listeners", err)
+	}
+
+	ts := createTestTransportServer("transportserver", "tcp-30050", "TCP")
+
+	expectedProblems := []ConfigurationProblem{
+		{
+			Object:  ts,
+			IsError: false,
+			Reason:  nl.EventReasonRejected,
+			Message: `Listener tcp-30050 doesn't exist`,
+		},
+	}
+	var expectedChanges []ResourceChange
+
+	changes, problems := configuration.AddOrUpdateTransportServer(ts)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateTransportServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateTransportServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
 func TestDeleteNonExistingTransportServer(t *testing.T) {
 	configuration := createTestConfiguration()
 
@@ -3309,6 +3395,71 @@ func TestAddMultipleVirtualServersWithTheSameCustomListeners(t *testing.T) {
 	addOrUpdateVirtualServer(t, configuration, virtualServerFoo, expectedChangesForVsFoo, noProblems)
 }
 
+func TestAddVirtualServersWithQUICListener(t *testing.T) {
+	t.Parallel()
+	configuration := createTestConfiguration()
+
+	listeners := []conf_v1.Listener{
+		{
+			Name:     "http-8082",
+			Port:     8082,
+			Protocol: "HTTP",
+		},
+		{
+			Name:     "https-8442",
+			Port:     8442,
+			Protocol: "HTTP",
+			Ssl:      true,
+			QUIC:     true,
+		},
+	}
+	addOrUpdateGlobalConfiguration(t, configuration, listeners, noChanges, noProblems)
+
+	virtualServerFoo := createTestVirtualServerWithListeners("foo", "foo.example.com", "http-8082", "https-8442")
+	virtualServerFoo.Spec.TLS = &conf_v1.TLS{Secret: "foo-secret"}
+
+	expectedChanges := []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer:      virtualServerFoo,
+				HTTPPort:           8082,
+				HTTPSPort:          8442,
+				HTTPSQUIC:          true,
+				HTTPSQUICReusePort: true,
+			},
+		},
+	}
+	addOrUpdateVirtualServer(t, configuration, virtualServerFoo, expectedChanges, noProblems)
+
+	// the reuseport parameter moves to the VirtualServer with the first host
+	virtualServerCafe := createTestVirtualServerWithListeners("cafe", "cafe.example.com", "http-8082", "https-8442")
+	virtualServerCafe.Spec.TLS = &conf_v1.TLS{Secret: "cafe-secret"}
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: virtualServerFoo,
+				HTTPPort:      8082,
+				HTTPSPort:     8442,
+				HTTPSQUIC:     true,
+			},
+		},
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer:      virtualServerCafe,
+				HTTPPort:           8082,
+				HTTPSPort:          8442,
+				HTTPSQUIC:          true,
+				HTTPSQUICReusePort: true,
+			},
+		},
+	}
+	addOrUpdateVirtualServer(t, configuration, virtualServerCafe, expectedChanges, noProblems)
+}
+
 func TestUpdateGlobalConfigurationWithVirtualServerDeployedWithNoCustomListeners(t *testing.T) {
 	t.Parallel()
 	configuration := createTestConfiguration()
@@ -32,6 +32,7 @@ import (
 	"github.com/nginx/kubernetes-ingress/internal/k8s/appprotect"
 	"github.com/nginx/kubernetes-ingress/internal/k8s/appprotectdos"
 	"github.com/nginx/kubernetes-ingress/internal/telemetry"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/rest"
 
@@ -51,6 +52,7 @@ import (
 	cm_controller "github.com/nginx/kubernetes-ingress/internal/certmanager"
 	"github.com/nginx/kubernetes-ingress/internal/configs"
 	ed_controller "github.com/nginx/kubernetes-ingress/internal/externaldns"
+	"github.com/nginx/kubernetes-ingress/internal/healthcheck"
 	"github.com/nginx/kubernetes-ingress/internal/metrics/collectors"
 
 	api_v1 "k8s.io/api/core/v1"
@@ -97,6 +99,10 @@ var (
 type podEndpoint struct {
 	Address string
 	PodName string
+	// Draining is true for an endpoint of a terminating Pod that still serves requests
+	Draining bool
+	// OtherZone is true for an endpoint that is not meant for the zone of the Ingress Controller
+	OtherZone bool
 	// MeshPodOwner is used for NGINX Service Mesh metrics
 	configs.MeshPodOwner
 }
@@ -112,6 +118,8 @@ type specialSecrets struct {
 type controllerMetadata struct {
 	namespace string
 	pod       *api_v1.Pod
+	// zone is the topology zone of the node of the Ingress Controller pod
+	zone string
 }
 
 // LoadBalancerController watches Kubernetes API and
@@ -186,6 +194,8 @@ type LoadBalancerController struct {
 	nginxConfigMapName            string
 	mgmtConfigMapName             string
 	ShuttingDown                  bool
+	healthProber                  *healthcheck.Prober
+	tlsPassthroughPort            int
 }
 
 var keyFunc = cache.DeletionHandlingMetaNamespaceKeyFunc
@@ -212,6 +222,8 @@ type NewLoadBalancerControllerInput struct {
 	IngressLink                  string
 	ControllerNamespace          string
 	Pod                          *api_v1.Pod
+	Zone                         string
+	HealthProber                 *healthcheck.Prober
 	ReportIngressStatus          bool
 	IsLeaderElectionEnabled      bool
 	LeaderElectionLockName       string
@@ -276,7 +288,7 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		resync:                       input.ResyncPeriod,
 		namespaceList:                input.Namespace,
 		secretNamespaceList:          input.SecretNamespace,
-		metadata:                     controllerMetadata{namespace: input.ControllerNamespace, pod: input.Pod},
+		metadata:                     controllerMetadata{namespace: input.ControllerNamespace, pod: input.Pod, zone: input.Zone},
 		areCustomResourcesEnabled:    input.AreCustomResourcesEnabled,
 		enableOIDC:                   input.EnableOIDC,
 		metricsCollector:             input.MetricsCollector,
@@ -290,9 +302,17 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		nginxConfigMapName:           input.ConfigMaps,
 		mgmtConfigMapName:            input.MGMTConfigMap,
 		ShuttingDown:                 input.ShuttingDown,
+		healthProber:                 input.HealthProber,
+		tlsPassthroughPort:           input.TLSPassthroughPort,
 	}
 
 	lbc.syncQueue = newTaskQueue(lbc.Logger, lbc.sync)
+	if lbc.healthProber != nil {
+		// the resources of the service are regenerated without the unhealthy endpoints
+		lbc.healthProber.OnChange = func(svcKey string) {
+			lbc.syncQueue.EnqueueTask(task{Kind: service, Key: svcKey})
+		}
+	}
 	var err error
 	if input.SpireAgentAddress != "" {
 		lbc.spiffeCertFetcher, err = spiffe.NewX509CertFetcher(input.SpireAgentAddress, nil)
@@ -371,6 +391,7 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		keyFunc:                keyFunc,
 		confClient:             input.ConfClient,
 		hasCorrectIngressClass: lbc.HasCorrectIngressClass,
+		transportServerPorts:   lbc.getTransportServerPorts,
 		logger:                 lbc.Logger,
 	}
 
@@ -466,6 +487,7 @@ type namespacedInformer struct {
 	appProtectUserSigLister      cache.Store
 	transportServerLister        cache.Store
 	policyLister                 cache.Store
+	configMapLister              cache.Store
 	isSecretsEnabledNamespace    bool
 	areCustomResourcesEnabled    bool
 	appProtectEnabled            bool
@@ -517,7 +539,7 @@ func (lbc *LoadBalancerController) newNamespacedInformer(ns string) *namespacedI
 		nsi.addVirtualServerRouteHandler(createVirtualServerRouteHandlers(lbc))
 		nsi.addTransportServerHandler(createTransportServerHandlers(lbc))
 		nsi.addPolicyHandler(createPolicyHandlers(lbc))
-
+		nsi.addConfigMapHandler(createNamespacedConfigMapHandlers(lbc))
 	}
 
 	if lbc.appProtectEnabled || lbc.appProtectDosEnabled {
@@ -635,6 +657,10 @@ func (lbc *LoadBalancerController) Run() {
 		go lbc.leaderElector.Run(lbc.ctx)
 	}
 
+	if lbc.isNginxPlus && lbc.areCustomResourcesEnabled {
+		go wait.Until(lbc.updateTransportServersUpstreamStatus, transportServerUpstreamStatusPeriod, lbc.ctx.Done())
+	}
+
 	if lbc.telemetryCollector != nil {
 		go func(ctx context.Context) {
 			select {
@@ -876,7 +902,7 @@ func (lbc *LoadBalancerController) createExtendedResources(resources []Resource)
 				result.IngressExes = append(result.IngressExes, ingEx)
 			}
 		case *TransportServerConfiguration:
-			tsEx := lbc.createTransportServerEx(impl.TransportServer, impl.ListenerPort, impl.IPv4, impl.IPv6)
+			tsEx := lbc.createTransportServerEx(impl.TransportServer, impl.ListenerPort, impl.ListenerEndPort, impl.IPv4, impl.IPv6)
 			result.TransportServerExes = append(result.TransportServerExes, tsEx)
 		}
 	}
@@ -1033,7 +1059,7 @@ func (lbc *LoadBalancerController) sync(task task) {
 		lbc.updateIngressMetrics()
 		lbc.updateTransportServerMetrics()
 	case configMap:
-		if lbc.batchSyncEnabled {
+		if lbc.batchSyncEnabled && lbc.isMainOrMGMTConfigMapKey(task.Key) {
 			lbc.updateAllConfigsOnBatch = true
 		}
 		lbc.syncConfigMap(task)
@@ -1299,7 +1325,7 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 					lbc.updateRegularIngressStatusAndEvents(impl, warnings, addOrUpdateErr)
 				}
 			case *TransportServerConfiguration:
-				tsEx := lbc.createTransportServerEx(impl.TransportServer, impl.ListenerPort, impl.IPv4, impl.IPv6)
+				tsEx := lbc.createTransportServerEx(impl.TransportServer, impl.ListenerPort, impl.ListenerEndPort, impl.IPv4, impl.IPv6)
 				warnings, addOrUpdateErr := lbc.configurator.AddOrUpdateTransportServer(tsEx)
 				lbc.updateTransportServerStatusAndEvents(impl, warnings, addOrUpdateErr)
 			}
@@ -1312,6 +1338,9 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 				if deleteErr != nil {
 					nl.Errorf(lbc.Logger, "Error when deleting configuration for VirtualServer %v: %v", key, deleteErr)
 				}
+				if lbc.healthProber != nil {
+					lbc.healthProber.Remove(getHealthProberOwner(impl.VirtualServer))
+				}
 
 				var vsExists bool
 				var err error
@@ -1334,6 +1363,7 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 				if deleteErr != nil {
 					nl.Errorf(lbc.Logger, "Error when deleting configuration for Ingress %v: %v", key, deleteErr)
 				}
+				lbc.removeIngressFromHealthProber(impl)
 
 				var ingExists bool
 				var err error
@@ -1705,6 +1735,10 @@ func (lbc *LoadBalancerController) syncIngress(task task) {
 		nl.Debugf(lbc.Logger, "Deleting Ingress: %v", key)
 
 		changes, problems = lbc.configuration.DeleteIngress(key)
+		if lbc.healthProber != nil {
+			// a deleted minion only updates its master, so its health checks are stopped here
+			lbc.healthProber.Remove(getIngressHealthProberOwner(key))
+		}
 	} else {
 		nl.Debugf(lbc.Logger, "Adding or Updating Ingress: %v", key)
 
@@ -2127,11 +2161,52 @@ func (lbc *LoadBalancerController) updateVirtualServerRoutesStatusFromEvents() e
 func getIPAddressesFromEndpoints(endpoints []podEndpoint) []string {
 	var endps []string
 	for _, ep := range endpoints {
+		if ep.Draining {
+			continue
+		}
 		endps = append(endps, ep.Address)
 	}
 	return endps
 }
 
+// getIPAddressesByZoneFromEndpoints returns the addresses of the ready endpoints for the zone of the Ingress Controller
+// and the addresses of the ready endpoints for other zones. If there are fewer endpoints for the zone than
+// the configured minimum, all endpoints are returned as the endpoints for the zone.
+func (lbc *LoadBalancerController) getIPAddressesByZoneFromEndpoints(endpoints []podEndpoint) (zoneEndps []string, otherZoneEndps []string) {
+	for _, ep := range endpoints {
+		if ep.Draining {
+			continue
+		}
+		if ep.OtherZone {
+			otherZoneEndps = append(otherZoneEndps, ep.Address)
+		} else {
+			zoneEndps = append(zoneEndps, ep.Address)
+		}
+	}
+
+	if len(otherZoneEndps) > 0 && len(zoneEndps) < lbc.topologyAwareRoutingMinEndpoints() {
+		return getIPAddressesFromEndpoints(endpoints), nil
+	}
+	return zoneEndps, otherZoneEndps
+}
+
+func getDrainingIPAddressesFromEndpoints(endpoints []podEndpoint) []string {
+	ready := make(map[string]bool)
+	for _, ep := range endpoints {
+		if !ep.Draining {
+			ready[ep.Address] = true
+		}
+	}
+
+	var endps []string
+	for _, ep := range endpoints {
+		if ep.Draining && !ready[ep.Address] {
+			endps = append(endps, ep.Address)
+		}
+	}
+	return endps
+}
+
 func (lbc *LoadBalancerController) createMergeableIngresses(ingConfig *IngressConfiguration) *configs.MergeableIngresses {
 	// for master Ingress, validMinionPaths are nil
 	masterIngressEx := lbc.createIngressEx(ingConfig.Ingress, ingConfig.ValidHosts, nil)
@@ -2149,7 +2224,7 @@ func (lbc *LoadBalancerController) createMergeableIngresses(ingConfig *IngressCo
 }
 
 func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, validHosts map[string]bool, validMinionPaths map[string]bool) *configs.IngressEx {
-	var endps []string
+	var endps, otherZoneEndps []string
 	ingEx := &configs.IngressEx{
 		Ingress:          ing,
 		ValidHosts:       validHosts,
@@ -2232,11 +2307,40 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 	}
 
 	ingEx.Endpoints = make(map[string][]string)
+	ingEx.DrainingEndpoints = make(map[string][]string)
+	ingEx.OtherZoneEndpoints = make(map[string][]string)
+	ingEx.AppProtocols = make(map[string]string)
 	ingEx.HealthChecks = make(map[string]*api_v1.Probe)
 	ingEx.ExternalNameSvcs = make(map[string]bool)
 	ingEx.PodsByIP = make(map[string]configs.PodInfo)
 	hasUseClusterIP := ingEx.Ingress.Annotations[configs.UseClusterIPAnnotation] == "true"
 
+	var probeTargets []healthcheck.ProbeTarget
+	healthProberOwner := getHealthProberOwner(ing)
+	isProbingEnabled := lbc.healthProber != nil && lbc.isHealthCheckEnabled(ing)
+
+	// probeEndpoints registers the endpoints of a backend in the health prober and returns the endpoints
+	// that are not unhealthy. The health prober is only used with NGINX and checks the endpoints
+	// according to the readiness probe of the pods of the backend.
+	probeEndpoints := func(backend *networking.IngressBackend, endps []string, otherZoneEndps []string) ([]string, []string) {
+		if !isProbingEnabled {
+			return endps, otherZoneEndps
+		}
+		probe := lbc.getHealthChecksForIngressBackend(backend, ing.Namespace)
+		if probe == nil {
+			return endps, otherZoneEndps
+		}
+		upstream := backend.Service.Name + configs.GetBackendPortAsString(backend.Service.Port)
+		probeTargets = append(probeTargets, healthcheck.ProbeTarget{
+			Upstream: upstream,
+			Service:  ing.Namespace + "/" + backend.Service.Name,
+			Config:   healthcheck.NewProbeConfigForReadinessProbe(probe, ""),
+			Servers:  append(slices.Clone(endps), otherZoneEndps...),
+		})
+		return lbc.healthProber.HealthyServers(healthProberOwner, upstream, endps),
+			lbc.healthProber.HealthyServers(healthProberOwner, upstream, otherZoneEndps)
+	}
+
 	if ing.Spec.DefaultBackend != nil {
 		podEndps := []podEndpoint{}
 		var external bool
@@ -2244,6 +2348,9 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 		if err != nil {
 			nl.Warnf(lbc.Logger, "Error getting service %v: %v", ing.Spec.DefaultBackend.Service.Name, err)
 		} else {
+			if appProtocol := getAppProtocolForBackendPort(svc, ing.Spec.DefaultBackend.Service.Port); appProtocol != "" {
+				ingEx.AppProtocols[ing.Spec.DefaultBackend.Service.Name+configs.GetBackendPortAsString(ing.Spec.DefaultBackend.Service.Port)] = appProtocol
+			}
 			podEndps, external, err = lbc.getEndpointsForIngressBackend(ing.Spec.DefaultBackend, svc)
 			if err == nil && external && lbc.isNginxPlus {
 				ingEx.ExternalNameSvcs[svc.Name] = true
@@ -2265,7 +2372,14 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 			}
 			endps = []string{ipv6SafeAddrPort(svc.Spec.ClusterIP, ing.Spec.DefaultBackend.Service.Port.Number)}
 		} else {
-			endps = getIPAddressesFromEndpoints(podEndps)
+			endps, otherZoneEndps = lbc.getIPAddressesByZoneFromEndpoints(podEndps)
+			endps, otherZoneEndps = probeEndpoints(ing.Spec.DefaultBackend, endps, otherZoneEndps)
+			if len(otherZoneEndps) > 0 {
+				ingEx.OtherZoneEndpoints[ing.Spec.DefaultBackend.Service.Name+configs.GetBackendPortAsString(ing.Spec.DefaultBackend.Service.Port)] = otherZoneEndps
+			}
+			if drainingEndps := getDrainingIPAddressesFromEndpoints(podEndps); len(drainingEndps) > 0 {
+				ingEx.DrainingEndpoints[ing.Spec.DefaultBackend.Service.Name+configs.GetBackendPortAsString(ing.Spec.DefaultBackend.Service.Port)] = drainingEndps
+			}
 		}
 
 		// endps is empty if there was any error before this point
@@ -2312,6 +2426,9 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 			if err != nil {
 				nl.Debugf(lbc.Logger, "Error getting service %v: %v", &path.Backend.Service.Name, err)
 			} else {
+				if appProtocol := getAppProtocolForBackendPort(svc, path.Backend.Service.Port); appProtocol != "" {
+					ingEx.AppProtocols[path.Backend.Service.Name+configs.GetBackendPortAsString(path.Backend.Service.Port)] = appProtocol
+				}
 				podEndps, external, err = lbc.getEndpointsForIngressBackend(&path.Backend, svc)
 				if err == nil && external && lbc.isNginxPlus {
 					ingEx.ExternalNameSvcs[svc.Name] = true
@@ -2333,7 +2450,14 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 				}
 				endps = []string{ipv6SafeAddrPort(svc.Spec.ClusterIP, path.Backend.Service.Port.Number)}
 			} else {
-				endps = getIPAddressesFromEndpoints(podEndps)
+				endps, otherZoneEndps = lbc.getIPAddressesByZoneFromEndpoints(podEndps)
+				endps, otherZoneEndps = probeEndpoints(&path.Backend, endps, otherZoneEndps)
+				if len(otherZoneEndps) > 0 {
+					ingEx.OtherZoneEndpoints[path.Backend.Service.Name+configs.GetBackendPortAsString(path.Backend.Service.Port)] = otherZoneEndps
+				}
+				if drainingEndps := getDrainingIPAddressesFromEndpoints(podEndps); len(drainingEndps) > 0 {
+					ingEx.DrainingEndpoints[path.Backend.Service.Name+configs.GetBackendPortAsString(path.Backend.Service.Port)] = drainingEndps
+				}
 			}
 
 			// endps is empty if there was any error before this point
@@ -2358,6 +2482,10 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 		}
 	}
 
+	if lbc.healthProber != nil {
+		lbc.healthProber.Update(healthProberOwner, probeTargets)
+	}
+
 	return ingEx
 }
 
@@ -2381,6 +2509,8 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		virtualServerEx.HTTPIPv6 = vsc.HTTPIPv6
 		virtualServerEx.HTTPSIPv4 = vsc.HTTPSIPv4
 		virtualServerEx.HTTPSIPv6 = vsc.HTTPSIPv6
+		virtualServerEx.HTTPSQUIC = vsc.HTTPSQUIC
+		virtualServerEx.HTTPSQUICReusePort = vsc.HTTPSQUICReusePort
 	}
 
 	if virtualServer.Spec.TLS != nil && virtualServer.Spec.TLS.Secret != "" {
@@ -2394,6 +2524,12 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		virtualServerEx.SecretRefs[scrtKey] = scrtRef
 	}
 
+	configMaps, configMapErrors := lbc.getConfigMapsForVirtualServer(virtualServer)
+	for _, err := range configMapErrors {
+		nl.Warnf(lbc.Logger, "Error getting ConfigMap for VirtualServer %s/%s: %v", virtualServer.Namespace, virtualServer.Name, err)
+	}
+	virtualServerEx.ConfigMaps = configMaps
+
 	policies, policyErrors := lbc.getPolicies(virtualServer.Spec.Policies, virtualServer.Namespace)
 	for _, err := range policyErrors {
 		nl.Warnf(lbc.Logger, "Error getting policy for VirtualServer %s/%s: %v", virtualServer.Namespace, virtualServer.Name, err)
@@ -2440,6 +2576,9 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 	}
 
 	endpoints := make(map[string][]string)
+	drainingEndpoints := make(map[string][]string)
+	otherZoneEndpoints := make(map[string][]string)
+	appProtocols := make(map[string]string)
 	externalNameSvcs := make(map[string]bool)
 	podsByIP := make(map[string]configs.PodInfo)
 
@@ -2462,9 +2601,33 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		endpoints[backupEndpointsKey] = bendps
 	}
 
+	var probeTargets []healthcheck.ProbeTarget
+	healthProberOwner := getHealthProberOwner(virtualServer)
+
+	// probeEndpoints registers the endpoints of an upstream with an active health check in the health prober
+	// and returns the endpoints that are not unhealthy. The health prober is only used with NGINX.
+	probeEndpoints := func(namespace string, upstreamName string, u conf_v1.Upstream, endps []string, otherZoneEndps []string) ([]string, []string) {
+		if lbc.healthProber == nil || u.HealthCheck == nil || !u.HealthCheck.Enable {
+			return endps, otherZoneEndps
+		}
+		probeTargets = append(probeTargets, healthcheck.ProbeTarget{
+			Upstream: upstreamName,
+			Service:  namespace + "/" + u.Service,
+			Config:   healthcheck.NewProbeConfigForUpstream(u, virtualServer.Spec.Host, lbc.configurator.CfgParams),
+			Servers:  append(slices.Clone(endps), otherZoneEndps...),
+		})
+		return lbc.healthProber.HealthyServers(healthProberOwner, upstreamName, endps),
+			lbc.healthProber.HealthyServers(healthProberOwner, upstreamName, otherZoneEndps)
+	}
+	virtualServerUpstreamNamer := configs.NewUpstreamNamerForVirtualServer(virtualServer)
+
 	for _, u := range virtualServer.Spec.Upstreams {
 		endpointsKey := configs.GenerateEndpointsKey(virtualServer.Namespace, u.Service, u.Subselector, u.Port)
 
+		if appProtocol := lbc.getAppProtocolForUpstream(virtualServer.Namespace, u.Service, u.Port); appProtocol != "" {
+			appProtocols[endpointsKey] = appProtocol
+		}
+
 		var endps []string
 		if u.UseClusterIP {
 			s, err := lbc.getServiceForUpstream(virtualServer.Namespace, u.Service, u.Port)
@@ -2493,7 +2656,15 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 				nl.Warnf(lbc.Logger, "Error getting Endpoints for Upstream %v: %v", u.Name, err)
 			}
 
-			endps = getIPAddressesFromEndpoints(podEndps)
+			var otherZoneEndps []string
+			endps, otherZoneEndps = lbc.getIPAddressesByZoneFromEndpoints(podEndps)
+			endps, otherZoneEndps = probeEndpoints(virtualServer.Namespace, virtualServerUpstreamNamer.GetNameForUpstream(u.Name), u, endps, otherZoneEndps)
+			if len(otherZoneEndps) > 0 {
+				otherZoneEndpoints[endpointsKey] = otherZoneEndps
+			}
+			if drainingEndps := getDrainingIPAddressesFromEndpoints(podEndps); len(drainingEndps) > 0 {
+				drainingEndpoints[endpointsKey] = drainingEndps
+			}
 
 			if (lbc.isNginxPlus && lbc.isPrometheusEnabled) || lbc.isLatencyMetricsEnabled {
 				for _, endpoint := range podEndps {
@@ -2604,6 +2775,10 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		for _, u := range vsr.Spec.Upstreams {
 			endpointsKey := configs.GenerateEndpointsKey(vsr.Namespace, u.Service, u.Subselector, u.Port)
 
+			if appProtocol := lbc.getAppProtocolForUpstream(vsr.Namespace, u.Service, u.Port); appProtocol != "" {
+				appProtocols[endpointsKey] = appProtocol
+			}
+
 			var endps []string
 			if u.UseClusterIP {
 				s, err := lbc.getServiceForUpstream(vsr.Namespace, u.Service, u.Port)
@@ -2630,7 +2805,15 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 					nl.Warnf(lbc.Logger, "Error getting Endpoints for Upstream %v: %v", u.Name, err)
 				}
 
-				endps = getIPAddressesFromEndpoints(podEndps)
+				var otherZoneEndps []string
+				endps, otherZoneEndps = lbc.getIPAddressesByZoneFromEndpoints(podEndps)
+				endps, otherZoneEndps = probeEndpoints(vsr.Namespace, configs.NewUpstreamNamerForVirtualServerRoute(virtualServer, vsr).GetNameForUpstream(u.Name), u, endps, otherZoneEndps)
+				if len(otherZoneEndps) > 0 {
+					otherZoneEndpoints[endpointsKey] = otherZoneEndps
+				}
+				if drainingEndps := getDrainingIPAddressesFromEndpoints(podEndps); len(drainingEndps) > 0 {
+					drainingEndpoints[endpointsKey] = drainingEndps
+				}
 
 				if lbc.isNginxPlus || lbc.isLatencyMetricsEnabled {
 					for _, endpoint := range podEndps {
@@ -2647,7 +2830,14 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		}
 	}
 
+	if lbc.healthProber != nil {
+		lbc.healthProber.Update(healthProberOwner, probeTargets)
+	}
+
 	virtualServerEx.Endpoints = endpoints
+	virtualServerEx.DrainingEndpoints = drainingEndpoints
+	virtualServerEx.OtherZoneEndpoints = otherZoneEndpoints
+	virtualServerEx.AppProtocols = appProtocols
 	virtualServerEx.VirtualServerRoutes = virtualServerRoutes
 	virtualServerEx.ExternalNameSvcs = externalNameSvcs
 	virtualServerEx.Policies = createPolicyMap(policies)
@@ -2978,7 +3168,7 @@ func (lbc *LoadBalancerController) getEndpointsForServiceWithSubselector(targetP
 		return nil, err
 	}
 
-	endps = getEndpointsFromEndpointSlicesForSubselectedPods(targetPort, pods, svcEndpointSlices)
+	endps = getEndpointsFromEndpointSlicesForSubselectedPods(targetPort, pods, svcEndpointSlices, lbc.endpointDrainPeriod(), lbc.topologyAwareRoutingZone())
 	return endps, nil
 }
 
@@ -2999,10 +3189,15 @@ func selectEndpointSlicesForPort(targetPort int32, esx []discovery_v1.EndpointSl
 }
 
 // filterReadyEndpoinsFrom returns ready Endpoints from given EndpointSlices.
-func filterReadyEndpointsFrom(esx []discovery_v1.EndpointSlice) []discovery_v1.Endpoint {
+// If includeDraining is true, it also returns terminating Endpoints that are still serving.
+func filterReadyEndpointsFrom(esx []discovery_v1.EndpointSlice, includeDraining bool) []discovery_v1.Endpoint {
 	epx := make([]discovery_v1.Endpoint, 0, len(esx))
 	for _, es := range esx {
 		for _, e := range es.Endpoints {
+			if includeDraining && isDrainingEndpoint(e) {
+				epx = append(epx, e)
+				continue
+			}
 			if e.Conditions.Ready == nil {
 				continue
 			}
@@ -3014,7 +3209,63 @@ func filterReadyEndpointsFrom(esx []discovery_v1.EndpointSlice) []discovery_v1.E
 	return epx
 }
 
-func getEndpointsFromEndpointSlicesForSubselectedPods(targetPort int32, pods []*api_v1.Pod, svcEndpointSlices []discovery_v1.EndpointSlice) (podEndpoints []podEndpoint) {
+// isDrainingEndpoint returns true if the Endpoint is not ready because it is terminating, but still serves requests.
+func isDrainingEndpoint(e discovery_v1.Endpoint) bool {
+	if e.Conditions.Ready != nil && *e.Conditions.Ready {
+		return false
+	}
+	return e.Conditions.Serving != nil && *e.Conditions.Serving && e.Conditions.Terminating != nil && *e.Conditions.Terminating
+}
+
+// isOtherZoneEndpoint returns true if the Endpoint is not meant for the zone.
+// The zone hints of the Endpoint take precedence over the zone of the Endpoint.
+// An Endpoint without zone information is meant for every zone.
+func isOtherZoneEndpoint(e discovery_v1.Endpoint, zone string) bool {
+	if zone == "" {
+		return false
+	}
+	if e.Hints != nil && len(e.Hints.ForZones) > 0 {
+		for _, z := range e.Hints.ForZones {
+			if z.Name == zone {
+				return false
+			}
+		}
+		return true
+	}
+	return e.Zone != nil && *e.Zone != "" && *e.Zone != zone
+}
+
+// topologyAwareRoutingZone returns the zone of the Ingress Controller if topology-aware routing is enabled.
+func (lbc *LoadBalancerController) topologyAwareRoutingZone() string {
+	if lbc.configurator == nil || lbc.configurator.CfgParams == nil || !lbc.configurator.CfgParams.TopologyAwareRouting {
+		return ""
+	}
+	return lbc.metadata.zone
+}
+
+// topologyAwareRoutingMinEndpoints returns the minimum number of ready endpoints in the zone of the Ingress Controller
+// for topology-aware routing.
+func (lbc *LoadBalancerController) topologyAwareRoutingMinEndpoints() int {
+	if lbc.configurator == nil || lbc.configurator.CfgParams == nil {
+		return 1
+	}
+	return lbc.configurator.CfgParams.TopologyAwareRoutingMinEndpoints
+}
+
+// isDrainPeriodExpired returns true if the Pod has been terminating for longer than the drain period.
+func isDrainPeriodExpired(pod *api_v1.Pod, drainPeriod time.Duration) bool {
+	return pod != nil && pod.DeletionTimestamp != nil && time.Since(pod.DeletionTimestamp.Time) > drainPeriod
+}
+
+// endpointDrainPeriod returns the period during which terminating endpoints are kept as draining servers.
+func (lbc *LoadBalancerController) endpointDrainPeriod() time.Duration {
+	if lbc.configurator == nil || lbc.configurator.CfgParams == nil {
+		return 0
+	}
+	return lbc.configurator.CfgParams.EndpointDrainPeriod
+}
+
+func getEndpointsFromEndpointSlicesForSubselectedPods(targetPort int32, pods []*api_v1.Pod, svcEndpointSlices []discovery_v1.EndpointSlice, drainPeriod time.Duration, zone string) (podEndpoints []podEndpoint) {
 	// Match ready endpoints IP ddresses with Pod's IP. If they match create a new podEnpoint.
 	makePodEndpoints := func(pods []*api_v1.Pod, endpoints []discovery_v1.Endpoint) []podEndpoint {
 		endpointSet := make(map[podEndpoint]struct{})
@@ -3023,11 +3274,17 @@ func getEndpointsFromEndpointSlicesForSubselectedPods(targetPort int32, pods []*
 			for _, endpoint := range endpoints {
 				for _, address := range endpoint.Addresses {
 					if pod.Status.PodIP == address {
+						draining := isDrainingEndpoint(endpoint)
+						if draining && isDrainPeriodExpired(pod, drainPeriod) {
+							continue
+						}
 						addr := ipv6SafeAddrPort(pod.Status.PodIP, targetPort)
 						ownerType, ownerName := getPodOwnerTypeAndName(pod)
 						podEndpoint := podEndpoint{
-							Address: addr,
-							PodName: getPodName(endpoint.TargetRef),
+							Address:   addr,
+							PodName:   getPodName(endpoint.TargetRef),
+							Draining:  draining,
+							OtherZone: isOtherZoneEndpoint(endpoint, zone),
 							MeshPodOwner: configs.MeshPodOwner{
 								OwnerType: ownerType,
 								OwnerName: ownerName,
@@ -3041,7 +3298,7 @@ func getEndpointsFromEndpointSlicesForSubselectedPods(targetPort int32, pods []*
 		return slices.Collect(maps.Keys(endpointSet))
 	}
 
-	return makePodEndpoints(pods, filterReadyEndpointsFrom(selectEndpointSlicesForPort(targetPort, svcEndpointSlices)))
+	return makePodEndpoints(pods, filterReadyEndpointsFrom(selectEndpointSlicesForPort(targetPort, svcEndpointSlices), drainPeriod > 0))
 }
 
 func ipv6SafeAddrPort(addr string, port int32) string {
@@ -3158,14 +3415,23 @@ func (lbc *LoadBalancerController) getEndpointsForPortFromEndpointSlices(endpoin
 		return nil, fmt.Errorf("no port %v in service %s", backendPort, svc.Name)
 	}
 
+	drainPeriod := lbc.endpointDrainPeriod()
+	zone := lbc.topologyAwareRoutingZone()
+
 	makePodEndpoints := func(port int32, epx []discovery_v1.Endpoint) []podEndpoint {
 		endpointSet := make(map[podEndpoint]struct{})
 
 		for _, ep := range epx {
+			draining := isDrainingEndpoint(ep)
+			if draining && ep.TargetRef != nil && isDrainPeriodExpired(lbc.getPodByName(ep.TargetRef.Namespace, ep.TargetRef.Name), drainPeriod) {
+				continue
+			}
 			for _, addr := range ep.Addresses {
 				address := ipv6SafeAddrPort(addr, port)
 				podEndpoint := podEndpoint{
-					Address: address,
+					Address:   address,
+					Draining:  draining,
+					OtherZone: isOtherZoneEndpoint(ep, zone),
 				}
 				if ep.TargetRef != nil {
 					parentType, parentName := lbc.getPodOwnerTypeAndNameFromAddress(ep.TargetRef.Namespace, ep.TargetRef.Name)
@@ -3179,7 +3445,7 @@ func (lbc *LoadBalancerController) getEndpointsForPortFromEndpointSlices(endpoin
 		return slices.Collect(maps.Keys(endpointSet))
 	}
 
-	endpoints := makePodEndpoints(targetPort, filterReadyEndpointsFrom(selectEndpointSlicesForPort(targetPort, endpointSlices)))
+	endpoints := makePodEndpoints(targetPort, filterReadyEndpointsFrom(selectEndpointSlicesForPort(targetPort, endpointSlices), drainPeriod > 0))
 	if len(endpoints) == 0 {
 		return nil, fmt.Errorf("no endpointslices for target port %v in service %s", targetPort, svc.Name)
 	}
@@ -3203,6 +3469,18 @@ func (lbc *LoadBalancerController) getPodOwnerTypeAndNameFromAddress(ns, name st
 	return "", ""
 }
 
+func (lbc *LoadBalancerController) getPodByName(ns, name string) *api_v1.Pod {
+	obj, exists, err := lbc.getNamespacedInformer(ns).podLister.GetByKey(fmt.Sprintf("%s/%s", ns, name))
+	if err != nil {
+		nl.Warnf(lbc.Logger, "could not get pod by key %s/%s: %v", ns, name, err)
+		return nil
+	}
+	if !exists {
+		return nil
+	}
+	return obj.(*api_v1.Pod)
+}
+
 func getPodOwnerTypeAndName(pod *api_v1.Pod) (parentType, parentName string) {
 	parentType = "deployment"
 	for _, owner := range pod.GetOwnerReferences() {
@@ -3270,6 +3548,29 @@ func (lbc *LoadBalancerController) getServiceForUpstream(namespace string, upstr
 	return lbc.getServiceForIngressBackend(backend, namespace)
 }
 
+// getAppProtocolForUpstream returns the appProtocol of the Service port of the upstream.
+// It returns an empty string if the Service doesn't exist or the port has no appProtocol.
+func (lbc *LoadBalancerController) getAppProtocolForUpstream(namespace string, upstreamService string, upstreamPort uint16) string {
+	svc, err := lbc.getServiceForUpstream(namespace, upstreamService, upstreamPort)
+	if err != nil {
+		return ""
+	}
+	return getAppProtocolForBackendPort(svc, networking.ServiceBackendPort{Number: int32(upstreamPort)})
+}
+
+// getAppProtocolForBackendPort returns the appProtocol of the Service port that matches the backend port.
+func getAppProtocolForBackendPort(svc *api_v1.Service, backendPort networking.ServiceBackendPort) string {
+	for _, port := range svc.Spec.Ports {
+		if (backendPort.Name == "" && port.Port == backendPort.Number) || (backendPort.Name != "" && port.Name == backendPort.Name) {
+			if port.AppProtocol != nil {
+				return *port.AppProtocol
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
 func (lbc *LoadBalancerController) getServiceForIngressBackend(backend *networking.IngressBackend, namespace string) (*api_v1.Service, error) {
 	svcKey := namespace + "/" + backend.Service.Name
 	var svcObj interface{}
@@ -3317,6 +3618,33 @@ func (lbc *LoadBalancerController) HasCorrectIngressClass(obj interface{}) bool
 	return class == lbc.ingressClass || class == ""
 }
 
+// getHealthProberOwner returns the owner of the upstreams of an Ingress or a VirtualServer in the health prober.
+func getHealthProberOwner(obj interface{}) string {
+	switch o := obj.(type) {
+	case *networking.Ingress:
+		return getIngressHealthProberOwner(getResourceKey(&o.ObjectMeta))
+	case *conf_v1.VirtualServer:
+		return "VirtualServer/" + getResourceKey(&o.ObjectMeta)
+	}
+	return ""
+}
+
+// getIngressHealthProberOwner returns the owner of the upstreams of the Ingress with the key in the health prober.
+func getIngressHealthProberOwner(key string) string {
+	return "Ingress/" + key
+}
+
+// removeIngressFromHealthProber stops the health checks of the Ingress and, for a master, of its minions.
+func (lbc *LoadBalancerController) removeIngressFromHealthProber(ingConfig *IngressConfiguration) {
+	if lbc.healthProber == nil {
+		return
+	}
+	lbc.healthProber.Remove(getHealthProberOwner(ingConfig.Ingress))
+	for _, minion := range ingConfig.Minions {
+		lbc.healthProber.Remove(getHealthProberOwner(minion.Ingress))
+	}
+}
+
 // isHealthCheckEnabled checks if health checks are enabled so we can only query pods if enabled.
 func (lbc *LoadBalancerController) isHealthCheckEnabled(ing *networking.Ingress) bool {
 	if healthCheckEnabled, exists, err := configs.GetMapKeyAsBool(ing.Annotations, "nginx.com/health-checks", ing); exists {
@@ -3355,6 +3683,21 @@ func (lbc *LoadBalancerController) addInternalRouteServer() {
 	}
 }
 
+// processVSMaintenanceToggle toggles the maintenance mode of a VirtualServer via the keyval without reloading NGINX Plus.
+func (lbc *LoadBalancerController) processVSMaintenanceToggle(vsOld *conf_v1.VirtualServer, vsNew *conf_v1.VirtualServer) {
+	if vsOld.Status.State == conf_v1.StateInvalid {
+		lbc.AddSyncQueue(vsNew)
+		return
+	}
+
+	if lbc.haltIfVSConfigInvalid(vsNew) {
+		return
+	}
+
+	update := configs.NewMaintenanceKeyValUpdate(vsNew)
+	lbc.configurator.UpsertSplitClientsKeyVal(update.Zone, update.Key, update.Value)
+}
+
 func (lbc *LoadBalancerController) processVSWeightChangesDynamicReload(vsOld *conf_v1.VirtualServer, vsNew *conf_v1.VirtualServer) {
 	var weightUpdates []configs.WeightUpdate
 	var splitClientsIndex int
@@ -3571,6 +3914,9 @@ func (lbc *LoadBalancerController) haltIfVSConfigInvalid(vsNew *conf_v1.VirtualS
 				if deleteErr != nil {
 					nl.Errorf(lbc.Logger, "Error when deleting configuration for VirtualServer %v: %v", key, deleteErr)
 				}
+				if lbc.healthProber != nil {
+					lbc.healthProber.Remove(getHealthProberOwner(impl.VirtualServer))
+				}
 
 				var vsExists bool
 				var err error
@@ -19,6 +19,7 @@ import (
 	"github.com/nginx/kubernetes-ingress/internal/configs"
 	"github.com/nginx/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginx/kubernetes-ingress/internal/configs/version2"
+	"github.com/nginx/kubernetes-ingress/internal/healthcheck"
 	"github.com/nginx/kubernetes-ingress/internal/k8s/secrets"
 	"github.com/nginx/kubernetes-ingress/internal/metrics/collectors"
 	"github.com/nginx/kubernetes-ingress/internal/nginx"
@@ -1202,7 +1203,7 @@ func TestGetEndpointSlicesBySubselectedPods_FindOnePodInOneEndpointSlice(t *test
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices)
+			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices, 0, "")
 
 			if result := unorderedEqual(gotEndpoints, test.expectedEndpoints); !result {
 				t.Errorf("getEndpointsFromEndpointSlicesForSubselectedPods() = got %v, want %v", gotEndpoints, test.expectedEndpoints)
@@ -1308,7 +1309,7 @@ func TestGetEndpointSlicesBySubselectedPods_GetsEndpointsOnNilValues(t *testing.
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			got := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices)
+			got := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices, 0, "")
 			if !cmp.Equal(got, test.want) {
 				t.Error(cmp.Diff(got, test.want))
 			}
@@ -1397,7 +1398,7 @@ func TestGetEndpointSlicesBySubselectedPods_FindOnePodInTwoEndpointSlicesWithDup
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices)
+			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices, 0, "")
 
 			if result := unorderedEqual(gotEndpoints, test.expectedEndpoints); !result {
 				t.Errorf("getEndpointsFromEndpointSlicesForSubselectedPods() = got %v, want %v", gotEndpoints, test.expectedEndpoints)
@@ -1499,7 +1500,7 @@ func TestGetEndpointSlicesBySubselectedPods_FindTwoPodsInOneEndpointSlice(t *tes
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices)
+			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices, 0, "")
 
 			if result := unorderedEqual(gotEndpoints, test.expectedEndpoints); !result {
 				t.Errorf("getEndpointsFromEndpointSlicesForSubselectedPods() = got %v, want %v", gotEndpoints, test.expectedEndpoints)
@@ -1610,7 +1611,7 @@ func TestGetEndpointSlicesBySubselectedPods_FindTwoPodsInTwoEndpointSlices(t *te
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices)
+			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices, 0, "")
 
 			if result := unorderedEqual(gotEndpoints, test.expectedEndpoints); !result {
 				t.Errorf("getEndpointsFromEndpointSlicesForSubselectedPods() = got %v, want %v", gotEndpoints, test.expectedEndpoints)
@@ -1706,7 +1707,7 @@ func TestGetEndpointSlicesBySubselectedPods_FindOnePodEndpointInOneEndpointSlice
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices)
+			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices, 0, "")
 
 			if result := unorderedEqual(gotEndpoints, test.expectedEndpoints); !result {
 				t.Errorf("getEndpointsFromEndpointSlicesForSubselectedPods() = got %v, want %v", gotEndpoints, test.expectedEndpoints)
@@ -1811,7 +1812,7 @@ func TestGetEndpointSlicesBySubselectedPods_FindOnePodEndpointInTwoEndpointSlice
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices)
+			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices, 0, "")
 
 			if result := unorderedEqual(gotEndpoints, test.expectedEndpoints); !result {
 				t.Errorf("getEndpointsFromEndpointSlicesForSubselectedPods() = got %v, want %v", gotEndpoints, test.expectedEndpoints)
@@ -1820,6 +1821,260 @@ func TestGetEndpointSlicesBySubselectedPods_FindOnePodEndpointInTwoEndpointSlice
 	}
 }
 
+func TestGetEndpointSlicesBySubselectedPods_KeepsTerminatingServingEndpointsAsDraining(t *testing.T) {
+	t.Parallel()
+	endpointPort := int32(8080)
+	boolPointer := func(b bool) *bool { return &b }
+	deletedNow := meta_v1.Now()
+	deletedLongAgo := meta_v1.NewTime(time.Now().Add(-time.Hour))
+	pod := func(ip string, deletionTimestamp *meta_v1.Time) *api_v1.Pod {
+		return &api_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{
+				DeletionTimestamp: deletionTimestamp,
+				OwnerReferences: []meta_v1.OwnerReference{
+					{
+						Kind:       "Deployment",
+						Name:       "deploy-1",
+						Controller: boolPointer(true),
+					},
+				},
+			},
+			Status: api_v1.PodStatus{
+				PodIP: ip,
+			},
+		}
+	}
+	pods := []*api_v1.Pod{
+		pod("1.2.3.4", nil),
+		pod("5.6.7.8", &deletedNow),
+		pod("9.9.9.9", &deletedLongAgo),
+		pod("10.0.0.1", &deletedNow),
+	}
+	svcEndpointSlices := []discovery_v1.EndpointSlice{
+		{
+			Ports: []discovery_v1.EndpointPort{
+				{
+					Port: &endpointPort,
+				},
+			},
+			Endpoints: []discovery_v1.Endpoint{
+				{
+					Addresses: []string{"1.2.3.4"},
+					Conditions: discovery_v1.EndpointConditions{
+						Ready: boolPointer(true),
+					},
+				},
+				{
+					Addresses: []string{"5.6.7.8"},
+					Conditions: discovery_v1.EndpointConditions{
+						Ready:       boolPointer(false),
+						Serving:     boolPointer(true),
+						Terminating: boolPointer(true),
+					},
+				},
+				{
+					Addresses: []string{"9.9.9.9"},
+					Conditions: discovery_v1.EndpointConditions{
+						Ready:       boolPointer(false),
+						Serving:     boolPointer(true),
+						Terminating: boolPointer(true),
+					},
+				},
+				{
+					Addresses: []string{"10.0.0.1"},
+					Conditions: discovery_v1.EndpointConditions{
+						Ready:       boolPointer(false),
+						Serving:     boolPointer(false),
+						Terminating: boolPointer(true),
+					},
+				},
+			},
+		},
+	}
+	meshPodOwner := configs.MeshPodOwner{
+		OwnerType: "deployment",
+		OwnerName: "deploy-1",
+	}
+
+	tests := []struct {
+		desc              string
+		drainPeriod       time.Duration
+		expectedEndpoints []podEndpoint
+	}{
+		{
+			desc:        "drain period is not configured",
+			drainPeriod: 0,
+			expectedEndpoints: []podEndpoint{
+				{
+					Address:      "1.2.3.4:8080",
+					MeshPodOwner: meshPodOwner,
+				},
+			},
+		},
+		{
+			desc:        "drain period is configured",
+			drainPeriod: 30 * time.Second,
+			expectedEndpoints: []podEndpoint{
+				{
+					Address:      "1.2.3.4:8080",
+					MeshPodOwner: meshPodOwner,
+				},
+				{
+					Address:      "5.6.7.8:8080",
+					Draining:     true,
+					MeshPodOwner: meshPodOwner,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(8080, pods, svcEndpointSlices, test.drainPeriod, "")
+
+			if result := unorderedEqual(gotEndpoints, test.expectedEndpoints); !result {
+				t.Errorf("getEndpointsFromEndpointSlicesForSubselectedPods() = got %v, want %v", gotEndpoints, test.expectedEndpoints)
+			}
+		})
+	}
+}
+
+func TestGetDrainingIPAddressesFromEndpoints(t *testing.T) {
+	t.Parallel()
+	endpoints := []podEndpoint{
+		{Address: "1.2.3.4:8080"},
+		{Address: "5.6.7.8:8080", Draining: true},
+		{Address: "1.2.3.4:8080", Draining: true},
+	}
+
+	wantReady := []string{"1.2.3.4:8080"}
+	if got := getIPAddressesFromEndpoints(endpoints); !reflect.DeepEqual(got, wantReady) {
+		t.Errorf("getIPAddressesFromEndpoints() = %v, want %v", got, wantReady)
+	}
+
+	wantDraining := []string{"5.6.7.8:8080"}
+	if got := getDrainingIPAddressesFromEndpoints(endpoints); !reflect.DeepEqual(got, wantDraining) {
+		t.Errorf("getDrainingIPAddressesFromEndpoints() = %v, want %v", got, wantDraining)
+	}
+}
+
+func TestIsOtherZoneEndpoint(t *testing.T) {
+	t.Parallel()
+	zoneA := "zone-a"
+	zoneB := "zone-b"
+	tests := []struct {
+		endpoint discovery_v1.Endpoint
+		zone     string
+		want     bool
+		msg      string
+	}{
+		{
+			endpoint: discovery_v1.Endpoint{Zone: &zoneB},
+			zone:     "",
+			want:     false,
+			msg:      "zone of the Ingress Controller is unknown",
+		},
+		{
+			endpoint: discovery_v1.Endpoint{},
+			zone:     zoneA,
+			want:     false,
+			msg:      "endpoint without zone",
+		},
+		{
+			endpoint: discovery_v1.Endpoint{Zone: &zoneA},
+			zone:     zoneA,
+			want:     false,
+			msg:      "endpoint in the same zone",
+		},
+		{
+			endpoint: discovery_v1.Endpoint{Zone: &zoneB},
+			zone:     zoneA,
+			want:     true,
+			msg:      "endpoint in another zone",
+		},
+		{
+			endpoint: discovery_v1.Endpoint{
+				Zone: &zoneB,
+				Hints: &discovery_v1.EndpointHints{
+					ForZones: []discovery_v1.ForZone{{Name: zoneA}},
+				},
+			},
+			zone: zoneA,
+			want: false,
+			msg:  "endpoint in another zone with a hint for the zone",
+		},
+		{
+			endpoint: discovery_v1.Endpoint{
+				Zone: &zoneA,
+				Hints: &discovery_v1.EndpointHints{
+					ForZones: []discovery_v1.ForZone{{Name: zoneB}},
+				},
+			},
+			zone: zoneA,
+			want: true,
+			msg:  "endpoint in the same zone with a hint for another zone",
+		},
+	}
+
+	for _, test := range tests {
+		if got := isOtherZoneEndpoint(test.endpoint, test.zone); got != test.want {
+			t.Errorf("isOtherZoneEndpoint() returned %t but expected %t for the case of %s", got, test.want, test.msg)
+		}
+	}
+}
+
+func TestGetIPAddressesByZoneFromEndpoints(t *testing.T) {
+	t.Parallel()
+	endpoints := []podEndpoint{
+		{Address: "10.0.0.1:8080"},
+		{Address: "10.0.1.1:8080", OtherZone: true},
+		{Address: "10.0.1.2:8080", OtherZone: true, Draining: true},
+	}
+
+	tests := []struct {
+		minEndpoints       int
+		wantEndpoints      []string
+		wantOtherEndpoints []string
+		msg                string
+	}{
+		{
+			minEndpoints:       1,
+			wantEndpoints:      []string{"10.0.0.1:8080"},
+			wantOtherEndpoints: []string{"10.0.1.1:8080"},
+			msg:                "enough endpoints in the zone",
+		},
+		{
+			minEndpoints:       2,
+			wantEndpoints:      []string{"10.0.0.1:8080", "10.0.1.1:8080"},
+			wantOtherEndpoints: nil,
+			msg:                "not enough endpoints in the zone",
+		},
+	}
+
+	for _, test := range tests {
+		lbc := LoadBalancerController{
+			configurator: &configs.Configurator{
+				CfgParams: &configs.ConfigParams{
+					TopologyAwareRouting:             true,
+					TopologyAwareRoutingMinEndpoints: test.minEndpoints,
+				},
+			},
+		}
+		gotEndpoints, gotOtherEndpoints := lbc.getIPAddressesByZoneFromEndpoints(endpoints)
+		if !reflect.DeepEqual(gotEndpoints, test.wantEndpoints) || !reflect.DeepEqual(gotOtherEndpoints, test.wantOtherEndpoints) {
+			t.Errorf("getIPAddressesByZoneFromEndpoints() returned %v, %v but expected %v, %v for the case of %s",
+				gotEndpoints, gotOtherEndpoints, test.wantEndpoints, test.wantOtherEndpoints, test.msg)
+		}
+	}
+
+	// without a configurator, the default minimum of 1 endpoint is used
+	lbc := LoadBalancerController{}
+	gotEndpoints, gotOtherEndpoints := lbc.getIPAddressesByZoneFromEndpoints(endpoints)
+	if !reflect.DeepEqual(gotEndpoints, []string{"10.0.0.1:8080"}) || !reflect.DeepEqual(gotOtherEndpoints, []string{"10.0.1.1:8080"}) {
+		t.Errorf("getIPAddressesByZoneFromEndpoints() returned %v, %v without a configurator", gotEndpoints, gotOtherEndpoints)
+	}
+}
+
 func TestGetEndpointSlicesBySubselectedPods_FindNoPods(t *testing.T) {
 	t.Parallel()
 	endpointPort := int32(8080)
@@ -1876,7 +2131,7 @@ func TestGetEndpointSlicesBySubselectedPods_FindNoPods(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices)
+			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices, 0, "")
 
 			if result := unorderedEqual(gotEndpoints, test.expectedEndpoints); !result {
 				t.Errorf("getEndpointsFromEndpointSlicesForSubselectedPods() = got %v, want %v", gotEndpoints, test.expectedEndpoints)
@@ -1938,7 +2193,7 @@ func TestGetEndpointSlicesBySubselectedPods_TargetPortMismatch(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices)
+			gotEndpoints := getEndpointsFromEndpointSlicesForSubselectedPods(test.targetPort, test.pods, test.svcEndpointSlices, 0, "")
 
 			if result := unorderedEqual(gotEndpoints, test.expectedEndpoints); !result {
 				t.Errorf("getEndpointsFromEndpointSlicesForSubselectedPods() = got %v, want %v", gotEndpoints, test.expectedEndpoints)
@@ -2109,7 +2364,7 @@ func TestGetPoliciesGlobalWatch(t *testing.T) {
 
 	expectedPolicies := []*conf_v1.Policy{validPolicy}
 	expectedErrors := []error{
-		errors.New("policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `cache`, `jwt`, `oidc`, `waf`"),
+		errors.New("policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `cache`, `connectionLimit`, `jwt`, `oidc`, `waf`"),
 		errors.New("policy nginx-ingress/valid-policy doesn't exist"),
 		errors.New("failed to get policy nginx-ingress/some-policy: GetByKey error"),
 		errors.New("referenced policy default/valid-policy-ingress-class has incorrect ingress class: test-class (controller ingress class: )"),
@@ -2207,7 +2462,7 @@ func TestGetPoliciesNamespacedWatch(t *testing.T) {
 
 	expectedPolicies := []*conf_v1.Policy{validPolicy}
 	expectedErrors := []error{
-		errors.New("policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `cache`, `jwt`, `oidc`, `waf`"),
+		errors.New("policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `cache`, `connectionLimit`, `jwt`, `oidc`, `waf`"),
 		errors.New("failed to get namespace nginx-ingress"),
 		errors.New("referenced policy default/valid-policy-ingress-class has incorrect ingress class: test-class (controller ingress class: )"),
 	}
@@ -3722,3 +3977,145 @@ func TestIsPodMarkedForDeletion(t *testing.T) {
 		})
 	}
 }
+
+func TestIsReferencedOnlyByRedirectMap(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		spec     conf_v1.VirtualServerSpec
+		expected bool
+		msg      string
+	}{
+		{
+			spec:     conf_v1.VirtualServerSpec{},
+			expected: false,
+			msg:      "no redirect map",
+		},
+		{
+			spec: conf_v1.VirtualServerSpec{
+				RedirectMap: &conf_v1.RedirectMap{ConfigMap: "redirects"},
+			},
+			expected: true,
+			msg:      "referenced by the redirect map",
+		},
+		{
+			spec: conf_v1.VirtualServerSpec{
+				RedirectMap: &conf_v1.RedirectMap{ConfigMap: "other"},
+			},
+			expected: false,
+			msg:      "redirect map references another config map",
+		},
+		{
+			spec: conf_v1.VirtualServerSpec{
+				RedirectMap: &conf_v1.RedirectMap{ConfigMap: "redirects"},
+				Maintenance: &conf_v1.Maintenance{
+					ConfigMap: &conf_v1.MaintenanceConfigMap{Name: "maintenance"},
+				},
+			},
+			expected: true,
+			msg:      "maintenance page references another config map",
+		},
+		{
+			spec: conf_v1.VirtualServerSpec{
+				RedirectMap: &conf_v1.RedirectMap{ConfigMap: "redirects"},
+				Maintenance: &conf_v1.Maintenance{
+					ConfigMap: &conf_v1.MaintenanceConfigMap{Name: "redirects"},
+				},
+			},
+			expected: false,
+			msg:      "also referenced by the maintenance page",
+		},
+	}
+
+	for _, test := range tests {
+		vs := &conf_v1.VirtualServer{Spec: test.spec}
+		if result := isReferencedOnlyByRedirectMap("redirects", vs); result != test.expected {
+			t.Errorf("isReferencedOnlyByRedirectMap() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGetAppProtocolForBackendPort(t *testing.T) {
+	t.Parallel()
+	h2c := "kubernetes.io/h2c"
+	svc := &api_v1.Service{
+		Spec: api_v1.ServiceSpec{
+			Ports: []api_v1.ServicePort{
+				{Name: "grpc", Port: 50051, AppProtocol: &h2c},
+				{Name: "http", Port: 80},
+			},
+		},
+	}
+	tests := []struct {
+		port networking.ServiceBackendPort
+		want string
+		msg  string
+	}{
+		{
+			port: networking.ServiceBackendPort{Number: 50051},
+			want: h2c,
+			msg:  "port number with appProtocol",
+		},
+		{
+			port: networking.ServiceBackendPort{Name: "grpc"},
+			want: h2c,
+			msg:  "port name with appProtocol",
+		},
+		{
+			port: networking.ServiceBackendPort{Number: 80},
+			want: "",
+			msg:  "port without appProtocol",
+		},
+		{
+			port: networking.ServiceBackendPort{Number: 8080},
+			want: "",
+			msg:  "unknown port",
+		},
+	}
+	for _, test := range tests {
+		if got := getAppProtocolForBackendPort(svc, test.port); got != test.want {
+			t.Errorf("getAppProtocolForBackendPort() returned %q but expected %q for the case of %s", got, test.want, test.msg)
+		}
+	}
+}
+
+func TestRemoveIngressFromHealthProber(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	// the health checks are not run for a done context
+	cancel()
+
+	master := &networking.Ingress{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "cafe-master"}}
+	minion := &networking.Ingress{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "coffee-minion"}}
+	other := &networking.Ingress{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "tea"}}
+
+	prober := healthcheck.NewProber(ctx)
+	for _, ing := range []*networking.Ingress{master, minion, other} {
+		prober.Update(getHealthProberOwner(ing), []healthcheck.ProbeTarget{
+			{
+				Upstream: "upstream-" + ing.Name,
+				Config:   healthcheck.ProbeConfig{Interval: time.Hour},
+				Servers:  []string{"10.0.0.1:80"},
+			},
+		})
+	}
+
+	lbc := LoadBalancerController{healthProber: prober}
+	lbc.removeIngressFromHealthProber(&IngressConfiguration{
+		Ingress:  master,
+		IsMaster: true,
+		Minions:  []*MinionConfiguration{{Ingress: minion}},
+	})
+
+	upstreams, err := prober.GetUpstreams(context.Background())
+	if err != nil {
+		t.Fatalf("GetUpstreams() returned unexpected error: %v", err)
+	}
+	var names []string
+	for name := range *upstreams {
+		names = append(names, name)
+	}
+	expected := []string{"upstream-tea"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("removeIngressFromHealthProber() left upstreams %v but expected %v", names, expected)
+	}
+}
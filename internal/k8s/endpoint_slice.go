@@ -82,6 +82,12 @@ func (lbc *LoadBalancerController) syncEndpointSlices(task task) bool {
 
 	resourceExes := lbc.createExtendedResources(svcResource)
 
+	// terminating endpoints must be removed once the drain period is over,
+	// even if the EndpointSlice does not change in the meantime
+	if drainPeriod := lbc.endpointDrainPeriod(); drainPeriod > 0 && hasDrainingEndpoints(endpointSlice) {
+		lbc.syncQueue.EnqueueAfter(task, drainPeriod)
+	}
+
 	if len(resourceExes.IngressExes) > 0 {
 		for _, ingEx := range resourceExes.IngressExes {
 			if lbc.ingressRequiresEndpointsUpdate(ingEx, svcName) {
@@ -136,3 +142,12 @@ func (lbc *LoadBalancerController) syncEndpointSlices(task task) bool {
 	}
 	return resourcesFound
 }
+
+func hasDrainingEndpoints(endpointSlice *discovery_v1.EndpointSlice) bool {
+	for _, e := range endpointSlice.Endpoints {
+		if isDrainingEndpoint(e) {
+			return true
+		}
+	}
+	return false
+}
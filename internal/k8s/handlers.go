@@ -131,6 +131,12 @@ func createVirtualServerHandlers(lbc *LoadBalancerController) cache.ResourceEven
 			curVs := cur.(*conf_v1.VirtualServer)
 			oldVs := old.(*conf_v1.VirtualServer)
 
+			if lbc.isNginxPlus && isMaintenanceToggleOnly(oldVs, curVs) {
+				nl.Debugf(lbc.Logger, "VirtualServer %v maintenance mode changed, updating without reload", curVs.Name)
+				lbc.processVSMaintenanceToggle(oldVs, curVs)
+				return
+			}
+
 			if lbc.weightChangesDynamicReload {
 				var curVsCopy, oldVsCopy conf_v1.VirtualServer
 				err := copier.CopyWithOption(&curVsCopy, curVs, copier.Option{DeepCopy: true})
@@ -246,6 +252,21 @@ func areResourcesDifferent(l *slog.Logger, oldresource, resource *unstructured.U
 	return !eq, nil
 }
 
+// isMaintenanceToggleOnly checks if the maintenance mode toggle is the only change between two VirtualServers.
+func isMaintenanceToggleOnly(oldVs *conf_v1.VirtualServer, curVs *conf_v1.VirtualServer) bool {
+	if oldVs.Spec.Maintenance == nil || curVs.Spec.Maintenance == nil {
+		return false
+	}
+	if oldVs.Spec.Maintenance.Enable == curVs.Spec.Maintenance.Enable {
+		return false
+	}
+
+	oldSpec := oldVs.Spec.DeepCopy()
+	oldSpec.Maintenance.Enable = curVs.Spec.Maintenance.Enable
+
+	return reflect.DeepEqual(*oldSpec, curVs.Spec)
+}
+
 func zeroOutVirtualServerSplitWeights(vs *conf_v1.VirtualServer) {
 	for _, route := range vs.Spec.Routes {
 		for _, match := range route.Matches {
@@ -8,6 +8,7 @@ import (
 
 	nic_glog "github.com/nginx/kubernetes-ingress/internal/logger/glog"
 	"github.com/nginx/kubernetes-ingress/internal/logger/levels"
+	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -135,3 +136,60 @@ func TestAreResourcesDifferent(t *testing.T) {
 		}
 	}
 }
+
+func TestIsMaintenanceToggleOnly(t *testing.T) {
+	t.Parallel()
+
+	newVS := func(host string, m *conf_v1.Maintenance) *conf_v1.VirtualServer {
+		return &conf_v1.VirtualServer{
+			Spec: conf_v1.VirtualServerSpec{
+				Host:        host,
+				Maintenance: m,
+			},
+		}
+	}
+
+	tests := []struct {
+		oldVS, curVS *conf_v1.VirtualServer
+		expected     bool
+		msg          string
+	}{
+		{
+			oldVS:    newVS("cafe.example.com", &conf_v1.Maintenance{Enable: false, Code: 503}),
+			curVS:    newVS("cafe.example.com", &conf_v1.Maintenance{Enable: true, Code: 503}),
+			expected: true,
+			msg:      "maintenance enabled",
+		},
+		{
+			oldVS:    newVS("cafe.example.com", &conf_v1.Maintenance{Enable: true}),
+			curVS:    newVS("cafe.example.com", &conf_v1.Maintenance{Enable: false}),
+			expected: true,
+			msg:      "maintenance disabled",
+		},
+		{
+			oldVS:    newVS("cafe.example.com", nil),
+			curVS:    newVS("cafe.example.com", &conf_v1.Maintenance{Enable: true}),
+			expected: false,
+			msg:      "maintenance added",
+		},
+		{
+			oldVS:    newVS("cafe.example.com", &conf_v1.Maintenance{Enable: true}),
+			curVS:    newVS("cafe.example.com", &conf_v1.Maintenance{Enable: true, Code: 500}),
+			expected: false,
+			msg:      "maintenance code changed",
+		},
+		{
+			oldVS:    newVS("cafe.example.com", &conf_v1.Maintenance{Enable: false}),
+			curVS:    newVS("tea.example.com", &conf_v1.Maintenance{Enable: true}),
+			expected: false,
+			msg:      "maintenance enabled and host changed",
+		},
+	}
+
+	for _, test := range tests {
+		result := isMaintenanceToggleOnly(test.oldVS, test.curVS)
+		if result != test.expected {
+			t.Errorf("isMaintenanceToggleOnly() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
@@ -112,13 +112,13 @@ func (lbc *LoadBalancerController) syncIngressLink(task task) {
 	}
 
 	if lbc.areCustomResourcesEnabled && lbc.reportCustomResourceStatusEnabled() {
-		virtualServers := lbc.configuration.GetResourcesWithFilter(resourceFilter{VirtualServers: true})
+		resources := lbc.configuration.GetResourcesWithFilter(resourceFilter{VirtualServers: true, TransportServers: true})
 
-		nl.Debugf(lbc.Logger, "Updating status for %v VirtualServers", len(virtualServers))
+		nl.Debugf(lbc.Logger, "Updating status for %v VirtualServers and TransportServers", len(resources))
 
-		err := lbc.statusUpdater.UpdateExternalEndpointsForResources(virtualServers)
+		err := lbc.statusUpdater.UpdateExternalEndpointsForResources(resources)
 		if err != nil {
-			nl.Debugf(lbc.Logger, "Error updating VirtualServer/VirtualServerRoute status in syncIngressLink: %v", err)
+			nl.Debugf(lbc.Logger, "Error updating VirtualServer/VirtualServerRoute/TransportServer status in syncIngressLink: %v", err)
 		}
 	}
 }
@@ -95,8 +95,21 @@ func (rc *secretReferenceChecker) IsReferencedByTransportServer(secretNamespace
 		return false
 	}
 
-	if ts.Spec.TLS != nil && ts.Spec.TLS.Secret == secretName {
-		return true
+	if ts.Spec.TLS != nil {
+		if ts.Spec.TLS.Secret == secretName || ts.Spec.TLS.ClientCertSecret == secretName {
+			return true
+		}
+		for _, c := range ts.Spec.TLS.Certificates {
+			if c.Secret == secretName {
+				return true
+			}
+		}
+	}
+
+	for _, u := range ts.Spec.Upstreams {
+		if u.TLS != nil && (u.TLS.TLSSecret == secretName || u.TLS.TrustedCertSecret == secretName) {
+			return true
+		}
 	}
 
 	return false
@@ -186,6 +199,44 @@ func (rc *serviceReferenceChecker) IsReferencedByTransportServer(svcNamespace st
 	return false
 }
 
+type configMapReferenceChecker struct{}
+
+func newConfigMapReferenceChecker() *configMapReferenceChecker {
+	return &configMapReferenceChecker{}
+}
+
+func (rc *configMapReferenceChecker) IsReferencedByIngress(_ string, _ string, _ *networking.Ingress) bool {
+	return false
+}
+
+func (rc *configMapReferenceChecker) IsReferencedByMinion(_ string, _ string, _ *networking.Ingress) bool {
+	return false
+}
+
+func (rc *configMapReferenceChecker) IsReferencedByVirtualServer(configMapNamespace string, configMapName string, vs *conf_v1.VirtualServer) bool {
+	if vs.Namespace != configMapNamespace {
+		return false
+	}
+
+	if vs.Spec.Maintenance != nil && vs.Spec.Maintenance.ConfigMap != nil && vs.Spec.Maintenance.ConfigMap.Name == configMapName {
+		return true
+	}
+
+	if vs.Spec.RedirectMap != nil && vs.Spec.RedirectMap.ConfigMap == configMapName {
+		return true
+	}
+
+	return false
+}
+
+func (rc *configMapReferenceChecker) IsReferencedByVirtualServerRoute(_ string, _ string, _ *conf_v1.VirtualServerRoute) bool {
+	return false
+}
+
+func (rc *configMapReferenceChecker) IsReferencedByTransportServer(_ string, _ string, _ *conf_v1.TransportServer) bool {
+	return false
+}
+
 type policyReferenceChecker struct{}
 
 func newPolicyReferenceChecker() *policyReferenceChecker {
@@ -224,8 +275,8 @@ func (rc *policyReferenceChecker) IsReferencedByVirtualServerRoute(policyNamespa
 	return false
 }
 
-func (rc *policyReferenceChecker) IsReferencedByTransportServer(_ string, _ string, _ *conf_v1.TransportServer) bool {
-	return false
+func (rc *policyReferenceChecker) IsReferencedByTransportServer(policyNamespace string, policyName string, ts *conf_v1.TransportServer) bool {
+	return isPolicyReferenced(ts.Spec.Policies, ts.Namespace, policyNamespace, policyName)
 }
 
 // appProtectResourceReferenceChecker is a reference checker for AppProtect related resources.
@@ -352,6 +352,65 @@ func TestSecretIsReferencedByTransportServer(t *testing.T) {
 			expected:        false,
 			msg:             "tls secret is referenced but in another namespace",
 		},
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					TLS: &conf_v1.TransportServerTLS{
+						Secret:           "test-secret",
+						ClientCertSecret: "test-ca-secret",
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "test-ca-secret",
+			expected:        true,
+			msg:             "client cert secret is referenced",
+		},
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					TLS: &conf_v1.TransportServerTLS{
+						Secret: "test-secret",
+						Certificates: []conf_v1.TransportServerTLSCertificate{
+							{ServerName: "db.example.com", Secret: "test-db-secret"},
+						},
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "test-db-secret",
+			expected:        true,
+			msg:             "certificate secret is referenced",
+		},
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					Upstreams: []conf_v1.TransportServerUpstream{
+						{
+							Name: "upstream1",
+							TLS: &conf_v1.TransportServerUpstreamTLS{
+								Enable:            true,
+								TLSSecret:         "test-client-secret",
+								TrustedCertSecret: "test-trusted-ca-secret",
+							},
+						},
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "test-trusted-ca-secret",
+			expected:        true,
+			msg:             "upstream trusted cert secret is referenced",
+		},
 		{
 			ts: &conf_v1.TransportServer{
 				ObjectMeta: v1.ObjectMeta{
@@ -854,7 +913,7 @@ func TestBackupServiceIsReferencedByTransportServer(t *testing.T) {
 	}
 }
 
-func TestPolicyIsReferencedByIngressesAndTransportServers(t *testing.T) {
+func TestPolicyIsReferencedByIngresses(t *testing.T) {
 	t.Parallel()
 	rc := newPolicyReferenceChecker()
 
@@ -867,10 +926,74 @@ func TestPolicyIsReferencedByIngressesAndTransportServers(t *testing.T) {
 	if result {
 		t.Error("IsReferencedByMinion() returned true but expected false")
 	}
+}
 
-	result = rc.IsReferencedByTransportServer("", "", nil)
-	if result {
-		t.Error("IsReferencedByTransportServer() returned true but expected false")
+func TestPolicyIsReferencedByTransportServer(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		ts              *conf_v1.TransportServer
+		policyNamespace string
+		policyName      string
+		expected        bool
+		msg             string
+	}{
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					Policies: []conf_v1.PolicyReference{
+						{
+							Name: "test-policy",
+						},
+					},
+				},
+			},
+			policyNamespace: "default",
+			policyName:      "test-policy",
+			expected:        true,
+			msg:             "policy is referenced",
+		},
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					Policies: []conf_v1.PolicyReference{
+						{
+							Name:      "test-policy",
+							Namespace: "policies",
+						},
+					},
+				},
+			},
+			policyNamespace: "default",
+			policyName:      "test-policy",
+			expected:        false,
+			msg:             "wrong namespace for policy",
+		},
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+			},
+			policyNamespace: "default",
+			policyName:      "test-policy",
+			expected:        false,
+			msg:             "no policies",
+		},
+	}
+
+	rc := newPolicyReferenceChecker()
+
+	for _, test := range tests {
+		result := rc.IsReferencedByTransportServer(test.policyNamespace, test.policyName, test.ts)
+		if result != test.expected {
+			t.Errorf("IsReferencedByTransportServer() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
 	}
 }
 
@@ -1082,6 +1205,136 @@ func TestPolicyIsReferencedByVirtualServerAndVirtualServerRoute(t *testing.T) {
 	}
 }
 
+func TestConfigMapIsReferencedByIngressesAndTransportServers(t *testing.T) {
+	t.Parallel()
+	rc := newConfigMapReferenceChecker()
+
+	result := rc.IsReferencedByIngress("", "", nil)
+	if result {
+		t.Error("IsReferencedByIngress() returned true but expected false")
+	}
+
+	result = rc.IsReferencedByMinion("", "", nil)
+	if result {
+		t.Error("IsReferencedByMinion() returned true but expected false")
+	}
+
+	result = rc.IsReferencedByVirtualServerRoute("", "", nil)
+	if result {
+		t.Error("IsReferencedByVirtualServerRoute() returned true but expected false")
+	}
+
+	result = rc.IsReferencedByTransportServer("", "", nil)
+	if result {
+		t.Error("IsReferencedByTransportServer() returned true but expected false")
+	}
+}
+
+func TestConfigMapIsReferencedByVirtualServer(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		vs                 *conf_v1.VirtualServer
+		configMapNamespace string
+		configMapName      string
+		expected           bool
+		msg                string
+	}{
+		{
+			vs: &conf_v1.VirtualServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerSpec{
+					Maintenance: &conf_v1.Maintenance{
+						ConfigMap: &conf_v1.MaintenanceConfigMap{
+							Name: "maintenance-page",
+						},
+					},
+				},
+			},
+			configMapNamespace: "default",
+			configMapName:      "maintenance-page",
+			expected:           true,
+			msg:                "config map is referenced by the maintenance page",
+		},
+		{
+			vs: &conf_v1.VirtualServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerSpec{
+					Maintenance: &conf_v1.Maintenance{
+						ConfigMap: &conf_v1.MaintenanceConfigMap{
+							Name: "maintenance-page",
+						},
+					},
+				},
+			},
+			configMapNamespace: "some-namespace",
+			configMapName:      "maintenance-page",
+			expected:           false,
+			msg:                "wrong namespace for the maintenance page",
+		},
+		{
+			vs: &conf_v1.VirtualServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerSpec{
+					Maintenance: &conf_v1.Maintenance{
+						Body: "maintenance",
+					},
+				},
+			},
+			configMapNamespace: "default",
+			configMapName:      "maintenance-page",
+			expected:           false,
+			msg:                "maintenance without a config map",
+		},
+		{
+			vs: &conf_v1.VirtualServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerSpec{
+					RedirectMap: &conf_v1.RedirectMap{
+						ConfigMap: "legacy-redirects",
+					},
+				},
+			},
+			configMapNamespace: "default",
+			configMapName:      "legacy-redirects",
+			expected:           true,
+			msg:                "config map is referenced by the redirect map",
+		},
+		{
+			vs: &conf_v1.VirtualServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerSpec{
+					RedirectMap: &conf_v1.RedirectMap{
+						ConfigMap: "legacy-redirects",
+					},
+				},
+			},
+			configMapNamespace: "default",
+			configMapName:      "other-redirects",
+			expected:           false,
+			msg:                "wrong name for the redirect map",
+		},
+	}
+
+	for _, test := range tests {
+		rc := newConfigMapReferenceChecker()
+
+		result := rc.IsReferencedByVirtualServer(test.configMapNamespace, test.configMapName, test.vs)
+		if result != test.expected {
+			t.Errorf("IsReferencedByVirtualServer() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
 func TestAppProtectResourceIsReferencedByIngresses(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
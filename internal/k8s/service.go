@@ -93,7 +93,7 @@ func hasServiceExternalNameChanges(oldSvc, curSvc *v1.Service) bool {
 	return curSvc.Spec.Type == v1.ServiceTypeExternalName && oldSvc.Spec.ExternalName != curSvc.Spec.ExternalName
 }
 
-// hasServicePortChanges only compares ServicePort.Name and .Port.
+// hasServicePortChanges only compares ServicePort.Name, .Port and .AppProtocol.
 func hasServicePortChanges(oldServicePorts []v1.ServicePort, curServicePorts []v1.ServicePort) bool {
 	if len(oldServicePorts) != len(curServicePorts) {
 		return true
@@ -104,13 +104,22 @@ func hasServicePortChanges(oldServicePorts []v1.ServicePort, curServicePorts []v
 
 	for i := range oldServicePorts {
 		if oldServicePorts[i].Port != curServicePorts[i].Port ||
-			oldServicePorts[i].Name != curServicePorts[i].Name {
+			oldServicePorts[i].Name != curServicePorts[i].Name ||
+			!isEqualAppProtocol(oldServicePorts[i].AppProtocol, curServicePorts[i].AppProtocol) {
 			return true
 		}
 	}
 	return false
 }
 
+// isEqualAppProtocol compares the appProtocol of two ServicePorts, which is nil when not set.
+func isEqualAppProtocol(oldAppProtocol, curAppProtocol *string) bool {
+	if oldAppProtocol == nil || curAppProtocol == nil {
+		return oldAppProtocol == curAppProtocol
+	}
+	return *oldAppProtocol == *curAppProtocol
+}
+
 type portSort []v1.ServicePort
 
 func (a portSort) Len() int {
@@ -241,13 +250,13 @@ func (lbc *LoadBalancerController) syncService(task task) {
 		}
 
 		if lbc.areCustomResourcesEnabled && lbc.reportCustomResourceStatusEnabled() {
-			virtualServers := lbc.configuration.GetResourcesWithFilter(resourceFilter{VirtualServers: true})
+			resources := lbc.configuration.GetResourcesWithFilter(resourceFilter{VirtualServers: true, TransportServers: true})
 
-			nl.Infof(lbc.Logger, "Updating status for %v VirtualServers", len(virtualServers))
+			nl.Infof(lbc.Logger, "Updating status for %v VirtualServers and TransportServers", len(resources))
 
-			err := lbc.statusUpdater.UpdateExternalEndpointsForResources(virtualServers)
+			err := lbc.statusUpdater.UpdateExternalEndpointsForResources(resources)
 			if err != nil {
-				nl.Infof(lbc.Logger, "error updating VirtualServer/VirtualServerRoute status in syncService: %v", err)
+				nl.Infof(lbc.Logger, "error updating VirtualServer/VirtualServerRoute/TransportServer status in syncService: %v", err)
 			}
 		}
 
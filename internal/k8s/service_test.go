@@ -9,6 +9,8 @@ import (
 
 func TestHasServicePortChanges(t *testing.T) {
 	t.Parallel()
+	h2c := "kubernetes.io/h2c"
+	ws := "kubernetes.io/ws"
 	cases := []struct {
 		a      []v1.ServicePort
 		b      []v1.ServicePort
@@ -147,6 +149,52 @@ func TestHasServicePortChanges(t *testing.T) {
 			false,
 			"Some names some ports",
 		},
+		{
+			[]v1.ServicePort{{
+				Name:        "foo",
+				AppProtocol: &h2c,
+			}},
+			[]v1.ServicePort{{
+				Name:        "foo",
+				AppProtocol: &h2c,
+			}},
+			false,
+			"Same AppProtocol",
+		},
+		{
+			[]v1.ServicePort{{
+				Name:        "foo",
+				AppProtocol: &h2c,
+			}},
+			[]v1.ServicePort{{
+				Name:        "foo",
+				AppProtocol: &ws,
+			}},
+			true,
+			"Different AppProtocol",
+		},
+		{
+			[]v1.ServicePort{{
+				Name: "foo",
+			}},
+			[]v1.ServicePort{{
+				Name:        "foo",
+				AppProtocol: &h2c,
+			}},
+			true,
+			"Added AppProtocol",
+		},
+		{
+			[]v1.ServicePort{{
+				Name:        "foo",
+				AppProtocol: &h2c,
+			}},
+			[]v1.ServicePort{{
+				Name: "foo",
+			}},
+			true,
+			"Removed AppProtocol",
+		},
 	}
 
 	for _, c := range cases {
@@ -22,9 +22,9 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
-// statusUpdater reports Ingress, VirtualServer and VirtualServerRoute status information via the kubernetes
-// API. For external information, it primarily reports the IP or host of the LoadBalancer Service exposing the
-// Ingress Controller, or an external IP specified in the ConfigMap.
+// statusUpdater reports Ingress, VirtualServer, VirtualServerRoute and TransportServer status information via the
+// kubernetes API. For external information, it primarily reports the IP or host of the LoadBalancer Service exposing
+// the Ingress Controller, or an external IP specified in the ConfigMap.
 type statusUpdater struct {
 	client                   kubernetes.Interface
 	namespace                string
@@ -32,6 +32,7 @@ type statusUpdater struct {
 	externalStatusAddress    string
 	externalServiceAddresses []string
 	externalServicePorts     string
+	externalServiceTargets   map[int]bool
 	bigIPAddress             string
 	bigIPPorts               string
 	externalEndpoints        []conf_v1.ExternalEndpoint
@@ -41,6 +42,7 @@ type statusUpdater struct {
 	namespacedInformers      map[string]*namespacedInformer
 	confClient               k8s_nginx.Interface
 	hasCorrectIngressClass   func(interface{}) bool
+	transportServerPorts     func(*conf_v1.TransportServer) (int, int, bool)
 	logger                   *slog.Logger
 }
 
@@ -90,6 +92,8 @@ func (su *statusUpdater) UpdateExternalEndpointsForResource(r Resource) error {
 		if failed {
 			return fmt.Errorf("not all Resources updated")
 		}
+	case *TransportServerConfiguration:
+		return su.updateTransportServerExternalEndpoints(impl.TransportServer)
 	}
 
 	return nil
@@ -262,6 +266,51 @@ func getExternalServicePorts(svc *api_v1.Service) string {
 	return fmt.Sprintf("[%v]", strings.Join(ports, ","))
 }
 
+// getExternalServiceTargetPorts returns the target ports of the service, which are the ports of the listeners of the
+// Ingress Controller exposed by the service. Named target ports are skipped, because they are not resolved.
+func getExternalServiceTargetPorts(svc *api_v1.Service) map[int]bool {
+	if svc == nil {
+		return nil
+	}
+
+	targetPorts := make(map[int]bool)
+	for _, port := range svc.Spec.Ports {
+		switch {
+		case port.TargetPort.Type == intstr.String:
+			continue
+		case port.TargetPort.IntVal == 0:
+			targetPorts[int(port.Port)] = true
+		default:
+			targetPorts[int(port.TargetPort.IntVal)] = true
+		}
+	}
+
+	return targetPorts
+}
+
+// getUnexposedListenerPorts returns the ports from port to endPort, inclusive, that are not target ports of the
+// external service, as a list of ranges. For a listener without a port range, endPort is 0.
+func getUnexposedListenerPorts(targetPorts map[int]bool, port int, endPort int) string {
+	var ranges []string
+	for start := port; start <= max(port, endPort); start++ {
+		if targetPorts[start] {
+			continue
+		}
+		end := start
+		for end+1 <= endPort && !targetPorts[end+1] {
+			end++
+		}
+		if end == start {
+			ranges = append(ranges, strconv.Itoa(start))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", start, end))
+		}
+		start = end
+	}
+
+	return strings.Join(ranges, ",")
+}
+
 func getExternalServiceAddress(svc *api_v1.Service) []string {
 	addresses := []string{}
 	if svc == nil {
@@ -324,6 +373,7 @@ func (su *statusUpdater) SaveStatusFromExternalService(svc *api_v1.Service) {
 	su.externalServiceAddresses = ips
 	ports := getExternalServicePorts(svc)
 	su.externalServicePorts = ports
+	su.externalServiceTargets = getExternalServiceTargetPorts(svc)
 	if su.externalStatusAddress != "" {
 		nl.Info(su.logger, "skipping external service address/ports - external-status-address is set and takes precedence")
 		return
@@ -441,7 +491,9 @@ func (su *statusUpdater) UpdateTransportServerStatus(ts *conf_v1.TransportServer
 		return nil
 	}
 
-	if !hasTsStatusChanged(tsLatest.(*conf_v1.TransportServer), state, reason, message) {
+	externalEndpoints := su.generateTransportServerExternalEndpoints(ts)
+
+	if !hasTsStatusChanged(tsLatest.(*conf_v1.TransportServer), state, reason, message, externalEndpoints) {
 		return nil
 	}
 
@@ -449,6 +501,7 @@ func (su *statusUpdater) UpdateTransportServerStatus(ts *conf_v1.TransportServer
 	tsCopy.Status.State = state
 	tsCopy.Status.Reason = reason
 	tsCopy.Status.Message = message
+	tsCopy.Status.ExternalEndpoints = externalEndpoints
 
 	_, err = su.confClient.K8sV1().TransportServers(tsCopy.Namespace).UpdateStatus(context.TODO(), tsCopy, metav1.UpdateOptions{})
 	if err != nil {
@@ -458,7 +511,7 @@ func (su *statusUpdater) UpdateTransportServerStatus(ts *conf_v1.TransportServer
 	return err
 }
 
-func hasTsStatusChanged(ts *conf_v1.TransportServer, state string, reason string, message string) bool {
+func hasTsStatusChanged(ts *conf_v1.TransportServer, state string, reason string, message string, externalEndpoints []conf_v1.ExternalEndpoint) bool {
 	if ts.Status.State != state {
 		return true
 	}
@@ -468,9 +521,113 @@ func hasTsStatusChanged(ts *conf_v1.TransportServer, state string, reason string
 	if ts.Status.Message != message {
 		return true
 	}
+	if !reflect.DeepEqual(ts.Status.ExternalEndpoints, externalEndpoints) {
+		return true
+	}
 	return false
 }
 
+// UpdateTransportServerUpstreamStatus updates the health of the upstreams in the status of a TransportServer.
+func (su *statusUpdater) UpdateTransportServerUpstreamStatus(ts *conf_v1.TransportServer, upstreams []conf_v1.TransportServerUpstreamStatus) error {
+	tsLatest, exists, err := su.getNamespacedInformer(ts.Namespace).transportServerLister.Get(ts)
+	if err != nil {
+		nl.Infof(su.logger, "error getting TransportServer from Store: %v", err)
+		return err
+	}
+	if !exists {
+		nl.Infof(su.logger, "TransportServer doesn't exist in Store")
+		return nil
+	}
+
+	if reflect.DeepEqual(tsLatest.(*conf_v1.TransportServer).Status.Upstreams, upstreams) {
+		return nil
+	}
+
+	tsCopy := tsLatest.(*conf_v1.TransportServer).DeepCopy()
+	tsCopy.Status.Upstreams = upstreams
+
+	_, err = su.confClient.K8sV1().TransportServers(tsCopy.Namespace).UpdateStatus(context.TODO(), tsCopy, metav1.UpdateOptions{})
+	if err != nil {
+		nl.Infof(su.logger, "error setting TransportServer %v/%v status, retrying: %v", tsCopy.Namespace, tsCopy.Name, err)
+		return su.retryUpdateTransportServerStatus(tsCopy)
+	}
+	return err
+}
+
+func (su *statusUpdater) updateTransportServerExternalEndpoints(ts *conf_v1.TransportServer) error {
+	// Get a pristine TransportServer from the Store
+	tsLatest, exists, err := su.getNamespacedInformer(ts.Namespace).transportServerLister.Get(ts)
+	if err != nil {
+		nl.Infof(su.logger, "error getting TransportServer from Store: %v", err)
+		return err
+	}
+	if !exists {
+		nl.Infof(su.logger, "TransportServer doesn't exist in Store")
+		return nil
+	}
+
+	externalEndpoints := su.generateTransportServerExternalEndpoints(ts)
+	if reflect.DeepEqual(tsLatest.(*conf_v1.TransportServer).Status.ExternalEndpoints, externalEndpoints) {
+		return nil
+	}
+
+	tsCopy := tsLatest.(*conf_v1.TransportServer).DeepCopy()
+	tsCopy.Status.ExternalEndpoints = externalEndpoints
+
+	_, err = su.confClient.K8sV1().TransportServers(tsCopy.Namespace).UpdateStatus(context.TODO(), tsCopy, metav1.UpdateOptions{})
+	if err != nil {
+		nl.Infof(su.logger, "error setting TransportServer %v/%v status, retrying: %v", tsCopy.Namespace, tsCopy.Name, err)
+		return su.retryUpdateTransportServerStatus(tsCopy)
+	}
+	return err
+}
+
+// generateTransportServerExternalEndpoints returns the external endpoints of the Ingress Controller with the ports
+// of the listener of the TransportServer.
+func (su *statusUpdater) generateTransportServerExternalEndpoints(ts *conf_v1.TransportServer) []conf_v1.ExternalEndpoint {
+	if su.transportServerPorts == nil {
+		return nil
+	}
+
+	port, endPort, exists := su.transportServerPorts(ts)
+	if !exists {
+		return nil
+	}
+
+	ports := fmt.Sprintf("[%d]", port)
+	if endPort > 0 {
+		ports = fmt.Sprintf("[%d-%d]", port, endPort)
+	}
+
+	var externalEndpoints []conf_v1.ExternalEndpoint
+	for _, lb := range su.status {
+		externalEndpoints = append(externalEndpoints, conf_v1.ExternalEndpoint{IP: lb.IP, Hostname: lb.Hostname, Ports: ports})
+	}
+
+	return externalEndpoints
+}
+
+// generateUnexposedPortsWarning returns a warning with the ports of the listener of the TransportServer that are not
+// target ports of the external service, or an empty string. The ports are checked only when the status comes from
+// the external service.
+func (su *statusUpdater) generateUnexposedPortsWarning(ts *conf_v1.TransportServer) string {
+	if su.transportServerPorts == nil || su.externalServiceTargets == nil || su.externalStatusAddress != "" {
+		return ""
+	}
+
+	port, endPort, exists := su.transportServerPorts(ts)
+	if !exists {
+		return ""
+	}
+
+	unexposed := getUnexposedListenerPorts(su.externalServiceTargets, port, endPort)
+	if unexposed == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("Ports %v of the listener %v are not exposed by the service %v/%v", unexposed, ts.Spec.Listener.Name, su.namespace, su.externalServiceName)
+}
+
 // UpdateVirtualServerStatus updates the status of a VirtualServer.
 func (su *statusUpdater) UpdateVirtualServerStatus(vs *conf_v1.VirtualServer, state string, reason string, message string) error {
 	// Get an up-to-date VirtualServer from the Store
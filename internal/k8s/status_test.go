@@ -201,6 +201,127 @@ func TestUpdateTransportServerStatusMissingTransportServer(t *testing.T) {
 	}
 }
 
+func TestUpdateTransportServerStatusWithExternalEndpoints(t *testing.T) {
+	t.Parallel()
+	ts := &conf_v1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ts-1",
+			Namespace: "default",
+		},
+		Spec: conf_v1.TransportServerSpec{
+			Listener: conf_v1.TransportServerListener{
+				Name:     "dns-udp",
+				Protocol: "UDP",
+			},
+		},
+	}
+
+	fakeClient := fake_v1.NewSimpleClientset(
+		&conf_v1.TransportServerList{
+			Items: []conf_v1.TransportServer{
+				*ts,
+			},
+		})
+
+	tsLister := cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+
+	err := tsLister.Add(ts)
+	if err != nil {
+		t.Errorf("Error adding TransportServer to the transportserver lister: %v", err)
+	}
+	nsi := make(map[string]*namespacedInformer)
+	nsi["default"] = &namespacedInformer{transportServerLister: tsLister}
+	su := statusUpdater{
+		namespacedInformers: nsi,
+		confClient:          fakeClient,
+		keyFunc:             cache.DeletionHandlingMetaNamespaceKeyFunc,
+		status: []networking.IngressLoadBalancerIngress{
+			{IP: "1.2.3.4"},
+			{Hostname: "lb.example.com"},
+		},
+		transportServerPorts: func(*conf_v1.TransportServer) (int, int, bool) {
+			return 5353, 0, true
+		},
+	}
+
+	err = su.UpdateTransportServerStatus(ts, "Valid", "AddedOrUpdated", "message")
+	if err != nil {
+		t.Errorf("error updating transportserver status: %v", err)
+	}
+	updatedTs, _ := fakeClient.K8sV1().TransportServers(ts.Namespace).Get(context.TODO(), ts.Name, meta_v1.GetOptions{})
+
+	expectedStatus := conf_v1.TransportServerStatus{
+		State:   "Valid",
+		Reason:  "AddedOrUpdated",
+		Message: "message",
+		ExternalEndpoints: []conf_v1.ExternalEndpoint{
+			{IP: "1.2.3.4", Ports: "[5353]"},
+			{Hostname: "lb.example.com", Ports: "[5353]"},
+		},
+	}
+
+	if diff := cmp.Diff(expectedStatus, updatedTs.Status); diff != "" {
+		t.Errorf("Unexpected status (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateTransportServerUpstreamStatus(t *testing.T) {
+	t.Parallel()
+	ts := &conf_v1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ts-1",
+			Namespace: "default",
+		},
+		Status: conf_v1.TransportServerStatus{
+			State:   "Valid",
+			Reason:  "AddedOrUpdated",
+			Message: "message",
+		},
+	}
+
+	fakeClient := fake_v1.NewSimpleClientset(
+		&conf_v1.TransportServerList{
+			Items: []conf_v1.TransportServer{
+				*ts,
+			},
+		})
+
+	tsLister := cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+
+	err := tsLister.Add(ts)
+	if err != nil {
+		t.Errorf("Error adding TransportServer to the transportserver lister: %v", err)
+	}
+	nsi := make(map[string]*namespacedInformer)
+	nsi["default"] = &namespacedInformer{transportServerLister: tsLister}
+	su := statusUpdater{
+		namespacedInformers: nsi,
+		confClient:          fakeClient,
+		keyFunc:             cache.DeletionHandlingMetaNamespaceKeyFunc,
+	}
+
+	upstreams := []conf_v1.TransportServerUpstreamStatus{
+		{Name: "dns", Health: conf_v1.UpstreamHealthDegraded, Servers: 3, Up: 2},
+	}
+
+	err = su.UpdateTransportServerUpstreamStatus(ts, upstreams)
+	if err != nil {
+		t.Errorf("error updating transportserver upstream status: %v", err)
+	}
+	updatedTs, _ := fakeClient.K8sV1().TransportServers(ts.Namespace).Get(context.TODO(), ts.Name, meta_v1.GetOptions{})
+
+	expectedStatus := conf_v1.TransportServerStatus{
+		State:     "Valid",
+		Reason:    "AddedOrUpdated",
+		Message:   "message",
+		Upstreams: upstreams,
+	}
+
+	if diff := cmp.Diff(expectedStatus, updatedTs.Status); diff != "" {
+		t.Errorf("Unexpected status (-want +got):\n%s", diff)
+	}
+}
+
 func TestStatusUpdateWithExternalStatusAndExternalService(t *testing.T) {
 	t.Parallel()
 	ing := networking.Ingress{
@@ -821,6 +942,151 @@ func TestGetExternalServicePorts(t *testing.T) {
 	}
 }
 
+func TestGetExternalServiceTargetPorts(t *testing.T) {
+	t.Parallel()
+	svc := v1.Service{
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{
+					Port: int32(80),
+				},
+				{
+					Port:       int32(30000),
+					TargetPort: intstr.FromInt(30001),
+				},
+				{
+					Port:       int32(443),
+					TargetPort: intstr.FromString("https"),
+				},
+			},
+		},
+	}
+
+	expected := map[int]bool{80: true, 30001: true}
+	targetPorts := getExternalServiceTargetPorts(&svc)
+
+	if diff := cmp.Diff(expected, targetPorts); diff != "" {
+		t.Errorf("getExternalServiceTargetPorts() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	if targetPorts := getExternalServiceTargetPorts(nil); targetPorts != nil {
+		t.Errorf("getExternalServiceTargetPorts(nil) returned %v but expected nil", targetPorts)
+	}
+}
+
+func TestGetUnexposedListenerPorts(t *testing.T) {
+	t.Parallel()
+	targetPorts := map[int]bool{5353: true, 30000: true, 30001: true, 30003: true}
+
+	tests := []struct {
+		port     int
+		endPort  int
+		expected string
+		msg      string
+	}{
+		{
+			port:     5353,
+			endPort:  0,
+			expected: "",
+			msg:      "exposed port",
+		},
+		{
+			port:     5354,
+			endPort:  0,
+			expected: "5354",
+			msg:      "unexposed port",
+		},
+		{
+			port:     30000,
+			endPort:  30001,
+			expected: "",
+			msg:      "exposed range",
+		},
+		{
+			port:     30000,
+			endPort:  30006,
+			expected: "30002,30004-30006",
+			msg:      "partly exposed range",
+		},
+		{
+			port:     31000,
+			endPort:  31100,
+			expected: "31000-31100",
+			msg:      "unexposed range",
+		},
+	}
+
+	for _, test := range tests {
+		result := getUnexposedListenerPorts(targetPorts, test.port, test.endPort)
+		if result != test.expected {
+			t.Errorf("getUnexposedListenerPorts() returned %q but expected %q for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGenerateUnexposedPortsWarning(t *testing.T) {
+	t.Parallel()
+	ts := &conf_v1.TransportServer{
+		Spec: conf_v1.TransportServerSpec{
+			Listener: conf_v1.TransportServerListener{
+				Name:     "rtp",
+				Protocol: "UDP",
+			},
+		},
+	}
+	transportServerPorts := func(*conf_v1.TransportServer) (int, int, bool) {
+		return 30000, 30002, true
+	}
+
+	tests := []struct {
+		su       statusUpdater
+		expected string
+		msg      string
+	}{
+		{
+			su: statusUpdater{
+				namespace:              "nginx-ingress",
+				externalServiceName:    "nginx-ingress",
+				externalServiceTargets: map[int]bool{30000: true},
+				transportServerPorts:   transportServerPorts,
+			},
+			expected: "Ports 30001-30002 of the listener rtp are not exposed by the service nginx-ingress/nginx-ingress",
+			msg:      "partly exposed range",
+		},
+		{
+			su: statusUpdater{
+				externalServiceTargets: map[int]bool{30000: true, 30001: true, 30002: true},
+				transportServerPorts:   transportServerPorts,
+			},
+			expected: "",
+			msg:      "exposed range",
+		},
+		{
+			su: statusUpdater{
+				transportServerPorts: transportServerPorts,
+			},
+			expected: "",
+			msg:      "no external service",
+		},
+		{
+			su: statusUpdater{
+				externalStatusAddress:  "203.0.113.5",
+				externalServiceTargets: map[int]bool{30000: true},
+				transportServerPorts:   transportServerPorts,
+			},
+			expected: "",
+			msg:      "external status address",
+		},
+	}
+
+	for _, test := range tests {
+		result := test.su.generateUnexposedPortsWarning(ts)
+		if result != test.expected {
+			t.Errorf("generateUnexposedPortsWarning() returned %q but expected %q for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
 func TestIsRequiredPort(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -66,6 +66,12 @@ func (tq *taskQueue) Enqueue(obj interface{}) {
 	tq.queue.Add(task)
 }
 
+// EnqueueTask adds the task to the queue
+func (tq *taskQueue) EnqueueTask(t task) {
+	nl.Debugf(tq.logger, "Adding an element with a key: %v", t.Key)
+	tq.queue.Add(t)
+}
+
 // Requeue adds the task to the queue again and logs the given error
 func (tq *taskQueue) Requeue(task task, err error) {
 	nl.Errorf(tq.logger, "Requeuing %v, err %v", task.Key, err)
@@ -87,6 +93,15 @@ func (tq *taskQueue) RequeueAfter(t task, err error, after time.Duration) {
 	}(t, after)
 }
 
+// EnqueueAfter adds the task to the queue after the given duration
+func (tq *taskQueue) EnqueueAfter(t task, after time.Duration) {
+	nl.Debugf(tq.logger, "Adding %v to the queue after %s", t.Key, after.String())
+	go func(t task, after time.Duration) {
+		time.Sleep(after)
+		tq.queue.Add(t)
+	}(t, after)
+}
+
 // Worker processes work in the queue through sync.
 func (tq *taskQueue) worker() {
 	for {
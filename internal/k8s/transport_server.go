@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"time"
 
 	"github.com/nginx/kubernetes-ingress/internal/configs"
@@ -131,14 +132,17 @@ func (lbc *LoadBalancerController) updateTransportServerStatusAndEvents(tsConfig
 	eventWarningMessage := ""
 	state := conf_v1.StateValid
 
-	if len(tsConfig.Warnings) > 0 {
-		eventType = api_v1.EventTypeWarning
-		eventTitle = nl.EventReasonAddedOrUpdatedWithWarning
-		eventWarningMessage = fmt.Sprintf("with warning(s): %s", formatWarningMessages(tsConfig.Warnings))
-		state = conf_v1.StateWarning
+	messages := tsConfig.Warnings
+	if configWarnings, ok := warnings[tsConfig.TransportServer]; ok {
+		messages = configWarnings
+	}
+	if lbc.statusUpdater != nil {
+		if portsWarning := lbc.statusUpdater.generateUnexposedPortsWarning(tsConfig.TransportServer); portsWarning != "" {
+			messages = append(slices.Clone(messages), portsWarning)
+		}
 	}
 
-	if messages, ok := warnings[tsConfig.TransportServer]; ok {
+	if len(messages) > 0 {
 		eventType = api_v1.EventTypeWarning
 		eventTitle = nl.EventReasonAddedOrUpdatedWithWarning
 		eventWarningMessage = fmt.Sprintf("with warning(s): %s", formatWarningMessages(messages))
@@ -202,7 +206,7 @@ func (lbc *LoadBalancerController) updateTransportServersStatusFromEvents() erro
 	return nil
 }
 
-func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf_v1.TransportServer, listenerPort int, ipv4 string, ipv6 string) *configs.TransportServerEx {
+func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf_v1.TransportServer, listenerPort int, listenerEndPort int, ipv4 string, ipv6 string) *configs.TransportServerEx {
 	endpoints := make(map[string][]string)
 	externalNameSvcs := make(map[string]bool)
 	podsByIP := make(map[string]string)
@@ -248,8 +252,57 @@ func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf
 		scrtRefs[scrtKey] = scrtRef
 	}
 
+	if transportServer.Spec.TLS != nil && transportServer.Spec.TLS.ClientCertSecret != "" {
+		scrtKey := transportServer.Namespace + "/" + transportServer.Spec.TLS.ClientCertSecret
+
+		scrtRef := lbc.secretStore.GetSecret(scrtKey)
+		if scrtRef.Error != nil {
+			nl.Warnf(lbc.Logger, "Error trying to get the secret %v for TransportServer %v: %v", scrtKey, transportServer.Name, scrtRef.Error)
+		}
+
+		scrtRefs[scrtKey] = scrtRef
+	}
+
+	if transportServer.Spec.TLS != nil {
+		for _, c := range transportServer.Spec.TLS.Certificates {
+			scrtKey := transportServer.Namespace + "/" + c.Secret
+
+			scrtRef := lbc.secretStore.GetSecret(scrtKey)
+			if scrtRef.Error != nil {
+				nl.Warnf(lbc.Logger, "Error trying to get the secret %v for TransportServer %v: %v", scrtKey, transportServer.Name, scrtRef.Error)
+			}
+
+			scrtRefs[scrtKey] = scrtRef
+		}
+	}
+
+	for _, u := range transportServer.Spec.Upstreams {
+		if u.TLS == nil {
+			continue
+		}
+		for _, secretName := range []string{u.TLS.TLSSecret, u.TLS.TrustedCertSecret} {
+			if secretName == "" {
+				continue
+			}
+			scrtKey := transportServer.Namespace + "/" + secretName
+
+			scrtRef := lbc.secretStore.GetSecret(scrtKey)
+			if scrtRef.Error != nil {
+				nl.Warnf(lbc.Logger, "Error trying to get the secret %v for TransportServer %v: %v", scrtKey, transportServer.Name, scrtRef.Error)
+			}
+
+			scrtRefs[scrtKey] = scrtRef
+		}
+	}
+
+	policies, policyErrors := lbc.getPolicies(transportServer.Spec.Policies, transportServer.Namespace)
+	for _, err := range policyErrors {
+		nl.Warnf(lbc.Logger, "Error getting policy for TransportServer %s/%s: %v", transportServer.Namespace, transportServer.Name, err)
+	}
+
 	return &configs.TransportServerEx{
 		ListenerPort:     listenerPort,
+		ListenerEndPort:  listenerEndPort,
 		IPv4:             ipv4,
 		IPv6:             ipv6,
 		TransportServer:  transportServer,
@@ -258,6 +311,7 @@ func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf
 		ExternalNameSvcs: externalNameSvcs,
 		DisableIPV6:      disableIPV6,
 		SecretRefs:       scrtRefs,
+		Policies:         createPolicyMap(policies),
 	}
 }
 
@@ -269,3 +323,54 @@ func (lbc *LoadBalancerController) updateTransportServerMetrics() {
 	metrics := lbc.configuration.GetTransportServerMetrics()
 	lbc.metricsCollector.SetTransportServers(metrics.TotalTLSPassthrough, metrics.TotalTCP, metrics.TotalUDP)
 }
+
+// transportServerUpstreamStatusPeriod is the period of refreshing the health of the upstreams in the status of
+// the TransportServers from the NGINX Plus stream upstream stats.
+const transportServerUpstreamStatusPeriod = 30 * time.Second
+
+// updateTransportServersUpstreamStatus updates the health of the upstreams in the status of the TransportServers
+// that are bound to a listener.
+func (lbc *LoadBalancerController) updateTransportServersUpstreamStatus() {
+	if !lbc.reportCustomResourceStatusEnabled() {
+		return
+	}
+
+	var transportServers []*conf_v1.TransportServer
+	for _, r := range lbc.configuration.GetResourcesWithFilter(resourceFilter{TransportServers: true}) {
+		transportServers = append(transportServers, r.(*TransportServerConfiguration).TransportServer)
+	}
+	if len(transportServers) == 0 {
+		return
+	}
+
+	statuses, err := lbc.configurator.GetTransportServerUpstreamStatuses(transportServers)
+	if err != nil {
+		nl.Debugf(lbc.Logger, "Error getting the health of the TransportServer upstreams: %v", err)
+		return
+	}
+
+	for _, ts := range transportServers {
+		err := lbc.statusUpdater.UpdateTransportServerUpstreamStatus(ts, statuses[getResourceKey(&ts.ObjectMeta)])
+		if err != nil {
+			nl.Errorf(lbc.Logger, "Error when updating the upstream status for TransportServer %v/%v: %v", ts.Namespace, ts.Name, err)
+		}
+	}
+}
+
+// getTransportServerPorts returns the port and the end port of the range of the listener of the TransportServer for
+// its external endpoints. The end port is 0 for a listener without a port range. It returns false if the
+// TransportServer is not bound to a listener.
+func (lbc *LoadBalancerController) getTransportServerPorts(ts *conf_v1.TransportServer) (int, int, bool) {
+	if ts.Spec.Listener.Name == conf_v1.TLSPassthroughListenerName && ts.Spec.Listener.Protocol == conf_v1.TLSPassthroughListenerProtocol {
+		if !lbc.configuration.isTLSPassthroughEnabled {
+			return 0, 0, false
+		}
+		return lbc.tlsPassthroughPort, 0, true
+	}
+
+	listener, exists := lbc.configuration.FindListenerForTransportServer(ts)
+	if !exists {
+		return 0, 0, false
+	}
+	return listener.Port, listener.EndPort, true
+}
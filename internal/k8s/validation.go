@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -70,8 +71,13 @@ const (
 	grpcServicesAnnotation                = "nginx.org/grpc-services"
 	rewritesAnnotation                    = "nginx.org/rewrites"
 	stickyCookieServicesAnnotation        = "nginx.com/sticky-cookie-services"
+	sessionAffinityServicesAnnotation     = "nginx.org/session-affinity-services"
 	pathRegexAnnotation                   = "nginx.org/path-regex"
 	useClusterIPAnnotation                = "nginx.org/use-cluster-ip"
+	otelTraceAnnotation                   = "nginx.org/otel-trace"
+	otelTraceSamplingAnnotation           = "nginx.org/otel-trace-sampling"
+	otelSpanNameAnnotation                = "nginx.org/otel-span-name"
+	otelSpanAttributesAnnotation          = "nginx.org/otel-span-attributes"
 )
 
 const (
@@ -123,7 +129,6 @@ var (
 			validateLBMethodAnnotation,
 		},
 		healthChecksAnnotation: {
-			validatePlusOnlyAnnotation,
 			validateRequiredAnnotation,
 			validateBoolAnnotation,
 		},
@@ -339,12 +344,32 @@ var (
 			validateRequiredAnnotation,
 			validateStickyServiceListAnnotation,
 		},
+		sessionAffinityServicesAnnotation: {
+			validateRequiredAnnotation,
+			validateSessionAffinityServiceListAnnotation,
+		},
 		pathRegexAnnotation: {
 			validatePathRegex,
 		},
 		useClusterIPAnnotation: {
 			validateBoolAnnotation,
 		},
+		otelTraceAnnotation: {
+			validateRequiredAnnotation,
+			validateBoolAnnotation,
+		},
+		otelTraceSamplingAnnotation: {
+			validateRequiredAnnotation,
+			validatePercentageAnnotation,
+		},
+		otelSpanNameAnnotation: {
+			validateRequiredAnnotation,
+			validateOtelValueAnnotation,
+		},
+		otelSpanAttributesAnnotation: {
+			validateRequiredAnnotation,
+			validateOtelSpanAttributesAnnotation,
+		},
 	}
 	annotationNames = sortedAnnotationNames(annotationValidations)
 )
@@ -459,6 +484,67 @@ func validateProxySetHeaderAnnotation(context *annotationValidationContext) fiel
 	return allErrs
 }
 
+var (
+	otelVariableRegex = regexp.MustCompile(`\$(\{[A-Za-z0-9_]+\}|[A-Za-z0-9_]+)`)
+
+	otelVariables = map[string]bool{
+		"host":           true,
+		"remote_addr":    true,
+		"request_method": true,
+		"request_uri":    true,
+		"scheme":         true,
+		"server_name":    true,
+		"server_port":    true,
+		"status":         true,
+		"uri":            true,
+	}
+	otelVariablePrefixes = []string{"arg_", "cookie_", "http_"}
+)
+
+// validateOtelValue validates a value of an OpenTelemetry annotation. Unlike other annotations, the value can
+// reference a limited set of request variables.
+func validateOtelValue(value string) error {
+	for _, match := range otelVariableRegex.FindAllStringSubmatch(value, -1) {
+		name := strings.Trim(match[1], "{}")
+		if !otelVariables[name] && !slices.ContainsFunc(otelVariablePrefixes, func(prefix string) bool {
+			return strings.HasPrefix(name, prefix) && len(name) > len(prefix)
+		}) {
+			return fmt.Errorf("variable $%s is not allowed", name)
+		}
+	}
+	if !validAnnotationValueRegex.MatchString(otelVariableRegex.ReplaceAllString(value, "")) {
+		return errors.New(`must have all '"' escaped, must not end with an unescaped '\' and can only contain '$' as the start of a request variable`)
+	}
+	return nil
+}
+
+func validateOtelValueAnnotation(context *annotationValidationContext) field.ErrorList {
+	if err := validateOtelValue(context.value); err != nil {
+		return field.ErrorList{field.Invalid(context.fieldPath, context.value, err.Error())}
+	}
+	return nil
+}
+
+func validateOtelSpanAttributesAnnotation(context *annotationValidationContext) field.ErrorList {
+	attributes, err := configs.ParseSpanAttributes(strings.Split(context.value, commaDelimiter))
+	if err != nil {
+		return field.ErrorList{field.Invalid(context.fieldPath, context.value, err.Error())}
+	}
+
+	var allErrs field.ErrorList
+	for _, attribute := range attributes {
+		if !spanAttributeNameRegex.MatchString(attribute.Name) {
+			allErrs = append(allErrs, field.Invalid(context.fieldPath, attribute.Name, "a valid span attribute name must consist of alphanumeric characters, '-', '_' or '.'"))
+		}
+		if err := validateOtelValue(attribute.Value); err != nil {
+			allErrs = append(allErrs, field.Invalid(context.fieldPath, attribute.Value, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+var spanAttributeNameRegex = regexp.MustCompile(`^[-._A-Za-z0-9]+$`)
+
 func sortedAnnotationNames(annotationValidations annotationValidationConfig) []string {
 	sortedNames := make([]string, 0)
 	for annotationName := range annotationValidations {
@@ -718,6 +804,13 @@ func validateIntAnnotation(context *annotationValidationContext) field.ErrorList
 	return nil
 }
 
+func validatePercentageAnnotation(context *annotationValidationContext) field.ErrorList {
+	if v, err := configs.ParseInt(context.value); err != nil || v < 1 || v > 100 {
+		return field.ErrorList{field.Invalid(context.fieldPath, context.value, "must be an integer between 1 and 100")}
+	}
+	return nil
+}
+
 func validatePortListAnnotation(context *annotationValidationContext) field.ErrorList {
 	if _, err := configs.ParsePortList(context.value); err != nil {
 		return field.ErrorList{field.Invalid(context.fieldPath, context.value, "must be a comma-separated list of port numbers")}
@@ -750,6 +843,13 @@ func validateStickyServiceListAnnotation(context *annotationValidationContext) f
 	return nil
 }
 
+func validateSessionAffinityServiceListAnnotation(context *annotationValidationContext) field.ErrorList {
+	if _, err := configs.ParseSessionAffinityServiceList(context.value); err != nil {
+		return field.ErrorList{field.Invalid(context.fieldPath, context.value, err.Error())}
+	}
+	return nil
+}
+
 func validateRewriteListAnnotation(context *annotationValidationContext) field.ErrorList {
 	var unknownServices []string
 	rewrites, err := configs.ParseRewriteList(context.value)
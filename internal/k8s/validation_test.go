@@ -589,10 +589,8 @@ func TestValidateNginxIngressAnnotations(t *testing.T) {
 			appProtectEnabled:     false,
 			appProtectDosEnabled:  false,
 			internalRoutesEnabled: false,
-			expectedErrors: []string{
-				"annotations.nginx.com/health-checks: Forbidden: annotation requires NGINX Plus",
-			},
-			msg: "invalid nginx.com/health-checks annotation, nginx plus only",
+			expectedErrors:        nil,
+			msg:                   "valid nginx.com/health-checks annotation, nginx",
 		},
 		{
 			annotations: map[string]string{
@@ -1265,6 +1263,97 @@ func TestValidateNginxIngressAnnotations(t *testing.T) {
 			msg: "invalid nginx.org/proxy-pass-headers annotation, multi-value containing '$' after valid header",
 		},
 
+		{
+			annotations: map[string]string{
+				"nginx.org/otel-trace":           "true",
+				"nginx.org/otel-trace-sampling":  "25",
+				"nginx.org/otel-span-name":       "${request_method} ${uri}",
+				"nginx.org/otel-span-attributes": "team: coffee, http.user_agent: $http_user_agent",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			directiveAutoAdjust:   false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/otel annotations",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/otel-trace-sampling": "0",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			directiveAutoAdjust:   false,
+			expectedErrors: []string{
+				`annotations.nginx.org/otel-trace-sampling: Invalid value: "0": must be an integer between 1 and 100`,
+			},
+			msg: "invalid nginx.org/otel-trace-sampling annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/otel-span-name": "$secret",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			directiveAutoAdjust:   false,
+			expectedErrors: []string{
+				`annotations.nginx.org/otel-span-name: Invalid value: "$secret": variable $secret is not allowed`,
+			},
+			msg: "invalid nginx.org/otel-span-name annotation, variable not allowed",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/otel-span-name": `span "name"`,
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			directiveAutoAdjust:   false,
+			expectedErrors: []string{
+				`annotations.nginx.org/otel-span-name: Invalid value: "span \"name\"": must have all '"' escaped, must not end with an unescaped '\' and can only contain '$' as the start of a request variable`,
+			},
+			msg: "invalid nginx.org/otel-span-name annotation, unescaped double quotes",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/otel-span-attributes": "team",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			directiveAutoAdjust:   false,
+			expectedErrors: []string{
+				`annotations.nginx.org/otel-span-attributes: Invalid value: "team": invalid span attribute format: "team"`,
+			},
+			msg: "invalid nginx.org/otel-span-attributes annotation, missing value",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/otel-span-attributes": "team name: coffee",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			directiveAutoAdjust:   false,
+			expectedErrors: []string{
+				`annotations.nginx.org/otel-span-attributes: Invalid value: "team name": a valid span attribute name must consist of alphanumeric characters, '-', '_' or '.'`,
+			},
+			msg: "invalid nginx.org/otel-span-attributes annotation, invalid name",
+		},
 		{
 			annotations: map[string]string{
 				"nginx.org/proxy-set-headers": "header-1",
@@ -3110,6 +3199,32 @@ func TestValidateNginxIngressAnnotations(t *testing.T) {
 			expectedErrors:        nil,
 			msg:                   "valid nginx.com/sticky-cookie-services annotation, single-value",
 		},
+		{
+			annotations: map[string]string{
+				"nginx.org/session-affinity-services": "serviceName=service-1 srv_id expires=1h path=/service-1",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/session-affinity-services annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/session-affinity-services": "serviceName=service-1 srv-id expires=1h",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/session-affinity-services: Invalid value: "serviceName=service-1 srv-id expires=1h": invalid session affinity cookie name "srv-id": must consist of alphanumeric characters or '_'`,
+			},
+			msg: "invalid nginx.org/session-affinity-services annotation, invalid cookie name",
+		},
 		{
 			annotations: map[string]string{
 				"nginx.com/sticky-cookie-services": "serviceName=service-1 srv_id expires=1h path=/service-1;serviceName=service-2 srv_id expires=2h path=/service-2",
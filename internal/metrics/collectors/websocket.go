@@ -0,0 +1,89 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	nl "github.com/nginx/kubernetes-ingress/internal/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// WebSocketMetricsCollector implements prometheus.Collector interface.
+// It reports the active upgraded connections of the upstreams with WebSocket, which are counted by NGINX.
+type WebSocketMetricsCollector struct {
+	httpClient  *http.Client
+	endpoint    string
+	connections *prometheus.GaugeVec
+	logger      *slog.Logger
+}
+
+// NewWebSocketMetricsCollector creates a new WebSocketMetricsCollector.
+// The endpoint is the URL of the location of NGINX that returns the active upgraded connections.
+func NewWebSocketMetricsCollector(ctx context.Context, httpClient *http.Client, endpoint string, constLabels map[string]string) *WebSocketMetricsCollector {
+	return &WebSocketMetricsCollector{
+		httpClient: httpClient,
+		endpoint:   endpoint,
+		connections: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "upstream_websocket_connections",
+				Namespace:   metricsNamespace,
+				Help:        "Number of active upgraded connections, such as WebSocket connections, to an upstream",
+				ConstLabels: constLabels,
+			},
+			[]string{"upstream"},
+		),
+		logger: nl.LoggerFromContext(ctx),
+	}
+}
+
+// updateConnections sets the number of active upgraded connections of each upstream
+func (wc *WebSocketMetricsCollector) updateConnections() {
+	connections, err := wc.getConnections()
+	if err != nil {
+		nl.Errorf(wc.logger, "unable to collect WebSocket metrics: %v", err)
+		return
+	}
+
+	wc.connections.Reset()
+	for upstream, count := range connections {
+		wc.connections.WithLabelValues(upstream).Set(count)
+	}
+}
+
+func (wc *WebSocketMetricsCollector) getConnections() (map[string]float64, error) {
+	resp, err := wc.httpClient.Get(wc.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %v: %w", wc.endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("expected %v response, got %v", http.StatusOK, resp.StatusCode)
+	}
+
+	var connections map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&connections); err != nil {
+		return nil, fmt.Errorf("failed to decode the response: %w", err)
+	}
+
+	return connections, nil
+}
+
+// Collect implements the prometheus.Collector interface Collect method
+func (wc *WebSocketMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	wc.updateConnections()
+	wc.connections.Collect(ch)
+}
+
+// Describe implements prometheus.Collector interface Describe method
+func (wc *WebSocketMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	wc.connections.Describe(ch)
+}
+
+// Register registers all the metrics of the collector
+func (wc *WebSocketMetricsCollector) Register(registry *prometheus.Registry) error {
+	return registry.Register(wc)
+}
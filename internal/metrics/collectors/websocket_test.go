@@ -0,0 +1,51 @@
+package collectors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWebSocketMetricsCollector(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"vs_default_cafe_chat":3,"vs_default_cafe_events":1}`))
+	}))
+	defer server.Close()
+
+	wc := NewWebSocketMetricsCollector(context.Background(), server.Client(), server.URL, nil)
+	wc.updateConnections()
+
+	if got := testutil.ToFloat64(wc.connections.WithLabelValues("vs_default_cafe_chat")); got != 3 {
+		t.Errorf("connections of vs_default_cafe_chat = %v, want 3", got)
+	}
+	if got := testutil.ToFloat64(wc.connections.WithLabelValues("vs_default_cafe_events")); got != 1 {
+		t.Errorf("connections of vs_default_cafe_events = %v, want 1", got)
+	}
+}
+
+func TestWebSocketMetricsCollector_KeepsMetricsOnError(t *testing.T) {
+	t.Parallel()
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"vs_default_cafe_chat":2}`))
+	}))
+	defer server.Close()
+
+	wc := NewWebSocketMetricsCollector(context.Background(), server.Client(), server.URL, nil)
+	wc.updateConnections()
+	fail = true
+	wc.updateConnections()
+
+	if got := testutil.ToFloat64(wc.connections.WithLabelValues("vs_default_cafe_chat")); got != 2 {
+		t.Errorf("connections of vs_default_cafe_chat = %v, want 2", got)
+	}
+}
@@ -137,8 +137,8 @@ func (*FakeManager) SetPlusClients(_ *client.NginxClient, _ *http.Client) {
 }
 
 // UpdateServersInPlus provides a fake implementation of UpdateServersInPlus.
-func (fm *FakeManager) UpdateServersInPlus(upstream string, servers []string, _ ServerConfig) error {
-	nl.Debugf(fm.logger, "Updating servers of %v: %v", upstream, servers)
+func (fm *FakeManager) UpdateServersInPlus(upstream string, servers []string, drainingServers []string, backupServers []string, _ ServerConfig) error {
+	nl.Debugf(fm.logger, "Updating servers of %v: %v, draining: %v, backup: %v", upstream, servers, drainingServers, backupServers)
 	return nil
 }
 
@@ -148,6 +148,12 @@ func (fm *FakeManager) UpdateStreamServersInPlus(upstream string, servers []stri
 	return nil
 }
 
+// GetStreamUpstreamsInPlus provides a fake implementation of GetStreamUpstreamsInPlus.
+func (fm *FakeManager) GetStreamUpstreamsInPlus() (*client.StreamUpstreams, error) {
+	nl.Debugf(fm.logger, "Getting stream upstreams")
+	return &client.StreamUpstreams{}, nil
+}
+
 // AppProtectPluginStart is a fake implementation AppProtectPluginStart
 func (fm *FakeManager) AppProtectPluginStart(_ chan error, _ string) {
 	nl.Debugf(fm.logger, "Starting FakeAppProtectPlugin")
@@ -193,6 +199,11 @@ func (fm *FakeManager) UpsertSplitClientsKeyVal(_ string, _ string, _ string) {
 	nl.Debugf(fm.logger, "Creating split clients key")
 }
 
+// ReplaceKeyValPairs is a fake implementation of ReplaceKeyValPairs
+func (fm *FakeManager) ReplaceKeyValPairs(_ string, _ map[string]string) {
+	nl.Debugf(fm.logger, "Replacing key value pairs")
+}
+
 // DeleteKeyValStateFiles is a fake implementation of DeleteKeyValStateFiles
 func (fm *FakeManager) DeleteKeyValStateFiles(_ string) {
 	nl.Debugf(fm.logger, "Deleting keyval state files")
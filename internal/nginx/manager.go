@@ -88,8 +88,9 @@ type Manager interface {
 	Quit()
 	UpdateConfigVersionFile()
 	SetPlusClients(plusClient *client.NginxClient, plusConfigVersionCheckClient *http.Client)
-	UpdateServersInPlus(upstream string, servers []string, config ServerConfig) error
+	UpdateServersInPlus(upstream string, servers []string, drainingServers []string, backupServers []string, config ServerConfig) error
 	UpdateStreamServersInPlus(upstream string, servers []string) error
+	GetStreamUpstreamsInPlus() (*client.StreamUpstreams, error)
 	AppProtectPluginStart(appDone chan error, logLevel string)
 	AppProtectPluginQuit()
 	AppProtectDosAgentStart(apdaDone chan error, debug bool, maxDaemon int, maxWorkers int, memory int)
@@ -99,6 +100,7 @@ type Manager interface {
 	AgentVersion() string
 	GetSecretsDir() string
 	UpsertSplitClientsKeyVal(zoneName string, key string, value string)
+	ReplaceKeyValPairs(zoneName string, pairs map[string]string)
 	DeleteKeyValStateFiles(virtualServerName string)
 }
 
@@ -407,7 +409,8 @@ func (lm *LocalManager) SetPlusClients(plusClient *client.NginxClient, plusConfi
 }
 
 // UpdateServersInPlus updates NGINX Plus servers of the given upstream.
-func (lm *LocalManager) UpdateServersInPlus(upstream string, servers []string, config ServerConfig) error {
+// The drainingServers are kept in the upstream in the draining mode, the backupServers are added as backup servers.
+func (lm *LocalManager) UpdateServersInPlus(upstream string, servers []string, drainingServers []string, backupServers []string, config ServerConfig) error {
 	err := verifyConfigVersion(lm.plusConfigVersionCheckClient, lm.configVersion, lm.verifyClient.timeout)
 	if err != nil {
 		return fmt.Errorf("error verifying config version: %w", err)
@@ -415,6 +418,7 @@ func (lm *LocalManager) UpdateServersInPlus(upstream string, servers []string, c
 
 	nl.Debugf(lm.logger, "API has the correct config version: %v.", lm.configVersion)
 
+	backup := true
 	var upsServers []client.UpstreamServer
 	for _, s := range servers {
 		upsServers = append(upsServers, client.UpstreamServer{
@@ -425,6 +429,26 @@ func (lm *LocalManager) UpdateServersInPlus(upstream string, servers []string, c
 			SlowStart:   config.SlowStart,
 		})
 	}
+	for _, s := range drainingServers {
+		upsServers = append(upsServers, client.UpstreamServer{
+			Server:      s,
+			MaxFails:    &config.MaxFails,
+			MaxConns:    &config.MaxConns,
+			FailTimeout: config.FailTimeout,
+			SlowStart:   config.SlowStart,
+			Drain:       true,
+		})
+	}
+	for _, s := range backupServers {
+		upsServers = append(upsServers, client.UpstreamServer{
+			Server:      s,
+			MaxFails:    &config.MaxFails,
+			MaxConns:    &config.MaxConns,
+			FailTimeout: config.FailTimeout,
+			SlowStart:   config.SlowStart,
+			Backup:      &backup,
+		})
+	}
 
 	added, removed, updated, err := lm.plusClient.UpdateHTTPServers(context.Background(), upstream, upsServers)
 	if err != nil {
@@ -492,6 +516,16 @@ func (lm *LocalManager) UpdateStreamServersInPlus(upstream string, servers []str
 	return nil
 }
 
+// GetStreamUpstreamsInPlus returns the stream upstreams of NGINX Plus with the states of their servers.
+func (lm *LocalManager) GetStreamUpstreamsInPlus() (*client.StreamUpstreams, error) {
+	streamUpstreams, err := lm.plusClient.GetStreamUpstreams(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting stream upstreams: %w", err)
+	}
+
+	return streamUpstreams, nil
+}
+
 // verifyConfigVersion is used to check if the worker process that the API client is connected
 // to is using the latest version of nginx config. This way we avoid making changes on
 // a worker processes that is being shut down.
@@ -735,6 +769,51 @@ func (lm *LocalManager) tryModifyKeyValPair(zoneName, key, value string) {
 	}
 }
 
+// ReplaceKeyValPairs replaces the key-value pairs of a keyval zone with the given pairs.
+// Only the pairs that differ from the pairs in the zone are added, modified or deleted.
+func (lm *LocalManager) ReplaceKeyValPairs(zoneName string, pairs map[string]string) {
+	ctx := context.Background()
+
+	currentPairs, err := lm.plusClient.GetKeyValPairs(ctx, zoneName)
+	if err != nil {
+		nl.Warnf(lm.logger, "Failed to get key value pairs of zone %v: %v", zoneName, err)
+		currentPairs = nil
+	}
+
+	var added, modified, deleted int
+
+	for key := range currentPairs {
+		if _, exists := pairs[key]; exists {
+			continue
+		}
+		if err := lm.plusClient.DeleteKeyValuePair(ctx, zoneName, key); err != nil {
+			nl.Warnf(lm.logger, "Failed to delete key value pair for key %v in zone %v: %v", key, zoneName, err)
+			continue
+		}
+		deleted++
+	}
+
+	for key, value := range pairs {
+		currentValue, exists := currentPairs[key]
+		switch {
+		case !exists:
+			if err := lm.plusClient.AddKeyValPair(ctx, zoneName, key, value); err != nil {
+				nl.Warnf(lm.logger, "Failed to add key value pair for key %v in zone %v: %v", key, zoneName, err)
+				continue
+			}
+			added++
+		case currentValue != value:
+			if err := lm.plusClient.ModifyKeyValPair(ctx, zoneName, key, value); err != nil {
+				nl.Warnf(lm.logger, "Failed to modify key value pair for key %v in zone %v: %v", key, zoneName, err)
+				continue
+			}
+			modified++
+		}
+	}
+
+	nl.Infof(lm.logger, "Updated key value pairs of zone %v: %d added, %d modified, %d deleted", zoneName, added, modified, deleted)
+}
+
 // DeleteKeyValStateFiles deletes the state files in the /etc/nginx/state_files folder for the given virtual server.
 func (lm *LocalManager) DeleteKeyValStateFiles(virtualServerName string) {
 	files, err := os.ReadDir(lm.stateFilesPath)
@@ -12,6 +12,12 @@ const (
 	StateValid = "Valid"
 	// StateInvalid is used when the resource failed validation or NGINX failed to reload the corresponding config.
 	StateInvalid = "Invalid"
+	// UpstreamHealthHealthy is used when all servers of a TransportServer upstream are up.
+	UpstreamHealthHealthy = "Healthy"
+	// UpstreamHealthDegraded is used when some, but not all, servers of a TransportServer upstream are up.
+	UpstreamHealthDegraded = "Degraded"
+	// UpstreamHealthUnhealthy is used when no servers of a TransportServer upstream are up.
+	UpstreamHealthUnhealthy = "Unhealthy"
 	// HTTPProtocol defines a constant for the HTTP protocol in GlobalConfinguration.
 	HTTPProtocol = "HTTP"
 	// TLSPassthroughListenerName is the name of a built-in TLS Passthrough listener.
@@ -69,6 +75,65 @@ type VirtualServerSpec struct {
 	ExternalDNS ExternalDNS `json:"externalDNS"`
 	// InternalRoute allows for the configuration of internal routing.
 	InternalRoute bool `json:"internalRoute"`
+	// The maintenance mode configuration. When enabled, all routes of the VirtualServer, including the routes of referenced VirtualServerRoutes, respond with the configured maintenance response.
+	Maintenance *Maintenance `json:"maintenance"`
+	// Bulk redirects loaded from a ConfigMap. The redirects are applied before the routes are matched.
+	RedirectMap *RedirectMap `json:"redirectMap"`
+	// The request ID configuration. Overrides the request-id ConfigMap keys.
+	RequestID *RequestID `json:"requestID"`
+	// The access log configuration. Overrides the access-log ConfigMap key for all routes of the VirtualServer.
+	AccessLog *AccessLog `json:"accessLog"`
+	// The OpenTelemetry tracing configuration for all routes of the VirtualServer. Requires the otel-exporter-endpoint ConfigMap key.
+	Tracing *Tracing `json:"tracing"`
+}
+
+// Maintenance defines the maintenance mode of a VirtualServer.
+type Maintenance struct {
+	// Enables the maintenance mode. With NGINX Plus, toggling this field does not require an NGINX reload. The default is false.
+	Enable bool `json:"enable"`
+	// The status code of the maintenance response. The allowed values are: 2XX, 4XX or 5XX. The default is 503.
+	Code int `json:"code"`
+	// The MIME type of the maintenance response. The default is text/html.
+	Type string `json:"type"`
+	// The body of the maintenance response. Supports NGINX variables*. Variables must be enclosed in curly brackets. Cannot be used together with configMap.
+	Body string `json:"body"`
+	// A reference to a ConfigMap key in the namespace of the VirtualServer holding the body of the maintenance response. Cannot be used together with body.
+	ConfigMap *MaintenanceConfigMap `json:"configMap"`
+	// A list of client IP addresses or CIDR ranges that bypass the maintenance mode.
+	Allow []string `json:"allow"`
+	// A request header that bypasses the maintenance mode when it is present with the given value.
+	BypassHeader *Header `json:"bypassHeader"`
+}
+
+// MaintenanceConfigMap references a ConfigMap key holding a maintenance page.
+type MaintenanceConfigMap struct {
+	// The name of the ConfigMap.
+	Name string `json:"name"`
+	// The key in the data of the ConfigMap. The default is maintenance.html.
+	Key string `json:"key"`
+}
+
+// RedirectMap references a ConfigMap key holding bulk redirects.
+// Each line of the value of the key defines a redirect in the format source target [code], for example, /old-page /new-page 302.
+// The source is matched against the exact normalized URI of a request. Empty lines and lines starting with # are ignored.
+type RedirectMap struct {
+	// The name of a ConfigMap in the namespace of the VirtualServer.
+	ConfigMap string `json:"configMap"`
+	// The key in the data of the ConfigMap. The default is redirects.
+	Key string `json:"key"`
+	// The status code of the redirects that don't specify a code. The allowed values are: 301, 302, 307 or 308. The default is 301.
+	Code int `json:"code"`
+}
+
+// RequestID defines how the request ID is handled for the routes of a VirtualServer.
+// The request ID is passed to the upstreams and returned to the clients in the configured header.
+type RequestID struct {
+	// Enables the request ID. The default is the value of the request-id ConfigMap key.
+	Enable *bool `json:"enable"`
+	// The name of the request header that carries the request ID. The default is the value of the request-id-header ConfigMap key.
+	Header string `json:"header"`
+	// Preserves the request ID received from a client in the header. When the header is missing or empty, a new request ID is generated. The default is the value of the request-id-trust-incoming ConfigMap key.
+	TrustIncoming *bool `json:"trustIncoming"`
 }
 
 // VirtualServerListener references a custom http and/or https listener defined in GlobalConfiguration.
@@ -159,7 +224,7 @@ type Upstream struct {
 	ClientMaxBodySize string `json:"client-max-body-size"`
 	// The TLS configuration for the Upstream.
 	TLS UpstreamTLS `json:"tls"`
-	// The health check configuration for the Upstream. Note: this feature is supported only in NGINX Plus.
+	// The health check configuration for the Upstream. With NGINX, the Ingress Controller performs the health checks and removes unhealthy endpoints from the upstream.
 	HealthCheck *HealthCheck `json:"healthCheck"`
 	// The slow start allows an upstream server to gradually recover its weight from 0 to its nominal value after it has been recovered or became available or when the server becomes available after a period of time it was considered unavailable. By default, the slow start is disabled. Note: The parameter cannot be used along with the random, hash or ip_hash load balancing methods and will be ignored.
 	SlowStart string `json:"slow-start"`
@@ -167,12 +232,18 @@ type Upstream struct {
 	Queue *UpstreamQueue `json:"queue"`
 	// The SessionCookie field configures session persistence which allows requests from the same client to be passed to the same upstream server. The information about the designated upstream server is passed in a session cookie generated by NGINX Plus.
 	SessionCookie *SessionCookie `json:"sessionCookie"`
+	// The SessionAffinity field configures session persistence for both NGINX and NGINX Plus. With NGINX Plus, it is the same as the SessionCookie field. With NGINX, a cookie with an opaque identifier is added to the first response, and requests are routed with the consistent hash load balancing method on the value of the cookie, which overrides the lb-method.
+	SessionAffinity *SessionCookie `json:"sessionAffinity"`
 	// Enables using the Cluster IP and port of the service instead of the default behavior of using the IP and port of the pods. When this field is enabled, the fields that configure NGINX behavior related to multiple upstream servers (like lb-method and next-upstream) will have no effect, as NGINX Ingress Controller will configure NGINX with only one upstream server that will match the service Cluster IP.
 	UseClusterIP bool `json:"use-cluster-ip"`
 	// Allows proxying requests with NTLM Authentication. In order for NTLM authentication to work, it is necessary to enable keepalive connections to upstream servers using the keepalive field. Note: this feature is supported only in NGINX Plus.
 	NTLM bool `json:"ntlm"`
 	// The type of the upstream. Supported values are http and grpc. The default is http. For gRPC, it is necessary to enable HTTP/2 in the ConfigMap and configure TLS termination in the VirtualServer.
 	Type string `json:"type"`
+	// The gRPC-Web configuration for the Upstream. Requires the grpc type.
+	GRPCWeb *GRPCWeb `json:"grpcWeb"`
+	// The WebSocket configuration for the Upstream. Requires the http type.
+	WebSocket *WebSocket `json:"websocket"`
 	// The name of the backup service of type ExternalName. This will be used when the primary servers are unavailable. Note: The parameter cannot be used along with the random, hash or ip_hash load balancing methods.
 	Backup string `json:"backup"`
 	// The port of the backup service. The backup port is required if the backup service name is provided. The port must fall into the range 1..65535.
@@ -261,10 +332,38 @@ type SessionCookie struct {
 	SameSite string `json:"samesite"`
 }
 
+// GRPCWeb defines the translation of gRPC-Web requests from browser clients to gRPC.
+type GRPCWeb struct {
+	// Enables the translation of gRPC-Web requests and responses, including the CORS preflight requests. The application/grpc-web, application/grpc-web+proto, application/grpc-web-text and application/grpc-web-text+proto formats are supported. Other requests are passed to the upstream unchanged. The default is false.
+	Enable bool `json:"enable"`
+	// The origin that is allowed to make cross-origin requests, for example, https://app.example.com. The default is *, which allows any origin.
+	AllowOrigin string `json:"allowOrigin"`
+}
+
+// WebSocket defines the handling of WebSocket and other upgraded connections to an Upstream.
+type WebSocket struct {
+	// Enables the dedicated handling of the requests that upgrade the connection, for example, to the WebSocket protocol. The Upgrade and Connection headers are passed to the upstream servers, the idleTimeout applies to the upgraded connections only, and the active upgraded connections are reported in the upstream_websocket_connections metric. The default is false.
+	Enable bool `json:"enable"`
+	// The time after which an upgraded connection is closed if no data is transmitted in either direction. Applies instead of the read-timeout and send-timeout of the Upstream. The default is the read-timeout and send-timeout of the Upstream.
+	IdleTimeout string `json:"idleTimeout"`
+}
+
+// Path types of a Route.
+const (
+	// PathTypeExact matches the URI of a request exactly.
+	PathTypeExact = "Exact"
+	// PathTypePrefix matches the URI of a request by prefix.
+	PathTypePrefix = "Prefix"
+	// PathTypeRegularExpression matches the URI of a request against a case sensitive regular expression.
+	PathTypeRegularExpression = "RegularExpression"
+)
+
 // Route defines a route.
 type Route struct {
 	// The path of the route. NGINX will match it against the URI of a request. Possible values are: a prefix ( / , /path ), an exact match ( =/exact/match ), a case insensitive regular expression ( ~*^/Bar.*\.jpg ) or a case sensitive regular expression ( ~^/foo.*\.jpg ). In the case of a prefix (must start with / ) or an exact match (must start with = ), the path must not include any whitespace characters, { , } or ;. In the case of the regex matches, all double quotes " must be escaped and the match can’t end in an unescaped backslash \. The path must be unique among the paths of all routes of the VirtualServer. Check the location directive for more information.
 	Path string `json:"path"`
+	// The type of the path. Possible values are: Exact, Prefix or RegularExpression. When set, the path must not include the = , ~ or ~* modifiers. For RegularExpression, the match is case sensitive. If not set, the type is derived from the path.
+	PathType string `json:"pathType"`
 	// A list of policies. The policies override the policies of the same type defined in the spec of the VirtualServer.
 	Policies []PolicyReference `json:"policies"`
 	// The name of a VirtualServerRoute resource that defines this route. If the VirtualServerRoute belongs to a different namespace than the VirtualServer, you need to include the namespace. For example, tea-namespace/tea.
@@ -281,6 +380,54 @@ type Route struct {
 	LocationSnippets string `json:"location-snippets"`
 	// A reference to a DosProtectedResource, setting this enables DOS protection of the VirtualServer route.
 	Dos string `json:"dos"`
+	// The access log configuration. Overrides the access log of the VirtualServer.
+	AccessLog *AccessLog `json:"accessLog"`
+	// The OpenTelemetry tracing configuration. Overrides the tracing of the VirtualServer.
+	Tracing *Tracing `json:"tracing"`
+}
+
+// AccessLog defines the access log of a VirtualServer, a route or a TransportServer.
+type AccessLog struct {
+	// Enables the access log. Setting it to false turns the access log off. The default is true.
+	Enable *bool `json:"enable"`
+	// The name of a log format. The default is main for a VirtualServer and stream-main for a TransportServer. Additional log formats can be defined with http-snippets or stream-snippets.
+	Format string `json:"format"`
+	// The log destination. Accepted values are syslog:server=<ip-address | localhost | fqdn>:<port> or an absolute path to a file, for example, /dev/stderr. The default is the destination of the access-log ConfigMap key for a VirtualServer and /dev/stdout for a TransportServer.
+	Destination string `json:"destination"`
+	// The percentage of requests (or connections for a TransportServer) that are logged. The allowed values are 1 to 100. The default is 100.
+	Sampling int `json:"sampling"`
+	// Logs only the requests (or connections for a TransportServer) with a matching status code. The allowed values are: 4xx, 5xx or errors, which matches both 4xx and 5xx. By default, all requests are logged.
+	Condition string `json:"condition"`
+}
+
+// Conditions of an AccessLog.
+const (
+	// AccessLogCondition4xx logs only the requests with a 4xx status code.
+	AccessLogCondition4xx = "4xx"
+	// AccessLogCondition5xx logs only the requests with a 5xx status code.
+	AccessLogCondition5xx = "5xx"
+	// AccessLogConditionErrors logs only the requests with a 4xx or a 5xx status code.
+	AccessLogConditionErrors = "errors"
+)
+
+// Tracing defines the OpenTelemetry tracing of a VirtualServer or a route.
+type Tracing struct {
+	// Enables tracing. Setting it to false turns tracing off. The default is true.
+	Enable *bool `json:"enable"`
+	// The percentage of requests that are traced. The allowed values are 1 to 100. The default is 100.
+	Sampling int `json:"sampling"`
+	// The name of the span. Supports NGINX variables*. Variables must be enclosed in curly brackets. The default is the name of the location.
+	SpanName string `json:"spanName"`
+	// A list of attributes added to the span.
+	SpanAttributes []SpanAttribute `json:"spanAttributes"`
+}
+
+// SpanAttribute defines an attribute of a span.
+type SpanAttribute struct {
+	// The name of the attribute.
+	Name string `json:"name"`
+	// The value of the attribute. Supports NGINX variables*. Variables must be enclosed in curly brackets.
+	Value string `json:"value"`
 }
 
 // Action defines an action.
@@ -372,6 +519,10 @@ type Condition struct {
 	Argument string `json:"argument"`
 	// The name of an NGINX variable. Must start with $.
 	Variable string `json:"variable"`
+	// The HTTP method of a request, for example, GET or POST. Use ! to negate the match, for example, !GET. Cannot be used together with value.
+	Method string `json:"method"`
+	// The host of a request, for example, api.example.com. Useful with wildcard hosts. Use ! to negate the match. Cannot be used together with value.
+	Host string `json:"host"`
 	// The value to match the condition against.
 	Value string `json:"value"`
 }
@@ -565,12 +716,16 @@ type Listener struct {
 	Protocol string `json:"protocol"`
 	// The port on which the listener will accept connections.
 	Port int `json:"port"`
+	// The last port of the range of ports, starting with port, on which the listener will accept connections. Supported only for the TCP and UDP protocols. By default, the listener accepts connections only on port.
+	EndPort int `json:"endPort"`
 	// Specifies the IPv4 address to listen on.
 	IPv4 string `json:"ipv4"`
 	// ipv6 addresse that NGINX will listen on.
 	IPv6 string `json:"ipv6"`
 	// Whether the listener will be listening for SSL connections
 	Ssl bool `json:"ssl"`
+	// Whether the listener will also accept HTTP/3 connections over QUIC on the same UDP port. Requires the HTTP protocol and ssl. The UDP port cannot be used by another listener.
+	QUIC bool `json:"quic"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -592,6 +747,10 @@ type GlobalConfigurationList struct {
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`,description="Current state of the TransportServer. If the resource has a valid status, it means it has been validated and accepted by the Ingress Controller."
 // +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.reason`
+// +kubebuilder:printcolumn:name="IP",type=string,JSONPath=`.status.externalEndpoints[*].ip`
+// +kubebuilder:printcolumn:name="ExternalHostname",type=string,priority=1,JSONPath=`.status.externalEndpoints[*].hostname`
+// +kubebuilder:printcolumn:name="Ports",type=string,JSONPath=`.status.externalEndpoints[*].ports`
+// +kubebuilder:printcolumn:name="Health",type=string,JSONPath=`.status.upstreams[*].health`,description="Health of the upstreams of the TransportServer. Reported only for NGINX Plus."
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // TransportServer defines the TransportServer resource.
@@ -625,11 +784,45 @@ type TransportServerSpec struct {
 	SessionParameters *SessionParameters `json:"sessionParameters"`
 	// The action to perform for a request.
 	Action *TransportServerAction `json:"action"`
+	// The access log configuration.
+	AccessLog *AccessLog `json:"accessLog"`
+	// A list of policies. Only the accessControl and connectionLimit policies are supported.
+	Policies []PolicyReference `json:"policies"`
+	// Routes TLS connections to upstreams by the server name and the ALPN protocol requested by the client. The connections that do not match any route are handled by the action. Requires TLS termination.
+	Routes []TransportServerRoute `json:"routes"`
+}
+
+// TransportServerRoute routes the TLS connections of a TransportServer to an upstream.
+type TransportServerRoute struct {
+	// The server name requested by the client through SNI. Must be a valid subdomain as defined in RFC 1123. Cannot be used along with host.
+	ServerName string `json:"serverName"`
+	// The protocol requested by the client through ALPN, for example h2 or http/1.1.
+	ALPN string `json:"alpn"`
+	// Passes connections to an upstream. The upstream with that name must be defined in the resource.
+	Pass string `json:"pass"`
 }
 
 // TransportServerTLS defines TransportServerTLS configuration for a TransportServer.
 type TransportServerTLS struct {
 	Secret string `json:"secret"`
+	// The name of the Kubernetes secret that stores the CA certificate for the verification of client certificates. It must be in the same namespace as the TransportServer resource. The secret must be of the type nginx.org/ca, and the certificate must be stored in the secret under the key ca.crt, otherwise the secret will be rejected as invalid.
+	ClientCertSecret string `json:"clientCertSecret"`
+	// The file name of the Certificate Revocation List. NGINX Ingress Controller will look for this file in /etc/nginx/secrets
+	CrlFileName string `json:"crlFileName"`
+	// Verification for the client. Possible values are "on", "off", "optional", "optional_no_ca". The default is "on".
+	VerifyClient string `json:"verifyClient"`
+	// Sets the verification depth in the client certificates chain. The default is 1.
+	VerifyDepth *int `json:"verifyDepth"`
+	// Additional certificates chosen by the server name requested by the client through SNI. The certificate of the secret is used for the other server names. Cannot be used along with host.
+	Certificates []TransportServerTLSCertificate `json:"certificates"`
+}
+
+// TransportServerTLSCertificate defines a TLS certificate chosen by the server name requested by the client.
+type TransportServerTLSCertificate struct {
+	// The server name. Must be a valid subdomain as defined in RFC 1123.
+	ServerName string `json:"serverName"`
+	// The name of the Kubernetes secret that stores the TLS certificate and key. It must be in the same namespace as the TransportServer resource. The secret must be of the type kubernetes.io/tls.
+	Secret string `json:"secret"`
 }
 
 // TransportServerListener defines a listener for a TransportServer.
@@ -658,10 +851,48 @@ type TransportServerUpstream struct {
 	HealthCheck *TransportServerHealthCheck `json:"healthCheck"`
 	// The method used to load balance the upstream servers. By default, connections are distributed between the servers using a weighted round-robin balancing method.
 	LoadBalancingMethod string `json:"loadBalancingMethod"`
+	// The structured load balancing configuration of the upstream. Cannot be used along with loadBalancingMethod.
+	LoadBalancing *TransportServerLoadBalancing `json:"loadBalancing"`
 	// The name of the backup service of type ExternalName. This will be used when the primary servers are unavailable. Note: The parameter cannot be used along with the random, hash or ip_hash load balancing methods.
 	Backup string `json:"backup"`
 	// The port of the backup service. The backup port is required if the backup service name is provided. The port must fall into the range 1..65535.
 	BackupPort *uint16 `json:"backupPort"`
+	// The TLS configuration for the connections to the upstream servers. Not supported for UDP and TLS Passthrough listeners.
+	TLS *TransportServerUpstreamTLS `json:"tls"`
+	// Enables the PROXY protocol for the connections to the upstream servers. Not supported for UDP listeners. The default is false.
+	ProxyProtocol bool `json:"proxyProtocol"`
+}
+
+// TransportServerLoadBalancing defines the load balancing method of an upstream of a TransportServer.
+type TransportServerLoadBalancing struct {
+	// The load balancing method. Allowed values are round_robin, least_conn, hash, random, random_two and least_time. The least_time method is supported only in NGINX Plus.
+	Method string `json:"method"`
+	// The key for the hash method. Can contain text and the ${remote_addr}, ${binary_remote_addr}, ${remote_port} and ${server_port} variables. The default is ${remote_addr}.
+	Key string `json:"key"`
+	// Enables ketama consistent hashing for the hash method, so that only a few keys are remapped to other servers when the upstream servers change. The default is false.
+	Consistent bool `json:"consistent"`
+	// The time the least_time and random_two methods use to choose a server. Allowed values are connect, first_byte and last_byte. The default is connect for least_time, random_two uses the least number of connections if not set. Supported only in NGINX Plus.
+	LeastTime string `json:"leastTime"`
+	// Takes incomplete connections into account for the least_time method. The default is false. Supported only in NGINX Plus.
+	Inflight bool `json:"inflight"`
+}
+
+// TransportServerUpstreamTLS defines the TLS configuration for the connections to the upstream servers of a TransportServer.
+type TransportServerUpstreamTLS struct {
+	// Enables TLS for the connections to the upstream servers. The default is false.
+	Enable bool `json:"enable"`
+	// The name of the Kubernetes secret that stores the client TLS certificate and key presented to the upstream servers. It must be in the same namespace as the TransportServer resource. The secret must be of the type kubernetes.io/tls.
+	TLSSecret string `json:"tlsSecret"`
+	// Enables verification of the upstream server certificate. Requires trustedCertSecret. The default is false.
+	VerifyServer bool `json:"verifyServer"`
+	// Sets the verification depth in the upstream server certificates chain. The default is 1.
+	VerifyDepth *int `json:"verifyDepth"`
+	// The name of the Kubernetes secret that stores the CA certificate used to verify the upstream server certificate. It must be in the same namespace as the TransportServer resource. The secret must be of the type nginx.org/ca.
+	TrustedCertSecret string `json:"trustedCertSecret"`
+	// Enables passing of the server name through the Server Name Indication extension. The default is false.
+	ServerName bool `json:"serverName"`
+	// Overrides the server name used to verify the upstream server certificate and passed through SNI. By default, the name of the generated upstream is used.
+	SSLName string `json:"sslName"`
 }
 
 // TransportServerHealthCheck defines the parameters for active Upstream HealthChecks.
@@ -682,6 +913,10 @@ type TransportServerHealthCheck struct {
 	Fails int `json:"fails"`
 	// Controls the data to send and the response to expect for the healthcheck.
 	Match *TransportServerMatch `json:"match"`
+	// Require every newly added server to pass all configured health checks before NGINX Plus sends traffic to it. If this is not specified, or is set to false, the server will be initially considered healthy.
+	Mandatory bool `json:"mandatory"`
+	// Set the initial “up” state for a server after reload if the server was considered healthy before reload. Enabling persistent requires that the mandatory parameter is also set to true.
+	Persistent bool `json:"persistent"`
 }
 
 // TransportServerMatch defines the parameters of a custom health check.
@@ -690,6 +925,12 @@ type TransportServerMatch struct {
 	Send string `json:"send"`
 	// A literal string or a regular expression that the data obtained from the server should match. The regular expression is specified with the preceding ~* modifier (for case-insensitive matching), or the ~ modifier (for case-sensitive matching). NGINX Ingress Controller validates a regular expression using the RE2 syntax.
 	Expect string `json:"expect"`
+	// The hex-encoded data to send to an upstream server, for example 0000000804d2162f. Cannot be used along with send.
+	SendHex string `json:"sendHex"`
+	// The hex-encoded data that the data obtained from the server should match. Cannot be used along with expect.
+	ExpectHex string `json:"expectHex"`
+	// A built-in probe that defines the data to send and the response to expect. Possible values are dns (a DNS query, UDP only), tls (a TLS 1.2 handshake), redis (a Redis PING command) and postgresql (a PostgreSQL SSLRequest packet). Cannot be used along with send, expect, sendHex and expectHex.
+	Profile string `json:"profile"`
 }
 
 // UpstreamParameters defines parameters for an upstream.
@@ -706,6 +947,12 @@ type UpstreamParameters struct {
 	NextUpstreamTimeout string `json:"nextUpstreamTimeout"`
 	// The number of tries for passing a connection to the next server. The default is 0.
 	NextUpstreamTries int `json:"nextUpstreamTries"`
+	// Limits the speed of reading the data from the upstream server, in bytes per second. For example, 1m limits the speed to 1 megabyte per second. The value 0 disables rate limiting. The default is 0.
+	DownloadRate string `json:"downloadRate"`
+	// Limits the speed of reading the data from the client, in bytes per second. For example, 512k limits the speed to 512 kilobytes per second. The value 0 disables rate limiting. The default is 0.
+	UploadRate string `json:"uploadRate"`
+	// The maximum number of simultaneous connections to the TransportServer. New connections over the limit are closed. The limit is applied by every NGINX instance separately. The value 0 means that there is no limit. The default is 0.
+	MaxConnections *int `json:"maxConnections"`
 }
 
 // SessionParameters defines session parameters.
@@ -716,6 +963,16 @@ type SessionParameters struct {
 
 // TransportServerAction defines an action.
 type TransportServerAction struct {
+	// Passes connections/datagrams to an upstream. The upstream with that name must be defined in the resource. Cannot be used along with splits.
+	Pass string `json:"pass"`
+	// Splits connections/datagrams between two or more upstreams by the client address. Cannot be used along with pass.
+	Splits []TransportServerSplit `json:"splits"`
+}
+
+// TransportServerSplit defines a weighted split of the connections/datagrams of a TransportServer.
+type TransportServerSplit struct {
+	// The weight of the split. Must fall into the range 0..100. The sum of the weights of all splits must be equal to 100.
+	Weight int `json:"weight"`
 	// Passes connections/datagrams to an upstream. The upstream with that name must be defined in the resource.
 	Pass string `json:"pass"`
 }
@@ -728,6 +985,22 @@ type TransportServerStatus struct {
 	Reason string `json:"reason"`
 	// The message of the current state of the resource. It can contain more detailed information about the reason.
 	Message string `json:"message"`
+	// The IP addresses or hostnames and the listener ports used to connect to the TransportServer.
+	ExternalEndpoints []ExternalEndpoint `json:"externalEndpoints,omitempty"`
+	// The health of the upstreams of the TransportServer, reported by NGINX Plus.
+	Upstreams []TransportServerUpstreamStatus `json:"upstreams,omitempty"`
+}
+
+// TransportServerUpstreamStatus defines the health of the servers of an upstream of the TransportServer.
+type TransportServerUpstreamStatus struct {
+	// The name of the upstream.
+	Name string `json:"name"`
+	// The health of the upstream. Possible values are Healthy (all servers are up), Degraded (some servers are up), or Unhealthy (no servers are up).
+	Health string `json:"health"`
+	// The number of servers of the upstream.
+	Servers int `json:"servers"`
+	// The number of servers of the upstream that are up.
+	Up int `json:"up"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -793,6 +1066,8 @@ type PolicySpec struct {
 	APIKey *APIKey `json:"apiKey"`
 	// The Cache Key defines a cache policy for proxy caching
 	Cache *Cache `json:"cache"`
+	// The connection limit policy limits the number of simultaneous connections per a defined key. Only supported in TransportServer.
+	ConnectionLimit *ConnectionLimit `json:"connectionLimit"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -841,6 +1116,22 @@ type RateLimit struct {
 	Condition *RateLimitCondition `json:"condition"`
 }
 
+// ConnectionLimit defines a connection limit policy.
+type ConnectionLimit struct {
+	// The key to which the connection limit is applied. Can contain text, variables, or a combination of them.
+	// Variables must be surrounded by ${}. For example: ${binary_remote_addr}. Accepted variables are
+	// $binary_remote_addr, $remote_addr, $server_addr, $server_port.
+	Key string `json:"key"`
+	// The maximum number of simultaneous connections permitted per key.
+	Connections int `json:"connections"`
+	// Size of the shared memory zone. Only positive values are allowed. Allowed suffixes are k or m, if none are present k is assumed.
+	ZoneSize string `json:"zoneSize"`
+	// Enables the dry run mode. In this mode, the number of connections is not limited, but the number of excessive connections is accounted as usual in the shared memory zone.
+	DryRun *bool `json:"dryRun"`
+	// Sets the desired logging level for cases when the server limits the number of connections. Allowed values are info, notice, warn or error. Default is error.
+	LogLevel string `json:"logLevel"`
+}
+
 // RateLimitCondition defines a condition for a rate limit policy.
 type RateLimitCondition struct {
 	// defines a JWT condition to rate limit against.
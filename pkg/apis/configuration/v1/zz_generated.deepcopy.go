@@ -57,6 +57,27 @@ func (in *AccessControl) DeepCopy() *AccessControl {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLog) DeepCopyInto(out *AccessLog) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLog.
+func (in *AccessLog) DeepCopy() *AccessLog {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Action) DeepCopyInto(out *Action) {
 	*out = *in
@@ -247,6 +268,27 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionLimit) DeepCopyInto(out *ConnectionLimit) {
+	*out = *in
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionLimit.
+func (in *ConnectionLimit) DeepCopy() *ConnectionLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressMTLS) DeepCopyInto(out *EgressMTLS) {
 	*out = *in
@@ -382,6 +424,22 @@ func (in *ExternalEndpoint) DeepCopy() *ExternalEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCWeb) DeepCopyInto(out *GRPCWeb) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCWeb.
+func (in *GRPCWeb) DeepCopy() *GRPCWeb {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCWeb)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GlobalConfiguration) DeepCopyInto(out *GlobalConfiguration) {
 	*out = *in
@@ -579,6 +637,53 @@ func (in *Listener) DeepCopy() *Listener {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Maintenance) DeepCopyInto(out *Maintenance) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(MaintenanceConfigMap)
+		**out = **in
+	}
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BypassHeader != nil {
+		in, out := &in.BypassHeader, &out.BypassHeader
+		*out = new(Header)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Maintenance.
+func (in *Maintenance) DeepCopy() *Maintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(Maintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceConfigMap) DeepCopyInto(out *MaintenanceConfigMap) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceConfigMap.
+func (in *MaintenanceConfigMap) DeepCopy() *MaintenanceConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Match) DeepCopyInto(out *Match) {
 	*out = *in
@@ -768,6 +873,11 @@ func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
 		*out = new(Cache)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConnectionLimit != nil {
+		in, out := &in.ConnectionLimit, &out.ConnectionLimit
+		*out = new(ConnectionLimit)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -971,6 +1081,48 @@ func (in *RateLimitCondition) DeepCopy() *RateLimitCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirectMap) DeepCopyInto(out *RedirectMap) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectMap.
+func (in *RedirectMap) DeepCopy() *RedirectMap {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirectMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestID) DeepCopyInto(out *RequestID) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrustIncoming != nil {
+		in, out := &in.TrustIncoming, &out.TrustIncoming
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestID.
+func (in *RequestID) DeepCopy() *RequestID {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestID)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
@@ -1005,6 +1157,16 @@ func (in *Route) DeepCopyInto(out *Route) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(AccessLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(Tracing)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1066,6 +1228,22 @@ func (in *SessionParameters) DeepCopy() *SessionParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpanAttribute) DeepCopyInto(out *SpanAttribute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpanAttribute.
+func (in *SpanAttribute) DeepCopy() *SpanAttribute {
+	if in == nil {
+		return nil
+	}
+	out := new(SpanAttribute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Split) DeepCopyInto(out *Split) {
 	*out = *in
@@ -1160,13 +1338,39 @@ func (in *TLSRedirect) DeepCopy() *TLSRedirect {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tracing) DeepCopyInto(out *Tracing) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SpanAttributes != nil {
+		in, out := &in.SpanAttributes, &out.SpanAttributes
+		*out = make([]SpanAttribute, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tracing.
+func (in *Tracing) DeepCopy() *Tracing {
+	if in == nil {
+		return nil
+	}
+	out := new(Tracing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServer) DeepCopyInto(out *TransportServer) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -1191,6 +1395,11 @@ func (in *TransportServer) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServerAction) DeepCopyInto(out *TransportServerAction) {
 	*out = *in
+	if in.Splits != nil {
+		in, out := &in.Splits, &out.Splits
+		*out = make([]TransportServerSplit, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1274,6 +1483,22 @@ func (in *TransportServerListener) DeepCopy() *TransportServerListener {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportServerLoadBalancing) DeepCopyInto(out *TransportServerLoadBalancing) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerLoadBalancing.
+func (in *TransportServerLoadBalancing) DeepCopy() *TransportServerLoadBalancing {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportServerLoadBalancing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServerMatch) DeepCopyInto(out *TransportServerMatch) {
 	*out = *in
@@ -1290,13 +1515,29 @@ func (in *TransportServerMatch) DeepCopy() *TransportServerMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportServerRoute) DeepCopyInto(out *TransportServerRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerRoute.
+func (in *TransportServerRoute) DeepCopy() *TransportServerRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportServerRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServerSpec) DeepCopyInto(out *TransportServerSpec) {
 	*out = *in
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(TransportServerTLS)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	out.Listener = in.Listener
 	if in.Upstreams != nil {
@@ -1319,7 +1560,22 @@ func (in *TransportServerSpec) DeepCopyInto(out *TransportServerSpec) {
 	if in.Action != nil {
 		in, out := &in.Action, &out.Action
 		*out = new(TransportServerAction)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(AccessLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]PolicyReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]TransportServerRoute, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
@@ -1334,9 +1590,35 @@ func (in *TransportServerSpec) DeepCopy() *TransportServerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportServerSplit) DeepCopyInto(out *TransportServerSplit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerSplit.
+func (in *TransportServerSplit) DeepCopy() *TransportServerSplit {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportServerSplit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServerStatus) DeepCopyInto(out *TransportServerStatus) {
 	*out = *in
+	if in.ExternalEndpoints != nil {
+		in, out := &in.ExternalEndpoints, &out.ExternalEndpoints
+		*out = make([]ExternalEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Upstreams != nil {
+		in, out := &in.Upstreams, &out.Upstreams
+		*out = make([]TransportServerUpstreamStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1353,6 +1635,16 @@ func (in *TransportServerStatus) DeepCopy() *TransportServerStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServerTLS) DeepCopyInto(out *TransportServerTLS) {
 	*out = *in
+	if in.VerifyDepth != nil {
+		in, out := &in.VerifyDepth, &out.VerifyDepth
+		*out = new(int)
+		**out = **in
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]TransportServerTLSCertificate, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1366,6 +1658,22 @@ func (in *TransportServerTLS) DeepCopy() *TransportServerTLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportServerTLSCertificate) DeepCopyInto(out *TransportServerTLSCertificate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerTLSCertificate.
+func (in *TransportServerTLSCertificate) DeepCopy() *TransportServerTLSCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportServerTLSCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServerUpstream) DeepCopyInto(out *TransportServerUpstream) {
 	*out = *in
@@ -1384,11 +1692,21 @@ func (in *TransportServerUpstream) DeepCopyInto(out *TransportServerUpstream) {
 		*out = new(TransportServerHealthCheck)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LoadBalancing != nil {
+		in, out := &in.LoadBalancing, &out.LoadBalancing
+		*out = new(TransportServerLoadBalancing)
+		**out = **in
+	}
 	if in.BackupPort != nil {
 		in, out := &in.BackupPort, &out.BackupPort
 		*out = new(uint16)
 		**out = **in
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TransportServerUpstreamTLS)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1402,6 +1720,43 @@ func (in *TransportServerUpstream) DeepCopy() *TransportServerUpstream {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportServerUpstreamStatus) DeepCopyInto(out *TransportServerUpstreamStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerUpstreamStatus.
+func (in *TransportServerUpstreamStatus) DeepCopy() *TransportServerUpstreamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportServerUpstreamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportServerUpstreamTLS) DeepCopyInto(out *TransportServerUpstreamTLS) {
+	*out = *in
+	if in.VerifyDepth != nil {
+		in, out := &in.VerifyDepth, &out.VerifyDepth
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerUpstreamTLS.
+func (in *TransportServerUpstreamTLS) DeepCopy() *TransportServerUpstreamTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportServerUpstreamTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Upstream) DeepCopyInto(out *Upstream) {
 	*out = *in
@@ -1453,6 +1808,21 @@ func (in *Upstream) DeepCopyInto(out *Upstream) {
 		*out = new(SessionCookie)
 		**out = **in
 	}
+	if in.SessionAffinity != nil {
+		in, out := &in.SessionAffinity, &out.SessionAffinity
+		*out = new(SessionCookie)
+		**out = **in
+	}
+	if in.GRPCWeb != nil {
+		in, out := &in.GRPCWeb, &out.GRPCWeb
+		*out = new(GRPCWeb)
+		**out = **in
+	}
+	if in.WebSocket != nil {
+		in, out := &in.WebSocket, &out.WebSocket
+		*out = new(WebSocket)
+		**out = **in
+	}
 	if in.BackupPort != nil {
 		in, out := &in.BackupPort, &out.BackupPort
 		*out = new(uint16)
@@ -1500,6 +1870,11 @@ func (in *UpstreamParameters) DeepCopyInto(out *UpstreamParameters) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -1783,6 +2158,31 @@ func (in *VirtualServerSpec) DeepCopyInto(out *VirtualServerSpec) {
 		}
 	}
 	in.ExternalDNS.DeepCopyInto(&out.ExternalDNS)
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(Maintenance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedirectMap != nil {
+		in, out := &in.RedirectMap, &out.RedirectMap
+		*out = new(RedirectMap)
+		**out = **in
+	}
+	if in.RequestID != nil {
+		in, out := &in.RequestID, &out.RequestID
+		*out = new(RequestID)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(AccessLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(Tracing)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1848,3 +2248,19 @@ func (in *WAF) DeepCopy() *WAF {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSocket) DeepCopyInto(out *WebSocket) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSocket.
+func (in *WebSocket) DeepCopy() *WebSocket {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSocket)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -98,19 +98,24 @@ func (gcv *GlobalConfigurationValidator) checkIPPortProtocolConflicts(combinatio
 	if combinations[ip] == nil {
 		combinations[ip] = make(map[int][]string) // map[ip]map[port][]protocol
 	}
-	existingProtocols, exists := combinations[ip][listener.Port]
-	if !exists {
-		return nil
-	}
-	for _, existingProtocol := range existingProtocols {
-		switch listener.Protocol {
-		case "HTTP", "TCP":
-			if existingProtocol == "HTTP" || existingProtocol == "TCP" {
-				return field.Invalid(fieldPath.Child("protocol"), listener.Protocol, fmt.Sprintf("Listener %s: Duplicated ip:port protocol combination %s:%d %s", listener.Name, ip, listener.Port, listener.Protocol))
-			}
-		case "UDP":
-			if existingProtocol == "UDP" {
-				return field.Invalid(fieldPath.Child("protocol"), listener.Protocol, fmt.Sprintf("Listener %s: Duplicated ip:port protocol combination %s:%d %s", listener.Name, ip, listener.Port, listener.Protocol))
+	for _, port := range getListenerPorts(listener) {
+		existingProtocols, exists := combinations[ip][port]
+		if !exists {
+			continue
+		}
+		for _, existingProtocol := range existingProtocols {
+			switch listener.Protocol {
+			case "HTTP", "TCP":
+				if existingProtocol == "HTTP" || existingProtocol == "TCP" {
+					return field.Invalid(fieldPath.Child("protocol"), listener.Protocol, fmt.Sprintf("Listener %s: Duplicated ip:port protocol combination %s:%d %s", listener.Name, ip, port, listener.Protocol))
+				}
+				if listener.QUIC && existingProtocol == "UDP" {
+					return field.Invalid(fieldPath.Child("quic"), listener.QUIC, fmt.Sprintf("Listener %s: QUIC ip:port %s:%d is already used by a UDP listener", listener.Name, ip, port))
+				}
+			case "UDP":
+				if existingProtocol == "UDP" {
+					return field.Invalid(fieldPath.Child("protocol"), listener.Protocol, fmt.Sprintf("Listener %s: Duplicated ip:port protocol combination %s:%d %s", listener.Name, ip, port, listener.Protocol))
+				}
 			}
 		}
 	}
@@ -123,7 +128,22 @@ func (gcv *GlobalConfigurationValidator) updatePortProtocolCombinations(combinat
 	if combinations[ip] == nil {
 		combinations[ip] = make(map[int][]string)
 	}
-	combinations[ip][listener.Port] = append(combinations[ip][listener.Port], listener.Protocol)
+	for _, port := range getListenerPorts(listener) {
+		combinations[ip][port] = append(combinations[ip][port], listener.Protocol)
+	}
+	if listener.QUIC {
+		// A QUIC listener also claims the UDP port.
+		combinations[ip][listener.Port] = append(combinations[ip][listener.Port], "UDP")
+	}
+}
+
+// getListenerPorts returns all ports of the given listener, including the ports of its range.
+func getListenerPorts(listener conf_v1.Listener) []int {
+	ports := []int{listener.Port}
+	for port := listener.Port + 1; port <= listener.EndPort; port++ {
+		ports = append(ports, port)
+	}
+	return ports
 }
 
 // getIP returns the appropriate IP address for the given ipType and listener.
@@ -147,13 +167,26 @@ func generatePortProtocolKey(port int, protocol string) string {
 func (gcv *GlobalConfigurationValidator) validateListener(listener conf_v1.Listener, fieldPath *field.Path) field.ErrorList {
 	allErrs := validateGlobalConfigurationListenerName(listener.Name, fieldPath.Child("name"))
 	allErrs = append(allErrs, gcv.validateListenerPort(listener.Name, listener.Port, fieldPath.Child("port"))...)
+	allErrs = append(allErrs, gcv.validateListenerEndPort(listener, fieldPath.Child("endPort"))...)
 	allErrs = append(allErrs, validateListenerProtocol(listener.Protocol, fieldPath.Child("protocol"))...)
 	allErrs = append(allErrs, validateListenerIPv4(listener.IPv4, fieldPath.Child("ipv4"))...)
 	allErrs = append(allErrs, validateListenerIPv6(listener.IPv6, fieldPath.Child("ipv6"))...)
+	allErrs = append(allErrs, validateListenerQUIC(listener, fieldPath.Child("quic"))...)
 
 	return allErrs
 }
 
+func validateListenerQUIC(listener conf_v1.Listener, fieldPath *field.Path) field.ErrorList {
+	if !listener.QUIC {
+		return nil
+	}
+	if listener.Protocol != "HTTP" || !listener.Ssl {
+		msg := fmt.Sprintf("Listener %v: quic requires the HTTP protocol and ssl", listener.Name)
+		return field.ErrorList{field.Forbidden(fieldPath, msg)}
+	}
+	return nil
+}
+
 func validateGlobalConfigurationListenerName(name string, fieldPath *field.Path) field.ErrorList {
 	if name == conf_v1.TLSPassthroughListenerName {
 		return field.ErrorList{field.Forbidden(fieldPath, "is the name of a built-in listener")}
@@ -174,6 +207,37 @@ func (gcv *GlobalConfigurationValidator) validateListenerPort(name string, port
 	return allErrs
 }
 
+func (gcv *GlobalConfigurationValidator) validateListenerEndPort(listener conf_v1.Listener, fieldPath *field.Path) field.ErrorList {
+	if listener.EndPort == 0 {
+		return nil
+	}
+
+	if listener.Protocol != "TCP" && listener.Protocol != "UDP" {
+		msg := fmt.Sprintf("Listener %v: a port range requires the TCP or UDP protocol", listener.Name)
+		return field.ErrorList{field.Forbidden(fieldPath, msg)}
+	}
+
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsValidPortNum(listener.EndPort) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, listener.EndPort, msg))
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	if listener.EndPort <= listener.Port {
+		return field.ErrorList{field.Invalid(fieldPath, listener.EndPort, "must be greater than port")}
+	}
+
+	for port := listener.Port + 1; port <= listener.EndPort; port++ {
+		if gcv.forbiddenListenerPorts[port] {
+			msg := fmt.Sprintf("Listener %v: port %v of the range is forbidden", listener.Name, port)
+			return field.ErrorList{field.Forbidden(fieldPath, msg)}
+		}
+	}
+	return nil
+}
+
 func validateListenerProtocol(protocol string, fieldPath *field.Path) field.ErrorList {
 	switch {
 	case allowedProtocols[protocol]:
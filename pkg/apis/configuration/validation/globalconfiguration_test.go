@@ -755,3 +755,251 @@ func TestValidateListenerProtocol_PassesOnUDPListenerUsingSamePortAsHTTPListener
 		t.Errorf("validateListeners() returned errors %v for valid input", allErrs)
 	}
 }
+
+func TestValidateListenerQUIC_PassesOnValidInput(t *testing.T) {
+	t.Parallel()
+	listener := conf_v1.Listener{
+		Name:     "https-listener",
+		Port:     8443,
+		Protocol: "HTTP",
+		Ssl:      true,
+		QUIC:     true,
+	}
+	allErrs := validateListenerQUIC(listener, field.NewPath("quic"))
+	if len(allErrs) != 0 {
+		t.Errorf("validateListenerQUIC() returned errors %v for valid input", allErrs)
+	}
+}
+
+func TestValidateListenerQUIC_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	invalidListeners := []conf_v1.Listener{
+		{
+			Name:     "http-listener",
+			Port:     8080,
+			Protocol: "HTTP",
+			QUIC:     true,
+		},
+		{
+			Name:     "tcp-listener",
+			Port:     5353,
+			Protocol: "TCP",
+			Ssl:      true,
+			QUIC:     true,
+		},
+		{
+			Name:     "udp-listener",
+			Port:     5353,
+			Protocol: "UDP",
+			QUIC:     true,
+		},
+	}
+	for _, l := range invalidListeners {
+		allErrs := validateListenerQUIC(l, field.NewPath("quic"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateListenerQUIC() returned no errors for invalid input %v", l)
+		}
+	}
+}
+
+func TestValidateListenerProtocol_FailsOnUDPListenerUsingSamePortAsQUICListener(t *testing.T) {
+	t.Parallel()
+	listeners := []conf_v1.Listener{
+		{
+			Name:     "https-listener",
+			Port:     8443,
+			Protocol: "HTTP",
+			Ssl:      true,
+			QUIC:     true,
+		},
+		{
+			Name:     "udp-listener",
+			Port:     8443,
+			Protocol: "UDP",
+		},
+	}
+	wantListeners := []conf_v1.Listener{
+		{
+			Name:     "https-listener",
+			Port:     8443,
+			Protocol: "HTTP",
+			Ssl:      true,
+			QUIC:     true,
+		},
+	}
+
+	gcv := createGlobalConfigurationValidator()
+
+	listeners, allErrs := gcv.getValidListeners(listeners, field.NewPath("listeners"))
+	if diff := cmp.Diff(listeners, wantListeners); diff != "" {
+		t.Errorf("getValidListeners() returned unexpected result: (-want +got):\n%s", diff)
+	}
+	if len(allErrs) != 1 {
+		t.Errorf("getValidListeners() returned unexpected number of errors. Got %d, want 1", len(allErrs))
+	}
+}
+
+func TestValidateListenerProtocol_FailsOnQUICListenerUsingSamePortAsUDPListener(t *testing.T) {
+	t.Parallel()
+	listeners := []conf_v1.Listener{
+		{
+			Name:     "udp-listener",
+			Port:     8443,
+			Protocol: "UDP",
+		},
+		{
+			Name:     "https-listener",
+			Port:     8443,
+			Protocol: "HTTP",
+			Ssl:      true,
+			QUIC:     true,
+		},
+	}
+	wantListeners := []conf_v1.Listener{
+		{
+			Name:     "udp-listener",
+			Port:     8443,
+			Protocol: "UDP",
+		},
+	}
+
+	gcv := createGlobalConfigurationValidator()
+
+	listeners, allErrs := gcv.getValidListeners(listeners, field.NewPath("listeners"))
+	if diff := cmp.Diff(listeners, wantListeners); diff != "" {
+		t.Errorf("getValidListeners() returned unexpected result: (-want +got):\n%s", diff)
+	}
+	if len(allErrs) != 1 {
+		t.Errorf("getValidListeners() returned unexpected number of errors. Got %d, want 1", len(allErrs))
+	}
+}
+
+func TestValidateListenerEndPort_PassesOnValidInput(t *testing.T) {
+	t.Parallel()
+	validListeners := []conf_v1.Listener{
+		{
+			Name:     "tcp-listener",
+			Port:     5353,
+			Protocol: "TCP",
+		},
+		{
+			Name:     "ftp-passive",
+			Port:     30000,
+			EndPort:  30100,
+			Protocol: "TCP",
+		},
+		{
+			Name:     "rtp",
+			Port:     10000,
+			EndPort:  20000,
+			Protocol: "UDP",
+		},
+	}
+
+	gcv := createGlobalConfigurationValidator()
+
+	for _, l := range validListeners {
+		allErrs := gcv.validateListenerEndPort(l, field.NewPath("endPort"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateListenerEndPort() returned errors %v for valid input %v", allErrs, l)
+		}
+	}
+}
+
+func TestValidateListenerEndPort_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	invalidListeners := []conf_v1.Listener{
+		{
+			Name:     "http-listener",
+			Port:     8000,
+			EndPort:  8010,
+			Protocol: "HTTP",
+		},
+		{
+			Name:     "tcp-listener",
+			Port:     5353,
+			EndPort:  5353,
+			Protocol: "TCP",
+		},
+		{
+			Name:     "tcp-listener",
+			Port:     5353,
+			EndPort:  5000,
+			Protocol: "TCP",
+		},
+		{
+			Name:     "tcp-listener",
+			Port:     5353,
+			EndPort:  70000,
+			Protocol: "TCP",
+		},
+		{
+			Name:     "tcp-listener",
+			Port:     9000,
+			EndPort:  9200,
+			Protocol: "TCP",
+		},
+	}
+
+	gcv := NewGlobalConfigurationValidator(map[int]bool{9113: true})
+
+	for _, l := range invalidListeners {
+		allErrs := gcv.validateListenerEndPort(l, field.NewPath("endPort"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateListenerEndPort() returned no errors for invalid input %v", l)
+		}
+	}
+}
+
+func TestValidateListeners_FailsOnOverlappingPortRanges(t *testing.T) {
+	t.Parallel()
+	listeners := []conf_v1.Listener{
+		{
+			Name:     "ftp-passive",
+			Port:     30000,
+			EndPort:  30100,
+			Protocol: "TCP",
+		},
+		{
+			Name:     "tcp-listener",
+			Port:     30050,
+			Protocol: "TCP",
+		},
+		{
+			Name:     "tcp-range",
+			Port:     29990,
+			EndPort:  30000,
+			Protocol: "TCP",
+		},
+		{
+			Name:     "udp-range",
+			Port:     30000,
+			EndPort:  30100,
+			Protocol: "UDP",
+		},
+	}
+	wantListeners := []conf_v1.Listener{
+		{
+			Name:     "ftp-passive",
+			Port:     30000,
+			EndPort:  30100,
+			Protocol: "TCP",
+		},
+		{
+			Name:     "udp-range",
+			Port:     30000,
+			EndPort:  30100,
+			Protocol: "UDP",
+		},
+	}
+
+	gcv := createGlobalConfigurationValidator()
+
+	listeners, allErrs := gcv.getValidListeners(listeners, field.NewPath("listeners"))
+	if diff := cmp.Diff(wantListeners, listeners); diff != "" {
+		t.Errorf("getValidListeners() returned unexpected result: (-want +got):\n%s", diff)
+	}
+	if len(allErrs) != 2 {
+		t.Errorf("getValidListeners() returned unexpected number of errors. Got %d, want 2", len(allErrs))
+	}
+}
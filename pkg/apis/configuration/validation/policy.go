@@ -97,8 +97,13 @@ func validatePolicySpec(spec *v1.PolicySpec, fieldPath *field.Path, isPlus, enab
 		fieldCount++
 	}
 
+	if spec.ConnectionLimit != nil {
+		allErrs = append(allErrs, validateConnectionLimit(spec.ConnectionLimit, fieldPath.Child("connectionLimit"))...)
+		fieldCount++
+	}
+
 	if fieldCount != 1 {
-		msg := "must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `cache`"
+		msg := "must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `apiKey`, `cache`, `connectionLimit`"
 		if isPlus {
 			msg = fmt.Sprint(msg, ", `jwt`, `oidc`, `waf`")
 		}
@@ -173,6 +178,21 @@ func validateRateLimit(rateLimit *v1.RateLimit, fieldPath *field.Path, isPlus bo
 	return allErrs
 }
 
+func validateConnectionLimit(connectionLimit *v1.ConnectionLimit, fieldPath *field.Path) field.ErrorList {
+	allErrs := validateRateLimitZoneSize(connectionLimit.ZoneSize, fieldPath.Child("zoneSize"))
+	allErrs = append(allErrs, validateConnectionLimitKey(connectionLimit.Key, fieldPath.Child("key"))...)
+
+	if connectionLimit.Connections <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("connections"), connectionLimit.Connections, "must be greater than 0"))
+	}
+
+	if connectionLimit.LogLevel != "" {
+		allErrs = append(allErrs, validateRateLimitLogLevel(connectionLimit.LogLevel, fieldPath.Child("logLevel"))...)
+	}
+
+	return allErrs
+}
+
 // validateJWT validates JWT Policy according the rules specified in documentation
 // for using [jwt] local k8s secrets and using [jwks] from remote location.
 //
@@ -735,6 +755,25 @@ func validateRateLimitKey(key string, fieldPath *field.Path, isPlus bool) field.
 	return append(allErrs, validateStringWithVariables(key, fieldPath, rateLimitKeySpecialVariables, rateLimitKeyVariables, isPlus)...)
 }
 
+// connectionLimitKeyVariables includes NGINX stream variables allowed to be used in a connectionLimit policy key.
+var connectionLimitKeyVariables = map[string]bool{
+	"binary_remote_addr": true,
+	"remote_addr":        true,
+	"server_addr":        true,
+	"server_port":        true,
+}
+
+func validateConnectionLimitKey(key string, fieldPath *field.Path) field.ErrorList {
+	if key == "" {
+		return field.ErrorList{field.Required(fieldPath, "")}
+	}
+	allErrs := field.ErrorList{}
+	if err := ValidateEscapedString(key, `Hello World! \n`, `\"${remote_addr}\" is unavailable. \n`); err != nil {
+		allErrs = append(allErrs, field.Invalid(fieldPath, key, err.Error()))
+	}
+	return append(allErrs, validateStringWithVariables(key, fieldPath, nil, connectionLimitKeyVariables, false)...)
+}
+
 var jwtTokenSpecialVariables = []string{"arg_", "http_", "cookie_"}
 
 func validateJWTToken(token string, fieldPath *field.Path) field.ErrorList {
@@ -877,6 +877,97 @@ func TestValidateRateLimitKey(t *testing.T) {
 	})
 }
 
+func TestValidateConnectionLimit_PassesOnValidInput(t *testing.T) {
+	t.Parallel()
+	dryRun := true
+
+	tests := []struct {
+		connectionLimit *v1.ConnectionLimit
+		msg             string
+	}{
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				Key:         "${binary_remote_addr}",
+				Connections: 10,
+				ZoneSize:    "10M",
+			},
+			msg: "only required fields are set",
+		},
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				Key:         "${remote_addr}_${server_port}",
+				Connections: 1,
+				ZoneSize:    "64k",
+				DryRun:      &dryRun,
+				LogLevel:    "warn",
+			},
+			msg: "connectionLimit all fields set",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateConnectionLimit(test.connectionLimit, field.NewPath("connectionLimit"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateConnectionLimit() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateConnectionLimit_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		connectionLimit *v1.ConnectionLimit
+		msg             string
+	}{
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				Key:      "${binary_remote_addr}",
+				ZoneSize: "10M",
+			},
+			msg: "missing connections",
+		},
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				Key:         "${request_uri}",
+				Connections: 10,
+				ZoneSize:    "10M",
+			},
+			msg: "invalid connectionLimit key variable use",
+		},
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				Connections: 10,
+				ZoneSize:    "10M",
+			},
+			msg: "missing key",
+		},
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				Key:         "${binary_remote_addr}",
+				Connections: 10,
+				ZoneSize:    "31k",
+			},
+			msg: "invalid connectionLimit zoneSize",
+		},
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				Key:         "${binary_remote_addr}",
+				Connections: 10,
+				ZoneSize:    "10M",
+				LogLevel:    "invalid",
+			},
+			msg: "invalid connectionLimit logLevel",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateConnectionLimit(test.connectionLimit, field.NewPath("connectionLimit"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateConnectionLimit() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
 func TestValidateJWT_PassesOnValidInput(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
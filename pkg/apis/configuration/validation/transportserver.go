@@ -3,7 +3,9 @@ package validation
 import (
 	"encoding/hex"
 	"fmt"
+	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 
 	conf_v1 "github.com/nginx/kubernetes-ingress/pkg/apis/configuration/v1"
@@ -30,19 +32,21 @@ func NewTransportServerValidator(tlsPassthrough bool, snippetsEnabled bool, isPl
 
 // ValidateTransportServer validates a TransportServer.
 func (tsv *TransportServerValidator) ValidateTransportServer(transportServer *conf_v1.TransportServer) error {
-	allErrs := tsv.validateTransportServerSpec(&transportServer.Spec, field.NewPath("spec"))
+	allErrs := tsv.validateTransportServerSpec(&transportServer.Spec, field.NewPath("spec"), transportServer.Namespace)
 	return allErrs.ToAggregate()
 }
 
-func (tsv *TransportServerValidator) validateTransportServerSpec(spec *conf_v1.TransportServerSpec, fieldPath *field.Path) field.ErrorList {
+func (tsv *TransportServerValidator) validateTransportServerSpec(spec *conf_v1.TransportServerSpec, fieldPath *field.Path, namespace string) field.ErrorList {
 	allErrs := tsv.validateTransportListener(&spec.Listener, fieldPath.Child("listener"))
 
 	isTLSPassthroughListener := isPotentialTLSPassthroughListener(&spec.Listener)
 	allErrs = append(allErrs, validateTransportServerHost(spec.Host, fieldPath.Child("host"), isTLSPassthroughListener, spec.Listener.Protocol, spec.TLS)...)
 
-	upstreamErrs, upstreamNames := validateTransportServerUpstreams(spec.Upstreams, fieldPath.Child("upstreams"), tsv.isPlus)
+	upstreamErrs, upstreamNames := validateTransportServerUpstreams(spec.Upstreams, fieldPath.Child("upstreams"), spec.Listener.Protocol, tsv.isPlus)
 	allErrs = append(allErrs, upstreamErrs...)
 
+	allErrs = append(allErrs, validateTransportServerUpstreamsConnections(spec.Upstreams, fieldPath.Child("upstreams"), spec.Listener.Protocol, isTLSPassthroughListener)...)
+
 	allErrs = append(allErrs, validateTransportServerUpstreamParameters(spec.UpstreamParameters, fieldPath.Child("upstreamParameters"), spec.Listener.Protocol)...)
 
 	allErrs = append(allErrs, validateSessionParameters(spec.SessionParameters, fieldPath.Child("sessionParameters"))...)
@@ -53,10 +57,18 @@ func (tsv *TransportServerValidator) validateTransportServerSpec(spec *conf_v1.T
 		allErrs = append(allErrs, validateTransportServerAction(spec.Action, fieldPath.Child("action"), upstreamNames)...)
 	}
 
+	allErrs = append(allErrs, validateTransportServerRoutes(spec, fieldPath.Child("routes"), isTLSPassthroughListener, upstreamNames)...)
+
+	allErrs = append(allErrs, validateTransportServerPassedUpstreams(spec, fieldPath)...)
+
 	allErrs = append(allErrs, validateSnippets(spec.ServerSnippets, fieldPath.Child("serverSnippets"), tsv.snippetsEnabled)...)
 
 	allErrs = append(allErrs, validateSnippets(spec.StreamSnippets, fieldPath.Child("streamSnippets"), tsv.snippetsEnabled)...)
 
+	allErrs = append(allErrs, validateAccessLog(spec.AccessLog, fieldPath.Child("accessLog"))...)
+
+	allErrs = append(allErrs, validatePolicies(spec.Policies, fieldPath.Child("policies"), namespace)...)
+
 	hostSpecified := spec.Host != ""
 	allErrs = append(allErrs, validateTLS(spec.TLS, isTLSPassthroughListener, fieldPath.Child("tls"), hostSpecified)...)
 
@@ -76,18 +88,150 @@ func validateTLS(
 		return nil
 	}
 
+	if hostSpecified && (tls == nil || tls.Secret == "") {
+		return field.ErrorList{field.Required(fieldPath, "must specify spec.tls.secret when host is specified, and the TransportServer is not using the TLS Passthrough listener")}
+	}
+
+	if tls == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	if tls.Secret != "" {
+		allErrs = append(allErrs, validateSecretName(tls.Secret, fieldPath.Child("secret"))...)
+	}
+
+	allErrs = append(allErrs, validateTransportServerTLSCertificates(tls, fieldPath, hostSpecified)...)
+
+	return append(allErrs, validateTransportServerTLSClientVerification(tls, fieldPath)...)
+}
+
+func validateTransportServerTLSCertificates(tls *conf_v1.TransportServerTLS, fieldPath *field.Path, hostSpecified bool) field.ErrorList {
+	if len(tls.Certificates) == 0 {
+		return nil
+	}
+
+	certificatesPath := fieldPath.Child("certificates")
 	if hostSpecified {
-		if tls == nil || tls.Secret == "" {
-			return field.ErrorList{field.Required(fieldPath, "must specify spec.tls.secret when host is specified, and the TransportServer is not using the TLS Passthrough listener")}
+		return field.ErrorList{field.Forbidden(certificatesPath, "cannot be used along with host")}
+	}
+	if tls.Secret == "" {
+		return field.ErrorList{field.Required(fieldPath.Child("secret"), "must specify secret when certificates are specified")}
+	}
+
+	allErrs := field.ErrorList{}
+	serverNames := sets.Set[string]{}
+	for i, c := range tls.Certificates {
+		idxPath := certificatesPath.Index(i)
+
+		allErrs = append(allErrs, validateTransportServerServerName(c.ServerName, idxPath.Child("serverName"))...)
+		if serverNames.Has(c.ServerName) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("serverName"), c.ServerName))
+		}
+		serverNames.Insert(c.ServerName)
+
+		if c.Secret == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("secret"), "must specify secret"))
+		} else {
+			allErrs = append(allErrs, validateSecretName(c.Secret, idxPath.Child("secret"))...)
 		}
-		return validateSecretName(tls.Secret, fieldPath.Child("secret"))
 	}
 
-	if tls != nil && tls.Secret != "" {
-		return validateSecretName(tls.Secret, fieldPath.Child("secret"))
+	return allErrs
+}
+
+func validateTransportServerServerName(serverName string, fieldPath *field.Path) field.ErrorList {
+	if serverName == "" {
+		return field.ErrorList{field.Required(fieldPath, "must specify serverName")}
 	}
 
-	return nil
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsDNS1123Subdomain(serverName) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, serverName, msg))
+	}
+	return allErrs
+}
+
+var alpnProtocolRegexp = regexp.MustCompile(`^[a-zA-Z0-9._/-]+$`)
+
+func validateTransportServerRoutes(spec *conf_v1.TransportServerSpec, fieldPath *field.Path, isTLSPassthroughListener bool, upstreamNames sets.Set[string]) field.ErrorList {
+	if len(spec.Routes) == 0 {
+		return nil
+	}
+
+	if spec.Listener.Protocol == "UDP" {
+		return field.ErrorList{field.Forbidden(fieldPath, "is not supported for UDP TransportServers")}
+	}
+	if isTLSPassthroughListener {
+		return field.ErrorList{field.Forbidden(fieldPath, "is not supported for TLS Passthrough TransportServers")}
+	}
+	if spec.TLS == nil || spec.TLS.Secret == "" {
+		return field.ErrorList{field.Required(fieldPath, "requires TLS termination, must specify spec.tls.secret")}
+	}
+
+	allErrs := field.ErrorList{}
+	matches := sets.Set[string]{}
+	for i, r := range spec.Routes {
+		idxPath := fieldPath.Index(i)
+
+		if r.ServerName == "" && r.ALPN == "" {
+			allErrs = append(allErrs, field.Required(idxPath, "must specify serverName or alpn"))
+		}
+
+		if r.ServerName != "" {
+			if spec.Host != "" {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("serverName"), "cannot be used along with host"))
+			} else {
+				allErrs = append(allErrs, validateTransportServerServerName(r.ServerName, idxPath.Child("serverName"))...)
+			}
+		}
+
+		if r.ALPN != "" && !alpnProtocolRegexp.MatchString(r.ALPN) {
+			msg := validation.RegexError("must be a valid ALPN protocol", alpnProtocolRegexp.String(), "h2", "http/1.1")
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("alpn"), r.ALPN, msg))
+		}
+
+		match := r.ServerName + ":" + r.ALPN
+		if matches.Has(match) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, match))
+		}
+		matches.Insert(match)
+
+		if r.Pass == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("pass"), "must specify pass"))
+		} else {
+			allErrs = append(allErrs, validateReferencedUpstream(r.Pass, idxPath.Child("pass"), upstreamNames)...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateTransportServerTLSClientVerification(tls *conf_v1.TransportServerTLS, fieldPath *field.Path) field.ErrorList {
+	if tls.ClientCertSecret == "" {
+		allErrs := field.ErrorList{}
+		if tls.VerifyClient != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("verifyClient"), "requires clientCertSecret"))
+		}
+		if tls.VerifyDepth != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("verifyDepth"), "requires clientCertSecret"))
+		}
+		if tls.CrlFileName != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("crlFileName"), "requires clientCertSecret"))
+		}
+		return allErrs
+	}
+
+	if tls.Secret == "" {
+		return field.ErrorList{field.Required(fieldPath.Child("secret"), "must specify secret when clientCertSecret is specified")}
+	}
+
+	allErrs := validateSecretName(tls.ClientCertSecret, fieldPath.Child("clientCertSecret"))
+	allErrs = append(allErrs, validateIngressMTLSVerifyClient(tls.VerifyClient, fieldPath.Child("verifyClient"))...)
+	if tls.VerifyDepth != nil {
+		allErrs = append(allErrs, validatePositiveIntOrZero(*tls.VerifyDepth, fieldPath.Child("verifyDepth"))...)
+	}
+	return allErrs
 }
 
 func validateSnippets(serverSnippet string, fieldPath *field.Path, snippetsEnabled bool) field.ErrorList {
@@ -162,7 +306,7 @@ func validateListenerName(name string, fieldPath *field.Path) field.ErrorList {
 	return validateDNS1035Label(name, fieldPath)
 }
 
-func validateTransportServerUpstreams(upstreams []conf_v1.TransportServerUpstream, fieldPath *field.Path, isPlus bool) (allErrs field.ErrorList, upstreamNames sets.Set[string]) {
+func validateTransportServerUpstreams(upstreams []conf_v1.TransportServerUpstream, fieldPath *field.Path, protocol string, isPlus bool) (allErrs field.ErrorList, upstreamNames sets.Set[string]) {
 	allErrs = field.ErrorList{}
 	upstreamNames = sets.Set[string]{}
 
@@ -187,14 +331,66 @@ func validateTransportServerUpstreams(upstreams []conf_v1.TransportServerUpstrea
 			allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), u.Port, msg))
 		}
 
-		allErrs = append(allErrs, validateTSUpstreamHealthChecks(u.HealthCheck, idxPath.Child("healthChecks"))...)
+		allErrs = append(allErrs, validateTSUpstreamHealthChecks(u.HealthCheck, idxPath.Child("healthChecks"), protocol)...)
 		allErrs = append(allErrs, validateLoadBalancingMethod(u.LoadBalancingMethod, idxPath.Child("loadBalancingMethod"), isPlus)...)
-		allErrs = append(allErrs, validateBackup(u.Backup, u.BackupPort, u.LoadBalancingMethod, idxPath)...)
+
+		lbMethod := u.LoadBalancingMethod
+		if u.LoadBalancing != nil {
+			if u.LoadBalancingMethod != "" {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("loadBalancing"), "cannot be used along with loadBalancingMethod"))
+			}
+			allErrs = append(allErrs, validateTransportServerLoadBalancing(u.LoadBalancing, idxPath.Child("loadBalancing"), protocol, isPlus)...)
+			lbMethod = u.LoadBalancing.Method
+		}
+		allErrs = append(allErrs, validateBackup(u.Backup, u.BackupPort, lbMethod, idxPath)...)
 	}
 
 	return allErrs, upstreamNames
 }
 
+// validateTransportServerUpstreamsConnections validates the TLS and PROXY protocol settings of the connections to the upstreams
+// against the protocol of the listener.
+func validateTransportServerUpstreamsConnections(upstreams []conf_v1.TransportServerUpstream, fieldPath *field.Path, protocol string, isTLSPassthroughListener bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, u := range upstreams {
+		idxPath := fieldPath.Index(i)
+
+		if u.ProxyProtocol && protocol == "UDP" {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("proxyProtocol"), "is not supported for UDP TransportServers"))
+		}
+
+		if u.TLS == nil {
+			continue
+		}
+		if protocol == "UDP" {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("tls"), "is not supported for UDP TransportServers"))
+			continue
+		}
+		if isTLSPassthroughListener {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("tls"), "is not supported for TLS Passthrough TransportServers"))
+			continue
+		}
+		allErrs = append(allErrs, validateTransportServerUpstreamTLS(u.TLS, idxPath.Child("tls"))...)
+	}
+
+	return allErrs
+}
+
+func validateTransportServerUpstreamTLS(tls *conf_v1.TransportServerUpstreamTLS, fieldPath *field.Path) field.ErrorList {
+	allErrs := validateSecretName(tls.TLSSecret, fieldPath.Child("tlsSecret"))
+	allErrs = append(allErrs, validateSecretName(tls.TrustedCertSecret, fieldPath.Child("trustedCertSecret"))...)
+	if tls.VerifyServer && tls.TrustedCertSecret == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("trustedCertSecret"), "must specify trustedCertSecret when verifyServer is enabled"))
+	}
+	if tls.VerifyDepth != nil {
+		allErrs = append(allErrs, validatePositiveIntOrZero(*tls.VerifyDepth, fieldPath.Child("verifyDepth"))...)
+	}
+	allErrs = append(allErrs, validateSSLName(tls.SSLName, fieldPath.Child("sslName"))...)
+
+	return allErrs
+}
+
 func validateLoadBalancingMethod(method string, fieldPath *field.Path, isPlus bool) field.ErrorList {
 	if method == "" {
 		return nil
@@ -236,6 +432,67 @@ var nginxPlusStreamLoadBalanceValidInput = map[string]bool{
 	"least_time last_byte inflight": true,
 }
 
+var (
+	transportServerLoadBalancingMethods = []string{"round_robin", "least_conn", "hash", "random", "random_two", "least_time"}
+	transportServerLeastTimeValues      = []string{"connect", "first_byte", "last_byte"}
+	transportServerHashKeyVariables     = map[string]bool{
+		"remote_addr":        true,
+		"binary_remote_addr": true,
+		"remote_port":        true,
+		"server_port":        true,
+	}
+)
+
+func validateTransportServerLoadBalancing(lb *conf_v1.TransportServerLoadBalancing, fieldPath *field.Path, protocol string, isPlus bool) field.ErrorList {
+	methodPath := fieldPath.Child("method")
+	if lb.Method == "" {
+		return field.ErrorList{field.Required(methodPath, "must specify method")}
+	}
+	if !slices.Contains(transportServerLoadBalancingMethods, lb.Method) {
+		return field.ErrorList{field.NotSupported(methodPath, lb.Method, transportServerLoadBalancingMethods)}
+	}
+	if lb.Method == "least_time" && !isPlus {
+		return field.ErrorList{field.Forbidden(methodPath, "least_time is only supported in NGINX Plus")}
+	}
+
+	allErrs := field.ErrorList{}
+
+	if lb.Method == "hash" {
+		if lb.Key != "" {
+			allErrs = append(allErrs, validateStringWithVariables(lb.Key, fieldPath.Child("key"), nil, transportServerHashKeyVariables, isPlus)...)
+			if err := ValidateEscapedString(lb.Key); err != nil {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("key"), lb.Key, err.Error()))
+			}
+		}
+	} else {
+		if lb.Key != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("key"), "is only supported for the hash method"))
+		}
+		if lb.Consistent {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("consistent"), "is only supported for the hash method"))
+		}
+	}
+
+	if lb.Inflight && lb.Method != "least_time" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("inflight"), "is only supported for the least_time method"))
+	}
+
+	leastTimePath := fieldPath.Child("leastTime")
+	switch {
+	case lb.LeastTime != "" && lb.Method != "least_time" && lb.Method != "random_two":
+		allErrs = append(allErrs, field.Forbidden(leastTimePath, "is only supported for the least_time and random_two methods"))
+	case lb.LeastTime != "" && !isPlus:
+		allErrs = append(allErrs, field.Forbidden(leastTimePath, "is only supported in NGINX Plus"))
+	case lb.LeastTime != "" && !slices.Contains(transportServerLeastTimeValues, lb.LeastTime):
+		allErrs = append(allErrs, field.NotSupported(leastTimePath, lb.LeastTime, transportServerLeastTimeValues))
+	case protocol == "UDP" && (lb.LeastTime == "connect" || (lb.Method == "least_time" && lb.LeastTime == "")):
+		// No connection is established to the UDP upstream servers, so the connect time is meaningless.
+		allErrs = append(allErrs, field.Invalid(leastTimePath, lb.LeastTime, "must be first_byte or last_byte for UDP TransportServers"))
+	}
+
+	return allErrs
+}
+
 var loadBalancingVariables = map[string]bool{
 	"remote_addr": true,
 }
@@ -262,7 +519,7 @@ func validateHashLoadBalancingMethod(method string, fieldPath *field.Path, isPlu
 	return allErrs
 }
 
-func validateTSUpstreamHealthChecks(hc *conf_v1.TransportServerHealthCheck, fieldPath *field.Path) field.ErrorList {
+func validateTSUpstreamHealthChecks(hc *conf_v1.TransportServerHealthCheck, fieldPath *field.Path, protocol string) field.ErrorList {
 	if hc == nil {
 		return nil
 	}
@@ -277,20 +534,70 @@ func validateTSUpstreamHealthChecks(hc *conf_v1.TransportServerHealthCheck, fiel
 			allErrs = append(allErrs, field.Invalid(fieldPath.Child("port"), hc.Port, msg))
 		}
 	}
-	allErrs = append(allErrs, validateHealthCheckMatch(hc.Match, fieldPath.Child("match"))...)
+	if hc.Persistent && !hc.Mandatory {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("mandatory"), "must be true when `persistent` is true"))
+	}
+	allErrs = append(allErrs, validateHealthCheckMatch(hc.Match, fieldPath.Child("match"), protocol)...)
 	return allErrs
 }
 
-func validateHealthCheckMatch(match *conf_v1.TransportServerMatch, fieldPath *field.Path) field.ErrorList {
+var healthCheckProfiles = []string{"dns", "postgresql", "redis", "tls"}
+
+func validateHealthCheckMatch(match *conf_v1.TransportServerMatch, fieldPath *field.Path, protocol string) field.ErrorList {
 	if match == nil {
 		return nil
 	}
 
+	if match.Profile != "" {
+		return validateHealthCheckProfile(match, fieldPath, protocol)
+	}
+
 	allErrs := validateMatchExpect(match.Expect, fieldPath.Child("expect"))
-	allErrs = append(allErrs, validateMatchSend(match.Expect, fieldPath.Child("send"))...)
+	allErrs = append(allErrs, validateMatchSend(match.Send, fieldPath.Child("send"))...)
+
+	if match.SendHex != "" {
+		if match.Send != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("sendHex"), "cannot be used along with send"))
+		}
+		allErrs = append(allErrs, validateHexEncodedString(match.SendHex, fieldPath.Child("sendHex"))...)
+	}
+	if match.ExpectHex != "" {
+		if match.Expect != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("expectHex"), "cannot be used along with expect"))
+		}
+		allErrs = append(allErrs, validateHexEncodedString(match.ExpectHex, fieldPath.Child("expectHex"))...)
+	}
+	return allErrs
+}
+
+func validateHealthCheckProfile(match *conf_v1.TransportServerMatch, fieldPath *field.Path, protocol string) field.ErrorList {
+	if !slices.Contains(healthCheckProfiles, match.Profile) {
+		return field.ErrorList{field.NotSupported(fieldPath.Child("profile"), match.Profile, healthCheckProfiles)}
+	}
+
+	// Only the DNS profile probes UDP servers.
+	profileProtocol := "TCP"
+	if match.Profile == "dns" {
+		profileProtocol = "UDP"
+	}
+
+	allErrs := field.ErrorList{}
+	if protocol != profileProtocol {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("profile"), fmt.Sprintf("the %s profile is only supported for %s TransportServers", match.Profile, profileProtocol)))
+	}
+	if match.Send != "" || match.Expect != "" || match.SendHex != "" || match.ExpectHex != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("profile"), "cannot be used along with send, expect, sendHex and expectHex"))
+	}
 	return allErrs
 }
 
+func validateHexEncodedString(s string, fieldPath *field.Path) field.ErrorList {
+	if _, err := hex.DecodeString(s); err != nil {
+		return field.ErrorList{field.Invalid(fieldPath, s, fmt.Sprintf("must be a hex-encoded string: %v", err))}
+	}
+	return nil
+}
+
 func validateMatchExpect(expect string, fieldPath *field.Path) field.ErrorList {
 	if expect == "" {
 		return nil
@@ -366,6 +673,9 @@ func validateTransportServerUpstreamParameters(upstreamParameters *conf_v1.Upstr
 	allErrs = append(allErrs, validateTime(upstreamParameters.ConnectTimeout, fieldPath.Child("connectTimeout"))...)
 	allErrs = append(allErrs, validateTime(upstreamParameters.NextUpstreamTimeout, fieldPath.Child("nextUpstreamTimeout"))...)
 	allErrs = append(allErrs, validatePositiveIntOrZero(upstreamParameters.NextUpstreamTries, fieldPath.Child("nextUpstreamTries"))...)
+	allErrs = append(allErrs, validateSize(upstreamParameters.DownloadRate, fieldPath.Child("downloadRate"))...)
+	allErrs = append(allErrs, validateSize(upstreamParameters.UploadRate, fieldPath.Child("uploadRate"))...)
+	allErrs = append(allErrs, validatePositiveIntOrZeroFromPointer(upstreamParameters.MaxConnections, fieldPath.Child("maxConnections"))...)
 	return allErrs
 }
 
@@ -384,8 +694,91 @@ func validateUDPUpstreamParameter(parameter *int, fieldPath *field.Path, protoco
 }
 
 func validateTransportServerAction(action *conf_v1.TransportServerAction, fieldPath *field.Path, upstreamNames sets.Set[string]) field.ErrorList {
+	if len(action.Splits) > 0 {
+		if action.Pass != "" {
+			return field.ErrorList{field.Forbidden(fieldPath.Child("splits"), "cannot be used along with pass")}
+		}
+		return validateTransportServerSplits(action.Splits, fieldPath.Child("splits"), upstreamNames)
+	}
 	if action.Pass == "" {
-		return field.ErrorList{field.Required(fieldPath, "must specify pass")}
+		return field.ErrorList{field.Required(fieldPath, "must specify pass or splits")}
 	}
 	return validateReferencedUpstream(action.Pass, fieldPath.Child("pass"), upstreamNames)
 }
+
+func validateTransportServerSplits(splits []conf_v1.TransportServerSplit, fieldPath *field.Path, upstreamNames sets.Set[string]) field.ErrorList {
+	if len(splits) < 2 {
+		return field.ErrorList{field.Invalid(fieldPath, "", "must include at least 2 splits")}
+	}
+
+	allErrs := field.ErrorList{}
+	totalWeight := 0
+	for i, s := range splits {
+		idxPath := fieldPath.Index(i)
+
+		for _, msg := range validation.IsInRange(s.Weight, 0, 100) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("weight"), s.Weight, msg))
+		}
+
+		if s.Pass == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("pass"), "must specify pass"))
+		} else {
+			allErrs = append(allErrs, validateReferencedUpstream(s.Pass, idxPath.Child("pass"), upstreamNames)...)
+		}
+
+		totalWeight += s.Weight
+	}
+
+	if totalWeight != 100 {
+		allErrs = append(allErrs, field.Invalid(fieldPath, "", "the sum of the weights of all splits must be equal to 100"))
+	}
+
+	return allErrs
+}
+
+// validateTransportServerPassedUpstreams validates that the upstreams the connections are passed to share the settings
+// that NGINX applies to the whole server rather than to an upstream.
+func validateTransportServerPassedUpstreams(spec *conf_v1.TransportServerSpec, fieldPath *field.Path) field.ErrorList {
+	type passedUpstream struct {
+		name      string
+		fieldPath *field.Path
+	}
+
+	var passes []passedUpstream
+	if spec.Action != nil {
+		actionPath := fieldPath.Child("action")
+		if spec.Action.Pass != "" {
+			passes = append(passes, passedUpstream{spec.Action.Pass, actionPath.Child("pass")})
+		}
+		for i, s := range spec.Action.Splits {
+			passes = append(passes, passedUpstream{s.Pass, actionPath.Child("splits").Index(i).Child("pass")})
+		}
+	}
+	for i, r := range spec.Routes {
+		passes = append(passes, passedUpstream{r.Pass, fieldPath.Child("routes").Index(i).Child("pass")})
+	}
+
+	upstreamsByName := make(map[string]conf_v1.TransportServerUpstream)
+	for _, u := range spec.Upstreams {
+		upstreamsByName[u.Name] = u
+	}
+
+	allErrs := field.ErrorList{}
+	var first *conf_v1.TransportServerUpstream
+	for _, p := range passes {
+		u, exists := upstreamsByName[p.name]
+		if !exists {
+			continue
+		}
+		if first == nil {
+			first = &u
+			continue
+		}
+		if u.ProxyProtocol != first.ProxyProtocol || !reflect.DeepEqual(u.TLS, first.TLS) {
+			msg := fmt.Sprintf("must reference an upstream with the same tls and proxyProtocol settings as the upstream %s", first.Name)
+			allErrs = append(allErrs, field.Invalid(p.fieldPath, p.name, msg))
+		}
+	}
+
+	return allErrs
+}
@@ -131,6 +131,42 @@ func TestValidateTransportServer_FailsOnNotSupportedLBMethodForBackup(t *testing
 	}
 }
 
+func TestValidateTransportServer_Policies(t *testing.T) {
+	t.Parallel()
+
+	ts := makeTransportServer()
+	ts.Namespace = "default"
+	ts.Spec.Policies = []conf_v1.PolicyReference{
+		{Name: "allow-list"},
+		{Name: "connection-limit", Namespace: "policies"},
+	}
+
+	tsv := createTransportServerValidator()
+
+	err := tsv.ValidateTransportServer(&ts)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateTransportServer_FailsOnDuplicatePolicies(t *testing.T) {
+	t.Parallel()
+
+	ts := makeTransportServer()
+	ts.Namespace = "default"
+	ts.Spec.Policies = []conf_v1.PolicyReference{
+		{Name: "allow-list"},
+		{Name: "allow-list", Namespace: "default"},
+	}
+
+	tsv := createTransportServerValidator()
+
+	err := tsv.ValidateTransportServer(&ts)
+	if err == nil {
+		t.Error("want error on duplicate policy references")
+	}
+}
+
 func TestValidateTransportServer_FailsOnInvalidInput(t *testing.T) {
 	t.Parallel()
 	ts := conf_v1.TransportServer{
@@ -192,7 +228,7 @@ func TestValidateTransportServerUpstreams(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		allErrs, resultUpstreamNames := validateTransportServerUpstreams(test.upstreams, field.NewPath("upstreams"), true)
+		allErrs, resultUpstreamNames := validateTransportServerUpstreams(test.upstreams, field.NewPath("upstreams"), "TCP", true)
 		if len(allErrs) > 0 {
 			t.Fatalf("validateTransportServerUpstreams() returned errors %v for valid input for the case of %s", allErrs, test.msg)
 		}
@@ -264,10 +300,41 @@ func TestValidateTransportServerUpstreams_FailsOnInvalidInput(t *testing.T) {
 			},
 			msg: "duplicated upstreams",
 		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{
+					Name:                "upstream1",
+					Service:             "test-1",
+					Port:                80,
+					LoadBalancingMethod: "least_conn",
+					LoadBalancing:       &conf_v1.TransportServerLoadBalancing{Method: "hash"},
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": {},
+			},
+			msg: "both loadBalancingMethod and loadBalancing",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{
+					Name:          "upstream1",
+					Service:       "test-1",
+					Port:          80,
+					LoadBalancing: &conf_v1.TransportServerLoadBalancing{Method: "hash"},
+					Backup:        "backup-svc",
+					BackupPort:    createPointerFromUInt16(5505),
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": {},
+			},
+			msg: "backup with the hash load balancing",
+		},
 	}
 
 	for _, test := range tests {
-		allErrs, resultUpstreamNames := validateTransportServerUpstreams(test.upstreams, field.NewPath("upstreams"), true)
+		allErrs, resultUpstreamNames := validateTransportServerUpstreams(test.upstreams, field.NewPath("upstreams"), "TCP", true)
 		if len(allErrs) == 0 {
 			t.Fatalf("validateTransportServerUpstreams() returned no errors for the case of %s", test.msg)
 		}
@@ -277,6 +344,134 @@ func TestValidateTransportServerUpstreams_FailsOnInvalidInput(t *testing.T) {
 	}
 }
 
+func TestValidateTransportServerUpstreamsConnections(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		upstreams                []conf_v1.TransportServerUpstream
+		protocol                 string
+		isTLSPassthroughListener bool
+		msg                      string
+	}{
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "upstream1", Service: "test-1", Port: 80},
+			},
+			protocol: "UDP",
+			msg:      "no tls and no proxy protocol",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "upstream1", Service: "test-1", Port: 80, ProxyProtocol: true},
+			},
+			protocol: "TCP",
+			msg:      "proxy protocol for TCP",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "upstream1", Service: "test-1", Port: 80, ProxyProtocol: true},
+			},
+			protocol:                 "TLS_PASSTHROUGH",
+			isTLSPassthroughListener: true,
+			msg:                      "proxy protocol for TLS Passthrough",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{
+					Name:    "upstream1",
+					Service: "test-1",
+					Port:    443,
+					TLS: &conf_v1.TransportServerUpstreamTLS{
+						Enable:            true,
+						TLSSecret:         "client-secret",
+						VerifyServer:      true,
+						VerifyDepth:       createPointerFromInt(2),
+						TrustedCertSecret: "ca-secret",
+						ServerName:        true,
+						SSLName:           "backend.example.com",
+					},
+				},
+			},
+			protocol: "TCP",
+			msg:      "tls for TCP",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerUpstreamsConnections(test.upstreams, field.NewPath("upstreams"), test.protocol, test.isTLSPassthroughListener)
+		if len(allErrs) > 0 {
+			t.Errorf("validateTransportServerUpstreamsConnections() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateTransportServerUpstreamsConnections_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		upstreams                []conf_v1.TransportServerUpstream
+		protocol                 string
+		isTLSPassthroughListener bool
+		msg                      string
+	}{
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "upstream1", Service: "test-1", Port: 80, ProxyProtocol: true},
+			},
+			protocol: "UDP",
+			msg:      "proxy protocol for UDP",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "upstream1", Service: "test-1", Port: 80, TLS: &conf_v1.TransportServerUpstreamTLS{Enable: true}},
+			},
+			protocol: "UDP",
+			msg:      "tls for UDP",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "upstream1", Service: "test-1", Port: 80, TLS: &conf_v1.TransportServerUpstreamTLS{Enable: true}},
+			},
+			protocol:                 "TLS_PASSTHROUGH",
+			isTLSPassthroughListener: true,
+			msg:                      "tls for TLS Passthrough",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "upstream1", Service: "test-1", Port: 80, TLS: &conf_v1.TransportServerUpstreamTLS{Enable: true, VerifyServer: true}},
+			},
+			protocol: "TCP",
+			msg:      "verifyServer without trustedCertSecret",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "upstream1", Service: "test-1", Port: 80, TLS: &conf_v1.TransportServerUpstreamTLS{Enable: true, TLSSecret: "-invalid-"}},
+			},
+			protocol: "TCP",
+			msg:      "invalid tlsSecret",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "upstream1", Service: "test-1", Port: 80, TLS: &conf_v1.TransportServerUpstreamTLS{Enable: true, VerifyDepth: createPointerFromInt(-1)}},
+			},
+			protocol: "TCP",
+			msg:      "negative verifyDepth",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "upstream1", Service: "test-1", Port: 80, TLS: &conf_v1.TransportServerUpstreamTLS{Enable: true, SSLName: "_backend"}},
+			},
+			protocol: "TCP",
+			msg:      "invalid sslName",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerUpstreamsConnections(test.upstreams, field.NewPath("upstreams"), test.protocol, test.isTLSPassthroughListener)
+		if len(allErrs) == 0 {
+			t.Errorf("validateTransportServerUpstreamsConnections() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateTransportServerHost(t *testing.T) {
 	t.Parallel()
 	validCases := []struct {
@@ -484,6 +679,163 @@ func TestValidateTransportServerLoadBalancingMethod(t *testing.T) {
 	}
 }
 
+func TestValidateTransportServerLoadBalancing_PassesOnValidInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		lb       *conf_v1.TransportServerLoadBalancing
+		protocol string
+		isPlus   bool
+		msg      string
+	}{
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "round_robin"},
+			protocol: "TCP",
+			msg:      "round robin",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "hash"},
+			protocol: "UDP",
+			msg:      "hash with the default key",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "hash", Key: "${remote_addr}", Consistent: true},
+			protocol: "UDP",
+			msg:      "consistent hash on the client address",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "hash", Key: "shard-${server_port}"},
+			protocol: "TCP",
+			msg:      "hash on a custom key",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "random_two"},
+			protocol: "TCP",
+			msg:      "random two least_conn",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "random_two", LeastTime: "first_byte"},
+			protocol: "TCP",
+			isPlus:   true,
+			msg:      "random two least_time",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "least_time", LeastTime: "last_byte", Inflight: true},
+			protocol: "TCP",
+			isPlus:   true,
+			msg:      "least time with inflight",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "least_time"},
+			protocol: "TCP",
+			isPlus:   true,
+			msg:      "least time with the default time",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "least_time", LeastTime: "first_byte"},
+			protocol: "UDP",
+			isPlus:   true,
+			msg:      "least time for UDP",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerLoadBalancing(test.lb, field.NewPath("loadBalancing"), test.protocol, test.isPlus)
+		if len(allErrs) > 0 {
+			t.Errorf("validateTransportServerLoadBalancing() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateTransportServerLoadBalancing_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		lb       *conf_v1.TransportServerLoadBalancing
+		protocol string
+		isPlus   bool
+		msg      string
+	}{
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{},
+			protocol: "TCP",
+			msg:      "missing method",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "ip_hash"},
+			protocol: "TCP",
+			msg:      "unsupported method",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "least_time"},
+			protocol: "TCP",
+			msg:      "least time in OSS",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "hash", Key: "${request_uri}"},
+			protocol: "TCP",
+			msg:      "hash key with an unsupported variable",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "hash", Key: `${remote_addr}"`},
+			protocol: "TCP",
+			msg:      "hash key with an unescaped quote",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "least_conn", Key: "${remote_addr}"},
+			protocol: "TCP",
+			msg:      "key for a method other than hash",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "random", Consistent: true},
+			protocol: "TCP",
+			msg:      "consistent for a method other than hash",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "random_two", LeastTime: "connect"},
+			protocol: "TCP",
+			msg:      "least time for random two in OSS",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "random_two", LeastTime: "header"},
+			protocol: "TCP",
+			isPlus:   true,
+			msg:      "unsupported least time",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "least_conn", LeastTime: "connect"},
+			protocol: "TCP",
+			isPlus:   true,
+			msg:      "least time for least_conn",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "random_two", Inflight: true},
+			protocol: "TCP",
+			isPlus:   true,
+			msg:      "inflight for random two",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "least_time"},
+			protocol: "UDP",
+			isPlus:   true,
+			msg:      "least time with the default connect time for UDP",
+		},
+		{
+			lb:       &conf_v1.TransportServerLoadBalancing{Method: "random_two", LeastTime: "connect"},
+			protocol: "UDP",
+			isPlus:   true,
+			msg:      "random two with the connect time for UDP",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerLoadBalancing(test.lb, field.NewPath("loadBalancing"), test.protocol, test.isPlus)
+		if len(allErrs) == 0 {
+			t.Errorf("validateTransportServerLoadBalancing() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateTransportServerSnippet(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -736,9 +1088,36 @@ func TestValidateTSUpstreamHealthChecks(t *testing.T) {
 			},
 			msg: "valid Health check",
 		},
+		{
+			healthCheck: &conf_v1.TransportServerHealthCheck{
+				Enabled:    true,
+				Mandatory:  true,
+				Persistent: true,
+			},
+			msg: "mandatory and persistent health check",
+		},
+		{
+			healthCheck: &conf_v1.TransportServerHealthCheck{
+				Enabled: true,
+				Match: &conf_v1.TransportServerMatch{
+					Profile: "redis",
+				},
+			},
+			msg: "health check with profile",
+		},
+		{
+			healthCheck: &conf_v1.TransportServerHealthCheck{
+				Enabled: true,
+				Match: &conf_v1.TransportServerMatch{
+					SendHex:   "0000000804d2162f",
+					ExpectHex: "4e",
+				},
+			},
+			msg: "health check with hex-encoded data",
+		},
 	}
 	for _, test := range tests {
-		allErrs := validateTSUpstreamHealthChecks(test.healthCheck, field.NewPath("healthCheck"))
+		allErrs := validateTSUpstreamHealthChecks(test.healthCheck, field.NewPath("healthCheck"), "TCP")
 		if len(allErrs) > 0 {
 			t.Errorf("validateTSUpstreamHealthChecks() returned errors %v  for valid input for the case of %s", allErrs, test.msg)
 		}
@@ -823,10 +1202,26 @@ func TestValidateTSUpstreamHealthChecks_FailsOnInvalidInput(t *testing.T) {
 			},
 			msg: "invalid jitter value",
 		},
+		{
+			healthCheck: &conf_v1.TransportServerHealthCheck{
+				Enabled:    true,
+				Persistent: true,
+			},
+			msg: "persistent without mandatory",
+		},
+		{
+			healthCheck: &conf_v1.TransportServerHealthCheck{
+				Enabled: true,
+				Match: &conf_v1.TransportServerMatch{
+					Send: "\\x1",
+				},
+			},
+			msg: "invalid hex literal in send",
+		},
 	}
 
 	for _, test := range tests {
-		allErrs := validateTSUpstreamHealthChecks(test.healthCheck, field.NewPath("healthCheck"))
+		allErrs := validateTSUpstreamHealthChecks(test.healthCheck, field.NewPath("healthCheck"), "TCP")
 		if len(allErrs) == 0 {
 			t.Errorf("validateTSUpstreamHealthChecks() returned no error for invalid input %v", test.msg)
 		}
@@ -847,6 +1242,22 @@ func TestValidateUpstreamParameters(t *testing.T) {
 			parameters: &conf_v1.UpstreamParameters{},
 			msg:        "Non-nil parameters",
 		},
+		{
+			parameters: &conf_v1.UpstreamParameters{
+				DownloadRate:   "1m",
+				UploadRate:     "512k",
+				MaxConnections: createPointerFromInt(100),
+			},
+			msg: "rate and connection limits",
+		},
+		{
+			parameters: &conf_v1.UpstreamParameters{
+				DownloadRate:   "0",
+				UploadRate:     "0",
+				MaxConnections: createPointerFromInt(0),
+			},
+			msg: "disabled rate and connection limits",
+		},
 	}
 
 	for _, test := range tests {
@@ -857,6 +1268,40 @@ func TestValidateUpstreamParameters(t *testing.T) {
 	}
 }
 
+func TestValidateUpstreamParameters_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		parameters *conf_v1.UpstreamParameters
+		msg        string
+	}{
+		{
+			parameters: &conf_v1.UpstreamParameters{
+				DownloadRate: "1mb",
+			},
+			msg: "invalid download rate",
+		},
+		{
+			parameters: &conf_v1.UpstreamParameters{
+				UploadRate: "-1",
+			},
+			msg: "invalid upload rate",
+		},
+		{
+			parameters: &conf_v1.UpstreamParameters{
+				MaxConnections: createPointerFromInt(-1),
+			},
+			msg: "negative max connections",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerUpstreamParameters(test.parameters, field.NewPath("upstreamParameters"), "TCP")
+		if len(allErrs) == 0 {
+			t.Errorf("validateTransportServerUpstreamParameters() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateSessionParameters(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -981,12 +1426,35 @@ func TestValidateTransportServerAction(t *testing.T) {
 	}
 }
 
-func TestValidateTransportServerAction_FailsOnInvalidInput(t *testing.T) {
+func TestValidateTransportServerAction_PassesOnValidSplits(t *testing.T) {
 	t.Parallel()
-	upstreamNames := map[string]sets.Empty{}
+	upstreamNames := map[string]sets.Empty{
+		"test-v1": {},
+		"test-v2": {},
+	}
 
-	tests := []struct {
-		action *conf_v1.TransportServerAction
+	action := &conf_v1.TransportServerAction{
+		Splits: []conf_v1.TransportServerSplit{
+			{Weight: 90, Pass: "test-v1"},
+			{Weight: 10, Pass: "test-v2"},
+		},
+	}
+
+	allErrs := validateTransportServerAction(action, field.NewPath("action"), upstreamNames)
+	if len(allErrs) > 0 {
+		t.Errorf("validateTransportServerAction() returned errors %v for valid input", allErrs)
+	}
+}
+
+func TestValidateTransportServerAction_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	upstreamNames := map[string]sets.Empty{
+		"test-v1": {},
+		"test-v2": {},
+	}
+
+	tests := []struct {
+		action *conf_v1.TransportServerAction
 		msg    string
 	}{
 		{
@@ -1001,6 +1469,60 @@ func TestValidateTransportServerAction_FailsOnInvalidInput(t *testing.T) {
 			},
 			msg: "pass references a non-existing upstream",
 		},
+		{
+			action: &conf_v1.TransportServerAction{
+				Pass: "test-v1",
+				Splits: []conf_v1.TransportServerSplit{
+					{Weight: 90, Pass: "test-v1"},
+					{Weight: 10, Pass: "test-v2"},
+				},
+			},
+			msg: "both pass and splits",
+		},
+		{
+			action: &conf_v1.TransportServerAction{
+				Splits: []conf_v1.TransportServerSplit{
+					{Weight: 100, Pass: "test-v1"},
+				},
+			},
+			msg: "single split",
+		},
+		{
+			action: &conf_v1.TransportServerAction{
+				Splits: []conf_v1.TransportServerSplit{
+					{Weight: 90, Pass: "test-v1"},
+					{Weight: 20, Pass: "test-v2"},
+				},
+			},
+			msg: "weights do not sum up to 100",
+		},
+		{
+			action: &conf_v1.TransportServerAction{
+				Splits: []conf_v1.TransportServerSplit{
+					{Weight: 101, Pass: "test-v1"},
+					{Weight: -1, Pass: "test-v2"},
+				},
+			},
+			msg: "weights out of range",
+		},
+		{
+			action: &conf_v1.TransportServerAction{
+				Splits: []conf_v1.TransportServerSplit{
+					{Weight: 90, Pass: "test-v1"},
+					{Weight: 10},
+				},
+			},
+			msg: "split without pass",
+		},
+		{
+			action: &conf_v1.TransportServerAction{
+				Splits: []conf_v1.TransportServerSplit{
+					{Weight: 90, Pass: "test-v1"},
+					{Weight: 10, Pass: "non-existing"},
+				},
+			},
+			msg: "split references a non-existing upstream",
+		},
 	}
 
 	for _, test := range tests {
@@ -1011,6 +1533,447 @@ func TestValidateTransportServerAction_FailsOnInvalidInput(t *testing.T) {
 	}
 }
 
+func TestValidateTransportServerPassedUpstreams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		upstreams []conf_v1.TransportServerUpstream
+		expectErr bool
+		msg       string
+	}{
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "test-v1", ProxyProtocol: true, TLS: &conf_v1.TransportServerUpstreamTLS{Enable: true}},
+				{Name: "test-v2", ProxyProtocol: true, TLS: &conf_v1.TransportServerUpstreamTLS{Enable: true}},
+				{Name: "test-h2", ProxyProtocol: true, TLS: &conf_v1.TransportServerUpstreamTLS{Enable: true}},
+			},
+			expectErr: false,
+			msg:       "same settings",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "test-v1", ProxyProtocol: true},
+				{Name: "test-v2"},
+				{Name: "test-h2", ProxyProtocol: true},
+			},
+			expectErr: true,
+			msg:       "different proxy protocol of a split",
+		},
+		{
+			upstreams: []conf_v1.TransportServerUpstream{
+				{Name: "test-v1"},
+				{Name: "test-v2"},
+				{Name: "test-h2", TLS: &conf_v1.TransportServerUpstreamTLS{Enable: true}},
+			},
+			expectErr: true,
+			msg:       "different tls of a route",
+		},
+	}
+
+	for _, test := range tests {
+		spec := &conf_v1.TransportServerSpec{
+			Upstreams: test.upstreams,
+			Action: &conf_v1.TransportServerAction{
+				Splits: []conf_v1.TransportServerSplit{
+					{Weight: 90, Pass: "test-v1"},
+					{Weight: 10, Pass: "test-v2"},
+				},
+			},
+			Routes: []conf_v1.TransportServerRoute{
+				{ALPN: "h2", Pass: "test-h2"},
+			},
+		}
+		allErrs := validateTransportServerPassedUpstreams(spec, field.NewPath("spec"))
+		if test.expectErr && len(allErrs) == 0 {
+			t.Errorf("validateTransportServerPassedUpstreams() returned no errors for the case of %s", test.msg)
+		}
+		if !test.expectErr && len(allErrs) > 0 {
+			t.Errorf("validateTransportServerPassedUpstreams() returned errors %v for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateTransportServerRoutes(t *testing.T) {
+	t.Parallel()
+	upstreamNames := map[string]sets.Empty{
+		"default": {},
+		"db":      {},
+		"grpc":    {},
+	}
+
+	tests := []struct {
+		spec *conf_v1.TransportServerSpec
+		msg  string
+	}{
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "udp-listener", Protocol: "UDP"},
+			},
+			msg: "no routes",
+		},
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "tcp-listener", Protocol: "TCP"},
+				TLS:      &conf_v1.TransportServerTLS{Secret: "tls-secret"},
+				Routes: []conf_v1.TransportServerRoute{
+					{ServerName: "db.example.com", Pass: "db"},
+					{ServerName: "api.example.com", ALPN: "h2", Pass: "grpc"},
+					{ALPN: "http/1.1", Pass: "default"},
+				},
+			},
+			msg: "server name and alpn routes",
+		},
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "tcp-listener", Protocol: "TCP"},
+				Host:     "api.example.com",
+				TLS:      &conf_v1.TransportServerTLS{Secret: "tls-secret"},
+				Routes: []conf_v1.TransportServerRoute{
+					{ALPN: "h2", Pass: "grpc"},
+				},
+			},
+			msg: "alpn routes with host",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerRoutes(test.spec, field.NewPath("routes"), false, upstreamNames)
+		if len(allErrs) > 0 {
+			t.Errorf("validateTransportServerRoutes() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateTransportServerRoutes_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	upstreamNames := map[string]sets.Empty{
+		"db": {},
+	}
+
+	tests := []struct {
+		spec                     *conf_v1.TransportServerSpec
+		isTLSPassthroughListener bool
+		msg                      string
+	}{
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "udp-listener", Protocol: "UDP"},
+				Routes:   []conf_v1.TransportServerRoute{{ServerName: "db.example.com", Pass: "db"}},
+			},
+			msg: "UDP listener",
+		},
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "tls-passthrough", Protocol: "TLS_PASSTHROUGH"},
+				Host:     "example.com",
+				Routes:   []conf_v1.TransportServerRoute{{ServerName: "db.example.com", Pass: "db"}},
+			},
+			isTLSPassthroughListener: true,
+			msg:                      "TLS Passthrough listener",
+		},
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "tcp-listener", Protocol: "TCP"},
+				Routes:   []conf_v1.TransportServerRoute{{ServerName: "db.example.com", Pass: "db"}},
+			},
+			msg: "no TLS termination",
+		},
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "tcp-listener", Protocol: "TCP"},
+				TLS:      &conf_v1.TransportServerTLS{Secret: "tls-secret"},
+				Routes:   []conf_v1.TransportServerRoute{{Pass: "db"}},
+			},
+			msg: "no server name and alpn",
+		},
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "tcp-listener", Protocol: "TCP"},
+				Host:     "example.com",
+				TLS:      &conf_v1.TransportServerTLS{Secret: "tls-secret"},
+				Routes:   []conf_v1.TransportServerRoute{{ServerName: "db.example.com", Pass: "db"}},
+			},
+			msg: "server name with host",
+		},
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "tcp-listener", Protocol: "TCP"},
+				TLS:      &conf_v1.TransportServerTLS{Secret: "tls-secret"},
+				Routes:   []conf_v1.TransportServerRoute{{ServerName: "_db", Pass: "db"}},
+			},
+			msg: "invalid server name",
+		},
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "tcp-listener", Protocol: "TCP"},
+				TLS:      &conf_v1.TransportServerTLS{Secret: "tls-secret"},
+				Routes:   []conf_v1.TransportServerRoute{{ALPN: "h2 h3", Pass: "db"}},
+			},
+			msg: "invalid alpn",
+		},
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "tcp-listener", Protocol: "TCP"},
+				TLS:      &conf_v1.TransportServerTLS{Secret: "tls-secret"},
+				Routes: []conf_v1.TransportServerRoute{
+					{ServerName: "db.example.com", Pass: "db"},
+					{ServerName: "db.example.com", Pass: "db"},
+				},
+			},
+			msg: "duplicate routes",
+		},
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "tcp-listener", Protocol: "TCP"},
+				TLS:      &conf_v1.TransportServerTLS{Secret: "tls-secret"},
+				Routes:   []conf_v1.TransportServerRoute{{ServerName: "db.example.com"}},
+			},
+			msg: "missing pass",
+		},
+		{
+			spec: &conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{Name: "tcp-listener", Protocol: "TCP"},
+				TLS:      &conf_v1.TransportServerTLS{Secret: "tls-secret"},
+				Routes:   []conf_v1.TransportServerRoute{{ServerName: "db.example.com", Pass: "non-existing"}},
+			},
+			msg: "pass references a non-existing upstream",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerRoutes(test.spec, field.NewPath("routes"), test.isTLSPassthroughListener, upstreamNames)
+		if len(allErrs) == 0 {
+			t.Errorf("validateTransportServerRoutes() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateTransportServerTLSCertificates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tls           *conf_v1.TransportServerTLS
+		hostSpecified bool
+		expectErr     bool
+		msg           string
+	}{
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret: "default-secret",
+				Certificates: []conf_v1.TransportServerTLSCertificate{
+					{ServerName: "db.example.com", Secret: "db-secret"},
+					{ServerName: "mq.example.com", Secret: "mq-secret"},
+				},
+			},
+			expectErr: false,
+			msg:       "valid certificates",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:       "default-secret",
+				Certificates: []conf_v1.TransportServerTLSCertificate{{ServerName: "db.example.com", Secret: "db-secret"}},
+			},
+			hostSpecified: true,
+			expectErr:     true,
+			msg:           "certificates with host",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Certificates: []conf_v1.TransportServerTLSCertificate{{ServerName: "db.example.com", Secret: "db-secret"}},
+			},
+			expectErr: true,
+			msg:       "certificates without the default secret",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret: "default-secret",
+				Certificates: []conf_v1.TransportServerTLSCertificate{
+					{ServerName: "db.example.com", Secret: "db-secret"},
+					{ServerName: "db.example.com", Secret: "other-secret"},
+				},
+			},
+			expectErr: true,
+			msg:       "duplicate server names",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:       "default-secret",
+				Certificates: []conf_v1.TransportServerTLSCertificate{{ServerName: "db.example.com"}},
+			},
+			expectErr: true,
+			msg:       "missing secret",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:       "default-secret",
+				Certificates: []conf_v1.TransportServerTLSCertificate{{Secret: "db-secret"}},
+			},
+			expectErr: true,
+			msg:       "missing server name",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTransportServerTLSCertificates(test.tls, field.NewPath("tls"), test.hostSpecified)
+		if test.expectErr && len(allErrs) == 0 {
+			t.Errorf("validateTransportServerTLSCertificates() returned no errors for the case of %s", test.msg)
+		}
+		if !test.expectErr && len(allErrs) > 0 {
+			t.Errorf("validateTransportServerTLSCertificates() returned errors %v for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateHealthCheckMatch(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		match    *conf_v1.TransportServerMatch
+		protocol string
+		msg      string
+	}{
+		{
+			match:    nil,
+			protocol: "TCP",
+			msg:      "nil match",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Send:   `PING\r\n`,
+				Expect: "+PONG",
+			},
+			protocol: "TCP",
+			msg:      "send and expect",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				SendHex: "0000000804d2162f",
+				Expect:  "~^[SN]",
+			},
+			protocol: "TCP",
+			msg:      "sendHex and expect",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Send:      `PING\r\n`,
+				ExpectHex: "2b504f4e47",
+			},
+			protocol: "TCP",
+			msg:      "send and expectHex",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Profile: "dns",
+			},
+			protocol: "UDP",
+			msg:      "dns profile",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Profile: "tls",
+			},
+			protocol: "TCP",
+			msg:      "tls profile",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Profile: "postgresql",
+			},
+			protocol: "TCP",
+			msg:      "postgresql profile",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateHealthCheckMatch(test.match, field.NewPath("match"), test.protocol)
+		if len(allErrs) > 0 {
+			t.Errorf("validateHealthCheckMatch() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateHealthCheckMatch_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		match    *conf_v1.TransportServerMatch
+		protocol string
+		msg      string
+	}{
+		{
+			match: &conf_v1.TransportServerMatch{
+				Send:    "abc",
+				SendHex: "616263",
+			},
+			protocol: "TCP",
+			msg:      "send along with sendHex",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Expect:    "abc",
+				ExpectHex: "616263",
+			},
+			protocol: "TCP",
+			msg:      "expect along with expectHex",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				SendHex: "0x01",
+			},
+			protocol: "TCP",
+			msg:      "invalid sendHex",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				ExpectHex: "abc",
+			},
+			protocol: "TCP",
+			msg:      "odd length expectHex",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Send: `\xzz`,
+			},
+			protocol: "TCP",
+			msg:      "invalid hex literal in send",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Profile: "mysql",
+			},
+			protocol: "TCP",
+			msg:      "unsupported profile",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Profile: "dns",
+			},
+			protocol: "TCP",
+			msg:      "dns profile for TCP",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Profile: "redis",
+			},
+			protocol: "UDP",
+			msg:      "redis profile for UDP",
+		},
+		{
+			match: &conf_v1.TransportServerMatch{
+				Profile: "redis",
+				Expect:  "+PONG",
+			},
+			protocol: "TCP",
+			msg:      "profile along with expect",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateHealthCheckMatch(test.match, field.NewPath("match"), test.protocol)
+		if len(allErrs) == 0 {
+			t.Errorf("validateHealthCheckMatch() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateMatchSend(t *testing.T) {
 	t.Parallel()
 	validInput := []string{
@@ -1151,6 +2114,25 @@ func TestValidateTsTLS(t *testing.T) {
 			isTLSPassthrough: false,
 			hostSpecified:    false,
 		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:           "my-secret",
+				ClientCertSecret: "my-ca-secret",
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    true,
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:           "my-secret",
+				ClientCertSecret: "my-ca-secret",
+				CrlFileName:      "default-my-ca-secret-ca.crl",
+				VerifyClient:     "optional",
+				VerifyDepth:      createPointerFromInt(2),
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    false,
+		},
 	}
 
 	for _, tc := range validTestCases {
@@ -1196,6 +2178,39 @@ func TestValidateTsTLS_FailsOnInvalidInput(t *testing.T) {
 			isTLSPassthrough: false,
 			hostSpecified:    true,
 		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				ClientCertSecret: "my-ca-secret",
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    false,
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:           "my-secret",
+				ClientCertSecret: "my-ca-secret",
+				VerifyClient:     "invalid",
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    true,
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:           "my-secret",
+				ClientCertSecret: "my-ca-secret",
+				VerifyDepth:      createPointerFromInt(-1),
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    true,
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:       "my-secret",
+				VerifyClient: "on",
+			},
+			isTLSPassthrough: false,
+			hostSpecified:    true,
+		},
 	}
 
 	for _, test := range invalidTLSes {
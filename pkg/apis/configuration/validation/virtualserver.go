@@ -3,6 +3,7 @@ package validation
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -122,6 +123,12 @@ func (vsv *VirtualServerValidator) validateVirtualServerSpec(spec *v1.VirtualSer
 
 	allErrs = append(allErrs, vsv.validateExternalDNS(&spec.ExternalDNS, fieldPath.Child("externalDNS"))...)
 
+	allErrs = append(allErrs, vsv.validateMaintenance(spec.Maintenance, fieldPath.Child("maintenance"))...)
+	allErrs = append(allErrs, validateRedirectMap(spec.RedirectMap, fieldPath.Child("redirectMap"))...)
+	allErrs = append(allErrs, validateRequestID(spec.RequestID, fieldPath.Child("requestID"))...)
+	allErrs = append(allErrs, validateAccessLog(spec.AccessLog, fieldPath.Child("accessLog"))...)
+	allErrs = append(allErrs, vsv.validateTracing(spec.Tracing, fieldPath.Child("tracing"))...)
+
 	return allErrs
 }
 
@@ -240,6 +247,194 @@ func (vsv *VirtualServerValidator) validateExternalDNS(ed *v1.ExternalDNS, field
 	return nil
 }
 
+func (vsv *VirtualServerValidator) validateMaintenance(m *v1.Maintenance, fieldPath *field.Path) field.ErrorList {
+	if m == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	if m.Code != 0 {
+		allErrs = append(allErrs, validateActionReturnCode(m.Code, fieldPath.Child("code"))...)
+	}
+	if m.Type != "" {
+		allErrs = append(allErrs, validateActionReturnType(m.Type, fieldPath.Child("type"))...)
+	}
+
+	if m.Body != "" && m.ConfigMap != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("configMap"), "cannot be used together with body"))
+	}
+	if m.Body != "" {
+		allErrs = append(allErrs, validateEscapedStringWithVariables(m.Body, fieldPath.Child("body"), returnBodySpecialVariables, returnBodyVariables, vsv.isPlus)...)
+	}
+	if m.ConfigMap != nil {
+		cmPath := fieldPath.Child("configMap")
+		if m.ConfigMap.Name == "" {
+			allErrs = append(allErrs, field.Required(cmPath.Child("name"), ""))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(m.ConfigMap.Name) {
+				allErrs = append(allErrs, field.Invalid(cmPath.Child("name"), m.ConfigMap.Name, msg))
+			}
+		}
+		if m.ConfigMap.Key != "" {
+			for _, msg := range validation.IsConfigMapKey(m.ConfigMap.Key) {
+				allErrs = append(allErrs, field.Invalid(cmPath.Child("key"), m.ConfigMap.Key, msg))
+			}
+		}
+	}
+
+	for i, ipOrCIDR := range m.Allow {
+		allErrs = append(allErrs, validateIPorCIDR(ipOrCIDR, fieldPath.Child("allow").Index(i))...)
+	}
+
+	if m.BypassHeader != nil {
+		allErrs = append(allErrs, validateHeader(*m.BypassHeader, fieldPath.Child("bypassHeader"))...)
+		if m.BypassHeader.Value == "" {
+			allErrs = append(allErrs, field.Required(fieldPath.Child("bypassHeader").Child("value"), ""))
+		}
+	}
+
+	return allErrs
+}
+
+func validateRedirectMap(rm *v1.RedirectMap, fieldPath *field.Path) field.ErrorList {
+	if rm == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	if rm.ConfigMap == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("configMap"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(rm.ConfigMap) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("configMap"), rm.ConfigMap, msg))
+		}
+	}
+	if rm.Key != "" {
+		for _, msg := range validation.IsConfigMapKey(rm.Key) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("key"), rm.Key, msg))
+		}
+	}
+	if rm.Code != 0 {
+		allErrs = append(allErrs, validateRedirectStatusCode(rm.Code, fieldPath.Child("code"))...)
+	}
+
+	return allErrs
+}
+
+func validateRequestID(rid *v1.RequestID, fieldPath *field.Path) field.ErrorList {
+	if rid == nil || rid.Header == "" {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsHTTPHeaderName(rid.Header) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("header"), rid.Header, msg))
+	}
+
+	return allErrs
+}
+
+var accessLogConditions = []string{v1.AccessLogCondition4xx, v1.AccessLogCondition5xx, v1.AccessLogConditionErrors}
+
+func validateAccessLog(al *v1.AccessLog, fieldPath *field.Path) field.ErrorList {
+	if al == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	if al.Format != "" {
+		for _, msg := range isLogFormatName(al.Format) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("format"), al.Format, msg))
+		}
+	}
+	if al.Destination == "stderr" {
+		// unlike the error_log directive, the access_log directive treats stderr as a file name
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("destination"), al.Destination, "stderr is not supported for access logs, use /dev/stderr"))
+	} else if al.Destination != "" {
+		if err := ValidateAppProtectLogDestination(al.Destination); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("destination"), al.Destination, err.Error()))
+		}
+	}
+	if al.Sampling != 0 && (al.Sampling < 1 || al.Sampling > 100) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("sampling"), al.Sampling, validation.InclusiveRangeError(1, 100)))
+	}
+	if al.Condition != "" && !slices.Contains(accessLogConditions, al.Condition) {
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("condition"), al.Condition, accessLogConditions))
+	}
+
+	return allErrs
+}
+
+const (
+	logFormatNameFmt    string = "[-_A-Za-z0-9]+"
+	logFormatNameErrMsg string = "a valid log format name must consist of alphanumeric characters, '-' or '_'"
+)
+
+var logFormatNameRegexp = regexp.MustCompile("^" + logFormatNameFmt + "$")
+
+func isLogFormatName(value string) []string {
+	if !logFormatNameRegexp.MatchString(value) {
+		return []string{validation.RegexError(logFormatNameErrMsg, logFormatNameFmt, "main")}
+	}
+	return nil
+}
+
+func (vsv *VirtualServerValidator) validateTracing(tracing *v1.Tracing, fieldPath *field.Path) field.ErrorList {
+	if tracing == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	if tracing.Sampling != 0 && (tracing.Sampling < 1 || tracing.Sampling > 100) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("sampling"), tracing.Sampling, validation.InclusiveRangeError(1, 100)))
+	}
+	if tracing.SpanName != "" {
+		allErrs = append(allErrs, validateEscapedStringWithVariables(tracing.SpanName, fieldPath.Child("spanName"),
+			actionProxyHeaderSpecialVariables, actionProxyHeaderVariables, vsv.isPlus)...)
+	}
+
+	attributeNames := sets.Set[string]{}
+	for i, attr := range tracing.SpanAttributes {
+		idxPath := fieldPath.Child("spanAttributes").Index(i)
+		if attr.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else if attributeNames.Has(attr.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), attr.Name))
+		} else {
+			for _, msg := range isSpanAttributeName(attr.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), attr.Name, msg))
+			}
+			attributeNames.Insert(attr.Name)
+		}
+		if attr.Value == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("value"), ""))
+		} else {
+			allErrs = append(allErrs, validateEscapedStringWithVariables(attr.Value, idxPath.Child("value"),
+				actionProxyHeaderSpecialVariables, actionProxyHeaderVariables, vsv.isPlus)...)
+		}
+	}
+
+	return allErrs
+}
+
+const (
+	spanAttributeNameFmt    string = "[-._A-Za-z0-9]+"
+	spanAttributeNameErrMsg string = "a valid span attribute name must consist of alphanumeric characters, '-', '_' or '.'"
+)
+
+var spanAttributeNameRegexp = regexp.MustCompile("^" + spanAttributeNameFmt + "$")
+
+func isSpanAttributeName(value string) []string {
+	if !spanAttributeNameRegexp.MatchString(value) {
+		return []string{validation.RegexError(spanAttributeNameErrMsg, spanAttributeNameFmt, "http.route")}
+	}
+	return nil
+}
+
 func validateTLSRedirect(redirect *v1.TLSRedirect, fieldPath *field.Path) field.ErrorList {
 	if redirect == nil {
 		return nil
@@ -464,6 +659,64 @@ func validateSessionCookie(sc *v1.SessionCookie, fieldPath *field.Path) field.Er
 	return allErrs
 }
 
+// validateSessionAffinity implements validation rules for session affinity.
+func validateSessionAffinity(u v1.Upstream, fieldPath *field.Path) field.ErrorList {
+	if u.SessionAffinity == nil {
+		return nil
+	}
+
+	if u.SessionCookie != nil {
+		return field.ErrorList{field.Forbidden(fieldPath, "cannot be used together with sessionCookie")}
+	}
+
+	return validateSessionCookie(u.SessionAffinity, fieldPath)
+}
+
+const (
+	grpcWebOriginFmt    string = `https?://[a-zA-Z0-9.-]+(:[0-9]{1,5})?`
+	grpcWebOriginErrMsg string = "a valid origin must consist of the http or https scheme, a host and an optional port"
+)
+
+var grpcWebOriginRegexp = regexp.MustCompile("^" + grpcWebOriginFmt + "$")
+
+// validateGRPCWeb implements validation rules for gRPC-Web.
+func validateGRPCWeb(u v1.Upstream, fieldPath *field.Path) field.ErrorList {
+	if u.GRPCWeb == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	if u.Type != "" && u.Type != "grpc" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "requires the grpc type"))
+	}
+
+	origin := u.GRPCWeb.AllowOrigin
+	if origin != "" && origin != "*" && !grpcWebOriginRegexp.MatchString(origin) {
+		msg := validation.RegexError(grpcWebOriginErrMsg, grpcWebOriginFmt, "https://app.example.com", "http://localhost:8080")
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("allowOrigin"), origin, msg))
+	}
+
+	return allErrs
+}
+
+// validateWebSocket implements validation rules for WebSocket.
+func validateWebSocket(u v1.Upstream, fieldPath *field.Path) field.ErrorList {
+	if u.WebSocket == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	if u.Type == "grpc" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "requires the http type"))
+	}
+
+	allErrs = append(allErrs, validateTime(u.WebSocket.IdleTimeout, fieldPath.Child("idleTimeout"))...)
+
+	return allErrs
+}
+
 // validateUpstreamType validates that the protocol type of the upstream is of a supported protocol.
 // Current supported protocols are "http" and "grpc". If unset, it will default to "http".
 func validateUpstreamType(typeName string, fieldPath *field.Path) field.ErrorList {
@@ -641,7 +894,10 @@ func (vsv *VirtualServerValidator) validateUpstreams(upstreams []v1.Upstream, fi
 		allErrs = append(allErrs, validateSize(u.ProxyBusyBuffersSize, idxPath.Child("busy-buffers-size"))...)
 		allErrs = append(allErrs, validateQueue(u.Queue, idxPath.Child("queue"))...)
 		allErrs = append(allErrs, validateSessionCookie(u.SessionCookie, idxPath.Child("sessionCookie"))...)
+		allErrs = append(allErrs, validateSessionAffinity(u, idxPath.Child("sessionAffinity"))...)
 		allErrs = append(allErrs, validateUpstreamType(u.Type, idxPath.Child("type"))...)
+		allErrs = append(allErrs, validateGRPCWeb(u, idxPath.Child("grpcWeb"))...)
+		allErrs = append(allErrs, validateWebSocket(u, idxPath.Child("websocket"))...)
 
 		for _, msg := range validation.IsValidPortNum(int(u.Port)) {
 			allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), u.Port, msg))
@@ -759,10 +1015,10 @@ func (vsv *VirtualServerValidator) validateVirtualServerRoutes(routes []v1.Route
 		routeErrs := vsv.validateRoute(r, idxPath, upstreamNames, isRouteFieldForbidden, namespace)
 		if len(routeErrs) > 0 {
 			allErrs = append(allErrs, routeErrs...)
-		} else if allPaths.Has(r.Path) {
+		} else if allPaths.Has(configs.GetRoutePath(r)) {
 			allErrs = append(allErrs, field.Duplicate(idxPath.Child("path"), r.Path))
 		} else {
-			allPaths.Insert(r.Path)
+			allPaths.Insert(configs.GetRoutePath(r))
 		}
 	}
 
@@ -770,25 +1026,29 @@ func (vsv *VirtualServerValidator) validateVirtualServerRoutes(routes []v1.Route
 }
 
 func (vsv *VirtualServerValidator) validateRoute(route v1.Route, fieldPath *field.Path, upstreamNames sets.Set[string], isRouteFieldForbidden bool, namespace string) field.ErrorList {
-	allErrs := validateRoutePath(route.Path, fieldPath.Child("path"))
+	allErrs := validateRoutePathWithType(route.Path, route.PathType, fieldPath)
 	allErrs = append(allErrs, validatePolicies(route.Policies, fieldPath.Child("policies"), namespace)...)
+	allErrs = append(allErrs, validateAccessLog(route.AccessLog, fieldPath.Child("accessLog"))...)
+	allErrs = append(allErrs, vsv.validateTracing(route.Tracing, fieldPath.Child("tracing"))...)
+
+	path := configs.GetRoutePath(route)
 
 	fieldCount := 0
 
 	if route.Action != nil {
-		allErrs = append(allErrs, vsv.validateAction(route.Action, fieldPath.Child("action"), upstreamNames, route.Path, false)...)
+		allErrs = append(allErrs, vsv.validateAction(route.Action, fieldPath.Child("action"), upstreamNames, path, false)...)
 		fieldCount++
 	}
 
 	if len(route.Splits) > 0 {
-		allErrs = append(allErrs, vsv.validateSplits(route.Splits, fieldPath.Child("splits"), upstreamNames, route.Path)...)
+		allErrs = append(allErrs, vsv.validateSplits(route.Splits, fieldPath.Child("splits"), upstreamNames, path)...)
 		fieldCount++
 	}
 
 	// Matches are optional. that's why we don't do fieldCount++
 	if len(route.Matches) > 0 {
 		for i, m := range route.Matches {
-			allErrs = append(allErrs, vsv.validateMatch(m, fieldPath.Child("matches").Index(i), upstreamNames, route.Path)...)
+			allErrs = append(allErrs, vsv.validateMatch(m, fieldPath.Child("matches").Index(i), upstreamNames, path)...)
 		}
 	}
 
@@ -803,6 +1063,10 @@ func (vsv *VirtualServerValidator) validateRoute(route v1.Route, fieldPath *fiel
 			allErrs = append(allErrs, validateRouteField(route.Route, fieldPath.Child("route"))...)
 			fieldCount++
 		}
+
+		if route.PathType != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("pathType"), "cannot be used together with route"))
+		}
 	}
 
 	if fieldCount != 1 {
@@ -894,7 +1158,7 @@ func (vsv *VirtualServerValidator) validateErrorPageHeader(h v1.Header, fieldPat
 var validErrorPageRedirectVariables = map[string]bool{"scheme": true, "http_x_forwarded_proto": true}
 
 func (vsv *VirtualServerValidator) validateErrorPageRedirect(r *v1.ErrorPageRedirect, fieldPath *field.Path) field.ErrorList {
-	return vsv.validateActionRedirect(&r.ActionRedirect, fieldPath, validErrorPageRedirectVariables)
+	return vsv.validateActionRedirect(&r.ActionRedirect, fieldPath, validErrorPageRedirectVariables, 0)
 }
 
 func countActions(action *v1.Action) int {
@@ -966,7 +1230,12 @@ func (vsv *VirtualServerValidator) validateAction(action *v1.Action, fieldPath *
 	}
 
 	if action.Redirect != nil {
-		allErrs = append(allErrs, vsv.validateActionRedirect(action.Redirect, fieldPath.Child("redirect"), validRedirectVariableNames)...)
+		// Internal locations are reached through a rewrite, so the captures of the route path are not available to redirects.
+		captureGroups := 0
+		if !internal {
+			captureGroups = countRoutePathCaptureGroups(path)
+		}
+		allErrs = append(allErrs, vsv.validateActionRedirect(action.Redirect, fieldPath.Child("redirect"), validRedirectVariableNames, captureGroups)...)
 	}
 
 	if action.Return != nil {
@@ -980,8 +1249,8 @@ func (vsv *VirtualServerValidator) validateAction(action *v1.Action, fieldPath *
 	return allErrs
 }
 
-func (vsv *VirtualServerValidator) validateActionRedirect(redirect *v1.ActionRedirect, fieldPath *field.Path, validVars map[string]bool) field.ErrorList {
-	allErrs := vsv.validateRedirectURL(redirect.URL, fieldPath.Child("url"), validVars)
+func (vsv *VirtualServerValidator) validateActionRedirect(redirect *v1.ActionRedirect, fieldPath *field.Path, validVars map[string]bool, captureGroups int) field.ErrorList {
+	allErrs := vsv.validateRedirectURL(redirect.URL, fieldPath.Child("url"), validVars, captureGroups)
 
 	if redirect.Code != 0 {
 		allErrs = append(allErrs, validateRedirectStatusCode(redirect.Code, fieldPath.Child("code"))...)
@@ -1003,7 +1272,7 @@ func captureVariables(s string) []string {
 	return nVars
 }
 
-func (vsv *VirtualServerValidator) validateRedirectURL(redirectURL string, fieldPath *field.Path, validVars map[string]bool) field.ErrorList {
+func (vsv *VirtualServerValidator) validateRedirectURL(redirectURL string, fieldPath *field.Path, validVars map[string]bool, captureGroups int) field.ErrorList {
 	if redirectURL == "" {
 		return field.ErrorList{field.Required(fieldPath, "must specify a url")}
 	}
@@ -1013,7 +1282,41 @@ func (vsv *VirtualServerValidator) validateRedirectURL(redirectURL string, field
 	if err := ValidateEscapedString(redirectURL, "http://www.nginx.com", "${scheme}://${host}/green/", `\"http://www.nginx.com\"`); err != nil {
 		return field.ErrorList{field.Invalid(fieldPath, redirectURL, err.Error())}
 	}
-	return validateStringWithVariables(redirectURL, fieldPath, nil, validVars, vsv.isPlus)
+	if allErrs := validateCaptureGroupReferences(redirectURL, captureGroups, fieldPath); len(allErrs) > 0 {
+		return allErrs
+	}
+	return validateStringWithVariables(captureGroupReferenceRegexp.ReplaceAllString(redirectURL, ""), fieldPath, nil, validVars, vsv.isPlus)
+}
+
+var captureGroupReferenceRegexp = regexp.MustCompile(`\$([1-9])`)
+
+// validateCaptureGroupReferences validates that every capture group reference, for example $1, refers to one of the
+// capture groups of the regular expression path of the route.
+func validateCaptureGroupReferences(s string, captureGroups int, fieldPath *field.Path) field.ErrorList {
+	for _, ref := range captureGroupReferenceRegexp.FindAllStringSubmatch(s, -1) {
+		n, _ := strconv.Atoi(ref[1])
+		if n <= captureGroups {
+			continue
+		}
+		if captureGroups == 0 {
+			return field.ErrorList{field.Invalid(fieldPath, s, fmt.Sprintf("references capture group %s, but capture groups are only available in the action of a route with a regular expression path", ref[0]))}
+		}
+		return field.ErrorList{field.Invalid(fieldPath, s, fmt.Sprintf("references capture group %s, but the path of the route only defines %d capture group(s)", ref[0], captureGroups))}
+	}
+	return nil
+}
+
+// countRoutePathCaptureGroups returns the number of capture groups of a regular expression path. It returns 0 for
+// other paths.
+func countRoutePathCaptureGroups(path string) int {
+	if !strings.HasPrefix(path, "~") {
+		return 0
+	}
+	re, err := regexp2.Compile(strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(path, "~"), "*")), 0)
+	if err != nil {
+		return 0
+	}
+	return len(re.GetGroupNumbers()) - 1
 }
 
 func validateActionReturnCode(code int, fieldPath *field.Path) field.ErrorList {
@@ -1100,6 +1403,10 @@ func (vsv *VirtualServerValidator) validateActionProxy(p *v1.ActionProxy, fieldP
 		allErrs = append(allErrs, validateActionProxyRewritePath(p.RewritePath, fieldPath.Child("rewritePath"))...)
 	}
 
+	if strings.HasPrefix(path, "~") || !internal {
+		allErrs = append(allErrs, validateCaptureGroupReferences(p.RewritePath, countRoutePathCaptureGroups(path), fieldPath.Child("rewritePath"))...)
+	}
+
 	return allErrs
 }
 
@@ -1317,6 +1624,28 @@ func validateRoutePath(path string, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
+// validateRoutePathWithType validates the path of a route against its path type.
+func validateRoutePathWithType(path string, pathType string, fieldPath *field.Path) field.ErrorList {
+	switch pathType {
+	case "":
+		return validateRoutePath(path, fieldPath.Child("path"))
+	case v1.PathTypeExact, v1.PathTypePrefix:
+		return validatePath(path, fieldPath.Child("path"))
+	case v1.PathTypeRegularExpression:
+		if path == "" {
+			return field.ErrorList{field.Required(fieldPath.Child("path"), "")}
+		}
+		if strings.HasPrefix(path, "~") {
+			return field.ErrorList{field.Invalid(fieldPath.Child("path"), path, "must not start with ~ when pathType is RegularExpression")}
+		}
+		return validateRegexPath(path, fieldPath.Child("path"))
+	default:
+		return field.ErrorList{field.NotSupported(fieldPath.Child("pathType"), pathType, routePathTypes)}
+	}
+}
+
+var routePathTypes = []string{v1.PathTypeExact, v1.PathTypePrefix, v1.PathTypeRegularExpression}
+
 // validateRegexPath validates correctness of the string representing the path.
 //
 // Internally it uses Perl5 compatible regexp2 package.
@@ -1427,8 +1756,24 @@ func validateCondition(condition v1.Condition, fieldPath *field.Path) field.Erro
 		fieldCount++
 	}
 
+	if condition.Method != "" {
+		allErrs = append(allErrs, validateConditionMethod(condition.Method, fieldPath.Child("method"))...)
+		fieldCount++
+	}
+
+	if condition.Host != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(strings.TrimPrefix(condition.Host, "!")) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("host"), condition.Host, msg))
+		}
+		fieldCount++
+	}
+
 	if fieldCount != 1 {
-		allErrs = append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `header`, `cookie`, `argument` or `variable`"))
+		allErrs = append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `header`, `cookie`, `argument`, `variable`, `method` or `host`"))
+	}
+
+	if (condition.Method != "" || condition.Host != "") && condition.Value != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("value"), "cannot be used together with `method` or `host`"))
 	}
 
 	for _, msg := range isValidMatchValue(condition.Value) {
@@ -1438,6 +1783,15 @@ func validateCondition(condition v1.Condition, fieldPath *field.Path) field.Erro
 	return allErrs
 }
 
+var conditionMethods = []string{"CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT", "TRACE"}
+
+func validateConditionMethod(method string, fieldPath *field.Path) field.ErrorList {
+	if !slices.Contains(conditionMethods, strings.TrimPrefix(method, "!")) {
+		return field.ErrorList{field.NotSupported(fieldPath, method, conditionMethods)}
+	}
+	return nil
+}
+
 const (
 	cookieNameFmt    string = "[_A-Za-z0-9]+"
 	cookieNameErrMsg string = "a valid cookie name must consist of alphanumeric characters or '_'"
@@ -1549,7 +1903,7 @@ func (vsv *VirtualServerValidator) validateVirtualServerRouteSubroutes(routes []
 		}
 
 		idxPath := fieldPath.Index(0)
-		if routes[0].Path != vsPath {
+		if configs.GetRoutePath(routes[0]) != vsPath {
 			return append(allErrs, field.Invalid(idxPath.Child("path"), routes[0].Path, "must have the same path as the referenced VirtualServer route path"))
 		}
 
@@ -1569,16 +1923,44 @@ func (vsv *VirtualServerValidator) validateVirtualServerRouteSubroutes(routes []
 
 		if len(routeErrs) > 0 {
 			allErrs = append(allErrs, routeErrs...)
-		} else if allPaths.Has(r.Path) {
+		} else if allPaths.Has(configs.GetRoutePath(r)) {
 			allErrs = append(allErrs, field.Duplicate(idxPath.Child("path"), r.Path))
 		} else {
-			allPaths.Insert(r.Path)
+			allPaths.Insert(configs.GetRoutePath(r))
 		}
 	}
 
 	return allErrs
 }
 
+// rejectPlusHealthCheckFieldsInOSS rejects the fields of an active health check that the Ingress Controller
+// doesn't support when it performs the health checks for NGINX.
+func rejectPlusHealthCheckFieldsInOSS(hc *v1.HealthCheck, typeName string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if typeName == "grpc" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "active health checks of gRPC upstreams are only supported in NGINX Plus"))
+	}
+
+	if hc.Jitter != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("jitter"), "jitter is only supported in NGINX Plus"))
+	}
+
+	if hc.Mandatory {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("mandatory"), "mandatory health checks are only supported in NGINX Plus"))
+	}
+
+	if hc.Persistent {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("persistent"), "persistent health checks are only supported in NGINX Plus"))
+	}
+
+	if hc.KeepaliveTime != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("keepalive-time"), "keepalive-time is only supported in NGINX Plus"))
+	}
+
+	return allErrs
+}
+
 func rejectPlusResourcesInOSS(upstream v1.Upstream, idxPath *field.Path, isPlus bool) field.ErrorList {
 	if isPlus {
 		return nil
@@ -1586,7 +1968,7 @@ func rejectPlusResourcesInOSS(upstream v1.Upstream, idxPath *field.Path, isPlus
 
 	allErrs := field.ErrorList{}
 	if upstream.HealthCheck != nil {
-		allErrs = append(allErrs, field.Forbidden(idxPath.Child("healthCheck"), "active health checks are only supported in NGINX Plus"))
+		allErrs = append(allErrs, rejectPlusHealthCheckFieldsInOSS(upstream.HealthCheck, upstream.Type, idxPath.Child("healthCheck"))...)
 	}
 
 	if upstream.SlowStart != "" {
@@ -513,6 +513,481 @@ func TestValidateExternalDNSEnabled(t *testing.T) {
 	}
 }
 
+func TestValidateMaintenance(t *testing.T) {
+	t.Parallel()
+	tests := []*v1.Maintenance{
+		nil,
+		{
+			Enable: true,
+		},
+		{
+			Enable: true,
+			Code:   503,
+			Type:   "text/html",
+			Body:   "<h1>Down for maintenance</h1>",
+		},
+		{
+			Enable: true,
+			Body:   "${request_uri} is unavailable",
+		},
+		{
+			Enable: true,
+			ConfigMap: &v1.MaintenanceConfigMap{
+				Name: "maintenance-page",
+				Key:  "index.html",
+			},
+			Allow: []string{"10.0.0.0/8", "192.168.1.1"},
+			BypassHeader: &v1.Header{
+				Name:  "X-Maintenance-Bypass",
+				Value: "secret",
+			},
+		},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: false}
+
+	for _, test := range tests {
+		allErrs := vsv.validateMaintenance(test, field.NewPath("maintenance"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateMaintenance(%v) returned errors %v for valid input", test, allErrs)
+		}
+	}
+}
+
+func TestValidateMaintenanceFails(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		maintenance *v1.Maintenance
+		msg         string
+	}{
+		{
+			maintenance: &v1.Maintenance{
+				Code: 301,
+			},
+			msg: "invalid status code",
+		},
+		{
+			maintenance: &v1.Maintenance{
+				Type: `text/"html"`,
+			},
+			msg: "invalid type",
+		},
+		{
+			maintenance: &v1.Maintenance{
+				Body: "Hello ${somevar}",
+			},
+			msg: "invalid variable in body",
+		},
+		{
+			maintenance: &v1.Maintenance{
+				Body: "Hello",
+				ConfigMap: &v1.MaintenanceConfigMap{
+					Name: "maintenance-page",
+				},
+			},
+			msg: "body and configMap both defined",
+		},
+		{
+			maintenance: &v1.Maintenance{
+				ConfigMap: &v1.MaintenanceConfigMap{},
+			},
+			msg: "missing configMap name",
+		},
+		{
+			maintenance: &v1.Maintenance{
+				ConfigMap: &v1.MaintenanceConfigMap{
+					Name: "maintenance-page",
+					Key:  "index/html",
+				},
+			},
+			msg: "invalid configMap key",
+		},
+		{
+			maintenance: &v1.Maintenance{
+				Allow: []string{"10.0.0.0/33"},
+			},
+			msg: "invalid CIDR",
+		},
+		{
+			maintenance: &v1.Maintenance{
+				BypassHeader: &v1.Header{
+					Name: "X-Maintenance-Bypass",
+				},
+			},
+			msg: "missing bypass header value",
+		},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: false}
+
+	for _, test := range tests {
+		allErrs := vsv.validateMaintenance(test.maintenance, field.NewPath("maintenance"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateMaintenance() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateRedirectMap(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		redirectMap *v1.RedirectMap
+		msg         string
+	}{
+		{
+			redirectMap: nil,
+			msg:         "no redirect map",
+		},
+		{
+			redirectMap: &v1.RedirectMap{
+				ConfigMap: "legacy-redirects",
+			},
+			msg: "configMap only",
+		},
+		{
+			redirectMap: &v1.RedirectMap{
+				ConfigMap: "legacy-redirects",
+				Key:       "redirects.txt",
+				Code:      308,
+			},
+			msg: "configMap with key and code",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateRedirectMap(test.redirectMap, field.NewPath("redirectMap"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateRedirectMap() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateRedirectMapFails(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		redirectMap *v1.RedirectMap
+		msg         string
+	}{
+		{
+			redirectMap: &v1.RedirectMap{},
+			msg:         "missing configMap",
+		},
+		{
+			redirectMap: &v1.RedirectMap{
+				ConfigMap: "Legacy_Redirects",
+			},
+			msg: "invalid configMap name",
+		},
+		{
+			redirectMap: &v1.RedirectMap{
+				ConfigMap: "legacy-redirects",
+				Key:       "redirects/txt",
+			},
+			msg: "invalid key",
+		},
+		{
+			redirectMap: &v1.RedirectMap{
+				ConfigMap: "legacy-redirects",
+				Code:      200,
+			},
+			msg: "invalid code",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateRedirectMap(test.redirectMap, field.NewPath("redirectMap"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateRedirectMap() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateRequestID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		requestID *v1.RequestID
+		msg       string
+	}{
+		{
+			requestID: nil,
+			msg:       "no request ID",
+		},
+		{
+			requestID: &v1.RequestID{},
+			msg:       "default header",
+		},
+		{
+			requestID: &v1.RequestID{
+				Header: "X-Correlation-ID",
+			},
+			msg: "custom header",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateRequestID(test.requestID, field.NewPath("requestID"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateRequestID() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateRequestIDFails(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		requestID *v1.RequestID
+		msg       string
+	}{
+		{
+			requestID: &v1.RequestID{
+				Header: "X Correlation ID",
+			},
+			msg: "header with spaces",
+		},
+		{
+			requestID: &v1.RequestID{
+				Header: "X-Correlation-ID:",
+			},
+			msg: "header with colon",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateRequestID(test.requestID, field.NewPath("requestID"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateRequestID() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateAccessLog(t *testing.T) {
+	t.Parallel()
+	enable := false
+	tests := []struct {
+		accessLog *v1.AccessLog
+		msg       string
+	}{
+		{
+			accessLog: nil,
+			msg:       "no access log",
+		},
+		{
+			accessLog: &v1.AccessLog{
+				Enable: &enable,
+			},
+			msg: "access log off",
+		},
+		{
+			accessLog: &v1.AccessLog{
+				Format:      "main",
+				Destination: "syslog:server=logs.example.com:514",
+				Sampling:    10,
+				Condition:   "errors",
+			},
+			msg: "syslog destination with sampling and condition",
+		},
+		{
+			accessLog: &v1.AccessLog{
+				Format:      "json_log",
+				Destination: "/dev/stdout",
+				Sampling:    100,
+				Condition:   "5xx",
+			},
+			msg: "file destination",
+		},
+		{
+			accessLog: &v1.AccessLog{
+				Destination: "/dev/stderr",
+				Condition:   "4xx",
+			},
+			msg: "stderr destination",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateAccessLog(test.accessLog, field.NewPath("accessLog"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateAccessLog() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateAccessLogFails(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		accessLog *v1.AccessLog
+		msg       string
+	}{
+		{
+			accessLog: &v1.AccessLog{
+				Format: "main;",
+			},
+			msg: "invalid format",
+		},
+		{
+			accessLog: &v1.AccessLog{
+				Destination: "stderr",
+			},
+			msg: "stderr destination",
+		},
+		{
+			accessLog: &v1.AccessLog{
+				Destination: "syslog:server=localhost:99999",
+			},
+			msg: "invalid destination port",
+		},
+		{
+			accessLog: &v1.AccessLog{
+				Destination: "logs",
+			},
+			msg: "relative destination",
+		},
+		{
+			accessLog: &v1.AccessLog{
+				Sampling: 101,
+			},
+			msg: "sampling above 100",
+		},
+		{
+			accessLog: &v1.AccessLog{
+				Sampling: -1,
+			},
+			msg: "negative sampling",
+		},
+		{
+			accessLog: &v1.AccessLog{
+				Condition: "3xx",
+			},
+			msg: "invalid condition",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateAccessLog(test.accessLog, field.NewPath("accessLog"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateAccessLog() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateTracing(t *testing.T) {
+	t.Parallel()
+	enable := false
+	tests := []struct {
+		tracing *v1.Tracing
+		msg     string
+	}{
+		{
+			tracing: nil,
+			msg:     "no tracing",
+		},
+		{
+			tracing: &v1.Tracing{
+				Enable: &enable,
+			},
+			msg: "tracing off",
+		},
+		{
+			tracing: &v1.Tracing{
+				Sampling: 25,
+				SpanName: "${request_method} ${host}",
+				SpanAttributes: []v1.SpanAttribute{
+					{
+						Name:  "http.user_agent",
+						Value: "${http_user_agent}",
+					},
+					{
+						Name:  "team",
+						Value: "coffee",
+					},
+				},
+			},
+			msg: "sampling, span name and attributes",
+		},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: false}
+
+	for _, test := range tests {
+		allErrs := vsv.validateTracing(test.tracing, field.NewPath("tracing"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateTracing() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateTracingFails(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		tracing *v1.Tracing
+		msg     string
+	}{
+		{
+			tracing: &v1.Tracing{
+				Sampling: 101,
+			},
+			msg: "sampling above 100",
+		},
+		{
+			tracing: &v1.Tracing{
+				SpanName: "${unknown_variable}",
+			},
+			msg: "span name with invalid variable",
+		},
+		{
+			tracing: &v1.Tracing{
+				SpanName: `span "name"`,
+			},
+			msg: "span name with unescaped double quotes",
+		},
+		{
+			tracing: &v1.Tracing{
+				SpanAttributes: []v1.SpanAttribute{
+					{
+						Name:  "team name",
+						Value: "coffee",
+					},
+				},
+			},
+			msg: "invalid attribute name",
+		},
+		{
+			tracing: &v1.Tracing{
+				SpanAttributes: []v1.SpanAttribute{
+					{
+						Name: "team",
+					},
+				},
+			},
+			msg: "missing attribute value",
+		},
+		{
+			tracing: &v1.Tracing{
+				SpanAttributes: []v1.SpanAttribute{
+					{
+						Name:  "team",
+						Value: "coffee",
+					},
+					{
+						Name:  "team",
+						Value: "tea",
+					},
+				},
+			},
+			msg: "duplicated attribute name",
+		},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: false}
+
+	for _, test := range tests {
+		allErrs := vsv.validateTracing(test.tracing, field.NewPath("tracing"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateTracing() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateUpstreams(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -927,6 +1402,29 @@ func TestValidateVirtualServerRoutes(t *testing.T) {
 			},
 			msg: "valid route",
 		},
+		{
+			routes: []v1.Route{
+				{
+					Path:     "/test",
+					PathType: v1.PathTypeExact,
+					Action: &v1.Action{
+						Pass: "test-1",
+					},
+				},
+				{
+					Path:     "/test",
+					PathType: v1.PathTypePrefix,
+					Action: &v1.Action{
+						Pass: "test-2",
+					},
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test-1": {},
+				"test-2": {},
+			},
+			msg: "same path with different path types",
+		},
 	}
 
 	vsv := &VirtualServerValidator{isPlus: false}
@@ -967,6 +1465,28 @@ func TestValidateVirtualServerRoutesFails(t *testing.T) {
 			},
 			msg: "duplicated paths",
 		},
+		{
+			routes: []v1.Route{
+				{
+					Path: "=/test",
+					Action: &v1.Action{
+						Pass: "test-1",
+					},
+				},
+				{
+					Path:     "/test",
+					PathType: v1.PathTypeExact,
+					Action: &v1.Action{
+						Pass: "test-2",
+					},
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test-1": {},
+				"test-2": {},
+			},
+			msg: "duplicated exact paths with path type",
+		},
 
 		{
 			routes: []v1.Route{
@@ -1072,6 +1592,78 @@ func TestValidateRoute(t *testing.T) {
 			isRouteFieldForbidden: false,
 			msg:                   "valid route with route",
 		},
+		{
+			route: v1.Route{
+				Path:     "/coffee",
+				PathType: v1.PathTypeExact,
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid route with exact path type",
+		},
+		{
+			route: v1.Route{
+				Path:     "/coffee",
+				PathType: v1.PathTypePrefix,
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid route with prefix path type",
+		},
+		{
+			route: v1.Route{
+				Path:     "^/coffee/[a-z]+$",
+				PathType: v1.PathTypeRegularExpression,
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid route with regular expression path type",
+		},
+		{
+			route: v1.Route{
+				Path: `~ ^/images/([a-z]+)/(.*)\.jpg$`,
+				Action: &v1.Action{
+					Proxy: &v1.ActionProxy{
+						Upstream:    "test",
+						RewritePath: "/pictures/$2?category=$1",
+					},
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid rewrite path with capture groups",
+		},
+		{
+			route: v1.Route{
+				Path:     "^/old/(.*)$",
+				PathType: v1.PathTypeRegularExpression,
+				Action: &v1.Action{
+					Redirect: &v1.ActionRedirect{
+						URL: "${scheme}://${host}/new/$1",
+					},
+				},
+			},
+			upstreamNames:         map[string]sets.Empty{},
+			isRouteFieldForbidden: false,
+			msg:                   "valid redirect with capture groups",
+		},
 	}
 
 	vsv := &VirtualServerValidator{isPlus: false}
@@ -1094,7 +1686,145 @@ func TestValidateRouteFails(t *testing.T) {
 	}{
 		{
 			route: v1.Route{
-				Path: "",
+				Path: "",
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "empty path",
+		},
+		{
+			route: v1.Route{
+				Path:     "/coffee",
+				PathType: "Wildcard",
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "unsupported path type",
+		},
+		{
+			route: v1.Route{
+				Path:     "=/coffee",
+				PathType: v1.PathTypeExact,
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "exact path type with location modifier in path",
+		},
+		{
+			route: v1.Route{
+				Path:     "~ ^/coffee",
+				PathType: v1.PathTypeRegularExpression,
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "regular expression path type with location modifier in path",
+		},
+		{
+			route: v1.Route{
+				Path:     "^/coffee/(",
+				PathType: v1.PathTypeRegularExpression,
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "invalid regular expression with path type",
+		},
+		{
+			route: v1.Route{
+				Path:     "/coffee",
+				PathType: v1.PathTypeExact,
+				Route:    "default/coffee",
+			},
+			upstreamNames:         map[string]sets.Empty{},
+			isRouteFieldForbidden: false,
+			msg:                   "path type with route",
+		},
+		{
+			route: v1.Route{
+				Path: "~ ^/images/(.*)$",
+				Action: &v1.Action{
+					Proxy: &v1.ActionProxy{
+						Upstream:    "test",
+						RewritePath: "/pictures/$2",
+					},
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "rewrite path with non-existing capture group",
+		},
+		{
+			route: v1.Route{
+				Path: "/images",
+				Action: &v1.Action{
+					Proxy: &v1.ActionProxy{
+						Upstream:    "test",
+						RewritePath: "/pictures/$1",
+					},
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "rewrite path with capture group for prefix path",
+		},
+		{
+			route: v1.Route{
+				Path: "/old",
+				Action: &v1.Action{
+					Redirect: &v1.ActionRedirect{
+						URL: "http://example.com/$1",
+					},
+				},
+			},
+			upstreamNames:         map[string]sets.Empty{},
+			isRouteFieldForbidden: false,
+			msg:                   "redirect with capture group for prefix path",
+		},
+		{
+			route: v1.Route{
+				Path: "~ ^/old/(.*)$",
+				Matches: []v1.Match{
+					{
+						Conditions: []v1.Condition{
+							{
+								Method: "GET",
+							},
+						},
+						Action: &v1.Action{
+							Redirect: &v1.ActionRedirect{
+								URL: "http://example.com/$1",
+							},
+						},
+					},
+				},
 				Action: &v1.Action{
 					Pass: "test",
 				},
@@ -1103,7 +1833,7 @@ func TestValidateRouteFails(t *testing.T) {
 				"test": {},
 			},
 			isRouteFieldForbidden: false,
-			msg:                   "empty path",
+			msg:                   "redirect with capture group in match",
 		},
 		{
 			route: v1.Route{
@@ -1414,12 +2144,16 @@ func TestValidateRedirectURL(t *testing.T) {
 			redirectURL: "http://{abc}",
 			msg:         "url with curly braces with no $ prefix",
 		},
+		{
+			redirectURL: "${scheme}://${host}/new/$1",
+			msg:         "url with capture group",
+		},
 	}
 
 	vsv := &VirtualServerValidator{isPlus: false}
 
 	for _, test := range tests {
-		allErrs := vsv.validateRedirectURL(test.redirectURL, field.NewPath("url"), validRedirectVariableNames)
+		allErrs := vsv.validateRedirectURL(test.redirectURL, field.NewPath("url"), validRedirectVariableNames, 1)
 		if len(allErrs) > 0 {
 			t.Errorf("validateRedirectURL(%s) returned errors %v for valid input for the case of %s", test.redirectURL, allErrs, test.msg)
 		}
@@ -1484,18 +2218,121 @@ func TestValidateRedirectURLFails(t *testing.T) {
 			redirectURL: `http://${abca`,
 			msg:         "url containing a var without ending }",
 		},
+		{
+			redirectURL: "${scheme}://${host}/new/$2",
+			msg:         "url with non-existing capture group",
+		},
 	}
 
 	vsv := &VirtualServerValidator{isPlus: false}
 
 	for _, test := range tests {
-		allErrs := vsv.validateRedirectURL(test.redirectURL, field.NewPath("action"), validRedirectVariableNames)
+		allErrs := vsv.validateRedirectURL(test.redirectURL, field.NewPath("action"), validRedirectVariableNames, 1)
 		if len(allErrs) == 0 {
 			t.Errorf("validateRedirectURL(%s) returned no errors for invalid input for the case of %s", test.redirectURL, test.msg)
 		}
 	}
 }
 
+func TestValidateCaptureGroupReferences(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s             string
+		captureGroups int
+		msg           string
+	}{
+		{
+			s:             "/rewrite",
+			captureGroups: 0,
+			msg:           "no references",
+		},
+		{
+			s:             "${scheme}://${host}/rewrite",
+			captureGroups: 0,
+			msg:           "variables only",
+		},
+		{
+			s:             "/rewrite/$1/$2",
+			captureGroups: 2,
+			msg:           "existing references",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateCaptureGroupReferences(test.s, test.captureGroups, field.NewPath("url"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateCaptureGroupReferences(%q, %d) returned errors %v for valid input for the case of %s", test.s, test.captureGroups, allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateCaptureGroupReferencesFails(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s             string
+		captureGroups int
+		msg           string
+	}{
+		{
+			s:             "/rewrite/$1",
+			captureGroups: 0,
+			msg:           "no capture groups",
+		},
+		{
+			s:             "/rewrite/$1/$3",
+			captureGroups: 2,
+			msg:           "non-existing reference",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateCaptureGroupReferences(test.s, test.captureGroups, field.NewPath("url"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateCaptureGroupReferences(%q, %d) returned no errors for invalid input for the case of %s", test.s, test.captureGroups, test.msg)
+		}
+	}
+}
+
+func TestCountRoutePathCaptureGroups(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path     string
+		expected int
+	}{
+		{
+			path:     "/coffee",
+			expected: 0,
+		},
+		{
+			path:     "=/coffee",
+			expected: 0,
+		},
+		{
+			path:     "~ ^/coffee$",
+			expected: 0,
+		},
+		{
+			path:     `~ ^/images/([a-z]+)/(.*)\.jpg$`,
+			expected: 2,
+		},
+		{
+			path:     `~* ^/images/(?<name>.*)$`,
+			expected: 1,
+		},
+		{
+			path:     `~ ^/images/(?:small|large)/(.*)$`,
+			expected: 1,
+		},
+	}
+
+	for _, test := range tests {
+		result := countRoutePathCaptureGroups(test.path)
+		if result != test.expected {
+			t.Errorf("countRoutePathCaptureGroups(%q) returned %d, but expected %d", test.path, result, test.expected)
+		}
+	}
+}
+
 func TestValidateRouteField(t *testing.T) {
 	t.Parallel()
 	validRouteFields := []string{
@@ -1962,6 +2799,30 @@ func TestValidateCondition(t *testing.T) {
 			},
 			msg: "valid variable",
 		},
+		{
+			condition: v1.Condition{
+				Method: "POST",
+			},
+			msg: "valid method",
+		},
+		{
+			condition: v1.Condition{
+				Method: "!GET",
+			},
+			msg: "valid negated method",
+		},
+		{
+			condition: v1.Condition{
+				Host: "cafe.example.com",
+			},
+			msg: "valid host",
+		},
+		{
+			condition: v1.Condition{
+				Host: "!tea.example.com",
+			},
+			msg: "valid negated host",
+		},
 	}
 
 	for _, test := range tests {
@@ -2016,6 +2877,45 @@ func TestValidateConditionFails(t *testing.T) {
 			},
 			msg: "invalid variable",
 		},
+		{
+			condition: v1.Condition{
+				Method: "FETCH",
+			},
+			msg: "invalid method",
+		},
+		{
+			condition: v1.Condition{
+				Method: "get",
+			},
+			msg: "lowercase method",
+		},
+		{
+			condition: v1.Condition{
+				Method: "GET",
+				Value:  "GET",
+			},
+			msg: "method with value",
+		},
+		{
+			condition: v1.Condition{
+				Method: "GET",
+				Header: "x-version",
+			},
+			msg: "method with header",
+		},
+		{
+			condition: v1.Condition{
+				Host: "cafe_example.com",
+			},
+			msg: "invalid host",
+		},
+		{
+			condition: v1.Condition{
+				Host:  "cafe.example.com",
+				Value: "cafe.example.com",
+			},
+			msg: "host with value",
+		},
 	}
 
 	for _, test := range tests {
@@ -3251,6 +4151,22 @@ func TestRejectPlusResourcesInOSS(t *testing.T) {
 		},
 		{
 			upstream: &v1.Upstream{
+				HealthCheck: &v1.HealthCheck{Jitter: "1s"},
+			},
+		},
+		{
+			upstream: &v1.Upstream{
+				HealthCheck: &v1.HealthCheck{Mandatory: true},
+			},
+		},
+		{
+			upstream: &v1.Upstream{
+				HealthCheck: &v1.HealthCheck{KeepaliveTime: "60s"},
+			},
+		},
+		{
+			upstream: &v1.Upstream{
+				Type:        "grpc",
 				HealthCheck: &v1.HealthCheck{},
 			},
 		},
@@ -3286,6 +4202,25 @@ func TestRejectPlusResourcesInOSS(t *testing.T) {
 	}
 }
 
+func TestRejectPlusResourcesInOSSAllowsHealthChecks(t *testing.T) {
+	t.Parallel()
+	upstream := v1.Upstream{
+		HealthCheck: &v1.HealthCheck{
+			Enable:      true,
+			Path:        "/healthz",
+			Interval:    "10s",
+			Fails:       3,
+			Passes:      2,
+			StatusMatch: "200-299",
+		},
+	}
+
+	allErrs := rejectPlusResourcesInOSS(upstream, field.NewPath("upstreams"), false)
+	if len(allErrs) != 0 {
+		t.Errorf("rejectPlusResourcesInOSS() returned errors %v for upstream with health check: %v", allErrs, upstream)
+	}
+}
+
 func TestValidateQueue(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -3431,6 +4366,171 @@ func TestValidateSessionCookie_FailsOnInvalidInput(t *testing.T) {
 	}
 }
 
+func TestValidateSessionAffinity(t *testing.T) {
+	t.Parallel()
+	u := v1.Upstream{SessionAffinity: &v1.SessionCookie{Enable: true, Name: "srv_id", Expires: "1h", Path: "/tea"}}
+	allErrs := validateSessionAffinity(u, field.NewPath("sessionAffinity"))
+	if len(allErrs) != 0 {
+		t.Errorf("validateSessionAffinity() returned errors %v for valid input", allErrs)
+	}
+}
+
+func TestValidateSessionAffinity_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		upstream v1.Upstream
+		msg      string
+	}{
+		{
+			upstream: v1.Upstream{SessionAffinity: &v1.SessionCookie{Enable: true}},
+			msg:      "missing required field: Name",
+		},
+		{
+			upstream: v1.Upstream{SessionAffinity: &v1.SessionCookie{Enable: true, Name: "srv-id"}},
+			msg:      "invalid name format",
+		},
+		{
+			upstream: v1.Upstream{
+				SessionAffinity: &v1.SessionCookie{Enable: true, Name: "srv_id"},
+				SessionCookie:   &v1.SessionCookie{Enable: true, Name: "srv_id"},
+			},
+			msg: "session affinity and session cookie",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			allErrs := validateSessionAffinity(test.upstream, field.NewPath("sessionAffinity"))
+			if len(allErrs) == 0 {
+				t.Errorf("validateSessionAffinity() did not return errors for invalid input for the case of: %s", test.msg)
+			}
+		})
+	}
+}
+
+func TestValidateGRPCWeb(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		upstream v1.Upstream
+		msg      string
+	}{
+		{
+			upstream: v1.Upstream{},
+			msg:      "no gRPC-Web",
+		},
+		{
+			upstream: v1.Upstream{Type: "grpc", GRPCWeb: &v1.GRPCWeb{Enable: true}},
+			msg:      "default origin",
+		},
+		{
+			upstream: v1.Upstream{GRPCWeb: &v1.GRPCWeb{Enable: true, AllowOrigin: "*"}},
+			msg:      "any origin with type from appProtocol",
+		},
+		{
+			upstream: v1.Upstream{Type: "grpc", GRPCWeb: &v1.GRPCWeb{Enable: true, AllowOrigin: "https://app.example.com"}},
+			msg:      "origin",
+		},
+		{
+			upstream: v1.Upstream{Type: "grpc", GRPCWeb: &v1.GRPCWeb{Enable: true, AllowOrigin: "http://localhost:8080"}},
+			msg:      "origin with port",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			allErrs := validateGRPCWeb(test.upstream, field.NewPath("grpcWeb"))
+			if len(allErrs) != 0 {
+				t.Errorf("validateGRPCWeb() returned errors %v for valid input for the case of: %s", allErrs, test.msg)
+			}
+		})
+	}
+}
+
+func TestValidateGRPCWeb_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		upstream v1.Upstream
+		msg      string
+	}{
+		{
+			upstream: v1.Upstream{Type: "http", GRPCWeb: &v1.GRPCWeb{Enable: true}},
+			msg:      "http type",
+		},
+		{
+			upstream: v1.Upstream{Type: "grpc", GRPCWeb: &v1.GRPCWeb{Enable: true, AllowOrigin: "app.example.com"}},
+			msg:      "origin without scheme",
+		},
+		{
+			upstream: v1.Upstream{Type: "grpc", GRPCWeb: &v1.GRPCWeb{Enable: true, AllowOrigin: "https://app.example.com/path"}},
+			msg:      "origin with path",
+		},
+		{
+			upstream: v1.Upstream{Type: "grpc", GRPCWeb: &v1.GRPCWeb{Enable: true, AllowOrigin: `https://app.example.com";`}},
+			msg:      "origin with quotes",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			allErrs := validateGRPCWeb(test.upstream, field.NewPath("grpcWeb"))
+			if len(allErrs) == 0 {
+				t.Errorf("validateGRPCWeb() did not return errors for invalid input for the case of: %s", test.msg)
+			}
+		})
+	}
+}
+
+func TestValidateWebSocket(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		upstream v1.Upstream
+		msg      string
+	}{
+		{
+			upstream: v1.Upstream{},
+			msg:      "no WebSocket",
+		},
+		{
+			upstream: v1.Upstream{WebSocket: &v1.WebSocket{Enable: true}},
+			msg:      "defaults",
+		},
+		{
+			upstream: v1.Upstream{Type: "http", WebSocket: &v1.WebSocket{Enable: true, IdleTimeout: "1h"}},
+			msg:      "idle timeout",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			allErrs := validateWebSocket(test.upstream, field.NewPath("websocket"))
+			if len(allErrs) != 0 {
+				t.Errorf("validateWebSocket() returned errors %v for valid input for the case of: %s", allErrs, test.msg)
+			}
+		})
+	}
+}
+
+func TestValidateWebSocket_FailsOnInvalidInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		upstream v1.Upstream
+		msg      string
+	}{
+		{
+			upstream: v1.Upstream{Type: "grpc", WebSocket: &v1.WebSocket{Enable: true}},
+			msg:      "grpc type",
+		},
+		{
+			upstream: v1.Upstream{WebSocket: &v1.WebSocket{Enable: true, IdleTimeout: "1 hour"}},
+			msg:      "invalid idle timeout",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			allErrs := validateWebSocket(test.upstream, field.NewPath("websocket"))
+			if len(allErrs) == 0 {
+				t.Errorf("validateWebSocket() did not return errors for invalid input for the case of: %s", test.msg)
+			}
+		})
+	}
+}
+
 func TestValidateRedirectStatusCode(t *testing.T) {
 	t.Parallel()
 	tests := []struct {